@@ -0,0 +1,649 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package engine
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+	"github.com/protolambda/zrnt/eth2/beacon/bellatrix"
+	"github.com/protolambda/zrnt/eth2/beacon/capella"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/beacon/deneb"
+	"github.com/protolambda/zrnt/eth2/configs"
+	"github.com/protolambda/ztyp/codec"
+	"github.com/protolambda/ztyp/view"
+)
+
+// This file implements the SSZ encoding of the payloads exchanged over the
+// binary engine API transport. The payloads are encoded with the ExecutionPayload
+// types of the consensus specs: Bellatrix for PayloadV1, Capella for PayloadV2
+// and Deneb for PayloadV3. The other containers mirror the parameters and
+// results of the corresponding JSON-RPC methods.
+
+const (
+	sszKZGProofSize   = 48
+	sszBlobSize       = 131072
+	sszCellsPerBlob   = 128
+	sszMaxRequests    = 1 << 20
+	sszMaxRequestSize = 1 << 30
+)
+
+var errSSZUnsupported = errors.New("ssz: payload fields not supported by the binary encoding")
+
+// sszSpec is the consensus spec defining the list limits of the containers.
+var sszSpec = configs.Mainnet
+
+// sszField is a field of the containers exchanged over the binary transport.
+type sszField interface {
+	codec.Serializable
+	codec.Deserializable
+}
+
+// sszContainer is an SSZ container of the given fields.
+type sszContainer []sszField
+
+func (c sszContainer) Serialize(w *codec.EncodingWriter) error {
+	fields := make([]codec.Serializable, len(c))
+	for i, f := range c {
+		fields[i] = f
+	}
+	return w.Container(fields...)
+}
+
+func (c sszContainer) ByteLength() uint64 {
+	fields := make([]codec.Serializable, len(c))
+	for i, f := range c {
+		fields[i] = f
+	}
+	return codec.ContainerLength(fields...)
+}
+
+func (c sszContainer) FixedLength() uint64 {
+	return 0
+}
+
+func (c sszContainer) Deserialize(dr *codec.DecodingReader) error {
+	fields := make([]codec.Deserializable, len(c))
+	for i, f := range c {
+		fields[i] = f
+	}
+	return dr.Container(fields...)
+}
+
+// sszEncode returns the SSZ encoding of the object.
+func sszEncode(obj codec.Serializable) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := obj.Serialize(codec.NewEncodingWriter(&buf)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// sszDecode decodes the object from its SSZ encoding.
+func sszDecode(blob []byte, obj codec.Deserializable) error {
+	return obj.Deserialize(codec.NewDecodingReader(bytes.NewReader(blob), uint64(len(blob))))
+}
+
+// sszVectors is a list of fixed-size byte vectors, for the KZG proofs, the blobs
+// and the versioned hashes which have no list types in zrnt.
+type sszVectors struct {
+	items *[]hexutil.Bytes
+	size  uint64
+	limit uint64
+}
+
+func (l sszVectors) Serialize(w *codec.EncodingWriter) error {
+	for _, item := range *l.items {
+		if uint64(len(item)) != l.size {
+			return fmt.Errorf("invalid item size %d, want %d", len(item), l.size)
+		}
+		if err := w.Write(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l sszVectors) ByteLength() uint64 {
+	return uint64(len(*l.items)) * l.size
+}
+
+func (l sszVectors) FixedLength() uint64 {
+	return 0
+}
+
+func (l sszVectors) Deserialize(dr *codec.DecodingReader) error {
+	scope := dr.Scope()
+	if scope%l.size != 0 {
+		return fmt.Errorf("scope %d is not a multiple of the item size %d", scope, l.size)
+	}
+	if scope/l.size > l.limit {
+		return fmt.Errorf("too many items in list: %d > %d", scope/l.size, l.limit)
+	}
+	buf := make([]byte, scope)
+	if _, err := dr.Read(buf); err != nil {
+		return err
+	}
+	*l.items = make([]hexutil.Bytes, scope/l.size)
+	for i := range *l.items {
+		(*l.items)[i] = buf[uint64(i)*l.size : uint64(i+1)*l.size]
+	}
+	return nil
+}
+
+// sszByteLists is a list of variable-size byte lists, for the execution
+// requests.
+type sszByteLists struct {
+	items *[][]byte
+}
+
+func (l sszByteLists) Serialize(w *codec.EncodingWriter) error {
+	return w.List(func(i uint64) codec.Serializable {
+		return sszByteList{&(*l.items)[i]}
+	}, 0, uint64(len(*l.items)))
+}
+
+func (l sszByteLists) ByteLength() (out uint64) {
+	for _, item := range *l.items {
+		out += codec.OFFSET_SIZE + uint64(len(item))
+	}
+	return out
+}
+
+func (l sszByteLists) FixedLength() uint64 {
+	return 0
+}
+
+func (l sszByteLists) Deserialize(dr *codec.DecodingReader) error {
+	*l.items = [][]byte{}
+	return dr.List(func() codec.Deserializable {
+		*l.items = append(*l.items, []byte{})
+		return sszByteList{&(*l.items)[len(*l.items)-1]}
+	}, 0, sszMaxRequests)
+}
+
+// sszByteList is an item of sszByteLists.
+type sszByteList struct {
+	data *[]byte
+}
+
+func (b sszByteList) Serialize(w *codec.EncodingWriter) error {
+	return w.Write(*b.data)
+}
+
+func (b sszByteList) ByteLength() uint64 {
+	return uint64(len(*b.data))
+}
+
+func (b sszByteList) FixedLength() uint64 {
+	return 0
+}
+
+func (b sszByteList) Deserialize(dr *codec.DecodingReader) error {
+	return dr.ByteList(b.data, sszMaxRequestSize)
+}
+
+// sszPayload returns the ExecutionPayload of the given version holding the
+// payload fields.
+func (data *ExecutableData) sszPayload(version PayloadVersion) (zrntcommon.SpecObj, error) {
+	if data.ExecutionWitness != nil || data.BlockAccessList != nil {
+		return nil, errSSZUnsupported
+	}
+	if len(data.LogsBloom) != types.BloomByteLength {
+		return nil, fmt.Errorf("ssz: invalid logs bloom size %d", len(data.LogsBloom))
+	}
+	if len(data.ExtraData) > zrntcommon.MAX_EXTRA_DATA_BYTES {
+		return nil, fmt.Errorf("ssz: extra data too long: %d", len(data.ExtraData))
+	}
+	var baseFee uint256.Int
+	if data.BaseFeePerGas != nil {
+		if data.BaseFeePerGas.Sign() < 0 || baseFee.SetFromBig(data.BaseFeePerGas) {
+			return nil, fmt.Errorf("ssz: invalid base fee %v", data.BaseFeePerGas)
+		}
+	}
+	payload := &deneb.ExecutionPayload{
+		ParentHash:    zrntcommon.Hash32(data.ParentHash),
+		FeeRecipient:  zrntcommon.Eth1Address(data.FeeRecipient),
+		StateRoot:     zrntcommon.Bytes32(data.StateRoot),
+		ReceiptsRoot:  zrntcommon.Bytes32(data.ReceiptsRoot),
+		LogsBloom:     zrntcommon.LogsBloom(data.LogsBloom),
+		PrevRandao:    zrntcommon.Bytes32(data.Random),
+		BlockNumber:   view.Uint64View(data.Number),
+		GasLimit:      view.Uint64View(data.GasLimit),
+		GasUsed:       view.Uint64View(data.GasUsed),
+		Timestamp:     zrntcommon.Timestamp(data.Timestamp),
+		ExtraData:     data.ExtraData,
+		BaseFeePerGas: view.Uint256View(baseFee),
+		BlockHash:     zrntcommon.Hash32(data.BlockHash),
+		Transactions:  make(zrntcommon.PayloadTransactions, len(data.Transactions)),
+		Withdrawals:   make(zrntcommon.Withdrawals, len(data.Withdrawals)),
+	}
+	for i, tx := range data.Transactions {
+		payload.Transactions[i] = tx
+	}
+	for i, w := range data.Withdrawals {
+		payload.Withdrawals[i] = zrntcommon.Withdrawal{
+			Index:          zrntcommon.WithdrawalIndex(w.Index),
+			ValidatorIndex: zrntcommon.ValidatorIndex(w.Validator),
+			Address:        zrntcommon.Eth1Address(w.Address),
+			Amount:         zrntcommon.Gwei(w.Amount),
+		}
+	}
+	if version < PayloadV2 && data.Withdrawals != nil {
+		return nil, errSSZUnsupported
+	}
+	if version < PayloadV3 && (data.BlobGasUsed != nil || data.ExcessBlobGas != nil) {
+		return nil, errSSZUnsupported
+	}
+	switch version {
+	case PayloadV1:
+		return &bellatrix.ExecutionPayload{
+			ParentHash:    payload.ParentHash,
+			FeeRecipient:  payload.FeeRecipient,
+			StateRoot:     payload.StateRoot,
+			ReceiptsRoot:  payload.ReceiptsRoot,
+			LogsBloom:     payload.LogsBloom,
+			PrevRandao:    payload.PrevRandao,
+			BlockNumber:   payload.BlockNumber,
+			GasLimit:      payload.GasLimit,
+			GasUsed:       payload.GasUsed,
+			Timestamp:     payload.Timestamp,
+			ExtraData:     payload.ExtraData,
+			BaseFeePerGas: payload.BaseFeePerGas,
+			BlockHash:     payload.BlockHash,
+			Transactions:  payload.Transactions,
+		}, nil
+	case PayloadV2:
+		return &capella.ExecutionPayload{
+			ParentHash:    payload.ParentHash,
+			FeeRecipient:  payload.FeeRecipient,
+			StateRoot:     payload.StateRoot,
+			ReceiptsRoot:  payload.ReceiptsRoot,
+			LogsBloom:     payload.LogsBloom,
+			PrevRandao:    payload.PrevRandao,
+			BlockNumber:   payload.BlockNumber,
+			GasLimit:      payload.GasLimit,
+			GasUsed:       payload.GasUsed,
+			Timestamp:     payload.Timestamp,
+			ExtraData:     payload.ExtraData,
+			BaseFeePerGas: payload.BaseFeePerGas,
+			BlockHash:     payload.BlockHash,
+			Transactions:  payload.Transactions,
+			Withdrawals:   payload.Withdrawals,
+		}, nil
+	case PayloadV3:
+		if data.BlobGasUsed == nil || data.ExcessBlobGas == nil {
+			return nil, errors.New("ssz: missing blob gas fields")
+		}
+		payload.BlobGasUsed = view.Uint64View(*data.BlobGasUsed)
+		payload.ExcessBlobGas = view.Uint64View(*data.ExcessBlobGas)
+		return payload, nil
+	default:
+		return nil, fmt.Errorf("ssz: unsupported payload version %d", version)
+	}
+}
+
+// setSSZPayload sets the payload fields from the ExecutionPayload of the given
+// version.
+func (data *ExecutableData) setSSZPayload(version PayloadVersion, payload zrntcommon.SpecObj) {
+	var p deneb.ExecutionPayload
+	switch version {
+	case PayloadV1:
+		v1 := payload.(*bellatrix.ExecutionPayload)
+		p = deneb.ExecutionPayload{
+			ParentHash:    v1.ParentHash,
+			FeeRecipient:  v1.FeeRecipient,
+			StateRoot:     v1.StateRoot,
+			ReceiptsRoot:  v1.ReceiptsRoot,
+			LogsBloom:     v1.LogsBloom,
+			PrevRandao:    v1.PrevRandao,
+			BlockNumber:   v1.BlockNumber,
+			GasLimit:      v1.GasLimit,
+			GasUsed:       v1.GasUsed,
+			Timestamp:     v1.Timestamp,
+			ExtraData:     v1.ExtraData,
+			BaseFeePerGas: v1.BaseFeePerGas,
+			BlockHash:     v1.BlockHash,
+			Transactions:  v1.Transactions,
+		}
+	case PayloadV2:
+		v2 := payload.(*capella.ExecutionPayload)
+		p = deneb.ExecutionPayload{
+			ParentHash:    v2.ParentHash,
+			FeeRecipient:  v2.FeeRecipient,
+			StateRoot:     v2.StateRoot,
+			ReceiptsRoot:  v2.ReceiptsRoot,
+			LogsBloom:     v2.LogsBloom,
+			PrevRandao:    v2.PrevRandao,
+			BlockNumber:   v2.BlockNumber,
+			GasLimit:      v2.GasLimit,
+			GasUsed:       v2.GasUsed,
+			Timestamp:     v2.Timestamp,
+			ExtraData:     v2.ExtraData,
+			BaseFeePerGas: v2.BaseFeePerGas,
+			BlockHash:     v2.BlockHash,
+			Transactions:  v2.Transactions,
+			Withdrawals:   v2.Withdrawals,
+		}
+	default:
+		p = *payload.(*deneb.ExecutionPayload)
+	}
+	*data = ExecutableData{
+		ParentHash:    common.Hash(p.ParentHash),
+		FeeRecipient:  common.Address(p.FeeRecipient),
+		StateRoot:     common.Hash(p.StateRoot),
+		ReceiptsRoot:  common.Hash(p.ReceiptsRoot),
+		LogsBloom:     p.LogsBloom[:],
+		Random:        common.Hash(p.PrevRandao),
+		Number:        uint64(p.BlockNumber),
+		GasLimit:      uint64(p.GasLimit),
+		GasUsed:       uint64(p.GasUsed),
+		Timestamp:     uint64(p.Timestamp),
+		ExtraData:     p.ExtraData,
+		BaseFeePerGas: (*uint256.Int)(&p.BaseFeePerGas).ToBig(),
+		BlockHash:     common.Hash(p.BlockHash),
+		Transactions:  make([][]byte, len(p.Transactions)),
+	}
+	for i, tx := range p.Transactions {
+		data.Transactions[i] = tx
+	}
+	if version >= PayloadV2 {
+		data.Withdrawals = make([]*types.Withdrawal, len(p.Withdrawals))
+		for i, w := range p.Withdrawals {
+			data.Withdrawals[i] = &types.Withdrawal{
+				Index:     uint64(w.Index),
+				Validator: uint64(w.ValidatorIndex),
+				Address:   common.Address(w.Address),
+				Amount:    uint64(w.Amount),
+			}
+		}
+	}
+	if version >= PayloadV3 {
+		blobGasUsed, excessBlobGas := uint64(p.BlobGasUsed), uint64(p.ExcessBlobGas)
+		data.BlobGasUsed, data.ExcessBlobGas = &blobGasUsed, &excessBlobGas
+	}
+}
+
+// newSSZPayload returns an empty ExecutionPayload of the given version.
+func newSSZPayload(version PayloadVersion) zrntcommon.SpecObj {
+	switch version {
+	case PayloadV1:
+		return new(bellatrix.ExecutionPayload)
+	case PayloadV2:
+		return new(capella.ExecutionPayload)
+	default:
+		return new(deneb.ExecutionPayload)
+	}
+}
+
+// EncodeSSZ returns the SSZ encoding of the payload with the ExecutionPayload
+// schema of the given version.
+func (data *ExecutableData) EncodeSSZ(version PayloadVersion) ([]byte, error) {
+	payload, err := data.sszPayload(version)
+	if err != nil {
+		return nil, err
+	}
+	return sszEncode(sszSpec.Wrap(payload))
+}
+
+// DecodeSSZ decodes the payload from its SSZ encoding with the ExecutionPayload
+// schema of the given version.
+func (data *ExecutableData) DecodeSSZ(version PayloadVersion, blob []byte) error {
+	payload := newSSZPayload(version)
+	if err := sszDecode(blob, sszSpec.Wrap(payload)); err != nil {
+		return err
+	}
+	data.setSSZPayload(version, payload)
+	return nil
+}
+
+// sszBundle is the SSZ container of the blobs bundle, holding the commitments,
+// the proofs and the blobs as lists of fixed-size vectors.
+type sszBundle struct {
+	bundle      *BlobsBundleV1
+	commitments deneb.KZGCommitments
+}
+
+// newSSZBundle returns the container of the bundle to be encoded.
+func newSSZBundle(bundle *BlobsBundleV1) (*sszBundle, error) {
+	if bundle == nil {
+		bundle = new(BlobsBundleV1)
+	}
+	b := &sszBundle{bundle: bundle, commitments: make(deneb.KZGCommitments, len(bundle.Commitments))}
+	for i, c := range bundle.Commitments {
+		if len(c) != zrntcommon.KZGCommitmentSize {
+			return nil, fmt.Errorf("ssz: invalid commitment size %d", len(c))
+		}
+		b.commitments[i] = zrntcommon.KZGCommitment(c)
+	}
+	return b, nil
+}
+
+func (b *sszBundle) fields() sszContainer {
+	return sszContainer{
+		sszSpec.Wrap(&b.commitments),
+		sszVectors{&b.bundle.Proofs, sszKZGProofSize, uint64(sszSpec.MAX_BLOB_COMMITMENTS_PER_BLOCK) * sszCellsPerBlob},
+		sszVectors{&b.bundle.Blobs, sszBlobSize, uint64(sszSpec.MAX_BLOB_COMMITMENTS_PER_BLOCK)},
+	}
+}
+
+func (b *sszBundle) Serialize(w *codec.EncodingWriter) error {
+	return b.fields().Serialize(w)
+}
+
+func (b *sszBundle) ByteLength() uint64 {
+	return b.fields().ByteLength()
+}
+
+func (b *sszBundle) FixedLength() uint64 {
+	return 0
+}
+
+func (b *sszBundle) Deserialize(dr *codec.DecodingReader) error {
+	if err := b.fields().Deserialize(dr); err != nil {
+		return err
+	}
+	b.bundle.Commitments = make([]hexutil.Bytes, len(b.commitments))
+	for i := range b.commitments {
+		b.bundle.Commitments[i] = b.commitments[i][:]
+	}
+	return nil
+}
+
+// EncodeSSZ returns the SSZ encoding of the blobs bundle, a container of the
+// commitments, the proofs and the blobs as lists of fixed-size vectors.
+func (bundle *BlobsBundleV1) EncodeSSZ() ([]byte, error) {
+	b, err := newSSZBundle(bundle)
+	if err != nil {
+		return nil, err
+	}
+	return sszEncode(b)
+}
+
+// DecodeSSZ decodes the blobs bundle from its SSZ encoding.
+func (bundle *BlobsBundleV1) DecodeSSZ(blob []byte) error {
+	return sszDecode(blob, &sszBundle{bundle: bundle})
+}
+
+// payloadSchema returns the ExecutionPayload schema used by the given version
+// of the engine_newPayload and engine_getPayload methods.
+func payloadSchema(method int) PayloadVersion {
+	switch {
+	case method <= 1:
+		return PayloadV1
+	case method == 2:
+		return PayloadV2
+	default:
+		return PayloadV3
+	}
+}
+
+// EncodeSSZ returns the SSZ encoding of the envelope as returned by the given
+// version of engine_getPayload. Version 1 carries only the payload, version 2
+// adds the block value, version 3 adds the blobs bundle and the override flag,
+// and the later versions add the execution requests.
+func (env *ExecutionPayloadEnvelope) EncodeSSZ(method int) ([]byte, error) {
+	if env.ExecutionPayload == nil {
+		return nil, errors.New("ssz: missing execution payload")
+	}
+	if env.Witness != nil {
+		return nil, errSSZUnsupported
+	}
+	payload, err := env.ExecutionPayload.sszPayload(payloadSchema(method))
+	if err != nil {
+		return nil, err
+	}
+	if method <= 1 {
+		return sszEncode(sszSpec.Wrap(payload))
+	}
+	var value uint256.Int
+	if env.BlockValue != nil {
+		if env.BlockValue.Sign() < 0 || value.SetFromBig(env.BlockValue) {
+			return nil, fmt.Errorf("ssz: invalid block value %v", env.BlockValue)
+		}
+	}
+	fields := sszContainer{sszSpec.Wrap(payload), (*view.Uint256View)(&value)}
+	if method >= 3 {
+		bundle, err := newSSZBundle(env.BlobsBundle)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, bundle, (*view.BoolView)(&env.Override))
+	}
+	if method >= 4 {
+		fields = append(fields, sszByteLists{&env.Requests})
+	}
+	return sszEncode(fields)
+}
+
+// DecodeSSZ decodes the envelope from its SSZ encoding as returned by the given
+// version of engine_getPayload.
+func (env *ExecutionPayloadEnvelope) DecodeSSZ(method int, blob []byte) error {
+	var (
+		version = payloadSchema(method)
+		payload = newSSZPayload(version)
+		value   view.Uint256View
+	)
+	if method <= 1 {
+		if err := sszDecode(blob, sszSpec.Wrap(payload)); err != nil {
+			return err
+		}
+	} else {
+		fields := sszContainer{sszSpec.Wrap(payload), &value}
+		if method >= 3 {
+			env.BlobsBundle = new(BlobsBundleV1)
+			fields = append(fields, &sszBundle{bundle: env.BlobsBundle}, (*view.BoolView)(&env.Override))
+		}
+		if method >= 4 {
+			fields = append(fields, sszByteLists{&env.Requests})
+		}
+		if err := sszDecode(blob, fields); err != nil {
+			return err
+		}
+		env.BlockValue = (*uint256.Int)(&value).ToBig()
+	}
+	env.ExecutionPayload = new(ExecutableData)
+	env.ExecutionPayload.setSSZPayload(version, payload)
+	return nil
+}
+
+// NewPayloadRequest contains the parameters of engine_newPayload, the request
+// body of the binary transport.
+type NewPayloadRequest struct {
+	ExecutionPayload *ExecutableData
+	VersionedHashes  []common.Hash
+	BeaconRoot       *common.Hash
+	Requests         [][]byte
+}
+
+// EncodeSSZ returns the SSZ encoding of the parameters of the given version of
+// engine_newPayload. Versions 1 and 2 carry only the payload, version 3 adds
+// the versioned hashes and the parent beacon block root, and version 4 adds
+// the execution requests.
+func (req *NewPayloadRequest) EncodeSSZ(method int) ([]byte, error) {
+	if req.ExecutionPayload == nil {
+		return nil, errors.New("ssz: missing execution payload")
+	}
+	payload, err := req.ExecutionPayload.sszPayload(payloadSchema(method))
+	if err != nil {
+		return nil, err
+	}
+	if method <= 2 {
+		return sszEncode(sszSpec.Wrap(payload))
+	}
+	if req.BeaconRoot == nil {
+		return nil, errors.New("ssz: missing beacon root")
+	}
+	hashes := make([]hexutil.Bytes, len(req.VersionedHashes))
+	for i := range req.VersionedHashes {
+		hashes[i] = req.VersionedHashes[i][:]
+	}
+	root := zrntcommon.Root(*req.BeaconRoot)
+	fields := sszContainer{
+		sszSpec.Wrap(payload),
+		sszVectors{&hashes, common.HashLength, uint64(sszSpec.MAX_BLOB_COMMITMENTS_PER_BLOCK)},
+		&root,
+	}
+	if method >= 4 {
+		fields = append(fields, sszByteLists{&req.Requests})
+	}
+	return sszEncode(fields)
+}
+
+// DecodeSSZ decodes the parameters of the given version of engine_newPayload
+// from their SSZ encoding.
+func (req *NewPayloadRequest) DecodeSSZ(method int, blob []byte) error {
+	var (
+		version = payloadSchema(method)
+		payload = newSSZPayload(version)
+	)
+	if method <= 2 {
+		if err := sszDecode(blob, sszSpec.Wrap(payload)); err != nil {
+			return err
+		}
+	} else {
+		var (
+			hashes []hexutil.Bytes
+			root   zrntcommon.Root
+		)
+		fields := sszContainer{
+			sszSpec.Wrap(payload),
+			sszVectors{&hashes, common.HashLength, uint64(sszSpec.MAX_BLOB_COMMITMENTS_PER_BLOCK)},
+			&root,
+		}
+		if method >= 4 {
+			fields = append(fields, sszByteLists{&req.Requests})
+		}
+		if err := sszDecode(blob, fields); err != nil {
+			return err
+		}
+		req.VersionedHashes = make([]common.Hash, len(hashes))
+		for i := range hashes {
+			req.VersionedHashes[i] = common.BytesToHash(hashes[i])
+		}
+		req.BeaconRoot = (*common.Hash)(&root)
+	}
+	req.ExecutionPayload = new(ExecutableData)
+	req.ExecutionPayload.setSSZPayload(version, payload)
+	return nil
+}
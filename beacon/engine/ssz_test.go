@@ -0,0 +1,260 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package engine
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+)
+
+// Positions of the offsets in the fixed part of a Deneb payload, and the size
+// of the fixed part.
+const (
+	testExtraDataOffset   = 436
+	testTxsOffset         = 504
+	testWithdrawalsOffset = 508
+	testFixedSize         = 528
+)
+
+func testPayload() *ExecutableData {
+	blobGasUsed, excessBlobGas := uint64(131072), uint64(0)
+	return &ExecutableData{
+		ParentHash:    common.Hash{1},
+		FeeRecipient:  common.Address{2},
+		StateRoot:     common.Hash{3},
+		ReceiptsRoot:  common.Hash{4},
+		LogsBloom:     make([]byte, types.BloomByteLength),
+		Random:        common.Hash{5},
+		Number:        6,
+		GasLimit:      7,
+		GasUsed:       8,
+		Timestamp:     9,
+		ExtraData:     []byte{0xde, 0xad},
+		BaseFeePerGas: big.NewInt(10),
+		BlockHash:     common.Hash{11},
+		Transactions:  [][]byte{{0x01, 0x02}, {}, {0x03}},
+		Withdrawals:   []*types.Withdrawal{{Index: 12, Validator: 13, Address: common.Address{14}, Amount: 15}},
+		BlobGasUsed:   &blobGasUsed,
+		ExcessBlobGas: &excessBlobGas,
+	}
+}
+
+func encodeTestPayload(t *testing.T) []byte {
+	t.Helper()
+	blob, err := testPayload().EncodeSSZ(PayloadV3)
+	if err != nil {
+		t.Fatalf("failed to encode payload: %v", err)
+	}
+	return blob
+}
+
+func TestPayloadSSZRoundTrip(t *testing.T) {
+	for _, version := range []PayloadVersion{PayloadV1, PayloadV2, PayloadV3} {
+		want := testPayload()
+		if version < PayloadV3 {
+			want.BlobGasUsed, want.ExcessBlobGas = nil, nil
+		}
+		if version < PayloadV2 {
+			want.Withdrawals = nil
+		}
+		blob, err := want.EncodeSSZ(version)
+		if err != nil {
+			t.Fatalf("v%d: failed to encode payload: %v", version, err)
+		}
+		var have ExecutableData
+		if err := have.DecodeSSZ(version, blob); err != nil {
+			t.Fatalf("v%d: failed to decode payload: %v", version, err)
+		}
+		wantJSON, _ := json.Marshal(want)
+		haveJSON, _ := json.Marshal(&have)
+		if string(haveJSON) != string(wantJSON) {
+			t.Fatalf("v%d: payload mismatch\nhave %s\nwant %s", version, haveJSON, wantJSON)
+		}
+	}
+}
+
+func TestEnvelopeSSZRoundTrip(t *testing.T) {
+	want := &ExecutionPayloadEnvelope{
+		ExecutionPayload: testPayload(),
+		BlockValue:       big.NewInt(16),
+		BlobsBundle: &BlobsBundleV1{
+			Commitments: []hexutil.Bytes{make([]byte, 48)},
+			Proofs:      []hexutil.Bytes{make([]byte, 48)},
+			Blobs:       []hexutil.Bytes{make([]byte, 131072)},
+		},
+		Override: true,
+		Requests: [][]byte{{0x00, 0x01}, {0x02}},
+	}
+	blob, err := want.EncodeSSZ(4)
+	if err != nil {
+		t.Fatalf("failed to encode envelope: %v", err)
+	}
+	var have ExecutionPayloadEnvelope
+	if err := have.DecodeSSZ(4, blob); err != nil {
+		t.Fatalf("failed to decode envelope: %v", err)
+	}
+	wantJSON, _ := json.Marshal(want)
+	haveJSON, _ := json.Marshal(&have)
+	if string(haveJSON) != string(wantJSON) {
+		t.Fatalf("envelope mismatch\nhave %s\nwant %s", haveJSON, wantJSON)
+	}
+}
+
+// Tests that payloads with malformed offsets or lengths are rejected.
+func TestPayloadSSZMalformed(t *testing.T) {
+	putOffset := func(blob []byte, pos int, offset int) []byte {
+		binary.LittleEndian.PutUint32(blob[pos:], uint32(offset))
+		return blob
+	}
+	tests := []struct {
+		name   string
+		mutate func(blob []byte) []byte
+	}{
+		{
+			name:   "empty",
+			mutate: func(blob []byte) []byte { return nil },
+		},
+		{
+			name:   "truncated fixed part",
+			mutate: func(blob []byte) []byte { return blob[:testFixedSize-1] },
+		},
+		{
+			name:   "first offset into the fixed part",
+			mutate: func(blob []byte) []byte { return putOffset(blob, testExtraDataOffset, testFixedSize-4) },
+		},
+		{
+			name:   "first offset past the fixed part",
+			mutate: func(blob []byte) []byte { return putOffset(blob, testExtraDataOffset, testFixedSize+1) },
+		},
+		{
+			name:   "decreasing offsets",
+			mutate: func(blob []byte) []byte { return putOffset(blob, testTxsOffset, testFixedSize-1) },
+		},
+		{
+			name:   "offset out of bounds",
+			mutate: func(blob []byte) []byte { return putOffset(blob, testWithdrawalsOffset, len(blob)+1) },
+		},
+		{
+			name: "extra data too long",
+			mutate: func(blob []byte) []byte {
+				const maxExtraData = zrntcommon.MAX_EXTRA_DATA_BYTES
+				// Grow the extra data past its limit, shifting the later fields.
+				grown := make([]byte, 0, len(blob)+maxExtraData)
+				grown = append(grown, blob[:testFixedSize]...)
+				grown = append(grown, make([]byte, maxExtraData+1)...)
+				grown = append(grown, blob[testFixedSize+2:]...)
+				putOffset(grown, testTxsOffset, int(binary.LittleEndian.Uint32(blob[testTxsOffset:]))+maxExtraData-1)
+				putOffset(grown, testWithdrawalsOffset, int(binary.LittleEndian.Uint32(blob[testWithdrawalsOffset:]))+maxExtraData-1)
+				return grown
+			},
+		},
+		{
+			name: "transaction offset out of bounds",
+			mutate: func(blob []byte) []byte {
+				txs := int(binary.LittleEndian.Uint32(blob[testTxsOffset:]))
+				return putOffset(blob, txs+4, 0xffff)
+			},
+		},
+		{
+			name: "misaligned first transaction offset",
+			mutate: func(blob []byte) []byte {
+				txs := int(binary.LittleEndian.Uint32(blob[testTxsOffset:]))
+				return putOffset(blob, txs, 5)
+			},
+		},
+		{
+			name:   "truncated withdrawal",
+			mutate: func(blob []byte) []byte { return blob[:len(blob)-1] },
+		},
+		{
+			name:   "trailing withdrawal bytes",
+			mutate: func(blob []byte) []byte { return append(blob, 0) },
+		},
+	}
+	for _, test := range tests {
+		blob := test.mutate(encodeTestPayload(t))
+		var data ExecutableData
+		if err := data.DecodeSSZ(PayloadV3, blob); err == nil {
+			t.Errorf("%s: malformed payload accepted", test.name)
+		}
+	}
+}
+
+// Tests that the newPayload parameters with malformed offsets or lengths are
+// rejected.
+func TestNewPayloadRequestSSZMalformed(t *testing.T) {
+	root := common.Hash{42}
+	req := NewPayloadRequest{
+		ExecutionPayload: testPayload(),
+		VersionedHashes:  []common.Hash{{1}, {2}},
+		BeaconRoot:       &root,
+		Requests:         [][]byte{{0x00, 0x01}},
+	}
+	encode := func() []byte {
+		blob, err := req.EncodeSSZ(4)
+		if err != nil {
+			t.Fatalf("failed to encode request: %v", err)
+		}
+		return blob
+	}
+	var decoded NewPayloadRequest
+	if err := decoded.DecodeSSZ(4, encode()); err != nil {
+		t.Fatalf("failed to decode request: %v", err)
+	}
+	if len(decoded.VersionedHashes) != 2 || *decoded.BeaconRoot != root || len(decoded.Requests) != 1 {
+		t.Fatalf("request mismatch: %+v", decoded)
+	}
+
+	// The fixed part holds the offsets of the payload, the versioned hashes and
+	// the requests, around the beacon root.
+	const (
+		hashesOffset   = 4
+		requestsOffset = 40
+	)
+	tests := map[string]func(blob []byte) []byte{
+		"truncated fixed part": func(blob []byte) []byte { return blob[:requestsOffset] },
+		"payload offset past the fixed part": func(blob []byte) []byte {
+			binary.LittleEndian.PutUint32(blob, 45)
+			return blob
+		},
+		"hashes offset out of bounds": func(blob []byte) []byte {
+			binary.LittleEndian.PutUint32(blob[hashesOffset:], uint32(len(blob)+1))
+			return blob
+		},
+		"decreasing offsets": func(blob []byte) []byte {
+			binary.LittleEndian.PutUint32(blob[requestsOffset:], binary.LittleEndian.Uint32(blob[hashesOffset:])-1)
+			return blob
+		},
+		"partial versioned hash": func(blob []byte) []byte {
+			binary.LittleEndian.PutUint32(blob[requestsOffset:], binary.LittleEndian.Uint32(blob[requestsOffset:])-1)
+			return blob
+		},
+	}
+	for name, mutate := range tests {
+		var req NewPayloadRequest
+		if err := req.DecodeSSZ(4, mutate(encode())); err == nil {
+			t.Errorf("%s: malformed request accepted", name)
+		}
+	}
+}
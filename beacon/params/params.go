@@ -46,6 +46,12 @@ const (
 	StateIndexExecHead                 = 908
 
 	BodyIndexExecPayload = 25
+
+	// Indices of the execution block hash in the beacon block. The execution
+	// payload gained the blob gas fields in Deneb, which deepened its tree by
+	// one level.
+	BlockIndexExecHashCapella = 3228
+	BlockIndexExecHashDeneb   = 6444
 )
 
 func StateIndexFinalBlock(forkName string) uint64 {
@@ -20,7 +20,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math/bits"
 
+	"github.com/ethereum/go-ethereum/beacon/merkle"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
@@ -52,8 +54,8 @@ func BlockFromJSON(forkName string, data []byte) (*BeaconBlock, error) {
 		obj = new(capella.BeaconBlock)
 	case "deneb":
 		obj = new(deneb.BeaconBlock)
-	case "electra":
-		obj = new(electra.BeaconBlock)
+	case "electra", "fulu":
+		obj = new(electra.BeaconBlock) // the block format is unchanged in Fulu
 	default:
 		return nil, fmt.Errorf("unsupported fork: %s", forkName)
 	}
@@ -125,6 +127,93 @@ func (b *BeaconBlock) Root() common.Hash {
 	return common.Hash(b.blockObj.HashTreeRoot(configs.Mainnet, tree.GetHashFn()))
 }
 
+// ExecutionBlockHashProof returns the merkle branch proving the execution block
+// hash of the block against the block root, ordered from the leaf upwards.
+func (b *BeaconBlock) ExecutionBlockHashProof() merkle.Values {
+	var (
+		spec   = configs.Mainnet
+		hashFn = tree.GetHashFn()
+		branch merkle.Values
+	)
+	switch obj := b.blockObj.(type) {
+	case *capella.BeaconBlock:
+		p, body := &obj.Body.ExecutionPayload, &obj.Body
+		branch = append(branch, fieldBranch(hashFn, payloadIndexBlockHash, &p.ParentHash, &p.FeeRecipient, &p.StateRoot,
+			&p.ReceiptsRoot, &p.LogsBloom, &p.PrevRandao, &p.BlockNumber, &p.GasLimit,
+			&p.GasUsed, &p.Timestamp, &p.ExtraData, &p.BaseFeePerGas,
+			&p.BlockHash, spec.Wrap(&p.Transactions), spec.Wrap(&p.Withdrawals))...)
+		branch = append(branch, fieldBranch(hashFn, bodyIndexExecPayload, body.RandaoReveal, &body.Eth1Data,
+			body.Graffiti, spec.Wrap(&body.ProposerSlashings),
+			spec.Wrap(&body.AttesterSlashings), spec.Wrap(&body.Attestations),
+			spec.Wrap(&body.Deposits), spec.Wrap(&body.VoluntaryExits),
+			spec.Wrap(&body.SyncAggregate), spec.Wrap(&body.ExecutionPayload),
+			spec.Wrap(&body.BLSToExecutionChanges))...)
+		branch = append(branch, fieldBranch(hashFn, blockIndexBody, obj.Slot, obj.ProposerIndex, obj.ParentRoot, obj.StateRoot, spec.Wrap(&obj.Body))...)
+	case *deneb.BeaconBlock:
+		p, body := &obj.Body.ExecutionPayload, &obj.Body
+		branch = append(branch, denebPayloadBranch(spec, hashFn, p)...)
+		branch = append(branch, fieldBranch(hashFn, bodyIndexExecPayload, body.RandaoReveal, &body.Eth1Data,
+			body.Graffiti, spec.Wrap(&body.ProposerSlashings),
+			spec.Wrap(&body.AttesterSlashings), spec.Wrap(&body.Attestations),
+			spec.Wrap(&body.Deposits), spec.Wrap(&body.VoluntaryExits),
+			spec.Wrap(&body.SyncAggregate), spec.Wrap(&body.ExecutionPayload),
+			spec.Wrap(&body.BLSToExecutionChanges),
+			spec.Wrap(&body.BlobKZGCommitments))...)
+		branch = append(branch, fieldBranch(hashFn, blockIndexBody, obj.Slot, obj.ProposerIndex, obj.ParentRoot, obj.StateRoot, spec.Wrap(&obj.Body))...)
+	case *electra.BeaconBlock:
+		p, body := &obj.Body.ExecutionPayload, &obj.Body
+		branch = append(branch, denebPayloadBranch(spec, hashFn, p)...)
+		branch = append(branch, fieldBranch(hashFn, bodyIndexExecPayload, body.RandaoReveal, &body.Eth1Data,
+			body.Graffiti, spec.Wrap(&body.ProposerSlashings),
+			spec.Wrap(&body.AttesterSlashings), spec.Wrap(&body.Attestations),
+			spec.Wrap(&body.Deposits), spec.Wrap(&body.VoluntaryExits),
+			spec.Wrap(&body.SyncAggregate), spec.Wrap(&body.ExecutionPayload),
+			spec.Wrap(&body.BLSToExecutionChanges),
+			spec.Wrap(&body.BlobKZGCommitments),
+			spec.Wrap(&body.ExecutionRequests))...)
+		branch = append(branch, fieldBranch(hashFn, blockIndexBody, obj.Slot, obj.ProposerIndex, obj.ParentRoot, obj.StateRoot, spec.Wrap(&obj.Body))...)
+	default:
+		panic(fmt.Errorf("unsupported block type %T", b.blockObj))
+	}
+	return branch
+}
+
+// Positions of the fields along the path from the block root to the execution
+// block hash.
+const (
+	blockIndexBody        = 4
+	bodyIndexExecPayload  = 9
+	payloadIndexBlockHash = 12
+)
+
+// denebPayloadBranch returns the merkle branch of the block hash in a Deneb
+// execution payload.
+func denebPayloadBranch(spec *zrntcommon.Spec, hashFn tree.HashFn, p *deneb.ExecutionPayload) merkle.Values {
+	return fieldBranch(hashFn, payloadIndexBlockHash, &p.ParentHash, &p.FeeRecipient, &p.StateRoot,
+		&p.ReceiptsRoot, &p.LogsBloom, &p.PrevRandao, &p.BlockNumber, &p.GasLimit,
+		&p.GasUsed, &p.Timestamp, &p.ExtraData, &p.BaseFeePerGas,
+		&p.BlockHash, spec.Wrap(&p.Transactions), spec.Wrap(&p.Withdrawals),
+		&p.BlobGasUsed, &p.ExcessBlobGas)
+}
+
+// fieldBranch returns the merkle branch of the field at the given position in a
+// container with the given fields, ordered from the field upwards.
+func fieldBranch(hashFn tree.HashFn, index int, fields ...tree.HTR) merkle.Values {
+	layer := make([]zrntcommon.Root, 1<<bits.Len(uint(len(fields)-1)))
+	for i, field := range fields {
+		layer[i] = field.HashTreeRoot(hashFn)
+	}
+	var branch merkle.Values
+	for ; len(layer) > 1; index /= 2 {
+		branch = append(branch, merkle.Value(layer[index^1]))
+		for i := 0; i < len(layer)/2; i++ {
+			layer[i] = hashFn(layer[2*i], layer[2*i+1])
+		}
+		layer = layer[:len(layer)/2]
+	}
+	return branch
+}
+
 // ExecutionRequestsList returns the execution layer requests of the block.
 func (b *BeaconBlock) ExecutionRequestsList() [][]byte {
 	switch obj := b.blockObj.(type) {
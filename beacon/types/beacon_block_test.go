@@ -21,6 +21,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/beacon/merkle"
+	"github.com/ethereum/go-ethereum/beacon/params"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -93,6 +95,14 @@ func TestBlockFromJSON(t *testing.T) {
 			if execBlock.Hash() != test.wantBlockHash {
 				t.Errorf("wrong block hash: %v", execBlock.Hash())
 			}
+			branch := beaconBlock.ExecutionBlockHashProof()
+			index := uint64(params.BlockIndexExecHashDeneb)
+			if test.version == "capella" {
+				index = params.BlockIndexExecHashCapella
+			}
+			if err := merkle.VerifyProof(beaconBlock.Root(), index, branch, merkle.Value(test.wantBlockHash)); err != nil {
+				t.Errorf("invalid execution block hash proof: %v", err)
+			}
 		})
 	}
 }
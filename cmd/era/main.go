@@ -42,12 +42,12 @@ var app = flags.NewApp("go-ethereum era tool")
 var (
 	dirFlag = &cli.StringFlag{
 		Name:  "dir",
-		Usage: "directory storing all relevant era1 files",
+		Usage: "directory storing all relevant era1 and era-E files",
 		Value: "eras",
 	}
 	networkFlag = &cli.StringFlag{
 		Name:  "network",
-		Usage: "network name associated with era1 files",
+		Usage: "network name associated with era files",
 		Value: "mainnet",
 	}
 	eraSizeFlag = &cli.IntFlag{
@@ -59,6 +59,10 @@ var (
 		Name:  "txs",
 		Usage: "print full transaction values",
 	}
+	summariesFlag = &cli.StringFlag{
+		Name:  "summaries",
+		Usage: "JSON file mapping historical roots periods to block summary roots, for verifying era-E proofs",
+	}
 )
 
 var (
@@ -80,8 +84,11 @@ var (
 	verifyCommand = &cli.Command{
 		Name:      "verify",
 		ArgsUsage: "<expected>",
-		Usage:     "verifies each era1 against expected accumulator root",
+		Usage:     "verifies each era1 and era-E against expected accumulator root",
 		Action:    verify,
+		Flags: []cli.Flag{
+			summariesFlag,
+		},
 	}
 )
 
@@ -105,15 +112,23 @@ func main() {
 	}
 }
 
-// block prints the specified block from an era1 store.
+// block prints the specified block from an era store.
 func block(ctx *cli.Context) error {
 	num, err := strconv.ParseUint(ctx.Args().First(), 10, 64)
 	if err != nil {
 		return fmt.Errorf("invalid block number: %w", err)
 	}
-	e, err := open(ctx, num/uint64(ctx.Int(eraSizeFlag.Name)))
+	epoch := num / uint64(ctx.Int(eraSizeFlag.Name))
+	e, err := open(ctx, epoch)
 	if err != nil {
-		return fmt.Errorf("error opening era1: %w", err)
+		return fmt.Errorf("error opening era: %w", err)
+	}
+	// The post-merge blocks of the merge epoch are archived in era-E file.
+	if e.Format() == era.FormatEra1 && num >= e.Start()+e.Count() {
+		e.Close()
+		if e, err = openE(ctx, epoch); err != nil {
+			return fmt.Errorf("error opening era-E: %w", err)
+		}
 	}
 	defer e.Close()
 	// Read block with number.
@@ -131,7 +146,7 @@ func block(ctx *cli.Context) error {
 	return nil
 }
 
-// info prints some high-level information about the era1 file.
+// info prints some high-level information about the era file.
 func info(ctx *cli.Context) error {
 	epoch, err := strconv.ParseUint(ctx.Args().First(), 10, 64)
 	if err != nil {
@@ -146,24 +161,28 @@ func info(ctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error reading accumulator: %w", err)
 	}
-	td, err := e.InitialTD()
-	if err != nil {
-		return fmt.Errorf("error reading total difficulty: %w", err)
+	var td *big.Int
+	if e.Format() == era.FormatEra1 {
+		if td, err = e.InitialTD(); err != nil {
+			return fmt.Errorf("error reading total difficulty: %w", err)
+		}
 	}
 	info := struct {
+		Format          string      `json:"format"`
 		Accumulator     common.Hash `json:"accumulator"`
-		TotalDifficulty *big.Int    `json:"totalDifficulty"`
+		TotalDifficulty *big.Int    `json:"totalDifficulty,omitempty"`
 		StartBlock      uint64      `json:"startBlock"`
 		Count           uint64      `json:"count"`
 	}{
-		acc, td, e.Start(), e.Count(),
+		e.Format().String(), acc, td, e.Start(), e.Count(),
 	}
 	b, _ := json.MarshalIndent(info, "", "  ")
 	fmt.Println(string(b))
 	return nil
 }
 
-// open opens an era1 file at a certain epoch.
+// open opens an era file at a certain epoch. The era-E file is opened if the
+// epoch is not covered by era1 files.
 func open(ctx *cli.Context, epoch uint64) (*era.Era, error) {
 	var (
 		dir     = ctx.String(dirFlag.Name)
@@ -173,14 +192,38 @@ func open(ctx *cli.Context, epoch uint64) (*era.Era, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error reading era dir: %w", err)
 	}
-	if epoch >= uint64(len(entries)) {
-		return nil, fmt.Errorf("epoch out-of-bounds: last %d, want %d", len(entries)-1, epoch)
+	if epoch < uint64(len(entries)) {
+		return era.Open(filepath.Join(dir, entries[epoch]))
 	}
-	return era.Open(filepath.Join(dir, entries[epoch]))
+	return openE(ctx, epoch)
 }
 
-// verify checks each era1 file in a directory to ensure it is well-formed and
-// that the accumulator matches the expected value.
+// openE opens an era-E file at a certain epoch.
+func openE(ctx *cli.Context, epoch uint64) (*era.Era, error) {
+	var (
+		dir     = ctx.String(dirFlag.Name)
+		network = ctx.String(networkFlag.Name)
+	)
+	entries, err := era.ReadDirE(dir, network)
+	if err != nil {
+		return nil, fmt.Errorf("error reading era dir: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("epoch out-of-bounds: no era files, want %d", epoch)
+	}
+	first, err := era.Epoch(entries[0])
+	if err != nil {
+		return nil, err
+	}
+	if epoch < first || epoch-first >= uint64(len(entries)) {
+		return nil, fmt.Errorf("epoch out-of-bounds: first %d, last %d, want %d", first, first+uint64(len(entries))-1, epoch)
+	}
+	return era.Open(filepath.Join(dir, entries[epoch-first]))
+}
+
+// verify checks each era1 and era-E file in a directory to ensure it is
+// well-formed and that the accumulator matches the expected value. The roots
+// of the era-E files are listed after the era1 ones.
 func verify(ctx *cli.Context) error {
 	if ctx.Args().Len() != 1 {
 		return errors.New("missing accumulators file")
@@ -190,6 +233,12 @@ func verify(ctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("unable to read expected roots file: %w", err)
 	}
+	var summaries map[uint64]common.Hash
+	if ctx.IsSet(summariesFlag.Name) {
+		if summaries, err = readSummaries(ctx.String(summariesFlag.Name)); err != nil {
+			return fmt.Errorf("unable to read historical summaries file: %w", err)
+		}
+	}
 
 	var (
 		dir      = ctx.String(dirFlag.Name)
@@ -202,9 +251,14 @@ func verify(ctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error reading %s: %w", dir, err)
 	}
+	entriesE, err := era.ReadDirE(dir, network)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", dir, err)
+	}
+	entries = append(entries, entriesE...)
 
 	if len(entries) != len(roots) {
-		return errors.New("number of era1 files should match the number of accumulator hashes")
+		return errors.New("number of era files should match the number of accumulator hashes")
 	}
 
 	// Verify each epoch matches the expected root.
@@ -214,7 +268,7 @@ func verify(ctx *cli.Context) error {
 			name := entries[i]
 			e, err := era.Open(filepath.Join(dir, name))
 			if err != nil {
-				return fmt.Errorf("error opening era1 file %s: %w", name, err)
+				return fmt.Errorf("error opening era file %s: %w", name, err)
 			}
 			defer e.Close()
 			// Read accumulator and check against expected.
@@ -224,12 +278,17 @@ func verify(ctx *cli.Context) error {
 				return fmt.Errorf("invalid root %s: got %s, want %s", name, got, want)
 			}
 			// Recompute accumulator.
-			if err := checkAccumulator(e); err != nil {
-				return fmt.Errorf("error verify era1 file %s: %w", name, err)
+			if e.Format() == era.FormatEraE {
+				err = checkBlockHashesRoot(e, summaries)
+			} else {
+				err = checkAccumulator(e)
+			}
+			if err != nil {
+				return fmt.Errorf("error verify era file %s: %w", name, err)
 			}
 			// Give the user some feedback that something is happening.
 			if time.Since(reported) >= 8*time.Second {
-				fmt.Printf("Verifying Era1 files \t\t verified=%d,\t elapsed=%s\n", i, common.PrettyDuration(time.Since(start)))
+				fmt.Printf("Verifying Era files \t\t verified=%d,\t elapsed=%s\n", i, common.PrettyDuration(time.Since(start)))
 				reported = time.Now()
 			}
 			return nil
@@ -280,15 +339,9 @@ func checkAccumulator(e *era.Era) error {
 		if it.Error() != nil {
 			return fmt.Errorf("error reading block %d: %w", it.Number(), err)
 		}
-		// 2) recompute tx root and verify against header.
-		tr := types.DeriveSha(block.Transactions(), trie.NewStackTrie(nil))
-		if tr != block.TxHash() {
-			return fmt.Errorf("tx root in block %d mismatch: want %s, got %s", block.NumberU64(), block.TxHash(), tr)
-		}
-		// 3) recompute receipt root and check value against block.
-		rr := types.DeriveSha(receipts, trie.NewStackTrie(nil))
-		if rr != block.ReceiptHash() {
-			return fmt.Errorf("receipt root in block %d mismatch: want %s, got %s", block.NumberU64(), block.ReceiptHash(), rr)
+		// 2+3) recompute tx and receipt roots and verify against header.
+		if err := checkRoots(block, receipts); err != nil {
+			return err
 		}
 		hashes = append(hashes, block.Hash())
 		td.Add(td, block.Difficulty())
@@ -305,6 +358,90 @@ func checkAccumulator(e *era.Era) error {
 	return nil
 }
 
+// checkBlockHashesRoot verifies the root of the block hashes matches the data
+// in the era-E file. The mandatory proofs of the blocks are verified against the
+// given historical summaries, if the summary of the proven period is available.
+func checkBlockHashesRoot(e *era.Era, summaries map[uint64]common.Hash) error {
+	want, err := e.Accumulator()
+	if err != nil {
+		return fmt.Errorf("error reading block hashes root: %w", err)
+	}
+	it, err := era.NewIterator(e)
+	if err != nil {
+		return fmt.Errorf("error making era iterator: %w", err)
+	}
+	var (
+		hashes  = make([]common.Hash, 0)
+		skipped int
+	)
+	for it.Next() {
+		if err := it.Error(); err != nil {
+			return fmt.Errorf("error reading block %d: %w", it.Number(), err)
+		}
+		block, receipts, err := it.BlockAndReceipts()
+		if err != nil {
+			return fmt.Errorf("error reading block %d: %w", it.Number(), err)
+		}
+		if block.Difficulty().Sign() != 0 {
+			return fmt.Errorf("pre-merge block %d in era-E file", block.NumberU64())
+		}
+		if err := checkRoots(block, receipts); err != nil {
+			return err
+		}
+		proof, err := it.Proof()
+		if err != nil {
+			return fmt.Errorf("error reading proof %d: %w", it.Number(), err)
+		}
+		if root, ok := summaries[proof.Period()]; ok {
+			if err := proof.Verify(block.Hash(), root); err != nil {
+				return fmt.Errorf("block %d: %w", block.NumberU64(), err)
+			}
+		} else {
+			skipped++
+		}
+		hashes = append(hashes, block.Hash())
+	}
+	got, err := era.ComputeBlockHashesRoot(hashes)
+	if err != nil {
+		return fmt.Errorf("error computing block hashes root: %w", err)
+	}
+	if got != want {
+		return fmt.Errorf("expected block hashes root does not match calculated: got %s, want %s", got, want)
+	}
+	if skipped > 0 {
+		fmt.Printf("Skipped %d proofs in era-E file starting at %d, historical summaries unavailable\n", skipped, e.Start())
+	}
+	return nil
+}
+
+// checkRoots verifies the transactions and receipts of a block against the
+// roots in its header.
+func checkRoots(block *types.Block, receipts types.Receipts) error {
+	tr := types.DeriveSha(block.Transactions(), trie.NewStackTrie(nil))
+	if tr != block.TxHash() {
+		return fmt.Errorf("tx root in block %d mismatch: want %s, got %s", block.NumberU64(), block.TxHash(), tr)
+	}
+	rr := types.DeriveSha(receipts, trie.NewStackTrie(nil))
+	if rr != block.ReceiptHash() {
+		return fmt.Errorf("receipt root in block %d mismatch: want %s, got %s", block.NumberU64(), block.ReceiptHash(), rr)
+	}
+	return nil
+}
+
+// readSummaries reads a JSON file mapping historical roots periods to the
+// block summary roots of the beacon state's historical summaries.
+func readSummaries(f string) (map[uint64]common.Hash, error) {
+	b, err := os.ReadFile(f)
+	if err != nil {
+		return nil, err
+	}
+	var summaries map[uint64]common.Hash
+	if err := json.Unmarshal(b, &summaries); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
 // readHashes reads a file of newline-delimited hashes.
 func readHashes(f string) ([]common.Hash, error) {
 	b, err := os.ReadFile(f)
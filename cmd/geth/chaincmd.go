@@ -145,7 +145,8 @@ be gzipped.`,
 		Flags:     slices.Concat([]cli.Flag{utils.TxLookupLimitFlag, utils.TransactionHistoryFlag}, utils.DatabaseFlags, utils.NetworkFlags),
 		Description: `
 The import-history command will import blocks and their corresponding receipts
-from Era archives.
+from Era archives. The pre-merge history is read from Era1 archives, the
+post-merge history from era-E archives.
 `,
 	}
 	exportHistoryCommand = &cli.Command{
@@ -153,10 +154,18 @@ from Era archives.
 		Name:      "export-history",
 		Usage:     "Export blockchain history to Era archives",
 		ArgsUsage: "<dir> <first> <last>",
-		Flags:     utils.DatabaseFlags,
+		Flags:     slices.Concat([]cli.Flag{utils.BeaconApiFlag, utils.BeaconApiHeaderFlag}, utils.DatabaseFlags),
 		Description: `
 The export-history command will export blocks and their corresponding receipts
-into Era archives. Eras are typically packaged in steps of 8192 blocks.
+into Era archives. Eras are typically packaged in steps of 8192 blocks. The
+pre-merge history is exported into Era1 archives, the post-merge history into
+era-E archives.
+
+Every block in an era-E archive carries a mandatory proof of its inclusion in
+the beacon chain, which is built from the beacon blocks retrieved from the
+beacon node API given by --beacon.api. Post-merge history can't be exported
+without it, and only complete historical roots periods of 8192 slots can be
+proven.
 `,
 	}
 	importPreimagesCommand = &cli.Command{
@@ -448,12 +457,16 @@ func importHistory(ctx *cli.Context) error {
 			if err != nil {
 				return fmt.Errorf("error reading %s: %w", dir, err)
 			}
-			if len(entries) > 0 {
+			entriesE, err := era.ReadDirE(dir, n)
+			if err != nil {
+				return fmt.Errorf("error reading %s: %w", dir, err)
+			}
+			if len(entries) > 0 || len(entriesE) > 0 {
 				networks = append(networks, n)
 			}
 		}
 		if len(networks) == 0 {
-			return fmt.Errorf("no era1 files found in %s", dir)
+			return fmt.Errorf("no era files found in %s", dir)
 		}
 		if len(networks) > 1 {
 			return errors.New("multiple networks found, use a network flag to specify desired network")
@@ -495,7 +508,8 @@ func exportHistory(ctx *cli.Context) error {
 	if head := chain.CurrentSnapBlock(); uint64(last) > head.Number.Uint64() {
 		utils.Fatalf("Export error: block number %d larger than head block %d\n", uint64(last), head.Number.Uint64())
 	}
-	err := utils.ExportHistory(chain, dir, uint64(first), uint64(last), uint64(era.MaxEra1Size))
+	proofs := utils.MakeBeaconProofSource(ctx, chain)
+	err := utils.ExportHistory(chain, dir, uint64(first), uint64(last), uint64(era.MaxEra1Size), proofs)
 	if err != nil {
 		utils.Fatalf("Export error: %v\n", err)
 	}
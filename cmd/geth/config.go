@@ -39,6 +39,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/catalyst"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/internal/flags"
+	"github.com/ethereum/go-ethereum/internal/telemetry"
 	"github.com/ethereum/go-ethereum/internal/version"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
@@ -105,10 +106,11 @@ type ethstatsConfig struct {
 }
 
 type gethConfig struct {
-	Eth      ethconfig.Config
-	Node     node.Config
-	Ethstats ethstatsConfig
-	Metrics  metrics.Config
+	Eth       ethconfig.Config
+	Node      node.Config
+	Ethstats  ethstatsConfig
+	Metrics   metrics.Config
+	Telemetry telemetry.Config
 }
 
 func loadConfig(file string, cfg *gethConfig) error {
@@ -142,9 +144,10 @@ func defaultNodeConfig() node.Config {
 func loadBaseConfig(ctx *cli.Context) gethConfig {
 	// Load defaults.
 	cfg := gethConfig{
-		Eth:     ethconfig.Defaults,
-		Node:    defaultNodeConfig(),
-		Metrics: metrics.DefaultConfig,
+		Eth:       ethconfig.Defaults,
+		Node:      defaultNodeConfig(),
+		Metrics:   metrics.DefaultConfig,
+		Telemetry: telemetry.DefaultConfig,
 	}
 
 	// Load config file.
@@ -176,6 +179,7 @@ func makeConfigNode(ctx *cli.Context) (*node.Node, gethConfig) {
 		cfg.Ethstats.URL = ctx.String(utils.EthStatsURLFlag.Name)
 	}
 	applyMetricConfig(ctx, &cfg)
+	applyTelemetryConfig(ctx, &cfg)
 
 	return stack, cfg
 }
@@ -195,6 +199,9 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 	// Start metrics export if enabled
 	utils.SetupMetrics(&cfg.Metrics)
 
+	// Start span export if enabled
+	utils.SetupTelemetry(stack, &cfg.Telemetry)
+
 	backend, eth := utils.RegisterEthService(stack, &cfg.Eth)
 
 	// Create gauge with geth system and build information
@@ -214,6 +221,13 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 	// Configure log filter RPC API.
 	filterSystem := utils.RegisterFilterAPI(stack, backend, &cfg.Eth)
 
+	// Configure the Otterscan RPC API.
+	utils.RegisterOtterscanAPI(stack, backend, filterSystem)
+
+	// Configure the remote database API if requested.
+	if ctx.Bool(utils.RemoteDBServeFlag.Name) {
+		utils.RegisterRemoteDBAPI(stack, backend.ChainDb())
+	}
 	// Configure GraphQL if requested.
 	if ctx.IsSet(utils.GraphQLEnabledFlag.Name) {
 		utils.RegisterGraphQLService(stack, backend, filterSystem, &cfg.Node)
@@ -285,6 +299,21 @@ func dumpConfig(ctx *cli.Context) error {
 	return nil
 }
 
+func applyTelemetryConfig(ctx *cli.Context, cfg *gethConfig) {
+	if ctx.IsSet(utils.TelemetryEnabledFlag.Name) {
+		cfg.Telemetry.Enabled = ctx.Bool(utils.TelemetryEnabledFlag.Name)
+	}
+	if ctx.IsSet(utils.TelemetryEndpointFlag.Name) {
+		cfg.Telemetry.Endpoint = ctx.String(utils.TelemetryEndpointFlag.Name)
+	}
+	if ctx.IsSet(utils.TelemetryFileFlag.Name) {
+		cfg.Telemetry.File = ctx.String(utils.TelemetryFileFlag.Name)
+	}
+	if ctx.IsSet(utils.TelemetrySampleRatioFlag.Name) {
+		cfg.Telemetry.SampleRatio = ctx.Float64(utils.TelemetrySampleRatioFlag.Name)
+	}
+}
+
 func applyMetricConfig(ctx *cli.Context, cfg *gethConfig) {
 	if ctx.IsSet(utils.MetricsEnabledFlag.Name) {
 		cfg.Metrics.Enabled = ctx.Bool(utils.MetricsEnabledFlag.Name)
@@ -18,7 +18,9 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -55,6 +57,11 @@ var (
 		Usage: "If set, selects the state data for removal",
 	}
 
+	verifyChainRestartFlag = &cli.BoolFlag{
+		Name:  "restart",
+		Usage: "Discard the verification progress and verify the chain from the genesis",
+	}
+
 	removedbCommand = &cli.Command{
 		Action:    removeDB,
 		Name:      "removedb",
@@ -78,6 +85,9 @@ Remove blockchain and state databases`,
 			dbPutCmd,
 			dbGetSlotsCmd,
 			dbDumpFreezerIndex,
+			dbConvertFreezerCmd,
+			dbConvertStateCmd,
+			dbVerifyChainCmd,
 			dbImportCmd,
 			dbExportCmd,
 			dbMetadataCmd,
@@ -163,6 +173,52 @@ WARNING: This is a low-level operation which may cause database corruption!`,
 		Flags:       slices.Concat(utils.NetworkFlags, utils.DatabaseFlags),
 		Description: "This command displays information about the freezer index.",
 	}
+	dbConvertFreezerCmd = &cli.Command{
+		Action:    freezerConvert,
+		Name:      "freezer-convert",
+		Usage:     "Re-compress a specific freezer table with another codec",
+		ArgsUsage: "<freezer-type> <table-type> <codec (snappy|zstd)>",
+		Flags: slices.Concat([]cli.Flag{
+			&cli.BoolFlag{
+				Name:  "dict",
+				Usage: "compress with a dictionary sampled from the table items (zstd only)",
+			},
+		}, utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command converts the items of the freezer table to the given codec in place.
+The node must be stopped during the conversion. The converted table is assembled
+next to the original one, so enough disk space must be available to hold both.`,
+	}
+	dbConvertStateCmd = &cli.Command{
+		Action:    convertState,
+		Name:      "convert-state",
+		Usage:     "Convert the state from the hash-based scheme to the path-based scheme in place",
+		ArgsUsage: "",
+		Flags:     slices.Concat(utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command regenerates the state of the chain head from the state snapshot
+and writes the trie nodes in the path-based scheme, so that the node can switch to
+the path-based scheme without a full resync. Afterwards, the trie nodes in the
+hash-based scheme are deleted as a separate step.
+
+The node must be stopped during the conversion, and the state snapshot must be
+complete. The command can be interrupted and rerun, picking up where it left off.`,
+	}
+	dbVerifyChainCmd = &cli.Command{
+		Action:    verifyChain,
+		Name:      "verify-chain",
+		Usage:     "Verify the integrity of the chain data in the freezer and key-value store",
+		ArgsUsage: "",
+		Flags:     slices.Concat([]cli.Flag{verifyChainRestartFlag}, utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command validates the index files of the chain freezer tables against their
+data files, then re-validates every canonical block up to the chain head: the header
+is checked against the canonical hash and the parent hash, the transactions, uncles
+and withdrawals against the roots in the header, and the receipts against the
+receipt root and the bloom.
+
+The chain data is opened read-only, the verification progress is saved when the
+command exits, so it can be interrupted and rerun, picking up where it left off.
+Once finished, the subsequent runs only verify the newly added blocks, unless
+--restart is specified.`,
+	}
 	dbImportCmd = &cli.Command{
 		Action:      importLDBdata,
 		Name:        "import",
@@ -605,6 +661,114 @@ func freezerInspect(ctx *cli.Context) error {
 	return rawdb.InspectFreezerTable(ancient, freezer, table, start, end)
 }
 
+func freezerConvert(ctx *cli.Context) error {
+	if ctx.NArg() < 3 {
+		return fmt.Errorf("required arguments: %v", ctx.Command.ArgsUsage)
+	}
+	var (
+		freezer = ctx.Args().Get(0)
+		table   = ctx.Args().Get(1)
+		codec   = ctx.Args().Get(2)
+	)
+	stack, _ := makeConfigNode(ctx)
+	ancient := stack.ResolveAncient("chaindata", ctx.String(utils.AncientFlag.Name))
+	stack.Close()
+	return rawdb.ConvertFreezerTable(ancient, freezer, table, codec, ctx.Bool("dict"))
+}
+
+func convertState(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, false)
+	defer db.Close()
+
+	switch rawdb.ReadStateScheme(db) {
+	case rawdb.HashScheme:
+		head := rawdb.ReadHeadBlock(db)
+		if head == nil {
+			return errors.New("failed to load head block")
+		}
+		tdb := triedb.NewDatabase(db, triedb.HashDefaults)
+		defer tdb.Close()
+
+		snapconfig := snapshot.Config{
+			CacheSize:  256,
+			Recovery:   false,
+			NoBuild:    true,
+			AsyncBuild: false,
+		}
+		snaptree, err := snapshot.New(snapconfig, db, tdb, head.Root())
+		if err != nil {
+			log.Error("Failed to open snapshot tree", "err", err)
+			return err
+		}
+		log.Info("Converting state into path scheme", "number", head.NumberU64(), "root", head.Root())
+		if err := snapshot.ConvertState(snaptree, head.Root(), db); err != nil {
+			log.Error("Failed to convert state", "err", err)
+			return err
+		}
+	case rawdb.PathScheme:
+		// The conversion is already done, delete the legacy trie nodes if
+		// it's not finished yet.
+		if rawdb.ReadStateConversion(db) == (common.Hash{}) {
+			log.Info("State is already in path scheme")
+			return nil
+		}
+	default:
+		return errors.New("state is not present")
+	}
+	return deleteLegacyTrieNodes(db)
+}
+
+// deleteLegacyTrieNodes deletes all the trie nodes along with the contract codes
+// stored in the hash-based scheme, in the final step of the state conversion.
+func deleteLegacyTrieNodes(db ethdb.Database) error {
+	var (
+		count  int
+		size   common.StorageSize
+		start  = time.Now()
+		logged = time.Now()
+		batch  = db.NewBatch()
+		it     = rawdb.NewKeyLengthIterator(db.NewIterator(nil, nil), common.HashLength)
+	)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		batch.Delete(key)
+		count++
+		size += common.StorageSize(len(key) + len(it.Value()))
+
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Deleting legacy trie nodes", "count", count, "size", size, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	rawdb.DeleteStateConversion(batch)
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	log.Info("Deleted legacy trie nodes", "count", count, "size", size, "elapsed", common.PrettyDuration(time.Since(start)))
+
+	cstart := time.Now()
+	log.Info("Compacting database")
+	if err := db.Compact(nil, nil); err != nil {
+		return err
+	}
+	log.Info("Compacted database", "elapsed", common.PrettyDuration(time.Since(cstart)))
+	return nil
+}
+
 func importLDBdata(ctx *cli.Context) error {
 	start := 0
 	switch ctx.NArg() {
@@ -640,6 +804,212 @@ func importLDBdata(ctx *cli.Context) error {
 	return utils.ImportLDBData(db, fName, int64(start), stop)
 }
 
+func verifyChain(ctx *cli.Context) error {
+	var (
+		stack, _  = makeConfigNode(ctx)
+		interrupt = make(chan os.Signal, 1)
+		stop      = make(chan struct{})
+	)
+	defer stack.Close()
+	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+	defer close(interrupt)
+	go func() {
+		if _, ok := <-interrupt; ok {
+			log.Info("Interrupted during chain verification, saving progress")
+		}
+		close(stop)
+	}()
+	// Validate the index files of the chain freezer tables before opening the
+	// database, which would otherwise repair them by truncating the files.
+	ancient := stack.ResolveAncient("chaindata", ctx.String(utils.AncientFlag.Name))
+	for _, table := range []string{rawdb.ChainFreezerHeaderTable, rawdb.ChainFreezerHashTable, rawdb.ChainFreezerBodiesTable, rawdb.ChainFreezerReceiptTable} {
+		err := rawdb.VerifyFreezerTable(ancient, rawdb.ChainFreezerName, table)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue // nothing frozen yet
+		}
+		if err != nil {
+			log.Error("Corrupted freezer table detected", "table", table, "err", err)
+			return err
+		}
+		log.Info("Verified freezer table", "table", table)
+	}
+	// Validate the canonical blocks on the read-only database, then record the
+	// position for the next run in the key-value store.
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	next, err := verifyBlocks(ctx, db, stop)
+	db.Close()
+	if next == nil {
+		return err
+	}
+	kvdb, dberr := stack.OpenDatabase("chaindata", 0, 0, "", false)
+	if dberr != nil {
+		return dberr
+	}
+	rawdb.WriteChainVerification(kvdb, *next)
+	kvdb.Close()
+	return err
+}
+
+// verifyBlocks validates the canonical blocks from the last saved position up
+// to the chain head. It returns the position the next run has to start from,
+// never skipping the corrupted blocks detected, or nil if there's nothing to
+// record.
+func verifyBlocks(ctx *cli.Context, db ethdb.Database, stop chan struct{}) (*uint64, error) {
+	frozen, err := db.Ancients()
+	if err != nil {
+		return nil, err
+	}
+	headHash := rawdb.ReadHeadBlockHash(db)
+	headNumber := rawdb.ReadHeaderNumber(db, headHash)
+	if headNumber == nil {
+		return nil, errors.New("failed to load head block")
+	}
+	var first uint64
+	if !ctx.Bool(verifyChainRestartFlag.Name) {
+		if next := rawdb.ReadChainVerification(db); next != nil {
+			first = *next
+		}
+	}
+	if first > *headNumber {
+		log.Info("Chain is already verified", "head", *headNumber)
+		return nil, nil
+	}
+	tail, err := db.Tail()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		start   = time.Now()
+		logged  = time.Now()
+		parent  common.Hash
+		number  = first
+		corrupt []uint64
+	)
+	if number > 0 {
+		parent = rawdb.ReadCanonicalHash(db, number-1)
+	}
+	progress := func() *uint64 {
+		next := number
+		if len(corrupt) > 0 {
+			next = min(next, corrupt[0])
+		}
+		return &next
+	}
+	for ; number <= *headNumber; number++ {
+		select {
+		case <-stop:
+			return progress(), errors.New("chain verification interrupted")
+		default:
+		}
+		hash, err := verifyBlock(db, number, parent, frozen, tail)
+		if err != nil {
+			log.Error("Corrupted block detected", "number", number, "hash", hash, "err", err)
+			corrupt = append(corrupt, number)
+		}
+		parent = hash
+
+		if time.Since(logged) > 8*time.Second {
+			var (
+				done = number - first + 1
+				left = *headNumber - number
+				eta  = time.Duration(float64(time.Since(start)) / float64(done) * float64(left))
+			)
+			log.Info("Verifying chain", "number", number, "head", *headNumber, "corrupted", len(corrupt), "elapsed", common.PrettyDuration(time.Since(start)), "eta", common.PrettyDuration(eta))
+			logged = time.Now()
+		}
+	}
+	if len(corrupt) > 0 {
+		return progress(), fmt.Errorf("detected %d corrupted blocks, first: %d", len(corrupt), corrupt[0])
+	}
+	log.Info("Verified chain", "from", first, "head", *headNumber, "elapsed", common.PrettyDuration(time.Since(start)))
+	return progress(), nil
+}
+
+// verifyBlock validates the canonical block with the given number, returning
+// its canonical hash. The header is checked against the hash and the parent, the
+// body and receipts are checked against the roots committed in the header. The
+// bodies and receipts before the freezer tail are treated as pruned.
+func verifyBlock(db ethdb.Database, number uint64, parent common.Hash, frozen uint64, tail uint64) (common.Hash, error) {
+	hash := rawdb.ReadCanonicalHash(db, number)
+	if hash == (common.Hash{}) {
+		return hash, errors.New("canonical hash is missing")
+	}
+	// Read the header from the freezer directly if it's frozen, as the accessor
+	// silently falls back to the key-value store on hash mismatch.
+	var blob []byte
+	if number < frozen {
+		blob, _ = db.Ancient(rawdb.ChainFreezerHeaderTable, number)
+	} else {
+		blob = rawdb.ReadHeaderRLP(db, hash, number)
+	}
+	if len(blob) == 0 {
+		return hash, errors.New("header is missing")
+	}
+	if have := crypto.Keccak256Hash(blob); have != hash {
+		return hash, fmt.Errorf("header hash mismatch, have %x", have)
+	}
+	var header types.Header
+	if err := rlp.DecodeBytes(blob, &header); err != nil {
+		return hash, fmt.Errorf("invalid header: %v", err)
+	}
+	if header.Number.Uint64() != number {
+		return hash, fmt.Errorf("header number mismatch, have %d", header.Number)
+	}
+	if number > 0 && header.ParentHash != parent {
+		return hash, fmt.Errorf("canonical chain is discontinuous, parent %x, have %x", parent, header.ParentHash)
+	}
+	if n := rawdb.ReadHeaderNumber(db, hash); n == nil || *n != number {
+		return hash, errors.New("hash to number mapping is missing or mismatched")
+	}
+	// Validate the body against the header
+	if len(rawdb.ReadBodyRLP(db, hash, number)) == 0 {
+		if number < tail {
+			return hash, nil
+		}
+		return hash, errors.New("body is missing")
+	}
+	body := rawdb.ReadBody(db, hash, number)
+	if body == nil {
+		return hash, errors.New("invalid body")
+	}
+	if have := types.DeriveSha(types.Transactions(body.Transactions), trie.NewStackTrie(nil)); have != header.TxHash {
+		return hash, fmt.Errorf("transaction root mismatch, have %x, want %x", have, header.TxHash)
+	}
+	if have := types.CalcUncleHash(body.Uncles); have != header.UncleHash {
+		return hash, fmt.Errorf("uncle hash mismatch, have %x, want %x", have, header.UncleHash)
+	}
+	if header.WithdrawalsHash != nil {
+		if body.Withdrawals == nil {
+			return hash, errors.New("withdrawals are missing")
+		}
+		if have := types.DeriveSha(types.Withdrawals(body.Withdrawals), trie.NewStackTrie(nil)); have != *header.WithdrawalsHash {
+			return hash, fmt.Errorf("withdrawal root mismatch, have %x, want %x", have, *header.WithdrawalsHash)
+		}
+	}
+	// Validate the receipts against the header
+	if len(rawdb.ReadReceiptsRLP(db, hash, number)) == 0 {
+		return hash, errors.New("receipts are missing")
+	}
+	receipts := rawdb.ReadRawReceipts(db, hash, number)
+	if receipts == nil {
+		return hash, errors.New("invalid receipts")
+	}
+	if len(receipts) != len(body.Transactions) {
+		return hash, fmt.Errorf("receipt count mismatch, have %d, want %d", len(receipts), len(body.Transactions))
+	}
+	for i, tx := range body.Transactions {
+		receipts[i].Type = tx.Type()
+	}
+	if have := types.DeriveSha(receipts, trie.NewStackTrie(nil)); have != header.ReceiptHash {
+		return hash, fmt.Errorf("receipt root mismatch, have %x, want %x", have, header.ReceiptHash)
+	}
+	if have := types.MergeBloom(receipts); have != header.Bloom {
+		return hash, errors.New("bloom mismatch")
+	}
+	return hash, nil
+}
+
 type preimageIterator struct {
 	iter ethdb.Iterator
 }
@@ -87,10 +87,15 @@ var (
 		utils.TxLookupLimitFlag, // deprecated
 		utils.TransactionHistoryFlag,
 		utils.ChainHistoryFlag,
+		utils.ChainHistoryRetentionFlag,
+		utils.ChainHistoryEraFlag,
 		utils.LogHistoryFlag,
 		utils.LogNoHistoryFlag,
 		utils.LogExportCheckpointsFlag,
 		utils.StateHistoryFlag,
+		utils.StatePruneIntervalFlag,
+		utils.StatePruneBloomSizeFlag,
+		utils.SnapServeDepthFlag,
 		utils.LightServeFlag,    // deprecated
 		utils.LightIngressFlag,  // deprecated
 		utils.LightEgressFlag,   // deprecated
@@ -137,6 +142,7 @@ var (
 		utils.DeveloperGasLimitFlag,
 		utils.DeveloperPeriodFlag,
 		utils.VMEnableDebugFlag,
+		utils.VMParallelFlag,
 		utils.VMTraceFlag,
 		utils.VMTraceJsonConfigFlag,
 		utils.NetworkIdFlag,
@@ -167,13 +173,16 @@ var (
 		utils.AuthListenFlag,
 		utils.AuthPortFlag,
 		utils.AuthVirtualHostsFlag,
+		utils.AuthH2CFlag,
 		utils.JWTSecretFlag,
+		utils.RemoteDBServeFlag,
 		utils.HTTPVirtualHostsFlag,
 		utils.GraphQLEnabledFlag,
 		utils.GraphQLCORSDomainFlag,
 		utils.GraphQLVirtualHostsFlag,
 		utils.HTTPApiFlag,
 		utils.HTTPPathPrefixFlag,
+		utils.HTTPH2CFlag,
 		utils.WSEnabledFlag,
 		utils.WSListenAddrFlag,
 		utils.WSPortFlag,
@@ -189,6 +198,11 @@ var (
 		utils.AllowUnprotectedTxs,
 		utils.BatchRequestLimit,
 		utils.BatchResponseMaxSize,
+		utils.RPCRateLimitFlag,
+		utils.RPCRateBurstFlag,
+		utils.RPCMethodCostsFlag,
+		utils.RPCPolicyFileFlag,
+		utils.RPCResponseCacheFlag,
 	}
 
 	metricsFlags = []cli.Flag{
@@ -206,6 +220,10 @@ var (
 		utils.MetricsInfluxDBTokenFlag,
 		utils.MetricsInfluxDBBucketFlag,
 		utils.MetricsInfluxDBOrganizationFlag,
+		utils.TelemetryEnabledFlag,
+		utils.TelemetryEndpointFlag,
+		utils.TelemetryFileFlag,
+		utils.TelemetrySampleRatioFlag,
 	}
 )
 
@@ -245,9 +245,9 @@ func readList(filename string) ([]string, error) {
 	return strings.Split(string(b), "\n"), nil
 }
 
-// ImportHistory imports Era1 files containing historical block information,
-// starting from genesis. The assumption is held that the provided chain
-// segment in Era1 file should all be canonical and verified.
+// ImportHistory imports Era1 and era-E files containing historical block
+// information, starting from genesis. The assumption is held that the provided
+// chain segment in the archives should all be canonical and verified.
 func ImportHistory(chain *core.BlockChain, dir string, network string) error {
 	if chain.CurrentSnapBlock().Number.BitLen() != 0 {
 		return errors.New("history import only supported when starting from genesis")
@@ -256,9 +256,33 @@ func ImportHistory(chain *core.BlockChain, dir string, network string) error {
 	if err != nil {
 		return fmt.Errorf("error reading %s: %w", dir, err)
 	}
-	checksums, err := readList(filepath.Join(dir, "checksums.txt"))
+	entriesE, err := era.ReadDirE(dir, network)
 	if err != nil {
-		return fmt.Errorf("unable to read checksums.txt: %w", err)
+		return fmt.Errorf("error reading %s: %w", dir, err)
+	}
+	if len(entries) == 0 && len(entriesE) == 0 {
+		return fmt.Errorf("no era files found in %s", dir)
+	}
+	// The post-merge history continues the pre-merge one.
+	if len(entries) > 0 {
+		if err := importArchives(chain, dir, entries, era.FormatEra1); err != nil {
+			return err
+		}
+	}
+	if len(entriesE) > 0 {
+		if err := importArchives(chain, dir, entriesE, era.FormatEraE); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importArchives imports the given archives of the specified format, after
+// validating them against the listed checksums.
+func importArchives(chain *core.BlockChain, dir string, entries []string, format era.Format) error {
+	checksums, err := readList(filepath.Join(dir, checksumsFile(format)))
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", checksumsFile(format), err)
 	}
 	if len(checksums) != len(entries) {
 		return fmt.Errorf("expected equal number of checksums and entries, have: %d checksums, %d entries", len(checksums), len(entries))
@@ -288,11 +312,14 @@ func ImportHistory(chain *core.BlockChain, dir string, network string) error {
 			h.Reset()
 			buf.Reset()
 
-			// Import all block data from Era1.
+			// Import all block data from the archive.
 			e, err := era.From(f)
 			if err != nil {
 				return fmt.Errorf("error opening era: %w", err)
 			}
+			if e.Format() != format {
+				return fmt.Errorf("unexpected archive format %s, want %s", e.Format(), format)
+			}
 			it, err := era.NewIterator(e)
 			if err != nil {
 				return fmt.Errorf("error making era reader: %w", err)
@@ -401,13 +428,18 @@ func ExportAppendChain(blockchain *core.BlockChain, fn string, first uint64, las
 }
 
 // ExportHistory exports blockchain history into the specified directory,
-// following the Era format.
-func ExportHistory(bc *core.BlockChain, dir string, first, last, step uint64) error {
+// following the Era format. The pre-merge history is packaged into Era1
+// archives, the post-merge history into era-E archives, which require the
+// beacon chain proofs of the blocks from the given proof source.
+func ExportHistory(bc *core.BlockChain, dir string, first, last, step uint64, proofs era.ProofSource) error {
 	log.Info("Exporting blockchain history", "dir", dir)
 	if head := bc.CurrentBlock().Number.Uint64(); head < last {
 		log.Warn("Last block beyond head, setting last = head", "head", head, "last", last)
 		last = head
 	}
+	if proofs == nil && bc.GetHeaderByNumber(last).Difficulty.Sign() == 0 {
+		return errors.New("exporting post-merge history requires a beacon chain proof source")
+	}
 	network := "unknown"
 	if name, ok := params.NetworkNames[bc.Config().ChainID.String()]; ok {
 		network = name
@@ -418,76 +450,131 @@ func ExportHistory(bc *core.BlockChain, dir string, first, last, step uint64) er
 	var (
 		start     = time.Now()
 		reported  = time.Now()
-		h         = sha256.New()
-		buf       = bytes.NewBuffer(nil)
-		checksums []string
+		checksums = make(map[era.Format][]string)
 	)
 	td := new(big.Int)
 	for i := uint64(0); i < first; i++ {
 		td.Add(td, bc.GetHeaderByNumber(i).Difficulty)
 	}
 	for i := first; i <= last; i += step {
-		err := func() error {
-			filename := filepath.Join(dir, era.Filename(network, int(i/step), common.Hash{}))
-			f, err := os.Create(filename)
-			if err != nil {
-				return fmt.Errorf("could not create era file: %w", err)
-			}
-			defer f.Close()
-
-			w := era.NewBuilder(f)
-			for j := uint64(0); j < step && j <= last-i; j++ {
-				var (
-					n     = i + j
-					block = bc.GetBlockByNumber(n)
-				)
-				if block == nil {
-					return fmt.Errorf("export failed on #%d: not found", n)
-				}
-				receipts := bc.GetReceiptsByHash(block.Hash())
-				if receipts == nil {
-					return fmt.Errorf("export failed on #%d: receipts not found", n)
-				}
-				td.Add(td, block.Difficulty())
-				if err := w.Add(block, receipts, new(big.Int).Set(td)); err != nil {
-					return err
-				}
-			}
-			root, err := w.Finalize()
+		// The archive containing the merge is cut short, the remaining blocks
+		// of the step are packaged into an era-E archive of the same epoch.
+		end := min(i+step-1, last)
+		for from := i; from <= end; {
+			next, format, checksum, err := exportArchive(bc, dir, network, from, end, int(i/step), td, proofs)
 			if err != nil {
-				return fmt.Errorf("export failed to finalize %d: %w", step/i, err)
-			}
-			// Set correct filename with root.
-			os.Rename(filename, filepath.Join(dir, era.Filename(network, int(i/step), root)))
-
-			// Compute checksum of entire Era1.
-			if _, err := f.Seek(0, io.SeekStart); err != nil {
 				return err
 			}
-			if _, err := io.Copy(h, f); err != nil {
-				return fmt.Errorf("unable to calculate checksum: %w", err)
-			}
-			checksums = append(checksums, common.BytesToHash(h.Sum(buf.Bytes()[:])).Hex())
-			h.Reset()
-			buf.Reset()
-			return nil
-		}()
-		if err != nil {
-			return err
+			checksums[format] = append(checksums[format], checksum)
+			from = next
 		}
 		if time.Since(reported) >= 8*time.Second {
 			log.Info("Exporting blocks", "exported", i, "elapsed", common.PrettyDuration(time.Since(start)))
 			reported = time.Now()
 		}
 	}
-
-	os.WriteFile(filepath.Join(dir, "checksums.txt"), []byte(strings.Join(checksums, "\n")), os.ModePerm)
-
+	for format, sums := range checksums {
+		os.WriteFile(filepath.Join(dir, checksumsFile(format)), []byte(strings.Join(sums, "\n")), os.ModePerm)
+	}
 	log.Info("Exported blockchain to", "dir", dir)
 
 	return nil
 }
 
+// exportArchive exports the blocks in the range [first, last] into a single
+// archive. The format is chosen by the first block; the pre-merge archives stop
+// at the merge. The number of the next block to export is returned, along with
+// the archive format and checksum.
+func exportArchive(bc *core.BlockChain, dir string, network string, first, last uint64, epoch int, td *big.Int, proofs era.ProofSource) (uint64, era.Format, string, error) {
+	format := era.FormatEra1
+	if bc.GetHeaderByNumber(first).Difficulty.Sign() == 0 {
+		format = era.FormatEraE
+	}
+	filename := filepath.Join(dir, archiveName(format, network, epoch, common.Hash{}))
+	f, err := os.Create(filename)
+	if err != nil {
+		return 0, format, "", fmt.Errorf("could not create era file: %w", err)
+	}
+	defer f.Close()
+
+	var (
+		n = first
+		w *era.Builder
+	)
+	if format == era.FormatEraE {
+		w = era.NewBuilderE(f)
+	} else {
+		w = era.NewBuilder(f)
+	}
+	for ; n <= last; n++ {
+		block := bc.GetBlockByNumber(n)
+		if block == nil {
+			return 0, format, "", fmt.Errorf("export failed on #%d: not found", n)
+		}
+		if format == era.FormatEra1 && block.Difficulty().Sign() == 0 {
+			break
+		}
+		receipts := bc.GetReceiptsByHash(block.Hash())
+		if receipts == nil {
+			return 0, format, "", fmt.Errorf("export failed on #%d: receipts not found", n)
+		}
+		if format == era.FormatEraE {
+			if err = w.Add(block, receipts, nil); err == nil {
+				err = proveBlock(w, proofs, block.Header())
+			}
+		} else {
+			td.Add(td, block.Difficulty())
+			err = w.Add(block, receipts, new(big.Int).Set(td))
+		}
+		if err != nil {
+			return 0, format, "", err
+		}
+	}
+	root, err := w.Finalize()
+	if err != nil {
+		return 0, format, "", fmt.Errorf("export failed to finalize %d: %w", epoch, err)
+	}
+	// Set correct filename with root.
+	os.Rename(filename, filepath.Join(dir, archiveName(format, network, epoch, root)))
+
+	// Compute checksum of entire archive.
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return 0, format, "", err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return 0, format, "", fmt.Errorf("unable to calculate checksum: %w", err)
+	}
+	return n, format, common.BytesToHash(h.Sum(nil)).Hex(), nil
+}
+
+// proveBlock adds the beacon chain proof of the last block added to the era-E
+// archive.
+func proveBlock(w *era.Builder, proofs era.ProofSource, header *types.Header) error {
+	proof, err := proofs.BlockProof(header)
+	if err != nil {
+		return fmt.Errorf("export failed to prove #%d: %w", header.Number, err)
+	}
+	return w.AddProof(proof)
+}
+
+// archiveName returns the file name of the archive in the given format.
+func archiveName(format era.Format, network string, epoch int, root common.Hash) string {
+	if format == era.FormatEraE {
+		return era.FilenameE(network, epoch, root)
+	}
+	return era.Filename(network, epoch, root)
+}
+
+// checksumsFile returns the name of the file listing the checksums of the
+// archives in the given format.
+func checksumsFile(format era.Format) string {
+	if format == era.FormatEraE {
+		return "checksums_erae.txt"
+	}
+	return "checksums.txt"
+}
+
 // ImportPreimages imports a batch of exported hash preimages into the database.
 // It's a part of the deprecated functionality, should be removed in the future.
 func ImportPreimages(db ethdb.Database, fn string) error {
@@ -37,6 +37,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/beacon/light/api"
 	bparams "github.com/ethereum/go-ethereum/beacon/params"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/fdlimit"
@@ -53,13 +54,16 @@ import (
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
+	"github.com/ethereum/go-ethereum/eth/otterscan"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/ethdb/remotedb"
 	"github.com/ethereum/go-ethereum/ethstats"
 	"github.com/ethereum/go-ethereum/graphql"
+	"github.com/ethereum/go-ethereum/internal/era"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/internal/flags"
+	"github.com/ethereum/go-ethereum/internal/telemetry"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/metrics/exp"
@@ -100,12 +104,27 @@ var (
 		Usage:    "URL for remote database",
 		Category: flags.LoggingCategory,
 	}
+	RemoteDBJWTSecretFlag = &flags.DirectoryFlag{
+		Name:     "remotedb.jwtsecret",
+		Usage:    "Path to a JWT secret to use for the authenticated endpoint of the remote database",
+		Category: flags.LoggingCategory,
+	}
+	RemoteDBServeFlag = &cli.BoolFlag{
+		Name:     "remotedb.serve",
+		Usage:    "Serve the chain database to remote database clients on the authenticated RPC endpoint",
+		Category: flags.APICategory,
+	}
 	DBEngineFlag = &cli.StringFlag{
 		Name:     "db.engine",
 		Usage:    "Backing database implementation to use ('pebble' or 'leveldb')",
 		Value:    node.DefaultConfig.DBEngine,
 		Category: flags.EthCategory,
 	}
+	DBFreezerCodecFlag = &cli.StringFlag{
+		Name:     "db.freezer.codec",
+		Usage:    "Comma separated compression codecs ('snappy' or 'zstd') of newly created freezer tables (e.g. bodies=zstd,receipts=zstd)",
+		Category: flags.EthCategory,
+	}
 	AncientFlag = &flags.DirectoryFlag{
 		Name:     "datadir.ancient",
 		Usage:    "Root directory for ancient data (default = inside chaindata)",
@@ -256,7 +275,7 @@ var (
 	}
 	GCModeFlag = &cli.StringFlag{
 		Name:     "gcmode",
-		Usage:    `Blockchain garbage collection mode, only relevant in state.scheme=hash ("full", "archive")`,
+		Usage:    `Blockchain garbage collection mode ("full", "archive")`,
 		Value:    "full",
 		Category: flags.StateCategory,
 	}
@@ -265,6 +284,22 @@ var (
 		Usage:    "Scheme to use for storing ethereum state ('hash' or 'path')",
 		Category: flags.StateCategory,
 	}
+	StatePruneIntervalFlag = &cli.Uint64Flag{
+		Name:     "state.prune.interval",
+		Usage:    "Number of blocks between two background state pruning runs, only relevant in state.scheme=hash (0 = disabled)",
+		Category: flags.StateCategory,
+	}
+	SnapServeDepthFlag = &cli.Uint64Flag{
+		Name:     "snap.servedepth",
+		Usage:    "Number of states below the persistent state for which snap requests are served by rolling back state history, only relevant in state.scheme=path (0 = disabled)",
+		Category: flags.StateCategory,
+	}
+	StatePruneBloomSizeFlag = &cli.Uint64Flag{
+		Name:     "state.prune.bloomsize",
+		Usage:    "Megabytes of memory allocated to bloom-filter for background state pruning",
+		Value:    ethconfig.Defaults.StatePruneBloomSize,
+		Category: flags.StateCategory,
+	}
 	StateHistoryFlag = &cli.Uint64Flag{
 		Name:     "history.state",
 		Usage:    "Number of recent blocks to retain state history for, only relevant in state.scheme=path (default = 90,000 blocks, 0 = entire chain)",
@@ -279,10 +314,21 @@ var (
 	}
 	ChainHistoryFlag = &cli.StringFlag{
 		Name:     "history.chain",
-		Usage:    `Blockchain history retention ("all" or "postmerge")`,
+		Usage:    `Blockchain history retention ("all", "postmerge" or "recent")`,
 		Value:    ethconfig.Defaults.HistoryMode.String(),
 		Category: flags.StateCategory,
 	}
+	ChainHistoryRetentionFlag = &cli.Uint64Flag{
+		Name:     "history.chain.retention",
+		Usage:    "Number of recent blocks to retain bodies and receipts for, only relevant in history.chain=recent (default = about one year)",
+		Value:    ethconfig.Defaults.HistoryRetention,
+		Category: flags.StateCategory,
+	}
+	ChainHistoryEraFlag = &flags.DirectoryFlag{
+		Name:     "history.chain.era",
+		Usage:    "Directory of Era1 archives to serve the pruned chain history from",
+		Category: flags.StateCategory,
+	}
 	LogHistoryFlag = &cli.Uint64Flag{
 		Name:     "history.logs",
 		Usage:    "Number of recent blocks to maintain log search index for (default = about one year, 0 = entire chain)",
@@ -549,6 +595,11 @@ var (
 		Usage:    "Record information useful for VM and contract debugging",
 		Category: flags.VMCategory,
 	}
+	VMParallelFlag = &cli.BoolFlag{
+		Name:     "vmparallel",
+		Usage:    "Execute the transactions of blocks carrying access lists in parallel (experimental)",
+		Category: flags.VMCategory,
+	}
 	VMTraceFlag = &cli.StringFlag{
 		Name:     "vmtrace",
 		Usage:    "Name of tracer which should record internal VM operations (costly)",
@@ -598,6 +649,11 @@ var (
 		Value:    strings.Join(node.DefaultConfig.AuthVirtualHosts, ","),
 		Category: flags.APICategory,
 	}
+	AuthH2CFlag = &cli.BoolFlag{
+		Name:     "authrpc.h2c",
+		Usage:    "Enable HTTP/2 without TLS (h2c) on the authenticated API",
+		Category: flags.APICategory,
+	}
 	JWTSecretFlag = &flags.DirectoryFlag{
 		Name:     "authrpc.jwtsecret",
 		Usage:    "Path to a JWT secret to use for authenticated RPC endpoints",
@@ -676,6 +732,11 @@ var (
 		Value:    "",
 		Category: flags.APICategory,
 	}
+	HTTPH2CFlag = &cli.BoolFlag{
+		Name:     "http.h2c",
+		Usage:    "Enable HTTP/2 without TLS (h2c) on the HTTP-RPC server",
+		Category: flags.APICategory,
+	}
 	GraphQLEnabledFlag = &cli.BoolFlag{
 		Name:     "graphql",
 		Usage:    "Enable GraphQL on the HTTP-RPC server. Note that GraphQL can only be started if an HTTP server is started as well.",
@@ -755,6 +816,31 @@ var (
 		Value:    node.DefaultConfig.BatchResponseMaxSize,
 		Category: flags.APICategory,
 	}
+	RPCRateLimitFlag = &cli.Float64Flag{
+		Name:     "rpc.ratelimit",
+		Usage:    "Request cost units granted per second to each HTTP/WS/authrpc client (0 = unlimited)",
+		Category: flags.APICategory,
+	}
+	RPCRateBurstFlag = &cli.IntFlag{
+		Name:     "rpc.ratelimit.burst",
+		Usage:    "Maximum request cost units a HTTP/WS/authrpc client can accumulate, at least the highest method cost (0 = rate)",
+		Category: flags.APICategory,
+	}
+	RPCResponseCacheFlag = &cli.IntFlag{
+		Name:     "rpc.cache",
+		Usage:    "Megabytes of memory allocated to caching the RPC responses of finalized blocks (0 = disabled)",
+		Category: flags.APICategory,
+	}
+	RPCPolicyFileFlag = &cli.StringFlag{
+		Name:     "rpc.policy",
+		Usage:    "Path to the JSON file of the method access policies of the HTTP, WS and authenticated endpoints",
+		Category: flags.APICategory,
+	}
+	RPCMethodCostsFlag = &cli.StringFlag{
+		Name:     "rpc.ratelimit.costs",
+		Usage:    "Comma separated method costs charged by the rate limiter (e.g. eth_call=5,debug_*=10)",
+		Category: flags.APICategory,
+	}
 
 	// Network Settings
 	MaxPeersFlag = &cli.IntFlag{
@@ -961,6 +1047,31 @@ Please note that --` + MetricsHTTPFlag.Name + ` must be set to start the server.
 		Value:    metrics.DefaultConfig.InfluxDBOrganization,
 		Category: flags.MetricsCategory,
 	}
+
+	// Distributed tracing settings
+	TelemetryEnabledFlag = &cli.BoolFlag{
+		Name:     "telemetry",
+		Usage:    "Enable OpenTelemetry span collection and export",
+		Category: flags.MetricsCategory,
+	}
+	TelemetryEndpointFlag = &cli.StringFlag{
+		Name:     "telemetry.endpoint",
+		Usage:    "OTLP/HTTP collector endpoint to export spans to (empty to disable)",
+		Value:    telemetry.DefaultConfig.Endpoint,
+		Category: flags.MetricsCategory,
+	}
+	TelemetryFileFlag = &cli.StringFlag{
+		Name:     "telemetry.file",
+		Usage:    "File to export spans to as JSON",
+		Value:    telemetry.DefaultConfig.File,
+		Category: flags.MetricsCategory,
+	}
+	TelemetrySampleRatioFlag = &cli.Float64Flag{
+		Name:     "telemetry.sampleratio",
+		Usage:    "Fraction of traces to sample, between 0 and 1",
+		Value:    telemetry.DefaultConfig.SampleRatio,
+		Category: flags.MetricsCategory,
+	}
 )
 
 var (
@@ -978,7 +1089,9 @@ var (
 		DataDirFlag,
 		AncientFlag,
 		RemoteDBFlag,
+		RemoteDBJWTSecretFlag,
 		DBEngineFlag,
+		DBFreezerCodecFlag,
 		StateSchemeFlag,
 		HttpHeaderFlag,
 	}
@@ -1165,6 +1278,9 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(AuthVirtualHostsFlag.Name) {
 		cfg.AuthVirtualHosts = SplitAndTrim(ctx.String(AuthVirtualHostsFlag.Name))
 	}
+	if ctx.IsSet(AuthH2CFlag.Name) {
+		cfg.AuthH2C = ctx.Bool(AuthH2CFlag.Name)
+	}
 
 	if ctx.IsSet(HTTPCORSDomainFlag.Name) {
 		cfg.HTTPCors = SplitAndTrim(ctx.String(HTTPCORSDomainFlag.Name))
@@ -1181,6 +1297,9 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(HTTPPathPrefixFlag.Name) {
 		cfg.HTTPPathPrefix = ctx.String(HTTPPathPrefixFlag.Name)
 	}
+	if ctx.IsSet(HTTPH2CFlag.Name) {
+		cfg.HTTPH2C = ctx.Bool(HTTPH2CFlag.Name)
+	}
 	if ctx.IsSet(AllowUnprotectedTxs.Name) {
 		cfg.AllowUnprotectedTxs = ctx.Bool(AllowUnprotectedTxs.Name)
 	}
@@ -1192,6 +1311,33 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(BatchResponseMaxSize.Name) {
 		cfg.BatchResponseMaxSize = ctx.Int(BatchResponseMaxSize.Name)
 	}
+
+	if ctx.IsSet(RPCRateLimitFlag.Name) {
+		cfg.RPCRateLimit = ctx.Float64(RPCRateLimitFlag.Name)
+	}
+	if ctx.IsSet(RPCRateBurstFlag.Name) {
+		cfg.RPCRateBurst = ctx.Int(RPCRateBurstFlag.Name)
+	}
+	if ctx.IsSet(RPCResponseCacheFlag.Name) {
+		cfg.RPCResponseCache = ctx.Int(RPCResponseCacheFlag.Name)
+	}
+	if ctx.IsSet(RPCPolicyFileFlag.Name) {
+		cfg.RPCPolicyFile = ctx.String(RPCPolicyFileFlag.Name)
+	}
+	if ctx.IsSet(RPCMethodCostsFlag.Name) {
+		cfg.RPCMethodCosts = make(map[string]int)
+		for _, entry := range strings.Split(ctx.String(RPCMethodCostsFlag.Name), ",") {
+			method, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			if !ok {
+				Fatalf("Invalid method cost entry: %s", entry)
+			}
+			cost, err := strconv.Atoi(value)
+			if err != nil || cost < 0 {
+				Fatalf("Invalid cost for method %s: %s", method, value)
+			}
+			cfg.RPCMethodCosts[method] = cost
+		}
+	}
 }
 
 // setGraphQL creates the GraphQL listener interface string from the set
@@ -1405,6 +1551,17 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 		log.Info(fmt.Sprintf("Using %s as db engine", dbEngine))
 		cfg.DBEngine = dbEngine
 	}
+	if ctx.IsSet(DBFreezerCodecFlag.Name) {
+		for _, entry := range strings.Split(ctx.String(DBFreezerCodecFlag.Name), ",") {
+			table, codec, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			if !ok {
+				Fatalf("Invalid freezer codec entry: %s", entry)
+			}
+			if err := rawdb.SetFreezerTableCodec(table, codec); err != nil {
+				Fatalf("Invalid freezer codec for table %s: %v", table, err)
+			}
+		}
+	}
 	// deprecation notice for log debug flags (TODO: find a more appropriate place to put these?)
 	if ctx.IsSet(LogBacktraceAtFlag.Name) {
 		log.Warn("log.backtrace flag is deprecated")
@@ -1619,6 +1776,12 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 			Fatalf("--%s: %v", ChainHistoryFlag.Name, err)
 		}
 	}
+	if ctx.IsSet(ChainHistoryRetentionFlag.Name) {
+		cfg.HistoryRetention = ctx.Uint64(ChainHistoryRetentionFlag.Name)
+	}
+	if ctx.IsSet(ChainHistoryEraFlag.Name) {
+		cfg.HistoryEraDir = ctx.String(ChainHistoryEraFlag.Name)
+	}
 
 	if ctx.IsSet(NetworkIdFlag.Name) {
 		cfg.NetworkId = ctx.Uint64(NetworkIdFlag.Name)
@@ -1652,6 +1815,15 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(StateSchemeFlag.Name) {
 		cfg.StateScheme = ctx.String(StateSchemeFlag.Name)
 	}
+	if ctx.IsSet(StatePruneIntervalFlag.Name) {
+		cfg.StatePruneInterval = ctx.Uint64(StatePruneIntervalFlag.Name)
+	}
+	if ctx.IsSet(StatePruneBloomSizeFlag.Name) {
+		cfg.StatePruneBloomSize = ctx.Uint64(StatePruneBloomSizeFlag.Name)
+	}
+	if ctx.IsSet(SnapServeDepthFlag.Name) {
+		cfg.SnapServeDepth = ctx.Uint64(SnapServeDepthFlag.Name)
+	}
 	// Parse transaction history flag, if user is still using legacy config
 	// file with 'TxLookupLimit' configured, copy the value to 'TransactionHistory'.
 	if cfg.TransactionHistory == ethconfig.Defaults.TransactionHistory && cfg.TxLookupLimit != ethconfig.Defaults.TxLookupLimit {
@@ -1670,7 +1842,14 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 			log.Warn("Disabled transaction unindexing for archive node")
 		}
 
-		if cfg.StateScheme != rawdb.HashScheme {
+		// Archive nodes running the path scheme retain the entire state history
+		// and serve historical state through the state history index.
+		if cfg.StateScheme == rawdb.PathScheme {
+			if cfg.StateHistory != 0 {
+				cfg.StateHistory = 0
+				log.Warn("Disabled state history unindexing for archive node")
+			}
+		} else if cfg.StateScheme != rawdb.HashScheme {
 			cfg.StateScheme = rawdb.HashScheme
 			log.Warn("Forcing hash state-scheme for archive mode")
 		}
@@ -1715,6 +1894,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 		// TODO(fjl): force-enable this in --dev mode
 		cfg.EnablePreimageRecording = ctx.Bool(VMEnableDebugFlag.Name)
 	}
+	if ctx.IsSet(VMParallelFlag.Name) {
+		cfg.ParallelExecution = ctx.Bool(VMParallelFlag.Name)
+	}
 
 	if ctx.IsSet(RPCGlobalGasCapFlag.Name) {
 		cfg.RPCGasCap = ctx.Uint64(RPCGlobalGasCapFlag.Name)
@@ -1946,17 +2128,38 @@ func MakeBeaconLightConfig(ctx *cli.Context) bparams.ClientConfig {
 	if config.Apis == nil {
 		Fatalf("Beacon node light client API URL not specified")
 	}
-	config.CustomHeader = make(map[string]string)
+	config.CustomHeader = makeBeaconApiHeaders(ctx)
+	config.Threshold = ctx.Int(BeaconThresholdFlag.Name)
+	config.NoFilter = ctx.Bool(BeaconNoFilterFlag.Name)
+	return config
+}
+
+// makeBeaconApiHeaders parses the custom HTTP header fields of the beacon node API.
+func makeBeaconApiHeaders(ctx *cli.Context) map[string]string {
+	headers := make(map[string]string)
 	for _, s := range ctx.StringSlice(BeaconApiHeaderFlag.Name) {
 		kv := strings.Split(s, ":")
 		if len(kv) != 2 {
 			Fatalf("Invalid custom API header entry: %s", s)
 		}
-		config.CustomHeader[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		headers[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
 	}
-	config.Threshold = ctx.Int(BeaconThresholdFlag.Name)
-	config.NoFilter = ctx.Bool(BeaconNoFilterFlag.Name)
-	return config
+	return headers
+}
+
+// MakeBeaconProofSource creates the source of the beacon chain proofs required
+// by exported era-E archives, backed by the first configured beacon node API.
+// Nil is returned if no beacon node API is configured.
+func MakeBeaconProofSource(ctx *cli.Context, chain HeaderReader) era.ProofSource {
+	apis := ctx.StringSlice(BeaconApiFlag.Name)
+	if len(apis) == 0 {
+		return nil
+	}
+	source, err := NewBeaconProofSource(api.NewBeaconLightApi(apis[0], makeBeaconApiHeaders(ctx)), chain)
+	if err != nil {
+		Fatalf("Failed to create beacon proof source: %v", err)
+	}
+	return source
 }
 
 // SetDNSDiscoveryDefaults configures DNS discovery with the given URL if
@@ -2010,6 +2213,19 @@ func RegisterFilterAPI(stack *node.Node, backend ethapi.Backend, ethcfg *ethconf
 	return filterSystem
 }
 
+// RegisterOtterscanAPI adds the ots RPC API used by the Otterscan block explorer
+// to the node.
+func RegisterOtterscanAPI(stack *node.Node, backend *eth.EthAPIBackend, filterSystem *filters.FilterSystem) {
+	stack.RegisterAPIs(otterscan.APIs(backend, filterSystem))
+}
+
+// RegisterRemoteDBAPI exposes the chain database to the remote database clients
+// on the authenticated RPC endpoint.
+func RegisterRemoteDBAPI(stack *node.Node, db ethdb.Database) {
+	stack.RegisterAPIs(remotedb.APIs(db))
+	log.Info("Registered remote database API", "addr", stack.Config().AuthAddr, "port", stack.Config().AuthPort)
+}
+
 // RegisterFullSyncTester adds the full-sync tester service into node.
 func RegisterFullSyncTester(stack *node.Node, eth *eth.Ethereum, target common.Hash) {
 	catalyst.RegisterFullSyncTester(stack, eth, target)
@@ -2065,6 +2281,19 @@ func SetupMetrics(cfg *metrics.Config) {
 	go metrics.CollectProcessMetrics(3 * time.Second)
 }
 
+// SetupTelemetry configures the span exporters if tracing is enabled, flushing
+// them when the node is stopped.
+func SetupTelemetry(stack *node.Node, cfg *telemetry.Config) {
+	if !cfg.Enabled {
+		return
+	}
+	provider, err := telemetry.Setup(*cfg, stack.Config().Version)
+	if err != nil {
+		Fatalf("Failed to set up tracing: %v", err)
+	}
+	stack.RegisterLifecycle(provider)
+}
+
 // SplitTagsFlag parses a comma-separated list of k=v metrics tags.
 func SplitTagsFlag(tagsFlag string) map[string]string {
 	tags := strings.Split(tagsFlag, ",")
@@ -2094,7 +2323,17 @@ func MakeChainDatabase(ctx *cli.Context, stack *node.Node, readonly bool) ethdb.
 	switch {
 	case ctx.IsSet(RemoteDBFlag.Name):
 		log.Info("Using remote db", "url", ctx.String(RemoteDBFlag.Name), "headers", len(ctx.StringSlice(HttpHeaderFlag.Name)))
-		client, err := DialRPCWithHeaders(ctx.String(RemoteDBFlag.Name), ctx.StringSlice(HttpHeaderFlag.Name))
+		var opts []rpc.ClientOption
+		if ctx.IsSet(RemoteDBJWTSecretFlag.Name) {
+			var secret []byte
+			secret, err = node.ReadJWTSecret(ctx.String(RemoteDBJWTSecretFlag.Name))
+			if err != nil {
+				break
+			}
+			opts = append(opts, rpc.WithHTTPAuth(node.NewJWTAuth([32]byte(secret))))
+		}
+		var client *rpc.Client
+		client, err = DialRPCWithHeaders(ctx.String(RemoteDBFlag.Name), ctx.StringSlice(HttpHeaderFlag.Name), opts...)
 		if err != nil {
 			break
 		}
@@ -2130,7 +2369,7 @@ func IsNetworkPreset(ctx *cli.Context) bool {
 	return false
 }
 
-func DialRPCWithHeaders(endpoint string, headers []string) (*rpc.Client, error) {
+func DialRPCWithHeaders(endpoint string, headers []string, opts ...rpc.ClientOption) (*rpc.Client, error) {
 	if endpoint == "" {
 		return nil, errors.New("endpoint must be specified")
 	}
@@ -2139,7 +2378,6 @@ func DialRPCWithHeaders(endpoint string, headers []string) (*rpc.Client, error)
 		// these prefixes.
 		endpoint = endpoint[4:]
 	}
-	var opts []rpc.ClientOption
 	if len(headers) > 0 {
 		customHeaders := make(http.Header)
 		for _, h := range headers {
@@ -0,0 +1,170 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"fmt"
+
+	btypes "github.com/ethereum/go-ethereum/beacon/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/era"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// secondsPerSlot is the duration of a beacon chain slot.
+const secondsPerSlot = 12
+
+// BeaconBlockAPI is the subset of the beacon node REST API used for proving the
+// exported blocks.
+type BeaconBlockAPI interface {
+	GetGenesisTime() (uint64, error)
+	GetBeaconBlockAtSlot(slot uint64) (*btypes.BeaconBlock, error)
+}
+
+// HeaderReader provides the local headers of the exported chain.
+type HeaderReader interface {
+	GetHeaderByNumber(number uint64) *types.Header
+}
+
+// BeaconProofSource proves execution blocks with the beacon blocks retrieved
+// from a beacon node. The proofs against the block summary root require the
+// roots of all the beacon blocks in the historical roots period, so the proofs
+// are built for a whole period at a time, which must be complete in the local
+// chain.
+type BeaconProofSource struct {
+	api         BeaconBlockAPI
+	chain       HeaderReader
+	genesisTime uint64
+
+	proofs map[common.Hash]*era.BlockProof // Proofs of the blocks in the last loaded period
+}
+
+// NewBeaconProofSource creates a proof source backed by the given beacon node.
+func NewBeaconProofSource(api BeaconBlockAPI, chain HeaderReader) (*BeaconProofSource, error) {
+	genesisTime, err := api.GetGenesisTime()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve beacon genesis time: %w", err)
+	}
+	return &BeaconProofSource{api: api, chain: chain, genesisTime: genesisTime}, nil
+}
+
+// BlockProof implements era.ProofSource.
+func (s *BeaconProofSource) BlockProof(header *types.Header) (*era.BlockProof, error) {
+	if proof, ok := s.proofs[header.Hash()]; ok {
+		return proof, nil
+	}
+	slot, err := s.slot(header)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.loadPeriod(slot/era.SlotsPerHistoricalRoot, header.Number.Uint64()); err != nil {
+		return nil, err
+	}
+	proof, ok := s.proofs[header.Hash()]
+	if !ok {
+		return nil, fmt.Errorf("block %d not found in the beacon chain", header.Number)
+	}
+	return proof, nil
+}
+
+// slot returns the beacon chain slot of the post-merge block.
+func (s *BeaconProofSource) slot(header *types.Header) (uint64, error) {
+	if header.Difficulty.Sign() != 0 {
+		return 0, fmt.Errorf("block %d is not a post-merge block", header.Number)
+	}
+	if header.Time < s.genesisTime || (header.Time-s.genesisTime)%secondsPerSlot != 0 {
+		return 0, fmt.Errorf("block %d timestamp %d is not aligned to the beacon chain slots", header.Number, header.Time)
+	}
+	return (header.Time - s.genesisTime) / secondsPerSlot, nil
+}
+
+// loadPeriod builds the proofs of all the local blocks within the historical
+// roots period, starting the search from the block with the given number.
+func (s *BeaconProofSource) loadPeriod(period uint64, number uint64) error {
+	var (
+		first = period * era.SlotsPerHistoricalRoot
+		end   = first + era.SlotsPerHistoricalRoot
+	)
+	// Find the first post-merge block of the period.
+	for number > 0 {
+		parent := s.chain.GetHeaderByNumber(number - 1)
+		if parent == nil || parent.Difficulty.Sign() != 0 {
+			break
+		}
+		slot, err := s.slot(parent)
+		if err != nil {
+			return err
+		}
+		if slot < first {
+			break
+		}
+		number--
+	}
+	// Retrieve the beacon blocks carrying the local blocks of the period. Empty
+	// slots repeat the root of the latest block before them.
+	var (
+		roots  = make([]common.Hash, era.SlotsPerHistoricalRoot)
+		proofs = make(map[common.Hash]*era.BlockProof)
+		list   []*era.BlockProof
+		next   = first
+		last   common.Hash
+	)
+	for ; ; number++ {
+		header := s.chain.GetHeaderByNumber(number)
+		if header == nil {
+			return fmt.Errorf("historical roots period %d is not complete, block %d is missing", period, number)
+		}
+		slot, err := s.slot(header)
+		if err != nil {
+			return err
+		}
+		if slot >= end {
+			break
+		}
+		block, err := s.api.GetBeaconBlockAtSlot(slot)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve beacon block at slot %d: %w", slot, err)
+		}
+		proof := &era.BlockProof{
+			Slot:            slot,
+			BeaconBlockRoot: block.Root(),
+		}
+		for _, value := range block.ExecutionBlockHashProof() {
+			proof.ExecutionBlockProof = append(proof.ExecutionBlockProof, common.Hash(value))
+		}
+		if len(list) == 0 {
+			last = block.Header().ParentRoot
+		}
+		for ; next < slot; next++ {
+			roots[next-first] = last
+		}
+		last = proof.BeaconBlockRoot
+		proofs[header.Hash()] = proof
+		list = append(list, proof)
+	}
+	for ; next < end; next++ {
+		roots[next-first] = last
+	}
+	root, err := era.ProvePeriod(roots, list)
+	if err != nil {
+		return err
+	}
+	log.Info("Proved historical roots period", "period", period, "blocks", len(list), "root", root)
+	s.proofs = proofs
+	return nil
+}
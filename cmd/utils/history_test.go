@@ -19,6 +19,7 @@ package utils
 import (
 	"bytes"
 	"crypto/sha256"
+	"errors"
 	"io"
 	"math/big"
 	"os"
@@ -26,7 +27,10 @@ import (
 	"strings"
 	"testing"
 
+	bparams "github.com/ethereum/go-ethereum/beacon/params"
+	btypes "github.com/ethereum/go-ethereum/beacon/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
@@ -37,6 +41,8 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/ethereum/go-ethereum/triedb"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/beacon/deneb"
 )
 
 var (
@@ -90,7 +96,7 @@ func TestHistoryImportAndExport(t *testing.T) {
 	dir := t.TempDir()
 
 	// Export history to temp directory.
-	if err := ExportHistory(chain, dir, 0, count, step); err != nil {
+	if err := ExportHistory(chain, dir, 0, count, step, nil); err != nil {
 		t.Fatalf("error exporting history: %v", err)
 	}
 
@@ -178,3 +184,215 @@ func TestHistoryImportAndExport(t *testing.T) {
 		t.Fatalf("imported chain does not match expected, have (%d, %s) want (%d, %s)", have.Number, have.Hash(), want.Number, want.Hash())
 	}
 }
+
+func TestHistoryImportAndExportMerge(t *testing.T) {
+	var (
+		config  = *params.TestChainConfig
+		genesis = &core.Genesis{Config: &config}
+		engine  = beacon.New(ethash.NewFaker())
+		merge   = 70
+	)
+	// Generate a chain transitioning to proof-of-stake midway.
+	genDb, preBlocks, _ := core.GenerateChainWithGenesis(genesis, engine, merge, nil)
+	td := new(big.Int).Set(params.GenesisDifficulty)
+	for _, block := range preBlocks {
+		td.Add(td, block.Difficulty())
+	}
+	config.TerminalTotalDifficulty = td
+	postBlocks, _ := core.GenerateChain(&config, preBlocks[len(preBlocks)-1], engine, genDb, int(count)-merge, func(i int, gen *core.BlockGen) {
+		gen.SetPoS()
+	})
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, genesis, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("unable to initialize chain: %v", err)
+	}
+	defer chain.Stop()
+	if _, err := chain.InsertChain(append(preBlocks, postBlocks...)); err != nil {
+		t.Fatalf("error inserting chain: %v", err)
+	}
+	dir := t.TempDir()
+	if err := ExportHistory(chain, dir, 0, count, step, nil); err == nil {
+		t.Fatal("post-merge history exported without proofs")
+	}
+	if err := ExportHistory(chain, dir, 0, count, step, testProofSource{}); err != nil {
+		t.Fatalf("error exporting history: %v", err)
+	}
+	// The pre-merge history is packaged into era1 archives, cut at the merge.
+	entries, _ := era.ReadDir(dir, "mainnet")
+	if want := merge/int(step) + 1; len(entries) != want {
+		t.Fatalf("unexpected number of era1 files: have %d, want %d", len(entries), want)
+	}
+	entriesE, _ := era.ReadDirE(dir, "mainnet")
+	if want := int(count)/int(step) - merge/int(step) + 1; len(entriesE) != want {
+		t.Fatalf("unexpected number of era-E files: have %d, want %d", len(entriesE), want)
+	}
+	next := uint64(0)
+	for _, filename := range append(entries, entriesE...) {
+		e, err := era.Open(filepath.Join(dir, filename))
+		if err != nil {
+			t.Fatalf("error opening era: %v", err)
+		}
+		if e.Start() != next {
+			t.Fatalf("archive %s: unexpected start, have %d, want %d", filename, e.Start(), next)
+		}
+		next += e.Count()
+		for n := e.Start(); n < next; n++ {
+			block, err := e.GetBlockByNumber(n)
+			if err != nil {
+				t.Fatalf("error reading block %d: %v", n, err)
+			}
+			if postMerge := block.Difficulty().Sign() == 0; postMerge != (e.Format() == era.FormatEraE) {
+				t.Fatalf("block %d archived in %s file", n, e.Format())
+			}
+			if want := chain.GetBlockByNumber(n); want.Hash() != block.Hash() {
+				t.Fatalf("block hash mismatch %d: want %s, got %s", n, want.Hash().Hex(), block.Hash().Hex())
+			}
+		}
+		e.Close()
+	}
+	if next != count+1 {
+		t.Fatalf("unexpected number of archived blocks: have %d, want %d", next, count+1)
+	}
+	// Now import Era.
+	db2, err := rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), "", "", false)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer db2.Close()
+
+	imported, err := core.NewBlockChain(db2, nil, genesis, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("unable to initialize chain: %v", err)
+	}
+	defer imported.Stop()
+	if err := ImportHistory(imported, dir, "mainnet"); err != nil {
+		t.Fatalf("failed to import chain: %v", err)
+	}
+	if have, want := imported.CurrentSnapBlock(), chain.CurrentHeader(); have.Hash() != want.Hash() {
+		t.Fatalf("imported chain does not match expected, have (%d, %s) want (%d, %s)", have.Number, have.Hash(), want.Number, want.Hash())
+	}
+}
+
+// testProofSource proves blocks with made up beacon blocks, which is enough
+// for the archives to be built but not verified.
+type testProofSource struct{}
+
+func (testProofSource) BlockProof(header *types.Header) (*era.BlockProof, error) {
+	var (
+		index = uint64(bparams.BlockIndexExecHashDeneb)
+		proof = &era.BlockProof{Slot: header.Number.Uint64()}
+		root  = header.Hash()
+	)
+	for ; index > 1; index >>= 1 {
+		sibling := common.Hash{byte(index)}
+		if index&1 == 0 {
+			root = sha256.Sum256(append(root[:], sibling[:]...))
+		} else {
+			root = sha256.Sum256(append(sibling[:], root[:]...))
+		}
+		proof.ExecutionBlockProof = append(proof.ExecutionBlockProof, sibling)
+	}
+	proof.BeaconBlockRoot = root
+	return proof, nil
+}
+
+// testBeaconAPI serves made up beacon blocks carrying the test headers.
+type testBeaconAPI struct {
+	genesisTime uint64
+	blocks      map[uint64]*btypes.BeaconBlock
+}
+
+func (api *testBeaconAPI) GetGenesisTime() (uint64, error) {
+	return api.genesisTime, nil
+}
+
+func (api *testBeaconAPI) GetBeaconBlockAtSlot(slot uint64) (*btypes.BeaconBlock, error) {
+	if block, ok := api.blocks[slot]; ok {
+		return block, nil
+	}
+	return nil, errors.New("not found")
+}
+
+// testHeaderReader serves the test headers by number.
+type testHeaderReader []*types.Header
+
+func (r testHeaderReader) GetHeaderByNumber(number uint64) *types.Header {
+	if number < uint64(len(r)) {
+		return r[number]
+	}
+	return nil
+}
+
+func TestBeaconProofSource(t *testing.T) {
+	var (
+		genesisTime = uint64(1000)
+		period      = uint64(1)
+		first       = period * era.SlotsPerHistoricalRoot
+		slots       = []uint64{first - 2, first + 5, first + 6, first + 100, first + era.SlotsPerHistoricalRoot - 1, first + era.SlotsPerHistoricalRoot + 7}
+		headers     = testHeaderReader{{Number: new(big.Int), Difficulty: big.NewInt(1)}}
+		api         = &testBeaconAPI{genesisTime: genesisTime, blocks: make(map[uint64]*btypes.BeaconBlock)}
+		parent      = common.Hash{0xff}
+		roots       = make(map[uint64]common.Hash)
+	)
+	for i, slot := range slots {
+		header := &types.Header{
+			Number:     big.NewInt(int64(i + 1)),
+			Difficulty: new(big.Int),
+			Time:       genesisTime + slot*secondsPerSlot,
+		}
+		headers = append(headers, header)
+
+		block := btypes.NewBeaconBlock(&deneb.BeaconBlock{
+			Slot:       zrntcommon.Slot(slot),
+			ParentRoot: zrntcommon.Root(parent),
+			Body: deneb.BeaconBlockBody{
+				ExecutionPayload: deneb.ExecutionPayload{BlockHash: zrntcommon.Hash32(header.Hash())},
+			},
+		})
+		api.blocks[slot] = block
+		roots[slot] = block.Root()
+		parent = block.Root()
+	}
+	source, err := NewBeaconProofSource(api, headers)
+	if err != nil {
+		t.Fatalf("failed to create proof source: %v", err)
+	}
+	// Compute the block summary root of the period, with the empty slots
+	// repeating the latest root before them.
+	layer := make([]common.Hash, era.SlotsPerHistoricalRoot)
+	last := roots[slots[0]]
+	for i := range layer {
+		if root, ok := roots[first+uint64(i)]; ok {
+			last = root
+		}
+		layer[i] = last
+	}
+	for len(layer) > 1 {
+		for i := 0; i < len(layer)/2; i++ {
+			layer[i] = sha256.Sum256(append(layer[2*i][:], layer[2*i+1][:]...))
+		}
+		layer = layer[:len(layer)/2]
+	}
+	summaryRoot := layer[0]
+
+	// Blocks of the complete period must be proven against the summary.
+	for _, header := range headers[2:5] {
+		proof, err := source.BlockProof(header)
+		if err != nil {
+			t.Fatalf("block %d: failed to prove: %v", header.Number, err)
+		}
+		if proof.Period() != period {
+			t.Fatalf("block %d: unexpected period %d", header.Number, proof.Period())
+		}
+		if err := proof.Verify(header.Hash(), summaryRoot); err != nil {
+			t.Fatalf("block %d: invalid proof: %v", header.Number, err)
+		}
+	}
+	// Pre-merge blocks and blocks of incomplete periods can't be proven.
+	if _, err := source.BlockProof(headers[0]); err == nil {
+		t.Fatal("pre-merge block proven")
+	}
+	if _, err := source.BlockProof(headers[len(headers)-1]); err == nil {
+		t.Fatal("block of incomplete period proven")
+	}
+}
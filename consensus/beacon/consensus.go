@@ -283,6 +283,14 @@ func (beacon *Beacon) verifyHeader(chain consensus.ChainHeaderReader, header, pa
 			return err
 		}
 	}
+	// Verify existence / non-existence of blockAccessListHash.
+	amsterdam := chain.Config().IsAmsterdam(header.Number, header.Time)
+	if amsterdam && header.BlockAccessListHash == nil {
+		return errors.New("missing blockAccessListHash")
+	}
+	if !amsterdam && header.BlockAccessListHash != nil {
+		return fmt.Errorf("invalid blockAccessListHash: have %x, expected nil", header.BlockAccessListHash)
+	}
 	return nil
 }
 
@@ -370,11 +378,23 @@ func (beacon *Beacon) FinalizeAndAssemble(chain consensus.ChainHeaderReader, hea
 		}
 	}
 	// Finalize and assemble the block.
+	amsterdam := chain.Config().IsAmsterdam(header.Number, header.Time)
+	if amsterdam {
+		state.SetAccessListIndex(len(body.Transactions) + 1)
+	}
 	beacon.Finalize(chain, header, state, body)
 
 	// Assign the final state root to header.
 	header.Root = state.IntermediateRoot(true)
 
+	// All blocks after Amsterdam must commit to their access list.
+	if amsterdam {
+		if !state.BlockAccessListEnabled() {
+			return nil, errors.New("block access list not recorded after Amsterdam activation")
+		}
+		body.AccessList = state.BlockAccessList()
+	}
+
 	// Assemble the final block.
 	block := types.NewBlock(header, body, receipts, trie.NewStackTrie(nil))
 
@@ -83,6 +83,8 @@ func CalcExcessBlobGas(config *params.ChainConfig, parent *types.Header, headTim
 func CalcBlobFee(config *params.ChainConfig, header *types.Header) *big.Int {
 	var frac uint64
 	switch config.LatestFork(header.Time) {
+	case forks.Amsterdam:
+		frac = config.BlobScheduleConfig.Amsterdam.UpdateFraction
 	case forks.Osaka:
 		frac = config.BlobScheduleConfig.Osaka.UpdateFraction
 	case forks.Prague:
@@ -105,6 +107,8 @@ func MaxBlobsPerBlock(cfg *params.ChainConfig, time uint64) int {
 		s      = cfg.BlobScheduleConfig
 	)
 	switch {
+	case cfg.IsAmsterdam(london, time) && s.Amsterdam != nil:
+		return s.Amsterdam.Max
 	case cfg.IsOsaka(london, time) && s.Osaka != nil:
 		return s.Osaka.Max
 	case cfg.IsPrague(london, time) && s.Prague != nil:
@@ -129,6 +133,8 @@ func LatestMaxBlobsPerBlock(cfg *params.ChainConfig) int {
 		return 0
 	}
 	switch {
+	case s.Amsterdam != nil:
+		return s.Amsterdam.Max
 	case s.Osaka != nil:
 		return s.Osaka.Max
 	case s.Prague != nil:
@@ -150,6 +156,8 @@ func targetBlobsPerBlock(cfg *params.ChainConfig, time uint64) int {
 		s      = cfg.BlobScheduleConfig
 	)
 	switch {
+	case cfg.IsAmsterdam(london, time) && s.Amsterdam != nil:
+		return s.Amsterdam.Target
 	case cfg.IsOsaka(london, time) && s.Osaka != nil:
 		return s.Osaka.Target
 	case cfg.IsPrague(london, time) && s.Prague != nil:
@@ -0,0 +1,194 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// makeAccessListChain generates a chain on top of the Amsterdam fork, whose
+// blocks contain transfers, storage modifications and withdrawals.
+func makeAccessListChain(t *testing.T) (*Genesis, []*types.Block, common.Address) {
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr    = crypto.PubkeyToAddress(key.PublicKey)
+		counter = common.HexToAddress("0xaaaa")
+		config  = *params.MergedTestChainConfig
+		engine  = beacon.New(ethash.NewFaker())
+	)
+	config.OsakaTime = new(uint64)
+	config.AmsterdamTime = new(uint64)
+	config.BlobScheduleConfig = &params.BlobScheduleConfig{
+		Cancun:    params.DefaultCancunBlobConfig,
+		Prague:    params.DefaultPragueBlobConfig,
+		Osaka:     params.DefaultOsakaBlobConfig,
+		Amsterdam: params.DefaultOsakaBlobConfig,
+	}
+	gspec := &Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			addr: {Balance: big.NewInt(params.Ether)},
+			// Increments slot 0 and reads slot 1
+			counter: {Code: []byte{
+				byte(vm.PUSH1), 0, byte(vm.SLOAD), byte(vm.PUSH1), 1, byte(vm.ADD), byte(vm.PUSH1), 0, byte(vm.SSTORE),
+				byte(vm.PUSH1), 1, byte(vm.SLOAD), byte(vm.POP), byte(vm.STOP),
+			}},
+		},
+	}
+	signer := types.LatestSigner(&config)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 3, func(i int, b *BlockGen) {
+		for j := 0; j < 3; j++ {
+			to := counter
+			if j == 1 {
+				to = common.BigToAddress(big.NewInt(int64(0x1000 + i)))
+			}
+			b.AddTx(types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+				ChainID:   config.ChainID,
+				Nonce:     b.TxNonce(addr),
+				To:        &to,
+				Value:     big.NewInt(1),
+				Gas:       100_000,
+				GasFeeCap: b.header.BaseFee,
+			}))
+		}
+		b.AddWithdrawal(&types.Withdrawal{Validator: 1, Address: common.HexToAddress("0xbbbb"), Amount: 1})
+	})
+	return gspec, blocks, counter
+}
+
+func TestBlockAccessList(t *testing.T) {
+	gspec, blocks, counter := makeAccessListChain(t)
+
+	// Check the generated access lists
+	for i, block := range blocks {
+		bal := block.AccessList()
+		if bal == nil || block.BlockAccessListHash() == nil || *block.BlockAccessListHash() != bal.Hash() {
+			t.Fatalf("block %d: invalid access list", i)
+		}
+		var found bool
+		for _, account := range bal {
+			switch account.Address {
+			case counter:
+				found = true
+				if len(account.StorageChanges) != 1 || len(account.StorageChanges[0].Changes) != 2 {
+					t.Fatalf("block %d: counter storage changes mismatch: %v", i, account.StorageChanges)
+				}
+				for k, change := range account.StorageChanges[0].Changes {
+					want := uint16(1 + 2*k) // the first and last transactions
+					if change.Index != want || change.Value != common.BigToHash(big.NewInt(int64(2*i+k+1))) {
+						t.Fatalf("block %d: counter change %d mismatch: %+v", i, k, change)
+					}
+				}
+				if len(account.StorageReads) != 1 || account.StorageReads[0] != common.BigToHash(common.Big1) {
+					t.Fatalf("block %d: counter storage reads mismatch: %v", i, account.StorageReads)
+				}
+			case common.HexToAddress("0xbbbb"):
+				if len(account.BalanceChanges) != 1 || account.BalanceChanges[0].Index != 4 {
+					t.Fatalf("block %d: withdrawal change mismatch: %v", i, account.BalanceChanges)
+				}
+			}
+		}
+		if !found {
+			t.Fatalf("block %d: counter missing from access list", i)
+		}
+	}
+	// Import the chain both sequentially and in parallel, the latter also with
+	// stateless self-validation, which requires a complete witness.
+	var roots []common.Hash
+	for _, config := range []vm.Config{{}, {ParallelExecution: true}, {ParallelExecution: true, StatelessSelfValidation: true}} {
+		chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, beacon.New(ethash.NewFaker()), config, nil)
+		if err != nil {
+			t.Fatalf("failed to create tester chain: %v", err)
+		}
+		if n, err := chain.InsertChain(blocks); err != nil {
+			t.Fatalf("parallel %v, stateless %v: block %d: failed to insert: %v", config.ParallelExecution, config.StatelessSelfValidation, n, err)
+		}
+		roots = append(roots, chain.CurrentBlock().Root)
+		chain.Stop()
+	}
+	for _, root := range roots[1:] {
+		if root != roots[0] || root != blocks[len(blocks)-1].Root() {
+			t.Fatalf("state root mismatch: sequential %x, parallel %x", roots[0], root)
+		}
+	}
+}
+
+func TestBlockAccessListInvalid(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		testBlockAccessListInvalid(t, parallel)
+	}
+}
+
+func testBlockAccessListInvalid(t *testing.T, parallel bool) {
+	gspec, blocks, counter := makeAccessListChain(t)
+
+	// Commit to a wrong access list, without providing it in the body
+	header := blocks[0].Header()
+	header.BlockAccessListHash = &common.Hash{0x01}
+	body := blocks[0].Body()
+	body.AccessList = nil
+	block := types.NewBlockWithHeader(header).WithBody(*body)
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, beacon.New(ethash.NewFaker()), vm.Config{ParallelExecution: parallel}, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain([]*types.Block{block}); err == nil {
+		t.Fatalf("parallel %v: block with invalid access list hash imported", parallel)
+	}
+	// Provide an access list not matching the header
+	bal := blocks[0].AccessList()
+	bal[0].BalanceChanges = append(bal[0].BalanceChanges, types.BalanceChange{Index: 3, Balance: common.U2560})
+	block = types.NewBlockWithHeader(blocks[0].Header()).WithBody(types.Body{
+		Transactions: blocks[0].Transactions(),
+		Withdrawals:  blocks[0].Withdrawals(),
+		AccessList:   bal,
+	})
+	if _, err := chain.InsertChain([]*types.Block{block}); err == nil {
+		t.Fatalf("parallel %v: block with mismatching access list imported", parallel)
+	}
+	// Commit to an access list with a wrong storage modification
+	bal = blocks[0].AccessList()
+	for _, account := range bal {
+		if account.Address == counter {
+			account.StorageChanges[0].Changes[0].Value = common.Hash{0x01}
+		}
+	}
+	header = blocks[0].Header()
+	hash := bal.Hash()
+	header.BlockAccessListHash = &hash
+	block = types.NewBlockWithHeader(header).WithBody(types.Body{
+		Transactions: blocks[0].Transactions(),
+		Withdrawals:  blocks[0].Withdrawals(),
+		AccessList:   bal,
+	})
+	if _, err := chain.InsertChain([]*types.Block{block}); err == nil {
+		t.Fatalf("parallel %v: block with wrong access list imported", parallel)
+	}
+}
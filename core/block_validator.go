@@ -81,6 +81,18 @@ func (v *BlockValidator) ValidateBody(block *types.Block) error {
 		return errors.New("withdrawals present in block body")
 	}
 
+	// Block access lists are committed to after the Amsterdam fork, but they
+	// are optional in the body as they can be recomputed during execution.
+	if header.BlockAccessListHash != nil {
+		if bal := block.AccessList(); bal != nil {
+			if hash := bal.Hash(); hash != *header.BlockAccessListHash {
+				return fmt.Errorf("block access list hash mismatch (header value %x, calculated %x)", *header.BlockAccessListHash, hash)
+			}
+		}
+	} else if block.AccessList() != nil {
+		return errors.New("block access list present in block body")
+	}
+
 	// Blob transactions may be present after the Cancun fork.
 	var blobs int
 	for i, tx := range block.Transactions() {
@@ -161,6 +173,15 @@ func (v *BlockValidator) ValidateState(block *types.Block, statedb *state.StateD
 	if root := statedb.IntermediateRoot(v.config.IsEIP158(header.Number)); header.Root != root {
 		return fmt.Errorf("invalid merkle root (remote: %x local: %x) dberr: %w", header.Root, root, statedb.Error())
 	}
+	// Validate the recorded block access list against the received one.
+	if header.BlockAccessListHash != nil {
+		if !statedb.BlockAccessListEnabled() {
+			return errors.New("block access list not recorded")
+		}
+		if hash := statedb.BlockAccessList().Hash(); hash != *header.BlockAccessListHash {
+			return fmt.Errorf("invalid block access list hash (remote: %x local: %x)", *header.BlockAccessListHash, hash)
+		}
+	}
 	return nil
 }
 
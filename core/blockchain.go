@@ -18,6 +18,7 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -48,6 +49,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/internal/syncx"
+	"github.com/ethereum/go-ethereum/internal/telemetry"
 	"github.com/ethereum/go-ethereum/internal/version"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
@@ -56,6 +58,8 @@ import (
 	"github.com/ethereum/go-ethereum/triedb"
 	"github.com/ethereum/go-ethereum/triedb/hashdb"
 	"github.com/ethereum/go-ethereum/triedb/pathdb"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
@@ -105,6 +109,9 @@ var (
 	forkReadyInterval = 3 * time.Minute
 )
 
+// tracer creates the spans of the block import pipeline.
+var tracer = telemetry.Tracer("github.com/ethereum/go-ethereum/core")
+
 const (
 	bodyCacheLimit     = 256
 	blockCacheLimit    = 256
@@ -165,6 +172,20 @@ type CacheConfig struct {
 	// This defines the cutoff block for history expiry.
 	// Blocks before this number may be unavailable in the chain database.
 	ChainHistoryMode history.HistoryMode
+
+	// Number of recent blocks whose history is kept in the rolling history
+	// mode, the older block bodies and receipts are pruned while running.
+	ChainHistoryRetention uint64
+
+	// Number of blocks between two background state pruning runs, zero means
+	// the online pruning is disabled. Only supported in the hash scheme.
+	StatePruneInterval  uint64
+	StatePruneBloomSize uint64 // Memory allowance (MB) for the bloom filter of state pruning
+
+	// Number of states below the persistent state which can be rolled back for
+	// serving the snap sync requests, zero means disabled. Only supported in the
+	// path scheme.
+	SnapServeDepth uint64
 }
 
 // triedbConfig derives the configures for trie database.
@@ -183,6 +204,10 @@ func (c *CacheConfig) triedbConfig(isVerkle bool) *triedb.Config {
 			StateHistory:    c.StateHistory,
 			CleanCacheSize:  c.TrieCleanLimit * 1024 * 1024,
 			WriteBufferSize: c.TrieDirtyLimit * 1024 * 1024,
+			RollbackDepth:   c.SnapServeDepth,
+
+			// Archive nodes index the state history for serving historical state
+			EnableStateIndexing: c.TrieDirtyDisabled,
 		}
 	}
 	return config
@@ -241,6 +266,8 @@ type BlockChain struct {
 	triedb        *triedb.Database                 // The database handler for maintaining trie nodes.
 	statedb       *state.CachingDB                 // State database to reuse between imports (contains state cache)
 	txIndexer     *txIndexer                       // Transaction indexer, might be nil if not enabled
+	historyPruner *historyPruner                   // Chain history pruner, might be nil if not enabled
+	statePruner   *statePruner                     // Online state pruner, might be nil if not enabled
 
 	hc               *HeaderChain
 	rmLogsFeed       event.Feed
@@ -494,6 +521,21 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 	if txLookupLimit != nil {
 		bc.txIndexer = newTxIndexer(*txLookupLimit, bc)
 	}
+	// Start history pruner if the rolling history mode is configured.
+	if bc.cacheConfig.ChainHistoryMode == history.KeepRecent {
+		bc.historyPruner = newHistoryPruner(bc.cacheConfig.ChainHistoryRetention, bc)
+	}
+	// Start state pruner if the online pruning is configured.
+	if bc.cacheConfig.StatePruneInterval != 0 {
+		switch {
+		case bc.triedb.Scheme() != rawdb.HashScheme:
+			log.Warn("Online state pruning is only supported in hash scheme")
+		case bc.cacheConfig.TrieDirtyDisabled:
+			log.Warn("Online state pruning is not supported in archive mode")
+		default:
+			bc.statePruner = newStatePruner(bc.cacheConfig.StatePruneInterval, bc.cacheConfig.StatePruneBloomSize, bc)
+		}
+	}
 	return bc, nil
 }
 
@@ -648,6 +690,24 @@ func (bc *BlockChain) initializeHistoryPruning(latest uint64) error {
 		bc.historyPrunePoint.Store(predefinedPoint)
 		return nil
 
+	case history.KeepRecent:
+		if bc.cacheConfig.ChainHistoryRetention < history.MinRetention {
+			return fmt.Errorf("chain history retention too low: %d < %d", bc.cacheConfig.ChainHistoryRetention, history.MinRetention)
+		}
+		// The history is pruned progressively, the pruning point is tracked
+		// by the freezer tail. Block headers are never pruned.
+		bc.historyPrunePoint.Store(nil)
+		if freezerTail == 0 {
+			return nil
+		}
+		hash := rawdb.ReadCanonicalHash(bc.db, freezerTail)
+		if hash == (common.Hash{}) {
+			log.Error("Chain history pruning point is missing", "tail", freezerTail)
+			return fmt.Errorf("missing history pruning point")
+		}
+		bc.historyPrunePoint.Store(&history.PrunePoint{BlockNumber: freezerTail, BlockHash: hash})
+		return nil
+
 	default:
 		return fmt.Errorf("invalid history mode: %d", bc.cacheConfig.ChainHistoryMode)
 	}
@@ -1184,6 +1244,14 @@ func (bc *BlockChain) stopWithoutSaving() {
 	if bc.txIndexer != nil {
 		bc.txIndexer.close()
 	}
+	// Signal shutdown history pruner.
+	if bc.historyPruner != nil {
+		bc.historyPruner.close()
+	}
+	// Signal shutdown state pruner.
+	if bc.statePruner != nil {
+		bc.statePruner.close()
+	}
 	// Unsubscribe all subscriptions registered from blockchain.
 	bc.scope.Close()
 
@@ -1650,7 +1718,7 @@ func (bc *BlockChain) InsertChain(chain types.Blocks) (int, error) {
 	}
 	defer bc.chainmu.Unlock()
 
-	_, n, err := bc.insertChain(chain, true, false) // No witness collection for mass inserts (would get super large)
+	_, n, err := bc.insertChain(context.Background(), chain, true, false) // No witness collection for mass inserts (would get super large)
 	return n, err
 }
 
@@ -1662,11 +1730,17 @@ func (bc *BlockChain) InsertChain(chain types.Blocks) (int, error) {
 // racey behaviour. If a sidechain import is in progress, and the historic state
 // is imported, but then new canon-head is added before the actual sidechain
 // completes, then the historic state could be pruned again
-func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool, makeWitness bool) (*stateless.Witness, int, error) {
+func (bc *BlockChain) insertChain(ctx context.Context, chain types.Blocks, setHead bool, makeWitness bool) (_ *stateless.Witness, _ int, err error) {
 	// If the chain is terminating, don't even bother starting up.
 	if bc.insertStopped() {
 		return nil, 0, nil
 	}
+	ctx, span := tracer.Start(ctx, "core.insertChain", trace.WithAttributes(
+		attribute.Int("blocks", len(chain)),
+		attribute.Int64("first", chain[0].Number().Int64()),
+		attribute.Int64("last", chain[len(chain)-1].Number().Int64()),
+	))
+	defer func() { telemetry.EndSpan(span, err) }()
 
 	if atomic.AddInt32(&bc.blockProcCounter, 1) == 1 {
 		bc.blockProcFeed.Send(true)
@@ -1850,6 +1924,10 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool, makeWitness
 				}
 			}
 			statedb.StartPrefetcher("chain", witness)
+
+			// Warm up all the state accessed by the block if it carries its
+			// access list (EIP-7928).
+			statedb.PrefetchBlockAccessList(block.AccessList())
 		}
 		activeState = statedb
 
@@ -1875,7 +1953,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool, makeWitness
 		}
 
 		// The traced section of block import.
-		res, err := bc.processBlock(block, statedb, start, setHead)
+		res, err := bc.processBlock(ctx, block, statedb, start, setHead)
 		followupInterrupt.Store(true)
 		if err != nil {
 			return nil, it.index, err
@@ -1942,7 +2020,7 @@ type blockProcessingResult struct {
 
 // processBlock executes and validates the given block. If there was no error
 // it writes the block and associated state to database.
-func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, start time.Time, setHead bool) (_ *blockProcessingResult, blockEndErr error) {
+func (bc *BlockChain) processBlock(ctx context.Context, block *types.Block, statedb *state.StateDB, start time.Time, setHead bool) (_ *blockProcessingResult, blockEndErr error) {
 	if bc.logger != nil && bc.logger.OnBlockStart != nil {
 		bc.logger.OnBlockStart(tracing.BlockEvent{
 			Block:     block,
@@ -1957,16 +2035,21 @@ func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, s
 	}
 
 	// Process block using the parent state as reference point
+	_, span := tracer.Start(ctx, "core.execute", trace.WithAttributes(attribute.Int64("number", block.Number().Int64())))
 	pstart := time.Now()
 	res, err := bc.processor.Process(block, statedb, bc.vmConfig)
+	telemetry.EndSpan(span, err)
 	if err != nil {
 		bc.reportBlock(block, res, err)
 		return nil, err
 	}
 	ptime := time.Since(pstart)
 
+	_, span = tracer.Start(ctx, "core.validate", trace.WithAttributes(attribute.Int64("number", block.Number().Int64())))
 	vstart := time.Now()
-	if err := bc.validator.ValidateState(block, statedb, res, false); err != nil {
+	err = bc.validator.ValidateState(block, statedb, res, false)
+	telemetry.EndSpan(span, err)
+	if err != nil {
 		bc.reportBlock(block, res, err)
 		return nil, err
 	}
@@ -2022,6 +2105,7 @@ func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, s
 	blockCrossValidationTimer.Update(xvtime)                                          // The time spent on stateless cross validation
 
 	// Write the block to the chain and get the status.
+	_, span = tracer.Start(ctx, "core.commit", trace.WithAttributes(attribute.Int64("number", block.Number().Int64())))
 	var (
 		wstart = time.Now()
 		status WriteStatus
@@ -2032,6 +2116,7 @@ func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, s
 	} else {
 		status, err = bc.writeBlockAndSetHead(block, res.Receipts, res.Logs, statedb, false)
 	}
+	telemetry.EndSpan(span, err)
 	if err != nil {
 		return nil, err
 	}
@@ -2135,7 +2220,7 @@ func (bc *BlockChain) insertSideChain(block *types.Block, it *insertIterator, ma
 		// memory here.
 		if len(blocks) >= 2048 || memory > 64*1024*1024 {
 			log.Info("Importing heavy sidechain segment", "blocks", len(blocks), "start", blocks[0].NumberU64(), "end", block.NumberU64())
-			if _, _, err := bc.insertChain(blocks, true, false); err != nil {
+			if _, _, err := bc.insertChain(context.Background(), blocks, true, false); err != nil {
 				return nil, 0, err
 			}
 			blocks, memory = blocks[:0], 0
@@ -2149,7 +2234,7 @@ func (bc *BlockChain) insertSideChain(block *types.Block, it *insertIterator, ma
 	}
 	if len(blocks) > 0 {
 		log.Info("Importing sidechain segment", "start", blocks[0].NumberU64(), "end", blocks[len(blocks)-1].NumberU64())
-		return bc.insertChain(blocks, true, makeWitness)
+		return bc.insertChain(context.Background(), blocks, true, makeWitness)
 	}
 	return nil, 0, nil
 }
@@ -2198,7 +2283,7 @@ func (bc *BlockChain) recoverAncestors(block *types.Block, makeWitness bool) (co
 		} else {
 			b = bc.GetBlock(hashes[i], numbers[i])
 		}
-		if _, _, err := bc.insertChain(types.Blocks{b}, false, makeWitness && i == 0); err != nil {
+		if _, _, err := bc.insertChain(context.Background(), types.Blocks{b}, false, makeWitness && i == 0); err != nil {
 			return b.ParentHash(), err
 		}
 	}
@@ -2418,13 +2503,13 @@ func (bc *BlockChain) reorg(oldHead *types.Header, newHead *types.Header) error
 // The key difference between the InsertChain is it won't do the canonical chain
 // updating. It relies on the additional SetCanonical call to finalize the entire
 // procedure.
-func (bc *BlockChain) InsertBlockWithoutSetHead(block *types.Block, makeWitness bool) (*stateless.Witness, error) {
+func (bc *BlockChain) InsertBlockWithoutSetHead(ctx context.Context, block *types.Block, makeWitness bool) (*stateless.Witness, error) {
 	if !bc.chainmu.TryLock() {
 		return nil, errChainStopped
 	}
 	defer bc.chainmu.Unlock()
 
-	witness, _, err := bc.insertChain(types.Blocks{block}, false, makeWitness)
+	witness, _, err := bc.insertChain(ctx, types.Blocks{block}, false, makeWitness)
 	return witness, err
 }
 
@@ -358,6 +358,13 @@ func (bc *BlockChain) StateAt(root common.Hash) (*state.StateDB, error) {
 	return state.New(root, bc.statedb)
 }
 
+// HistoricState returns a read-only state based on a particular point in time,
+// served by the indexed state history. It's only available for archive nodes
+// running the path scheme.
+func (bc *BlockChain) HistoricState(root common.Hash) (*state.StateDB, error) {
+	return state.New(root, state.NewHistoricDatabase(bc.db, bc.triedb))
+}
+
 // Config retrieves the chain's fork configuration.
 func (bc *BlockChain) Config() *params.ChainConfig { return bc.chainConfig }
 
@@ -407,6 +414,14 @@ func (bc *BlockChain) TxIndexProgress() (TxIndexProgress, error) {
 	return bc.txIndexer.txIndexProgress()
 }
 
+// HistoryPruneProgress returns the chain history pruning progress.
+func (bc *BlockChain) HistoryPruneProgress() (HistoryPruneProgress, error) {
+	if bc.historyPruner == nil {
+		return HistoryPruneProgress{}, errors.New("history pruner is not enabled")
+	}
+	return bc.historyPruner.report(bc.CurrentBlock().Number.Uint64()), nil
+}
+
 // HistoryPruningCutoff returns the configured history pruning point.
 // Blocks before this might not be available in the database.
 func (bc *BlockChain) HistoryPruningCutoff() (uint64, common.Hash) {
@@ -18,6 +18,7 @@ package core
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	gomath "math"
@@ -3425,7 +3426,7 @@ func testSetCanonical(t *testing.T, scheme string) {
 		gen.AddTx(tx)
 	})
 	for _, block := range side {
-		_, err := chain.InsertBlockWithoutSetHead(block, false)
+		_, err := chain.InsertBlockWithoutSetHead(context.Background(), block, false)
 		if err != nil {
 			t.Fatalf("Failed to insert into chain: %v", err)
 		}
@@ -4390,3 +4391,67 @@ func testInsertChainWithCutoff(t *testing.T, cutoff uint64, ancientLimit uint64,
 		}
 	}
 }
+
+// Tests that the historical state of an archive node running the path scheme
+// is served by the state history index once it's evicted from memory.
+func TestHistoricState(t *testing.T) {
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		funds   = big.NewInt(1000000000000000)
+		gspec   = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{address: {Balance: funds}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+		blocks = state.TriesInMemory + 16
+	)
+	_, chain, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), blocks, func(i int, gen *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(address), common.Address{byte(i + 1)}, big.NewInt(int64(i+1)), params.TxGas, gen.header.BaseFee, nil), signer, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gen.AddTx(tx)
+	})
+	cacheConfig := DefaultCacheConfigWithScheme(rawdb.PathScheme)
+	cacheConfig.TrieDirtyDisabled = true
+	cacheConfig.StateHistory = 0
+
+	db, err := rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("failed to create database with ancient backend: %v", err)
+	}
+	defer db.Close()
+
+	bc, err := NewBlockChain(db, cacheConfig, gspec, nil, ethash.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer bc.Stop()
+
+	if n, err := bc.InsertChain(chain); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	for i := 0; i < 8; i++ {
+		root := chain[i].Root()
+		if _, err := bc.StateAt(root); err == nil {
+			t.Fatalf("block %d: state is unexpectedly available", i+1)
+		}
+		statedb, err := bc.HistoricState(root)
+		if err != nil {
+			t.Fatalf("block %d: failed to open historic state: %v", i+1, err)
+		}
+		for j := 0; j < blocks; j++ {
+			var want uint64
+			if j <= i {
+				want = uint64(j + 1)
+			}
+			if have := statedb.GetBalance(common.Address{byte(j + 1)}).Uint64(); have != want {
+				t.Fatalf("block %d: balance mismatch for recipient %d, want %d, have %d", i+1, j+1, want, have)
+			}
+		}
+		if have, want := statedb.GetNonce(address), uint64(i+1); have != want {
+			t.Fatalf("block %d: nonce mismatch, want %d, have %d", i+1, want, have)
+		}
+	}
+}
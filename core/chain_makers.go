@@ -364,6 +364,17 @@ func GenerateChain(config *params.ChainConfig, parent *types.Block, engine conse
 		b := &BlockGen{i: i, cm: cm, parent: parent, statedb: statedb, engine: engine}
 		b.header = cm.makeHeader(parent, statedb, b.engine)
 
+		// Record the block access list if Amsterdam is enabled (EIP-7928). As
+		// the beacon root system call accesses state, it must be executed for
+		// the access list to match the one recorded during block processing.
+		amsterdam := config.IsAmsterdam(b.header.Number, b.header.Time)
+		if amsterdam {
+			statedb.EnableBlockAccessList()
+			if b.header.ParentBeaconRoot != nil {
+				b.SetParentBeaconRoot(*b.header.ParentBeaconRoot)
+			}
+		}
+
 		// Set the difficulty for clique block. The chain maker doesn't have access
 		// to a chain, so the difficulty will be left unset (nil). Set it here to the
 		// correct value.
@@ -403,6 +414,9 @@ func GenerateChain(config *params.ChainConfig, parent *types.Block, engine conse
 			gen(i, b)
 		}
 
+		if amsterdam {
+			statedb.SetAccessListIndex(len(b.txs) + 1)
+		}
 		requests := b.collectRequests(false)
 		if requests != nil {
 			reqHash := types.CalcRequestsHash(requests)
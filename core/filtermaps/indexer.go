@@ -423,12 +423,13 @@ func (f *FilterMaps) needTailEpoch(epoch uint32) bool {
 }
 
 // tailTargetBlock returns the target value for the tail block number according
-// to the log history parameter and the current index head.
+// to the log history parameter, the current index head and the history cutoff
+// point as the receipts before it are not available.
 func (f *FilterMaps) tailTargetBlock() uint64 {
 	if f.history == 0 || f.indexedView.HeadNumber() < f.history {
-		return 0
+		return f.historyCutoff
 	}
-	return f.indexedView.HeadNumber() + 1 - f.history
+	return max(f.indexedView.HeadNumber()+1-f.history, f.historyCutoff)
 }
 
 // tailPartialBlocks returns the number of rendered blocks in the partially
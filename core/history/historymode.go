@@ -32,10 +32,18 @@ const (
 
 	// KeepPostMerge sets the history pruning point to the merge activation block.
 	KeepPostMerge
+
+	// KeepRecent keeps the history of a rolling window of recent blocks, the
+	// history falling out of the window is pruned while the node is running.
+	KeepRecent
 )
 
+// MinRetention is the minimum number of recent blocks whose history must be kept
+// in the KeepRecent mode, matching the chain reorganization limit.
+const MinRetention = params.FullImmutabilityThreshold
+
 func (m HistoryMode) IsValid() bool {
-	return m <= KeepPostMerge
+	return m <= KeepRecent
 }
 
 func (m HistoryMode) String() string {
@@ -44,6 +52,8 @@ func (m HistoryMode) String() string {
 		return "all"
 	case KeepPostMerge:
 		return "postmerge"
+	case KeepRecent:
+		return "recent"
 	default:
 		return fmt.Sprintf("invalid HistoryMode(%d)", m)
 	}
@@ -64,8 +74,10 @@ func (m *HistoryMode) UnmarshalText(text []byte) error {
 		*m = KeepAll
 	case "postmerge":
 		*m = KeepPostMerge
+	case "recent":
+		*m = KeepRecent
 	default:
-		return fmt.Errorf(`unknown sync mode %q, want "all" or "postmerge"`, text)
+		return fmt.Errorf(`unknown history mode %q, want "all", "postmerge" or "recent"`, text)
 	}
 	return nil
 }
@@ -0,0 +1,189 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/history"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// historyPruneInterval is the minimum number of blocks the history pruning point
+// is moved forward by at once, which avoids touching the freezer on every block
+// and keeps the changes of the announced block range infrequent.
+const historyPruneInterval = 1024
+
+// HistoryPruneProgress is the struct describing the progress for history pruning.
+type HistoryPruneProgress struct {
+	Pruned    uint64 // number of blocks whose bodies and receipts are pruned
+	Remaining uint64 // number of blocks out of the retention window, not pruned yet
+}
+
+// Done returns an indicator if the history pruning is finished.
+func (progress HistoryPruneProgress) Done() bool {
+	return progress.Remaining == 0
+}
+
+// historyPruner is the module responsible for pruning the chain history out of
+// the configured retention window, by truncating the block bodies and receipts
+// from the chain freezer. The headers are kept.
+type historyPruner struct {
+	// retention denotes the number of recent blocks whose history is kept.
+	retention uint64
+	chain     *BlockChain
+	db        ethdb.Database
+	term      chan chan struct{}
+	closed    chan struct{}
+}
+
+// newHistoryPruner initializes the history pruner.
+func newHistoryPruner(retention uint64, chain *BlockChain) *historyPruner {
+	pruner := &historyPruner{
+		retention: retention,
+		chain:     chain,
+		db:        chain.db,
+		term:      make(chan chan struct{}),
+		closed:    make(chan struct{}),
+	}
+	go pruner.loop()
+
+	log.Info("Initialized chain history pruner", "retention", retention)
+	return pruner
+}
+
+// target returns the block number before which the chain history should be
+// pruned with the given chain head. Only the history already moved into the
+// freezer can be pruned.
+func (pruner *historyPruner) target(head uint64) uint64 {
+	if head < pruner.retention {
+		return 0
+	}
+	frozen, err := pruner.db.Ancients()
+	if err != nil {
+		return 0
+	}
+	return min(head+1-pruner.retention, frozen)
+}
+
+// run prunes the chain history out of the retention window with the given
+// chain head. The done channel will be closed once the task is complete.
+func (pruner *historyPruner) run(head uint64, done chan struct{}) {
+	defer close(done)
+
+	tail, err := pruner.db.Tail()
+	if err != nil {
+		log.Error("Failed to retrieve chain history tail", "err", err)
+		return
+	}
+	target := pruner.target(head)
+	if target < tail+historyPruneInterval {
+		return
+	}
+	hash := rawdb.ReadCanonicalHash(pruner.db, target)
+	if hash == (common.Hash{}) {
+		log.Error("Missing canonical hash of history pruning point", "number", target)
+		return
+	}
+	// Publish the new pruning point before touching the database, so that the
+	// history is no longer served and the transaction indexer starts to remove
+	// the indexes within it.
+	if cutoff, _ := pruner.chain.HistoryPruningCutoff(); cutoff < target {
+		pruner.chain.historyPrunePoint.Store(&history.PrunePoint{
+			BlockNumber: target,
+			BlockHash:   hash,
+		})
+	}
+	// The transaction indexes can only be removed while the block bodies are
+	// still available, wait for the indexer to catch up before truncating.
+	if pruner.chain.txIndexer != nil {
+		if itail := rawdb.ReadTxIndexTail(pruner.db); itail != nil && *itail < target {
+			log.Debug("Waiting for transaction unindexing", "tail", *itail, "target", target)
+			return
+		}
+	}
+	start := time.Now()
+	if _, err := pruner.db.TruncateTail(target); err != nil {
+		log.Error("Failed to prune chain history", "target", target, "err", err)
+		return
+	}
+	log.Info("Pruned chain history", "from", tail, "to", target, "elapsed", common.PrettyDuration(time.Since(start)))
+}
+
+// loop is the scheduler of the pruner, launching the pruning task whenever the
+// chain head is advanced.
+func (pruner *historyPruner) loop() {
+	defer close(pruner.closed)
+
+	var (
+		done   chan struct{} // Non-nil if background routine is active
+		headCh = make(chan ChainHeadEvent)
+		sub    = pruner.chain.SubscribeChainHeadEvent(headCh)
+	)
+	defer sub.Unsubscribe()
+
+	if head := pruner.chain.CurrentBlock(); head.Number.Uint64() != 0 {
+		done = make(chan struct{})
+		go pruner.run(head.Number.Uint64(), done)
+	}
+	for {
+		select {
+		case h := <-headCh:
+			if done == nil {
+				done = make(chan struct{})
+				go pruner.run(h.Header.Number.Uint64(), done)
+			}
+		case <-done:
+			done = nil
+		case ch := <-pruner.term:
+			if done != nil {
+				<-done
+			}
+			close(ch)
+			return
+		}
+	}
+}
+
+// report returns the history pruning progress.
+func (pruner *historyPruner) report(head uint64) HistoryPruneProgress {
+	tail, err := pruner.db.Tail()
+	if err != nil {
+		return HistoryPruneProgress{}
+	}
+	var remaining uint64
+	if target := pruner.target(head); target >= tail+historyPruneInterval {
+		remaining = target - tail
+	}
+	return HistoryPruneProgress{
+		Pruned:    tail,
+		Remaining: remaining,
+	}
+}
+
+// close shutdown the pruner. Safe to be called for multiple times.
+func (pruner *historyPruner) close() {
+	ch := make(chan struct{})
+	select {
+	case pruner.term <- ch:
+		<-ch
+	case <-pruner.closed:
+	}
+}
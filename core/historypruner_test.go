@@ -0,0 +1,134 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// TestHistoryPruner tests that the chain history out of the retention window is
+// pruned progressively, in coordination with the transaction indexer.
+func TestHistoryPruner(t *testing.T) {
+	var (
+		testBankKey, _  = crypto.GenerateKey()
+		testBankAddress = crypto.PubkeyToAddress(testBankKey.PublicKey)
+		testBankFunds   = big.NewInt(1000000000000000000)
+
+		gspec = &Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   types.GenesisAlloc{testBankAddress: {Balance: testBankFunds}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		engine    = ethash.NewFaker()
+		nonce     = uint64(0)
+		chainHead = uint64(3 * historyPruneInterval)
+		retention = uint64(historyPruneInterval / 2)
+	)
+	_, blocks, receipts := GenerateChainWithGenesis(gspec, engine, int(chainHead), func(i int, gen *BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(nonce, common.HexToAddress("0xdeadbeef"), big.NewInt(1000), params.TxGas, big.NewInt(10*params.InitialBaseFee), nil), types.HomesteadSigner{}, testBankKey)
+		gen.AddTx(tx)
+		nonce += 1
+	})
+	db, _ := rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), "", "", false)
+	defer db.Close()
+
+	genesis := gspec.ToBlock()
+	rawdb.WriteAncientBlocks(db, append([]*types.Block{genesis}, blocks...), append([]types.Receipts{{}}, receipts...))
+	for _, block := range append([]*types.Block{genesis}, blocks...) {
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+	}
+	indexer := &txIndexer{
+		db:       db,
+		progress: make(chan chan TxIndexProgress),
+	}
+	indexer.run(chainHead, make(chan struct{}), make(chan struct{}))
+	verify(t, db, blocks, 0)
+
+	chain := &BlockChain{
+		db:           db,
+		genesisBlock: genesis,
+		txIndexer:    indexer,
+	}
+	pruner := &historyPruner{
+		retention: retention,
+		chain:     chain,
+		db:        db,
+	}
+	checkTail := func(head uint64, want uint64) {
+		t.Helper()
+		if tail, _ := db.Tail(); tail != want {
+			t.Fatalf("head %d: unexpected freezer tail, want %d, got %d", head, want, tail)
+		}
+		if cutoff, _ := chain.HistoryPruningCutoff(); cutoff != want {
+			t.Fatalf("head %d: unexpected history cutoff, want %d, got %d", head, want, cutoff)
+		}
+		for _, block := range blocks {
+			body := rawdb.ReadBody(db, block.Hash(), block.NumberU64())
+			if block.NumberU64() < want && body != nil {
+				t.Fatalf("head %d: body of block %d is not pruned", head, block.NumberU64())
+			}
+			if block.NumberU64() >= want && body == nil {
+				t.Fatalf("head %d: body of block %d is missing", head, block.NumberU64())
+			}
+			if rawdb.ReadHeader(db, block.Hash(), block.NumberU64()) == nil {
+				t.Fatalf("head %d: header of block %d is missing", head, block.NumberU64())
+			}
+		}
+	}
+	// The history is not pruned until the window is moved far enough
+	head := retention + historyPruneInterval - 2
+	pruner.run(head, make(chan struct{}))
+	checkTail(head, 0)
+	if prog := pruner.report(head); prog.Pruned != 0 || prog.Remaining != 0 {
+		t.Fatalf("unexpected progress: %+v", prog)
+	}
+	// The pruning is deferred until the transactions are unindexed
+	head = chainHead - historyPruneInterval
+	target := head + 1 - retention
+
+	pruner.run(head, make(chan struct{}))
+	if tail, _ := db.Tail(); tail != 0 {
+		t.Fatalf("history pruned before transaction unindexing, tail %d", tail)
+	}
+	if cutoff, _ := chain.HistoryPruningCutoff(); cutoff != target {
+		t.Fatalf("unexpected history cutoff, want %d, got %d", target, cutoff)
+	}
+	if prog := pruner.report(head); prog.Pruned != 0 || prog.Remaining != target {
+		t.Fatalf("unexpected progress: %+v", prog)
+	}
+	indexer.cutoff, _ = chain.HistoryPruningCutoff()
+	indexer.run(chainHead, make(chan struct{}), make(chan struct{}))
+	verify(t, db, blocks, target)
+
+	pruner.run(head, make(chan struct{}))
+	checkTail(head, target)
+	if prog := pruner.report(head); prog.Pruned != target || prog.Remaining != 0 {
+		t.Fatalf("unexpected progress: %+v", prog)
+	}
+	// The history is pruned directly if the transaction indexer is disabled
+	chain.txIndexer = nil
+	pruner.run(chainHead, make(chan struct{}))
+	checkTail(chainHead, chainHead+1-retention)
+}
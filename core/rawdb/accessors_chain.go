@@ -285,6 +285,32 @@ func DeleteTxIndexTail(db ethdb.KeyValueWriter) {
 	}
 }
 
+// ReadChainVerification retrieves the number of the next block to be verified
+// by the chain data integrity check.
+func ReadChainVerification(db ethdb.KeyValueReader) *uint64 {
+	data, _ := db.Get(chainVerificationKey)
+	if len(data) != 8 {
+		return nil
+	}
+	number := binary.BigEndian.Uint64(data)
+	return &number
+}
+
+// WriteChainVerification stores the number of the next block to be verified
+// by the chain data integrity check.
+func WriteChainVerification(db ethdb.KeyValueWriter, number uint64) {
+	if err := db.Put(chainVerificationKey, encodeBlockNumber(number)); err != nil {
+		log.Crit("Failed to store the chain verification progress", "err", err)
+	}
+}
+
+// DeleteChainVerification deletes the chain verification progress.
+func DeleteChainVerification(db ethdb.KeyValueWriter) {
+	if err := db.Delete(chainVerificationKey); err != nil {
+		log.Crit("Failed to delete the chain verification progress", "err", err)
+	}
+}
+
 // ReadHeaderRange returns the rlp-encoded headers, starting at 'number', and going
 // backwards towards genesis. This method assumes that the caller already has
 // placed a cap on count, to prevent DoS issues.
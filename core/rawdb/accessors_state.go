@@ -141,6 +141,32 @@ func WritePersistentStateID(db ethdb.KeyValueWriter, number uint64) {
 	}
 }
 
+// ReadStateConversion retrieves the root of the state being converted from the
+// hash-based scheme to the path-based scheme. An empty hash is returned if no
+// conversion is in progress.
+func ReadStateConversion(db ethdb.KeyValueReader) common.Hash {
+	data, _ := db.Get(stateConversionKey)
+	if len(data) != common.HashLength {
+		return common.Hash{}
+	}
+	return common.BytesToHash(data)
+}
+
+// WriteStateConversion stores the root of the state being converted from the
+// hash-based scheme to the path-based scheme.
+func WriteStateConversion(db ethdb.KeyValueWriter, root common.Hash) {
+	if err := db.Put(stateConversionKey, root.Bytes()); err != nil {
+		log.Crit("Failed to store the state conversion root", "err", err)
+	}
+}
+
+// DeleteStateConversion deletes the root of the state being converted.
+func DeleteStateConversion(db ethdb.KeyValueWriter) {
+	if err := db.Delete(stateConversionKey); err != nil {
+		log.Crit("Failed to remove the state conversion root", "err", err)
+	}
+}
+
 // ReadTrieJournal retrieves the serialized in-memory trie nodes of layers saved at
 // the last shutdown.
 func ReadTrieJournal(db ethdb.KeyValueReader) []byte {
@@ -268,3 +294,95 @@ func WriteStateHistory(db ethdb.AncientWriter, id uint64, meta []byte, accountIn
 		return nil
 	})
 }
+
+// ReadStateHistoryIndexHead retrieves the id of the latest indexed state history.
+func ReadStateHistoryIndexHead(db ethdb.KeyValueReader) *uint64 {
+	data, _ := db.Get(headStateHistoryIndexKey)
+	if len(data) != 8 {
+		return nil
+	}
+	number := binary.BigEndian.Uint64(data)
+	return &number
+}
+
+// WriteStateHistoryIndexHead stores the id of the latest indexed state history.
+func WriteStateHistoryIndexHead(db ethdb.KeyValueWriter, number uint64) {
+	if err := db.Put(headStateHistoryIndexKey, encodeBlockNumber(number)); err != nil {
+		log.Crit("Failed to store the state history index head", "err", err)
+	}
+}
+
+// ReadAccountHistoryIndexBlock retrieves the state history index block of the
+// specified account, which is identified by the last state id it contains.
+func ReadAccountHistoryIndexBlock(db ethdb.KeyValueReader, accountHash common.Hash, last uint64) []byte {
+	data, _ := db.Get(accountHistoryIndexKey(accountHash, last))
+	return data
+}
+
+// WriteAccountHistoryIndexBlock stores the state history index block of the
+// specified account.
+func WriteAccountHistoryIndexBlock(db ethdb.KeyValueWriter, accountHash common.Hash, last uint64, data []byte) {
+	if err := db.Put(accountHistoryIndexKey(accountHash, last), data); err != nil {
+		log.Crit("Failed to store account history index", "err", err)
+	}
+}
+
+// DeleteAccountHistoryIndexBlock removes the state history index block of the
+// specified account.
+func DeleteAccountHistoryIndexBlock(db ethdb.KeyValueWriter, accountHash common.Hash, last uint64) {
+	if err := db.Delete(accountHistoryIndexKey(accountHash, last)); err != nil {
+		log.Crit("Failed to delete account history index", "err", err)
+	}
+}
+
+// IterateAccountHistoryIndex returns an iterator over the state history index
+// blocks of the specified account, starting at the first block whose last state
+// id is not less than the given one.
+func IterateAccountHistoryIndex(db ethdb.Iteratee, accountHash common.Hash, start uint64) ethdb.Iterator {
+	return db.NewIterator(append(StateHistoryAccountIndexPrefix, accountHash.Bytes()...), encodeBlockNumber(start))
+}
+
+// ReadStorageHistoryIndexBlock retrieves the state history index block of the
+// specified storage slot, which is identified by the last state id it contains.
+func ReadStorageHistoryIndexBlock(db ethdb.KeyValueReader, accountHash, storageHash common.Hash, last uint64) []byte {
+	data, _ := db.Get(storageHistoryIndexKey(accountHash, storageHash, last))
+	return data
+}
+
+// WriteStorageHistoryIndexBlock stores the state history index block of the
+// specified storage slot.
+func WriteStorageHistoryIndexBlock(db ethdb.KeyValueWriter, accountHash, storageHash common.Hash, last uint64, data []byte) {
+	if err := db.Put(storageHistoryIndexKey(accountHash, storageHash, last), data); err != nil {
+		log.Crit("Failed to store storage history index", "err", err)
+	}
+}
+
+// DeleteStorageHistoryIndexBlock removes the state history index block of the
+// specified storage slot.
+func DeleteStorageHistoryIndexBlock(db ethdb.KeyValueWriter, accountHash, storageHash common.Hash, last uint64) {
+	if err := db.Delete(storageHistoryIndexKey(accountHash, storageHash, last)); err != nil {
+		log.Crit("Failed to delete storage history index", "err", err)
+	}
+}
+
+// IterateStorageHistoryIndex returns an iterator over the state history index
+// blocks of the specified storage slot, starting at the first block whose last
+// state id is not less than the given one.
+func IterateStorageHistoryIndex(db ethdb.Iteratee, accountHash, storageHash common.Hash, start uint64) ethdb.Iterator {
+	prefix := make([]byte, 0, len(StateHistoryStorageIndexPrefix)+2*common.HashLength)
+	prefix = append(prefix, StateHistoryStorageIndexPrefix...)
+	prefix = append(prefix, accountHash.Bytes()...)
+	prefix = append(prefix, storageHash.Bytes()...)
+	return db.NewIterator(prefix, encodeBlockNumber(start))
+}
+
+// DeleteStateHistoryIndex removes the entire state history index along with
+// the indexing progress marker.
+func DeleteStateHistoryIndex(db ethdb.KeyValueStore) error {
+	for _, prefix := range [][]byte{StateHistoryAccountIndexPrefix, StateHistoryStorageIndexPrefix} {
+		if err := deletePrefixRange(db, prefix, false, func(bool) bool { return false }); err != nil {
+			return err
+		}
+	}
+	return db.Delete(headStateHistoryIndexKey)
+}
@@ -0,0 +1,433 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/internal/era"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// eraCacheSize is the maximum number of Era1 archives kept open at once.
+const eraCacheSize = 8
+
+// errEraMissing is returned if the requested item is not covered by any archive.
+var errEraMissing = errors.New("not covered by era archives")
+
+// eraStore is a read-only source of the canonical chain segment pruned from the
+// chain freezer, backed by a directory of Era1 archives. Each archive is verified
+// when it's opened for the first time: the accumulator root is recomputed from
+// the archived headers and total difficulties, and it must match both the root
+// recorded in the archive and its file name. The archived headers must also
+// match the local canonical chain, whose headers are never pruned, and the
+// archived bodies and receipts must match the roots committed to by them.
+type eraStore struct {
+	db     ethdb.Reader     // Database for looking up the local canonical hashes
+	dir    string           // Directory of the Era1 archives
+	files  []string         // Archive file names, indexed by epoch
+	hasher types.TrieHasher // Hasher for deriving the body and receipt roots
+
+	lock     sync.Mutex
+	open     lru.BasicLRU[uint64, *era.Era] // Recently used archives, all verified
+	verified map[uint64]bool                // Epochs of the archives already verified
+}
+
+// newEraStore opens the Era1 archives of the given network in the directory.
+func newEraStore(db ethdb.Reader, dir string, network string, hasher types.TrieHasher) (*eraStore, error) {
+	files, err := era.ReadDir(dir, network)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no %s era1 archives found in %s", network, dir)
+	}
+	return &eraStore{
+		db:       db,
+		dir:      dir,
+		files:    files,
+		hasher:   hasher,
+		open:     lru.NewBasicLRU[uint64, *era.Era](eraCacheSize),
+		verified: make(map[uint64]bool),
+	}, nil
+}
+
+// limit returns the number of blocks covered by the archives.
+func (s *eraStore) limit() uint64 {
+	return uint64(len(s.files)) * uint64(era.MaxEra1Size)
+}
+
+// archive returns the verified archive of the given epoch. The caller must hold
+// the store lock.
+func (s *eraStore) archive(epoch uint64) (*era.Era, error) {
+	if e, ok := s.open.Get(epoch); ok {
+		return e, nil
+	}
+	if epoch >= uint64(len(s.files)) {
+		return nil, errEraMissing
+	}
+	name := s.files[epoch]
+	e, err := era.Open(filepath.Join(s.dir, name))
+	if err != nil {
+		return nil, err
+	}
+	if !s.verified[epoch] {
+		if err := s.verify(epoch, name, e); err != nil {
+			e.Close()
+			return nil, fmt.Errorf("invalid era1 archive %s: %w", name, err)
+		}
+		s.verified[epoch] = true
+		log.Info("Verified era1 archive", "file", name, "start", e.Start(), "count", e.Count())
+	}
+	if s.open.Len() >= eraCacheSize {
+		if _, old, ok := s.open.RemoveOldest(); ok {
+			old.Close()
+		}
+	}
+	s.open.Add(epoch, e)
+	return e, nil
+}
+
+// verify checks the archive of the given epoch against its accumulator root and
+// the local canonical chain, and the archived bodies and receipts against the
+// roots in the headers.
+func (s *eraStore) verify(epoch uint64, name string, e *era.Era) error {
+	if e.Start() != epoch*uint64(era.MaxEra1Size) {
+		return fmt.Errorf("unexpected start block %d", e.Start())
+	}
+	want, err := e.Accumulator()
+	if err != nil {
+		return err
+	}
+	if !strings.HasSuffix(strings.TrimSuffix(name, ".era1"), want.Hex()[2:10]) {
+		return fmt.Errorf("accumulator root %x mismatches file name", want)
+	}
+	td, err := e.InitialTD()
+	if err != nil {
+		return err
+	}
+	it, err := era.NewRawIterator(e)
+	if err != nil {
+		return err
+	}
+	var (
+		hashes = make([]common.Hash, 0, e.Count())
+		tds    = make([]*big.Int, 0, e.Count())
+	)
+	for it.Next() {
+		if err := it.Error(); err != nil {
+			return err
+		}
+		var header types.Header
+		if err := rlp.Decode(it.Header, &header); err != nil {
+			return fmt.Errorf("failed to decode header %d: %w", it.Number(), err)
+		}
+		hash := header.Hash()
+		if local := ReadCanonicalHash(s.db, it.Number()); local != hash {
+			return fmt.Errorf("header %d mismatches local chain, have %x, want %x", it.Number(), hash, local)
+		}
+		if err := s.verifyBody(&header, it.Body, it.Receipts); err != nil {
+			return fmt.Errorf("block %d: %w", it.Number(), err)
+		}
+		blob, err := io.ReadAll(it.TotalDifficulty)
+		if err != nil {
+			return err
+		}
+		slices.Reverse(blob) // little endian
+		if new(big.Int).SetBytes(blob).Cmp(td.Add(td, header.Difficulty)) != 0 {
+			return fmt.Errorf("total difficulty %d mismatches", it.Number())
+		}
+		hashes = append(hashes, hash)
+		tds = append(tds, new(big.Int).Set(td))
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	got, err := era.ComputeAccumulator(hashes, tds)
+	if err != nil {
+		return err
+	}
+	if got != want {
+		return fmt.Errorf("accumulator root mismatch, have %x, want %x", got, want)
+	}
+	return nil
+}
+
+// verifyBody checks the archived body and receipts of a block against the roots
+// committed to by its header.
+func (s *eraStore) verifyBody(header *types.Header, bodyRLP, receiptsRLP io.Reader) error {
+	var (
+		body     types.Body
+		receipts types.Receipts
+	)
+	if err := rlp.Decode(bodyRLP, &body); err != nil {
+		return fmt.Errorf("failed to decode body: %w", err)
+	}
+	if err := rlp.Decode(receiptsRLP, &receipts); err != nil {
+		return fmt.Errorf("failed to decode receipts: %w", err)
+	}
+	if hash := types.CalcUncleHash(body.Uncles); hash != header.UncleHash {
+		return fmt.Errorf("uncle hash mismatch, have %x, want %x", hash, header.UncleHash)
+	}
+	if hash := s.deriveSha(types.Transactions(body.Transactions)); hash != header.TxHash {
+		return fmt.Errorf("transaction root mismatch, have %x, want %x", hash, header.TxHash)
+	}
+	if header.WithdrawalsHash != nil {
+		if hash := s.deriveSha(types.Withdrawals(body.Withdrawals)); hash != *header.WithdrawalsHash {
+			return fmt.Errorf("withdrawals root mismatch, have %x, want %x", hash, *header.WithdrawalsHash)
+		}
+	} else if body.Withdrawals != nil {
+		return errors.New("unexpected withdrawals")
+	}
+	if hash := s.deriveSha(receipts); hash != header.ReceiptHash {
+		return fmt.Errorf("receipt root mismatch, have %x, want %x", hash, header.ReceiptHash)
+	}
+	return nil
+}
+
+// deriveSha computes the root of a list the same way types.NewBlock does, the
+// root of an empty list is not hashed.
+func (s *eraStore) deriveSha(list types.DerivableList) common.Hash {
+	if list.Len() == 0 {
+		return types.EmptyRootHash
+	}
+	return types.DeriveSha(list, s.hasher)
+}
+
+// ancient retrieves the chain freezer item of the given kind from the archives,
+// in the same encoding as the chain freezer.
+func (s *eraStore) ancient(kind string, number uint64) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	e, err := s.archive(number / uint64(era.MaxEra1Size))
+	if err != nil {
+		return nil, err
+	}
+	switch kind {
+	case ChainFreezerHashTable:
+		header, err := e.GetHeaderByNumber(number)
+		if err != nil {
+			return nil, err
+		}
+		return header.Hash().Bytes(), nil
+
+	case ChainFreezerHeaderTable:
+		header, err := e.GetHeaderByNumber(number)
+		if err != nil {
+			return nil, err
+		}
+		return rlp.EncodeToBytes(header)
+
+	case ChainFreezerBodiesTable:
+		block, err := e.GetBlockByNumber(number)
+		if err != nil {
+			return nil, err
+		}
+		return rlp.EncodeToBytes(block.Body())
+
+	case ChainFreezerReceiptTable:
+		receipts, err := e.GetReceiptsByNumber(number)
+		if err != nil {
+			return nil, err
+		}
+		storage := make([]*types.ReceiptForStorage, len(receipts))
+		for i, receipt := range receipts {
+			storage[i] = (*types.ReceiptForStorage)(receipt)
+		}
+		return rlp.EncodeToBytes(storage)
+	}
+	return nil, errEraMissing
+}
+
+// close releases all the open archives.
+func (s *eraStore) close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, epoch := range s.open.Keys() {
+		if e, ok := s.open.Peek(epoch); ok {
+			e.Close()
+		}
+	}
+	s.open.Purge()
+}
+
+// eraDatabase is a database wrapper serving the chain segment pruned from the
+// chain freezer out of the Era1 archives, all the other operations are passed
+// through to the wrapped database.
+type eraDatabase struct {
+	ethdb.Database
+	era *eraStore
+}
+
+// NewEraDatabase wraps the database with a read-through ancient store, which
+// resolves the headers, bodies and receipts of the pruned chain segment from
+// the Era1 archives of the given network in the directory. The hasher is used to
+// verify the archived bodies and receipts against the header roots.
+func NewEraDatabase(db ethdb.Database, dir string, network string, hasher types.TrieHasher) (ethdb.Database, error) {
+	store, err := newEraStore(db, dir, network, hasher)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Opened era1 archives for pruned history", "dir", dir, "network", network, "files", len(store.files), "blocks", store.limit())
+	return &eraDatabase{Database: db, era: store}, nil
+}
+
+// Ancient implements ethdb.AncientReaderOp, falling back to the archives if the
+// item is not available in the chain freezer.
+func (db *eraDatabase) Ancient(kind string, number uint64) ([]byte, error) {
+	return eraFallback(db.Database, db.era, kind, number)
+}
+
+// HasAncient implements ethdb.AncientReaderOp.
+func (db *eraDatabase) HasAncient(kind string, number uint64) (bool, error) {
+	return eraHasAncient(db.Database, db.era, kind, number)
+}
+
+// AncientRange implements ethdb.AncientReaderOp.
+func (db *eraDatabase) AncientRange(kind string, start, count, maxBytes uint64) ([][]byte, error) {
+	return eraAncientRange(db.Database, db.era, kind, start, count, maxBytes)
+}
+
+// ReadAncients implements ethdb.AncientReader, running the read operation with
+// the archives as the fallback.
+func (db *eraDatabase) ReadAncients(fn func(ethdb.AncientReaderOp) error) error {
+	return db.Database.ReadAncients(func(reader ethdb.AncientReaderOp) error {
+		return fn(&eraReader{AncientReaderOp: reader, era: db.era})
+	})
+}
+
+// Close implements io.Closer, closing the archives along with the database.
+func (db *eraDatabase) Close() error {
+	db.era.close()
+	return db.Database.Close()
+}
+
+// eraReader wraps an ancient reader with the archives as the fallback.
+type eraReader struct {
+	ethdb.AncientReaderOp
+	era *eraStore
+}
+
+// Ancient implements ethdb.AncientReaderOp.
+func (r *eraReader) Ancient(kind string, number uint64) ([]byte, error) {
+	return eraFallback(r.AncientReaderOp, r.era, kind, number)
+}
+
+// HasAncient implements ethdb.AncientReaderOp.
+func (r *eraReader) HasAncient(kind string, number uint64) (bool, error) {
+	return eraHasAncient(r.AncientReaderOp, r.era, kind, number)
+}
+
+// AncientRange implements ethdb.AncientReaderOp.
+func (r *eraReader) AncientRange(kind string, start, count, maxBytes uint64) ([][]byte, error) {
+	return eraAncientRange(r.AncientReaderOp, r.era, kind, start, count, maxBytes)
+}
+
+// eraPruned reports whether the item is pruned from the chain freezer but
+// covered by the archives.
+func eraPruned(reader ethdb.AncientReaderOp, store *eraStore, number uint64) bool {
+	tail, err := reader.Tail()
+	if err != nil {
+		return false
+	}
+	return number < tail && number < store.limit()
+}
+
+// eraFallback retrieves the item from the chain freezer, or from the archives
+// if it's pruned.
+func eraFallback(reader ethdb.AncientReaderOp, store *eraStore, kind string, number uint64) ([]byte, error) {
+	data, err := reader.Ancient(kind, number)
+	if err == nil || !eraPruned(reader, store, number) {
+		return data, err
+	}
+	data, eraErr := store.ancient(kind, number)
+	if eraErr != nil {
+		log.Debug("Failed to read pruned history from era1 archive", "kind", kind, "number", number, "err", eraErr)
+		return nil, err
+	}
+	return data, nil
+}
+
+// eraHasAncient reports whether the item is available in either the chain
+// freezer or the archives.
+func eraHasAncient(reader ethdb.AncientReaderOp, store *eraStore, kind string, number uint64) (bool, error) {
+	if has, err := reader.HasAncient(kind, number); has || err != nil {
+		return has, err
+	}
+	return eraPruned(reader, store, number), nil
+}
+
+// eraAncientRange retrieves a range of items, resolving the ones pruned from the
+// chain freezer from the archives.
+func eraAncientRange(reader ethdb.AncientReaderOp, store *eraStore, kind string, start, count, maxBytes uint64) ([][]byte, error) {
+	if !eraPruned(reader, store, start) {
+		return reader.AncientRange(kind, start, count, maxBytes)
+	}
+	tail, err := reader.Tail()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		items [][]byte
+		size  uint64
+	)
+	for number := start; number < start+count; number++ {
+		// The remaining items are available in the chain freezer
+		if number >= tail {
+			if maxBytes != 0 && size >= maxBytes {
+				break
+			}
+			var limit uint64
+			if maxBytes != 0 {
+				limit = maxBytes - size
+			}
+			rest, err := reader.AncientRange(kind, number, start+count-number, limit)
+			if err != nil {
+				break
+			}
+			items = append(items, rest...)
+			break
+		}
+		data, err := eraFallback(reader, store, kind, number)
+		if err != nil {
+			if len(items) > 0 {
+				break
+			}
+			return nil, err
+		}
+		if maxBytes != 0 && len(items) > 0 && size+uint64(len(data)) > maxBytes {
+			break
+		}
+		items = append(items, data)
+		size += uint64(len(data))
+	}
+	return items, nil
+}
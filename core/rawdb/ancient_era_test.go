@@ -0,0 +1,252 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/era"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// makeEraTestChain creates a pre-merge chain segment with receipts carrying
+// logs, whose header roots and blooms are consistent with the bodies and the
+// receipts.
+func makeEraTestChain(n int) ([]*types.Block, []types.Receipts) {
+	var (
+		blocks   = make([]*types.Block, n)
+		receipts = make([]types.Receipts, n)
+		parent   common.Hash
+	)
+	for i := 0; i < n; i++ {
+		receipt := &types.Receipt{
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: uint64(21000 * (i + 1)),
+			Logs: []*types.Log{{
+				Address: common.Address{byte(i)},
+				Topics:  []common.Hash{{byte(i), 1}},
+				Data:    []byte{byte(i)},
+			}},
+		}
+		receipt.Bloom = types.CreateBloom(receipt)
+
+		header := &types.Header{
+			ParentHash: parent,
+			Number:     big.NewInt(int64(i)),
+			Difficulty: big.NewInt(int64(i + 1)),
+			Extra:      []byte("test block"),
+		}
+		tx := types.NewTransaction(uint64(i), common.Address{byte(i)}, big.NewInt(1), 21000, big.NewInt(1), nil)
+		receipts[i] = types.Receipts{receipt}
+		blocks[i] = types.NewBlock(header, &types.Body{Transactions: types.Transactions{tx}}, receipts[i], newTestHasher())
+		parent = blocks[i].Hash()
+	}
+	return blocks, receipts
+}
+
+// writeEraTestArchive exports the chain segment into an Era1 archive in the
+// directory, returning the file name.
+func writeEraTestArchive(t *testing.T, dir string, blocks []*types.Block, receipts []types.Receipts) string {
+	t.Helper()
+
+	f, err := os.CreateTemp(dir, "era")
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	defer f.Close()
+
+	var (
+		builder = era.NewBuilder(f)
+		td      = new(big.Int)
+	)
+	for i, block := range blocks {
+		td.Add(td, block.Difficulty())
+		if err := builder.Add(block, receipts[i], new(big.Int).Set(td)); err != nil {
+			t.Fatalf("Failed to add block %d: %v", i, err)
+		}
+	}
+	root, err := builder.Finalize()
+	if err != nil {
+		t.Fatalf("Failed to finalize archive: %v", err)
+	}
+	name := era.Filename("mainnet", 0, root)
+	if err := os.Rename(f.Name(), filepath.Join(dir, name)); err != nil {
+		t.Fatalf("Failed to rename archive: %v", err)
+	}
+	return name
+}
+
+// Tests that the chain segment pruned from the freezer is served out of the
+// Era1 archives.
+func TestEraDatabase(t *testing.T) {
+	var (
+		dir              = t.TempDir()
+		blocks, receipts = makeEraTestChain(64)
+		pruned           = uint64(48)
+	)
+	writeEraTestArchive(t, dir, blocks, receipts)
+
+	db, err := NewDatabaseWithFreezer(NewMemoryDatabase(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	if _, err := WriteAncientBlocks(db, blocks, receipts); err != nil {
+		t.Fatalf("Failed to write ancient blocks: %v", err)
+	}
+	if _, err := db.TruncateTail(pruned); err != nil {
+		t.Fatalf("Failed to prune history: %v", err)
+	}
+	if ReadBody(db, blocks[0].Hash(), 0) != nil {
+		t.Fatal("Pruned body is still available")
+	}
+	edb, err := NewEraDatabase(db, dir, "mainnet", newTestHasher())
+	if err != nil {
+		t.Fatalf("Failed to open era database: %v", err)
+	}
+	defer edb.Close()
+
+	for i, block := range blocks {
+		hash, number := block.Hash(), block.NumberU64()
+		if header := ReadHeader(edb, hash, number); header == nil || header.Hash() != hash {
+			t.Fatalf("Block %d: header mismatch", number)
+		}
+		body := ReadBodyRLP(edb, hash, number)
+		if body == nil {
+			t.Fatalf("Block %d: body is missing", number)
+		}
+		if want, _ := rlp.EncodeToBytes(block.Body()); !bytes.Equal(body, want) {
+			t.Fatalf("Block %d: body mismatch, have %x, want %x", number, body, want)
+		}
+		if !HasBody(edb, hash, number) || !HasReceipts(edb, hash, number) {
+			t.Fatalf("Block %d: history is not reported as available", number)
+		}
+		have := ReadRawReceipts(edb, hash, number)
+		if len(have) != len(receipts[i]) {
+			t.Fatalf("Block %d: receipt count mismatch, have %d, want %d", number, len(have), len(receipts[i]))
+		}
+		for j, receipt := range have {
+			want := receipts[i][j]
+			if receipt.Status != want.Status || receipt.CumulativeGasUsed != want.CumulativeGasUsed {
+				t.Fatalf("Block %d: receipt %d mismatch", number, j)
+			}
+			if !reflect.DeepEqual(receipt.Logs[0].Topics, want.Logs[0].Topics) || receipt.Logs[0].Address != want.Logs[0].Address {
+				t.Fatalf("Block %d: receipt %d log mismatch", number, j)
+			}
+		}
+	}
+	// Ranged retrievals across the pruning point are stitched together
+	items, err := edb.AncientRange(ChainFreezerBodiesTable, pruned-4, 8, 0)
+	if err != nil {
+		t.Fatalf("Failed to retrieve body range: %v", err)
+	}
+	if len(items) != 8 {
+		t.Fatalf("Unexpected body range size, have %d, want 8", len(items))
+	}
+}
+
+// Tests that the archives not matching the local canonical chain are rejected.
+func TestEraDatabaseMismatch(t *testing.T) {
+	var (
+		dir              = t.TempDir()
+		blocks, receipts = makeEraTestChain(16)
+	)
+	writeEraTestArchive(t, dir, blocks, receipts)
+
+	// Build a local chain with a different block in the middle
+	local := make([]*types.Block, len(blocks))
+	copy(local, blocks)
+	header := blocks[8].Header()
+	header.Extra = []byte("forked block")
+	local[8] = types.NewBlockWithHeader(header)
+
+	db, err := NewDatabaseWithFreezer(NewMemoryDatabase(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	if _, err := WriteAncientBlocks(db, local, receipts); err != nil {
+		t.Fatalf("Failed to write ancient blocks: %v", err)
+	}
+	if _, err := db.TruncateTail(uint64(len(local))); err != nil {
+		t.Fatalf("Failed to prune history: %v", err)
+	}
+	edb, err := NewEraDatabase(db, dir, "mainnet", newTestHasher())
+	if err != nil {
+		t.Fatalf("Failed to open era database: %v", err)
+	}
+	defer edb.Close()
+
+	if ReadBody(edb, blocks[0].Hash(), 0) != nil {
+		t.Fatal("Body served from mismatching archive")
+	}
+	if ReadRawReceipts(edb, blocks[0].Hash(), 0) != nil {
+		t.Fatal("Receipts served from mismatching archive")
+	}
+}
+
+// Tests that the archives whose bodies or receipts don't match the roots in the
+// headers are rejected.
+func TestEraDatabaseTampered(t *testing.T) {
+	blocks, receipts := makeEraTestChain(16)
+
+	tamperedBody := make([]*types.Block, len(blocks))
+	copy(tamperedBody, blocks)
+	tamperedBody[8] = blocks[8].WithBody(types.Body{Transactions: blocks[9].Transactions()})
+
+	tamperedReceipts := make([]types.Receipts, len(receipts))
+	copy(tamperedReceipts, receipts)
+	tamperedReceipts[8] = receipts[9]
+
+	for i, archive := range []struct {
+		blocks   []*types.Block
+		receipts []types.Receipts
+	}{
+		{tamperedBody, receipts},
+		{blocks, tamperedReceipts},
+	} {
+		dir := t.TempDir()
+		writeEraTestArchive(t, dir, archive.blocks, archive.receipts)
+
+		db, err := NewDatabaseWithFreezer(NewMemoryDatabase(), t.TempDir(), "", false)
+		if err != nil {
+			t.Fatalf("Failed to create database: %v", err)
+		}
+		if _, err := WriteAncientBlocks(db, blocks, receipts); err != nil {
+			t.Fatalf("Failed to write ancient blocks: %v", err)
+		}
+		if _, err := db.TruncateTail(uint64(len(blocks))); err != nil {
+			t.Fatalf("Failed to prune history: %v", err)
+		}
+		edb, err := NewEraDatabase(db, dir, "mainnet", newTestHasher())
+		if err != nil {
+			t.Fatalf("Failed to open era database: %v", err)
+		}
+		if ReadBody(edb, blocks[0].Hash(), 0) != nil {
+			t.Errorf("Archive %d: body served from tampered archive", i)
+		}
+		if ReadRawReceipts(edb, blocks[0].Hash(), 0) != nil {
+			t.Errorf("Archive %d: receipts served from tampered archive", i)
+		}
+		edb.Close()
+	}
+}
@@ -17,11 +17,13 @@
 package rawdb
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/gofrs/flock"
 )
 
 type tableSize struct {
@@ -117,6 +119,80 @@ func inspectFreezers(db ethdb.Database) ([]freezerInfo, error) {
 // be opened. Start and end specify the range for dumping out indexes.
 // Note this function can only be used for debugging purposes.
 func InspectFreezerTable(ancient string, freezerName string, tableName string, start, end int64) error {
+	path, config, err := resolveFreezerTable(ancient, freezerName, tableName)
+	if err != nil {
+		return err
+	}
+	table, err := newFreezerTable(path, tableName, config, true)
+	if err != nil {
+		return err
+	}
+	table.dumpIndexStdout(start, end)
+	return nil
+}
+
+// VerifyFreezerTable validates the index file of a specific freezer table against
+// its data files. The passed ancient indicates the path of root ancient directory
+// where the chain freezer can be opened. The table is opened in read-only mode,
+// so any corruption is reported instead of being repaired.
+func VerifyFreezerTable(ancient string, freezerName string, tableName string) error {
+	path, config, err := resolveFreezerTable(ancient, freezerName, tableName)
+	if err != nil {
+		return err
+	}
+	table, err := newFreezerTable(path, tableName, config, true)
+	if err != nil {
+		return err
+	}
+	defer table.Close()
+
+	return table.verifyIndex()
+}
+
+// ConvertFreezerTable re-compresses the items of a specific freezer table with
+// the named codec in place. If dict is set, a compression dictionary sampled
+// from the table items is used, which is only supported by zstd. The passed
+// ancient indicates the path of root ancient directory, the freezer must not be
+// in use during the conversion.
+func ConvertFreezerTable(ancient string, freezerName string, tableName string, codecName string, dict bool) error {
+	codec, err := parseFreezerCodec(codecName)
+	if err != nil {
+		return err
+	}
+	if dict && codec != codecZstd {
+		return fmt.Errorf("dictionary is not supported by %v", codec)
+	}
+	path, config, err := resolveFreezerTable(ancient, freezerName, tableName)
+	if err != nil {
+		return err
+	}
+	// Hold the freezer lock to prevent concurrent access during the conversion
+	lock := flock.New(filepath.Join(path, "FLOCK"))
+	if locked, err := lock.TryLock(); err != nil {
+		return err
+	} else if !locked {
+		return errors.New("freezer is in use")
+	}
+	defer lock.Unlock()
+
+	var blob []byte
+	if dict {
+		table, err := newFreezerTable(path, tableName, config, true)
+		if err != nil {
+			return err
+		}
+		blob, err = sampleDictionary(table, dictionarySize)
+		table.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return convertTable(path, tableName, config, codec, blob)
+}
+
+// resolveFreezerTable returns the directory and the configuration of the
+// specified freezer table.
+func resolveFreezerTable(ancient string, freezerName string, tableName string) (string, freezerTableConfig, error) {
 	var (
 		path   string
 		tables map[string]freezerTableConfig
@@ -127,20 +203,15 @@ func InspectFreezerTable(ancient string, freezerName string, tableName string, s
 	case MerkleStateFreezerName, VerkleStateFreezerName:
 		path, tables = filepath.Join(ancient, freezerName), stateFreezerTableConfigs
 	default:
-		return fmt.Errorf("unknown freezer, supported ones: %v", freezers)
+		return "", freezerTableConfig{}, fmt.Errorf("unknown freezer, supported ones: %v", freezers)
 	}
-	noSnappy, exist := tables[tableName]
+	config, exist := tables[tableName]
 	if !exist {
 		var names []string
 		for name := range tables {
 			names = append(names, name)
 		}
-		return fmt.Errorf("unknown table, supported ones: %v", names)
+		return "", freezerTableConfig{}, fmt.Errorf("unknown table, supported ones: %v", names)
 	}
-	table, err := newFreezerTable(path, tableName, noSnappy, true)
-	if err != nil {
-		return err
-	}
-	table.dumpIndexStdout(start, end)
-	return nil
+	return path, config, nil
 }
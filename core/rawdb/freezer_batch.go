@@ -22,7 +22,6 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/rlp"
-	"github.com/golang/snappy"
 )
 
 // This is the maximum amount of data that will be buffered in memory
@@ -85,7 +84,7 @@ func (batch *freezerBatch) commit() (item uint64, writeSize int64, err error) {
 type freezerTableBatch struct {
 	t *freezerTable
 
-	sb          *snappyBuffer
+	enc         itemEncoder
 	encBuffer   writeBuffer
 	dataBuffer  []byte
 	indexBuffer []byte
@@ -96,8 +95,8 @@ type freezerTableBatch struct {
 // newBatch creates a new batch for the freezer table.
 func (t *freezerTable) newBatch() *freezerTableBatch {
 	batch := &freezerTableBatch{t: t}
-	if !t.config.noSnappy {
-		batch.sb = new(snappyBuffer)
+	if t.codec != nil {
+		batch.enc = t.codec.encoder()
 	}
 	batch.reset()
 	return batch
@@ -125,8 +124,8 @@ func (batch *freezerTableBatch) Append(item uint64, data interface{}) error {
 		return err
 	}
 	encItem := batch.encBuffer.data
-	if batch.sb != nil {
-		encItem = batch.sb.compress(encItem)
+	if batch.enc != nil {
+		encItem = batch.enc.encode(encItem)
 	}
 	return batch.appendItem(encItem)
 }
@@ -140,8 +139,8 @@ func (batch *freezerTableBatch) AppendRaw(item uint64, blob []byte) error {
 	}
 
 	encItem := blob
-	if batch.sb != nil {
-		encItem = batch.sb.compress(blob)
+	if batch.enc != nil {
+		encItem = batch.enc.encode(blob)
 	}
 	return batch.appendItem(encItem)
 }
@@ -215,30 +214,6 @@ func (batch *freezerTableBatch) commit() error {
 	return nil
 }
 
-// snappyBuffer writes snappy in block format, and can be reused. It is
-// reset when WriteTo is called.
-type snappyBuffer struct {
-	dst []byte
-}
-
-// compress snappy-compresses the data.
-func (s *snappyBuffer) compress(data []byte) []byte {
-	// The snappy library does not care what the capacity of the buffer is,
-	// but only checks the length. If the length is too small, it will
-	// allocate a brand new buffer.
-	// To avoid that, we check the required size here, and grow the size of the
-	// buffer to utilize the full capacity.
-	if n := snappy.MaxEncodedLen(len(data)); len(s.dst) < n {
-		if cap(s.dst) < n {
-			s.dst = make([]byte, n)
-		}
-		s.dst = s.dst[:n]
-	}
-
-	s.dst = snappy.Encode(s.dst, data)
-	return s.dst
-}
-
 // writeBuffer implements io.Writer for a byte slice.
 type writeBuffer struct {
 	data []byte
@@ -0,0 +1,407 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// freezerCodec identifies the compression algorithm applied to the items of a
+// compressed freezer table. The codec in use is recorded in the table metadata,
+// tables created before the codec was configurable are all snappy-compressed.
+type freezerCodec uint8
+
+const (
+	codecSnappy freezerCodec = iota // Snappy block format, the legacy default
+	codecZstd                       // Zstd frames, optionally with a dictionary
+)
+
+// String implements the fmt.Stringer interface.
+func (c freezerCodec) String() string {
+	switch c {
+	case codecSnappy:
+		return "snappy"
+	case codecZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(c))
+	}
+}
+
+// parseFreezerCodec resolves the codec from its name.
+func parseFreezerCodec(name string) (freezerCodec, error) {
+	switch name {
+	case "snappy":
+		return codecSnappy, nil
+	case "zstd":
+		return codecZstd, nil
+	default:
+		return 0, fmt.Errorf("unknown freezer codec %q, supported ones: snappy, zstd", name)
+	}
+}
+
+// SetFreezerTableCodec configures the compression codec of the named chain or
+// state freezer table. It only applies to the tables created afterwards, the
+// existing ones keep the codec recorded in their metadata and can be converted
+// with ConvertFreezerTable. It must be called before the freezers are opened.
+func SetFreezerTableCodec(tableName string, codecName string) error {
+	codec, err := parseFreezerCodec(codecName)
+	if err != nil {
+		return err
+	}
+	for _, tables := range []map[string]freezerTableConfig{chainFreezerTableConfigs, stateFreezerTableConfigs} {
+		config, ok := tables[tableName]
+		if !ok {
+			continue
+		}
+		if config.noSnappy {
+			return fmt.Errorf("compression is disabled for freezer table %q", tableName)
+		}
+		config.codec = codec
+		tables[tableName] = config
+		return nil
+	}
+	return fmt.Errorf("unknown freezer table %q", tableName)
+}
+
+// itemCodec compresses and decompresses the items of a freezer table.
+type itemCodec interface {
+	// encoder creates a compressor for the table. The compressor can reuse its
+	// output buffer and is not safe for concurrent use.
+	encoder() itemEncoder
+
+	// decode decompresses the item. It's safe for concurrent use.
+	decode(item []byte) ([]byte, error)
+
+	// decodedLen returns the size of the decompressed item, or an error if the
+	// item is corrupted.
+	decodedLen(item []byte) (int, error)
+}
+
+// itemEncoder compresses the items appended to a freezer table. The returned
+// slice is only valid until the next invocation.
+type itemEncoder interface {
+	encode(data []byte) []byte
+}
+
+// newItemCodec creates the codec with the optional dictionary. Dictionaries
+// are only supported by zstd.
+func newItemCodec(codec freezerCodec, dict []byte) (itemCodec, error) {
+	switch codec {
+	case codecSnappy:
+		if len(dict) != 0 {
+			return nil, fmt.Errorf("dictionary is not supported by %v", codec)
+		}
+		return snappyCodec{}, nil
+	case codecZstd:
+		return newZstdCodec(dict)
+	default:
+		return nil, fmt.Errorf("unsupported freezer codec %v", codec)
+	}
+}
+
+// dictionaryID derives the identifier of the compression dictionary, which is
+// recorded in the metadata and in the zstd frames. Zero is reserved for the
+// absence of a dictionary.
+func dictionaryID(dict []byte) uint32 {
+	id := crc32.ChecksumIEEE(dict)
+	if id == 0 {
+		id = 1
+	}
+	return id
+}
+
+// snappyCodec compresses items in the snappy block format.
+type snappyCodec struct{}
+
+func (snappyCodec) encoder() itemEncoder { return new(snappyBuffer) }
+
+func (snappyCodec) decode(item []byte) ([]byte, error) {
+	return snappy.Decode(nil, item)
+}
+
+func (snappyCodec) decodedLen(item []byte) (int, error) {
+	return snappy.DecodedLen(item)
+}
+
+// snappyBuffer writes snappy in block format, and can be reused. It is
+// reset when WriteTo is called.
+type snappyBuffer struct {
+	dst []byte
+}
+
+// encode snappy-compresses the data.
+func (s *snappyBuffer) encode(data []byte) []byte {
+	// The snappy library does not care what the capacity of the buffer is,
+	// but only checks the length. If the length is too small, it will
+	// allocate a brand new buffer.
+	// To avoid that, we check the required size here, and grow the size of the
+	// buffer to utilize the full capacity.
+	if n := snappy.MaxEncodedLen(len(data)); len(s.dst) < n {
+		if cap(s.dst) < n {
+			s.dst = make([]byte, n)
+		}
+		s.dst = s.dst[:n]
+	}
+
+	s.dst = snappy.Encode(s.dst, data)
+	return s.dst
+}
+
+// zstdCodec compresses every item into a standalone zstd frame. A raw content
+// dictionary, typically sampled from the table itself, can be supplied to make
+// the compression of small and similar items (e.g. receipts) more effective.
+//
+// Only the stateless EncodeAll and DecodeAll are used, which don't spawn any
+// background routines, so the encoder and decoder don't need to be closed.
+type zstdCodec struct {
+	enc *zstd.Encoder
+	dec *zstd.Decoder
+}
+
+func newZstdCodec(dict []byte) (*zstdCodec, error) {
+	var (
+		eopts = []zstd.EOption{zstd.WithEncoderConcurrency(1), zstd.WithZeroFrames(true)}
+		dopts = []zstd.DOption{zstd.WithDecoderConcurrency(0)}
+	)
+	if len(dict) != 0 {
+		id := dictionaryID(dict)
+		eopts = append(eopts, zstd.WithEncoderDictRaw(id, dict))
+		dopts = append(dopts, zstd.WithDecoderDictRaw(id, dict))
+	}
+	enc, err := zstd.NewWriter(nil, eopts...)
+	if err != nil {
+		return nil, err
+	}
+	dec, err := zstd.NewReader(nil, dopts...)
+	if err != nil {
+		return nil, err
+	}
+	return &zstdCodec{enc: enc, dec: dec}, nil
+}
+
+func (c *zstdCodec) encoder() itemEncoder { return &zstdBuffer{enc: c.enc} }
+
+func (c *zstdCodec) decode(item []byte) ([]byte, error) {
+	return c.dec.DecodeAll(item, nil)
+}
+
+func (c *zstdCodec) decodedLen(item []byte) (int, error) {
+	var header zstd.Header
+	if err := header.Decode(item); err != nil {
+		return 0, err
+	}
+	if !header.HasFCS {
+		// The content size is always recorded by EncodeAll, only foreign
+		// frames can omit it. Fall back to decompress the item.
+		data, err := c.decode(item)
+		return len(data), err
+	}
+	return int(header.FrameContentSize), nil
+}
+
+// zstdBuffer compresses items with the shared zstd encoder, reusing the output
+// buffer across the items.
+type zstdBuffer struct {
+	enc *zstd.Encoder
+	dst []byte
+}
+
+// encode zstd-compresses the data.
+func (z *zstdBuffer) encode(data []byte) []byte {
+	z.dst = z.enc.EncodeAll(data, z.dst[:0])
+	return z.dst
+}
+
+// dictionarySize is the size of the compression dictionary sampled from the
+// items of a table.
+const dictionarySize = 64 * 1024
+
+// sampleDictionary assembles a raw content dictionary from the items evenly
+// distributed across the table. Zstd will reference the content as if it was
+// preceding every compressed item.
+func sampleDictionary(t *freezerTable, size int) ([]byte, error) {
+	var (
+		tail  = t.itemHidden.Load()
+		items = t.items.Load()
+		dict  []byte
+	)
+	if tail >= items {
+		return nil, errors.New("no items to sample")
+	}
+	step := (items - tail) / 1024
+	if step == 0 {
+		step = 1
+	}
+	for i := tail; i < items && len(dict) < size; i += step {
+		item, err := t.Retrieve(i)
+		if err != nil {
+			return nil, err
+		}
+		dict = append(dict, item...)
+	}
+	if len(dict) > size {
+		dict = dict[len(dict)-size:]
+	}
+	return dict, nil
+}
+
+// convertTable re-compresses the items of the freezer table in the specified
+// directory with the given codec and the optional dictionary. The table is
+// rewritten into a temporary directory first, after which the files are
+// swapped in place. The original files are moved into a backup directory
+// during the swap, which is removed once the conversion has been completed.
+//
+// The hidden items are dropped during the conversion, while the numbering of
+// the remaining items is preserved. The table must not be in use.
+func convertTable(path, name string, config freezerTableConfig, codec freezerCodec, dict []byte) error {
+	if config.noSnappy {
+		return fmt.Errorf("compression is disabled for table %s", name)
+	}
+	src, err := newFreezerTable(path, name, config, true)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tail, items := src.itemHidden.Load(), src.items.Load()
+	if tail > math.MaxUint32 {
+		return fmt.Errorf("too many items deleted from table %s: %d", name, tail)
+	}
+	tmpdir := filepath.Join(path, fmt.Sprintf("%s.convert", name))
+	if err := os.RemoveAll(tmpdir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(tmpdir, 0755); err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpdir)
+
+	// Initialize the index with the tail deleted, to retain the item numbering.
+	first := indexEntry{filenum: 0, offset: uint32(tail)}
+	if err := os.WriteFile(filepath.Join(tmpdir, fmt.Sprintf("%s.cidx", name)), first.append(nil), 0644); err != nil {
+		return err
+	}
+	dst, err := newFreezerTable(tmpdir, name, config, false)
+	if err != nil {
+		return err
+	}
+	if err := dst.setCodec(codec, dict); err != nil {
+		dst.Close()
+		return err
+	}
+	var (
+		batch  = dst.newBatch()
+		start  = time.Now()
+		logged = time.Now()
+	)
+	for next := tail; next < items; {
+		blobs, err := src.RetrieveItems(next, 1024, 16*1024*1024)
+		if err != nil {
+			dst.Close()
+			return err
+		}
+		for _, blob := range blobs {
+			if err := batch.AppendRaw(next, blob); err != nil {
+				dst.Close()
+				return err
+			}
+			next++
+		}
+		if err := batch.commit(); err != nil {
+			dst.Close()
+			return err
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Converting freezer table", "table", name, "codec", codec, "converted", next-tail, "total", items-tail, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	if err := src.Close(); err != nil {
+		return err
+	}
+	// Swap the table files, keeping the original ones until all the converted
+	// files are in place.
+	backup := filepath.Join(path, fmt.Sprintf("%s.backup", name))
+	if err := os.RemoveAll(backup); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(backup, 0755); err != nil {
+		return err
+	}
+	if err := moveTableFiles(path, backup, name); err != nil {
+		return err
+	}
+	if err := moveTableFiles(tmpdir, path, name); err != nil {
+		log.Error("Failed to move converted freezer table, original files are retained", "backup", backup, "err", err)
+		return err
+	}
+	log.Info("Converted freezer table", "table", name, "codec", codec, "items", items-tail, "elapsed", common.PrettyDuration(time.Since(start)))
+	return os.RemoveAll(backup)
+}
+
+// moveTableFiles moves the index, metadata, dictionary and data files of the
+// compressed freezer table from one directory to another.
+func moveTableFiles(from, to, name string) error {
+	entries, err := os.ReadDir(from)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !isTableFile(name, entry.Name()) {
+			continue
+		}
+		if err := os.Rename(filepath.Join(from, entry.Name()), filepath.Join(to, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isTableFile reports whether the file belongs to the compressed freezer table.
+func isTableFile(name, file string) bool {
+	suffix, ok := strings.CutPrefix(file, name+".")
+	if !ok {
+		return false
+	}
+	switch suffix {
+	case "cidx", "meta", "dict":
+		return true
+	}
+	num, ok := strings.CutSuffix(suffix, ".cdat")
+	if !ok {
+		return false
+	}
+	_, err := strconv.ParseUint(num, 10, 32)
+	return err == nil
+}
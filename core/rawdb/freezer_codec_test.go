@@ -0,0 +1,214 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// Tests that the codec of a table is only configurable for tables without any
+// items, and that it's persisted in the metadata afterwards, even if the table
+// is emptied.
+func TestFreezerTableCodec(t *testing.T) {
+	var (
+		dir  = t.TempDir()
+		zstd = freezerTableConfig{codec: codecZstd}
+	)
+	f, err := newFreezerTable(dir, "table", zstd, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeChunks(t, f, 16, 100)
+	f.Close()
+
+	// Reopen with the default codec, the recorded codec is expected to be kept
+	f, err = newFreezerTable(dir, "table", freezerTableConfig{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.metadata.codec != codecZstd {
+		t.Fatalf("Unexpected codec, have %v, want %v", f.metadata.codec, codecZstd)
+	}
+	for i := 0; i < 16; i++ {
+		blob, err := f.Retrieve(uint64(i))
+		if err != nil {
+			t.Fatalf("Failed to retrieve item %d: %v", i, err)
+		}
+		if !bytes.Equal(blob, getChunk(100, i)) {
+			t.Fatalf("Item %d mismatch", i)
+		}
+	}
+	// Ensure the items are actually compressed with zstd
+	items, _, err := f.retrieveItems(0, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(items, []byte{0x28, 0xb5, 0x2f, 0xfd}) {
+		t.Fatalf("Item is not zstd-compressed: %x", items)
+	}
+	if err := f.setCodec(codecSnappy, nil); err == nil {
+		t.Fatal("Codec switched for non-empty table")
+	}
+	if err := f.truncateHead(0); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	// Reopen the emptied table with the default codec, the recorded one is
+	// expected to be kept
+	f, err = newFreezerTable(dir, "table", freezerTableConfig{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.metadata.codec != codecZstd {
+		t.Fatalf("Unexpected codec of emptied table, have %v, want %v", f.metadata.codec, codecZstd)
+	}
+	f.Close()
+
+	// Switching the codec of the empty table is permitted
+	f, err = newFreezerTable(t.TempDir(), "table", freezerTableConfig{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if f.metadata.codec != codecSnappy {
+		t.Fatalf("Unexpected codec, have %v, want %v", f.metadata.codec, codecSnappy)
+	}
+	if err := f.setCodec(codecZstd, []byte("dictionary")); err != nil {
+		t.Fatalf("Failed to switch codec: %v", err)
+	}
+	if f.metadata.dictID != dictionaryID([]byte("dictionary")) {
+		t.Fatal("Dictionary is not recorded")
+	}
+}
+
+// Tests that a table can be converted to another codec in place, retaining the
+// tail and the numbering of the items.
+func TestConvertFreezerTable(t *testing.T) {
+	var (
+		dir        = t.TempDir()
+		rm, wm, sg = metrics.NewMeter(), metrics.NewMeter(), metrics.NewGauge()
+		config     = freezerTableConfig{prunable: true}
+	)
+	// Create a snappy table spanning many data files and prune its tail
+	f, err := newTable(dir, "table", rm, wm, sg, 100, config, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeChunks(t, f, 64, 40)
+	if err := f.truncateTail(21); err != nil {
+		t.Fatal(err)
+	}
+	dict, err := sampleDictionary(f, 256)
+	if err != nil {
+		t.Fatalf("Failed to sample dictionary: %v", err)
+	}
+	if len(dict) != 256 {
+		t.Fatalf("Unexpected dictionary size, have %d, want %d", len(dict), 256)
+	}
+	f.Close()
+
+	check := func(codec freezerCodec) {
+		t.Helper()
+
+		f, err := newFreezerTable(dir, "table", config, true)
+		if err != nil {
+			t.Fatalf("Failed to open converted table: %v", err)
+		}
+		defer f.Close()
+
+		if f.metadata.codec != codec {
+			t.Fatalf("Unexpected codec, have %v, want %v", f.metadata.codec, codec)
+		}
+		if have := f.items.Load(); have != 64 {
+			t.Fatalf("Unexpected item count, have %d, want %d", have, 64)
+		}
+		if have := f.itemHidden.Load(); have != 21 {
+			t.Fatalf("Unexpected tail, have %d, want %d", have, 21)
+		}
+		if _, err := f.Retrieve(20); err == nil {
+			t.Fatal("Pruned item is retrievable")
+		}
+		for i := 21; i < 64; i++ {
+			blob, err := f.Retrieve(uint64(i))
+			if err != nil {
+				t.Fatalf("Failed to retrieve item %d: %v", i, err)
+			}
+			if !bytes.Equal(blob, getChunk(40, i)) {
+				t.Fatalf("Item %d mismatch", i)
+			}
+		}
+	}
+	if err := convertTable(dir, "table", config, codecZstd, dict); err != nil {
+		t.Fatalf("Failed to convert table: %v", err)
+	}
+	check(codecZstd)
+
+	if err := convertTable(dir, "table", config, codecSnappy, nil); err != nil {
+		t.Fatalf("Failed to convert table: %v", err)
+	}
+	check(codecSnappy)
+
+	// Ensure no leftovers are retained
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		switch entry.Name() {
+		case "table.cidx", "table.meta", "table.0000.cdat":
+		default:
+			t.Errorf("Unexpected file %s", filepath.Join(dir, entry.Name()))
+		}
+	}
+}
+
+// Tests that the codecs of the builtin freezer tables are configurable, except
+// for the uncompressed ones.
+func TestSetFreezerTableCodec(t *testing.T) {
+	defer func(bodies, accounts freezerTableConfig) {
+		chainFreezerTableConfigs[ChainFreezerBodiesTable] = bodies
+		stateFreezerTableConfigs[stateHistoryAccountData] = accounts
+	}(chainFreezerTableConfigs[ChainFreezerBodiesTable], stateFreezerTableConfigs[stateHistoryAccountData])
+
+	if err := SetFreezerTableCodec(ChainFreezerBodiesTable, "zstd"); err != nil {
+		t.Fatalf("Failed to set chain freezer codec: %v", err)
+	}
+	if codec := chainFreezerTableConfigs[ChainFreezerBodiesTable].codec; codec != codecZstd {
+		t.Fatalf("Unexpected chain freezer codec, have %v, want %v", codec, codecZstd)
+	}
+	if err := SetFreezerTableCodec(stateHistoryAccountData, "zstd"); err != nil {
+		t.Fatalf("Failed to set state freezer codec: %v", err)
+	}
+	if codec := stateFreezerTableConfigs[stateHistoryAccountData].codec; codec != codecZstd {
+		t.Fatalf("Unexpected state freezer codec, have %v, want %v", codec, codecZstd)
+	}
+	if err := SetFreezerTableCodec(ChainFreezerHashTable, "zstd"); err == nil {
+		t.Fatal("Codec set for uncompressed table")
+	}
+	if err := SetFreezerTableCodec("unknown", "zstd"); err == nil {
+		t.Fatal("Codec set for unknown table")
+	}
+	if err := SetFreezerTableCodec(ChainFreezerReceiptTable, "lz4"); err == nil {
+		t.Fatal("Unknown codec set")
+	}
+}
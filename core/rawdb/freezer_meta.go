@@ -29,7 +29,8 @@ import (
 const (
 	freezerTableV1 = 1              // Initial version of metadata struct
 	freezerTableV2 = 2              // Add field: 'flushOffset'
-	freezerVersion = freezerTableV2 // The current used version
+	freezerTableV3 = 3              // Add fields: 'codec', 'dictID'
+	freezerVersion = freezerTableV3 // The current used version
 )
 
 // freezerTableMeta is a collection of additional properties that describe the
@@ -65,6 +66,15 @@ type freezerTableMeta struct {
 	// The offset could be moved forward by applying sync operation, or be moved
 	// backward in cases of head/tail truncation, etc.
 	flushOffset int64
+
+	// codec is the compression algorithm applied to the items of the table,
+	// it's ignored for tables without compression. Tables with legacy metadata
+	// are compressed with snappy.
+	codec freezerCodec
+
+	// dictID is the identifier of the compression dictionary used by the codec,
+	// zero means no dictionary is used.
+	dictID uint32
 }
 
 // decodeV1 attempts to decode the metadata structure in v1 format. If fails or
@@ -123,6 +133,41 @@ func decodeV2(file *os.File) *freezerTableMeta {
 	}
 }
 
+// decodeV3 attempts to decode the metadata structure in v3 format. If fails or
+// the result is incompatible, nil is returned.
+func decodeV3(file *os.File) *freezerTableMeta {
+	_, err := file.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil
+	}
+	type obj struct {
+		Version uint16
+		Tail    uint64
+		Offset  uint64
+		Codec   uint8
+		DictID  uint32
+	}
+	var o obj
+	if err := rlp.Decode(file, &o); err != nil {
+		return nil
+	}
+	if o.Version != freezerTableV3 {
+		return nil
+	}
+	if o.Offset > math.MaxInt64 {
+		log.Error("Invalid flushOffset %d in freezer metadata", o.Offset, "file", file.Name())
+		return nil
+	}
+	return &freezerTableMeta{
+		file:        file,
+		version:     freezerTableV3,
+		virtualTail: o.Tail,
+		flushOffset: int64(o.Offset),
+		codec:       freezerCodec(o.Codec),
+		dictID:      o.DictID,
+	}
+}
+
 // newMetadata initializes the metadata object, either by loading it from the file
 // or by constructing a new one from scratch.
 func newMetadata(file *os.File) (*freezerTableMeta, error) {
@@ -133,18 +178,22 @@ func newMetadata(file *os.File) (*freezerTableMeta, error) {
 	if stat.Size() == 0 {
 		m := &freezerTableMeta{
 			file:        file,
-			version:     freezerTableV2,
+			version:     freezerVersion,
 			virtualTail: 0,
 			flushOffset: 0,
+			codec:       codecSnappy,
 		}
 		if err := m.write(true); err != nil {
 			return nil, err
 		}
 		return m, nil
 	}
-	if m := decodeV2(file); m != nil {
+	if m := decodeV3(file); m != nil {
 		return m, nil
 	}
+	if m := decodeV2(file); m != nil {
+		return m, nil // legacy metadata, snappy is implied
+	}
 	if m := decodeV1(file); m != nil {
 		return m, nil // legacy metadata
 	}
@@ -163,17 +212,29 @@ func (m *freezerTableMeta) setFlushOffset(offset int64, sync bool) error {
 	return m.write(sync)
 }
 
+// setCodec sets the compression codec along with the dictionary identifier
+// and flushes the metadata.
+func (m *freezerTableMeta) setCodec(codec freezerCodec, dictID uint32) error {
+	m.codec = codec
+	m.dictID = dictID
+	return m.write(true)
+}
+
 // write flushes the content of metadata into file and performs a fsync if required.
 func (m *freezerTableMeta) write(sync bool) error {
 	type obj struct {
 		Version uint16
 		Tail    uint64
 		Offset  uint64
+		Codec   uint8
+		DictID  uint32
 	}
 	var o obj
 	o.Version = freezerVersion // forcibly use the current version
 	o.Tail = m.virtualTail
 	o.Offset = uint64(m.flushOffset)
+	o.Codec = uint8(m.codec)
+	o.DictID = m.dictID
 
 	_, err := m.file.Seek(0, io.SeekStart)
 	if err != nil {
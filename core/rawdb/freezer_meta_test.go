@@ -40,7 +40,7 @@ func TestReadWriteFreezerTableMeta(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to reload metadata %v", err)
 	}
-	if meta.version != freezerTableV2 {
+	if meta.version != freezerVersion {
 		t.Fatalf("Unexpected version field")
 	}
 	if meta.virtualTail != uint64(100) {
@@ -89,7 +89,7 @@ func TestUpgradeMetadata(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to read metadata %v", err)
 	}
-	if meta.version != freezerTableV2 {
+	if meta.version != freezerVersion {
 		t.Fatal("Unexpected version field")
 	}
 	if meta.virtualTail != uint64(100) {
@@ -100,6 +100,52 @@ func TestUpgradeMetadata(t *testing.T) {
 	}
 }
 
+func TestUpgradeMetadataCodec(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "*")
+	if err != nil {
+		t.Fatalf("Failed to create file %v", err)
+	}
+	defer f.Close()
+
+	// Write v2 metadata into file
+	type obj struct {
+		Version uint16
+		Tail    uint64
+		Offset  uint64
+	}
+	o := obj{Version: freezerTableV2, Tail: 100, Offset: 60}
+	if err := rlp.Encode(f, &o); err != nil {
+		t.Fatalf("Failed to encode %v", err)
+	}
+	// Reload the metadata, snappy is implied for the legacy tables
+	meta, err := newMetadata(f)
+	if err != nil {
+		t.Fatalf("Failed to read metadata %v", err)
+	}
+	if meta.version != freezerTableV2 {
+		t.Fatal("Unexpected version field")
+	}
+	if meta.codec != codecSnappy || meta.dictID != 0 {
+		t.Fatal("Unexpected codec field")
+	}
+	if err := meta.setCodec(codecZstd, 10); err != nil {
+		t.Fatalf("Failed to set codec %v", err)
+	}
+	meta, err = newMetadata(f)
+	if err != nil {
+		t.Fatalf("Failed to read metadata %v", err)
+	}
+	if meta.version != freezerTableV3 {
+		t.Fatal("Unexpected version field")
+	}
+	if meta.virtualTail != 100 || meta.flushOffset != 60 {
+		t.Fatal("Unexpected tail or flush offset field")
+	}
+	if meta.codec != codecZstd || meta.dictID != 10 {
+		t.Fatal("Unexpected codec field")
+	}
+}
+
 func TestInvalidMetadata(t *testing.T) {
 	f, err := os.CreateTemp(t.TempDir(), "*")
 	if err != nil {
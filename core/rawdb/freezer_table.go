@@ -32,7 +32,6 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
-	"github.com/golang/snappy"
 )
 
 var (
@@ -87,7 +86,7 @@ func (i *indexEntry) bounds(end *indexEntry) (startOffset, endOffset, fileId uin
 }
 
 // freezerTable represents a single chained data table within the freezer (e.g. blocks).
-// It consists of a data file (snappy encoded arbitrary data blobs) and an indexEntry
+// It consists of a data file (compressed arbitrary data blobs) and an indexEntry
 // file (uncompressed 64 bit indices into the data file).
 type freezerTable struct {
 	items      atomic.Uint64 // Number of items stored in the table (including items removed from tail)
@@ -100,7 +99,8 @@ type freezerTable struct {
 	// should never be lower than itemOffset.
 	itemHidden atomic.Uint64
 
-	config      freezerTableConfig // if true, disables snappy compression. Note: does not work retroactively
+	config      freezerTableConfig // settings of the table, the codec only applies to tables without items
+	codec       itemCodec          // compression codec of the items, nil if compression is disabled
 	readonly    bool
 	maxFileSize uint32 // Max file size for data-files
 	name        string
@@ -170,6 +170,11 @@ func newTable(path string, name string, readMeter, writeMeter *metrics.Meter, si
 	}
 	// Load metadata from the file. The tag will be true if legacy metadata
 	// is detected.
+	stat, err := meta.Stat()
+	if err != nil {
+		return nil, err
+	}
+	created := stat.Size() == 0
 	metadata, err := newMetadata(meta)
 	if err != nil {
 		return nil, err
@@ -194,6 +199,10 @@ func newTable(path string, name string, readMeter, writeMeter *metrics.Meter, si
 		tab.Close()
 		return nil, err
 	}
+	if err := tab.initCodec(created); err != nil {
+		tab.Close()
+		return nil, err
+	}
 	// Initialize the starting size counter
 	size, err := tab.sizeNolock()
 	if err != nil {
@@ -205,6 +214,75 @@ func newTable(path string, name string, readMeter, writeMeter *metrics.Meter, si
 	return tab, nil
 }
 
+// initCodec sets up the compression codec of the table. The configured codec
+// is only applied if the table has just been created, otherwise the one recorded
+// in the metadata is used, even if the table was emptied since.
+func (t *freezerTable) initCodec(created bool) error {
+	if t.config.noSnappy {
+		return nil
+	}
+	if created && !t.readonly && t.items.Load() == 0 && t.metadata.codec != t.config.codec {
+		if err := t.metadata.setCodec(t.config.codec, 0); err != nil {
+			return err
+		}
+	}
+	return t.loadCodec()
+}
+
+// loadCodec constructs the compression codec recorded in the metadata, along
+// with the dictionary if there is any.
+func (t *freezerTable) loadCodec() error {
+	var dict []byte
+	if t.metadata.dictID != 0 {
+		blob, err := os.ReadFile(t.dictPath())
+		if err != nil {
+			return fmt.Errorf("failed to load compression dictionary: %w", err)
+		}
+		if id := dictionaryID(blob); id != t.metadata.dictID {
+			return fmt.Errorf("compression dictionary mismatch, have %#x, want %#x", id, t.metadata.dictID)
+		}
+		dict = blob
+	}
+	codec, err := newItemCodec(t.metadata.codec, dict)
+	if err != nil {
+		return err
+	}
+	t.codec = codec
+	return nil
+}
+
+// setCodec switches the compression codec of the table, along with the optional
+// dictionary. It's only permitted if the table doesn't hold any items.
+func (t *freezerTable) setCodec(codec freezerCodec, dict []byte) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.config.noSnappy {
+		return errors.New("table compression is disabled")
+	}
+	if t.items.Load() != t.itemOffset.Load() {
+		return errors.New("table is not empty")
+	}
+	var id uint32
+	if len(dict) != 0 {
+		if err := os.WriteFile(t.dictPath(), dict, 0644); err != nil {
+			return err
+		}
+		id = dictionaryID(dict)
+	} else if err := os.Remove(t.dictPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := t.metadata.setCodec(codec, id); err != nil {
+		return err
+	}
+	return t.loadCodec()
+}
+
+// dictPath returns the path of the compression dictionary file.
+func (t *freezerTable) dictPath() string {
+	return filepath.Join(t.path, fmt.Sprintf("%s.dict", t.name))
+}
+
 // repair cross-checks the head and the index file and truncates them to
 // be in sync with each other after a potential crash / data loss.
 func (t *freezerTable) repair() error {
@@ -573,6 +651,71 @@ func (t *freezerTable) checkIndexItems(a, b indexEntry) error {
 	return nil
 }
 
+// verifyIndex validates the entire index file against the data files without
+// modifying anything. Besides the ordering rules enforced by checkIndex, it
+// ensures that each data file is exactly covered by the index, i.e. the end
+// offset of the last item placed in a data file matches the size of the file.
+func (t *freezerTable) verifyIndex() error {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	stat, err := t.index.Stat()
+	if err != nil {
+		return err
+	}
+	size := stat.Size()
+	if size%indexEntrySize != 0 {
+		return fmt.Errorf("index file size %d is not a multiple of %d", size, indexEntrySize)
+	}
+	var (
+		fr   = bufio.NewReader(io.NewSectionReader(t.index, 0, size))
+		buff = make([]byte, indexEntrySize)
+		head indexEntry
+		prev indexEntry
+		ends = make(map[uint32]uint32)
+	)
+	for offset := int64(0); offset < size; offset += indexEntrySize {
+		if _, err := io.ReadFull(fr, buff); err != nil {
+			return err
+		}
+		var entry indexEntry
+		entry.unmarshalBinary(buff)
+
+		if offset == 0 {
+			head = entry
+			continue
+		}
+		number := uint64(head.offset) + uint64(offset/indexEntrySize) - 1
+		if offset == indexEntrySize {
+			if entry.filenum != head.filenum && entry.filenum != head.filenum+1 {
+				return fmt.Errorf("item %d: index item with inconsistent file number, earliest: %d, next: %d", number, head.filenum, entry.filenum)
+			}
+		} else if err := t.checkIndexItems(prev, entry); err != nil {
+			return fmt.Errorf("item %d: %w", number, err)
+		}
+		ends[entry.filenum] = entry.offset
+		prev = entry
+	}
+	for num := t.tailId; num <= t.headId; num++ {
+		end, ok := ends[num]
+		if !ok {
+			continue // the earliest file without any item
+		}
+		f, ok := t.files[num]
+		if !ok {
+			return fmt.Errorf("data file %d is missing", num)
+		}
+		stat, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		if int64(end) != stat.Size() {
+			return fmt.Errorf("data file %d size mismatch, indexed: %d, stored: %d", num, end, stat.Size())
+		}
+	}
+	return nil
+}
+
 // preopen opens all files that the freezer will need. This method should be called from an init-context,
 // since it assumes that it doesn't have to bother with locking
 // The rationale for doing preopen is to not have to do it from within Retrieve, thus not needing to ever
@@ -987,14 +1130,14 @@ func (t *freezerTable) RetrieveItems(start, count, maxBytes uint64) ([][]byte, e
 		item := diskData[offset : offset+diskSize]
 		offset += diskSize
 		decompressedSize := diskSize
-		if !t.config.noSnappy {
-			decompressedSize, _ = snappy.DecodedLen(item)
+		if t.codec != nil {
+			decompressedSize, _ = t.codec.decodedLen(item)
 		}
 		if i > 0 && maxBytes != 0 && uint64(outputSize+decompressedSize) > maxBytes {
 			break
 		}
-		if !t.config.noSnappy {
-			data, err := snappy.Decode(nil, item)
+		if t.codec != nil {
+			data, err := t.codec.decode(item)
 			if err != nil {
 				return nil, err
 			}
@@ -1221,6 +1364,9 @@ func (t *freezerTable) dumpIndexString(start, stop int64) string {
 func (t *freezerTable) dumpIndex(w io.Writer, start, stop int64) {
 	fmt.Fprintf(w, "Version %d count %d, deleted %d, hidden %d\n",
 		t.metadata.version, t.items.Load(), t.itemOffset.Load(), t.itemHidden.Load())
+	if !t.config.noSnappy {
+		fmt.Fprintf(w, "Codec %v dictionary %#x\n", t.metadata.codec, t.metadata.dictID)
+	}
 
 	buf := make([]byte, indexEntrySize)
 
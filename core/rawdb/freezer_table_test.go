@@ -174,6 +174,41 @@ func TestFreezerRepairDanglingHead(t *testing.T) {
 	}
 }
 
+// TestFreezerVerifyIndex tests that the corruption of a non-head data file,
+// which is not detected by the repair on opening, is reported by verification.
+func TestFreezerVerifyIndex(t *testing.T) {
+	t.Parallel()
+	rm, wm, sg := metrics.NewMeter(), metrics.NewMeter(), metrics.NewGauge()
+	fname := fmt.Sprintf("verify_index-%d", rand.Uint64())
+
+	// Fill a table and close it
+	{
+		f, err := newTable(os.TempDir(), fname, rm, wm, sg, 50, freezerTableConfig{noSnappy: true}, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Write 15 bytes 255 times
+		writeChunks(t, f, 255, 15)
+		if err := f.verifyIndex(); err != nil {
+			t.Fatalf("Failed to verify index: %v", err)
+		}
+		f.Close()
+	}
+	// Chop off a byte from the data file in the middle
+	if err := os.Truncate(filepath.Join(os.TempDir(), fmt.Sprintf("%s.0010.rdat", fname)), 44); err != nil {
+		t.Fatal(err)
+	}
+	f, err := newTable(os.TempDir(), fname, rm, wm, sg, 50, freezerTableConfig{noSnappy: true}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if err := f.verifyIndex(); err == nil {
+		t.Fatal("Corrupted data file is not detected")
+	}
+}
+
 // TestFreezerRepairDanglingHeadLarge tests that we can recover if very many index entries are removed
 func TestFreezerRepairDanglingHeadLarge(t *testing.T) {
 	t.Parallel()
@@ -46,6 +46,10 @@ var (
 	// persistentStateIDKey tracks the id of latest stored state(for path-based only).
 	persistentStateIDKey = []byte("LastStateID")
 
+	// headStateHistoryIndexKey tracks the id of the latest indexed state history
+	// (for path-based only).
+	headStateHistoryIndexKey = []byte("LastStateHistoryIndex")
+
 	// lastPivotKey tracks the last pivot block used by fast sync (to reenable on sethead).
 	lastPivotKey = []byte("LastPivot")
 
@@ -96,6 +100,14 @@ var (
 	// snapSyncStatusFlagKey flags that status of snap sync.
 	snapSyncStatusFlagKey = []byte("SnapSyncStatus")
 
+	// stateConversionKey tracks the state root being converted from the hash-based
+	// scheme to the path-based scheme.
+	stateConversionKey = []byte("StateConversion")
+
+	// chainVerificationKey tracks the next block to be verified by the chain
+	// data integrity check.
+	chainVerificationKey = []byte("ChainVerification")
+
 	// Data item prefixes (use single byte to avoid mixing data types, avoid `i`, used for indexes).
 	headerPrefix       = []byte("h") // headerPrefix + num (uint64 big endian) + hash -> header
 	headerTDSuffix     = []byte("t") // headerPrefix + num (uint64 big endian) + hash + headerTDSuffix -> td (deprecated)
@@ -117,6 +129,10 @@ var (
 	TrieNodeStoragePrefix = []byte("O") // TrieNodeStoragePrefix + accountHash + hexPath -> trie node
 	stateIDPrefix         = []byte("L") // stateIDPrefix + state root -> state id
 
+	// State history index of path-based storage scheme.
+	StateHistoryAccountIndexPrefix = []byte("ma") // StateHistoryAccountIndexPrefix + account hash + last id -> index block
+	StateHistoryStorageIndexPrefix = []byte("ms") // StateHistoryStorageIndexPrefix + account hash + storage hash + last id -> index block
+
 	// VerklePrefix is the database prefix for Verkle trie data, which includes:
 	// (a) Trie nodes
 	// (b) In-memory trie node journal
@@ -257,6 +273,25 @@ func stateIDKey(root common.Hash) []byte {
 	return append(stateIDPrefix, root.Bytes()...)
 }
 
+// accountHistoryIndexKey = StateHistoryAccountIndexPrefix + account hash + last id (uint64 big endian)
+func accountHistoryIndexKey(accountHash common.Hash, last uint64) []byte {
+	buf := make([]byte, len(StateHistoryAccountIndexPrefix)+common.HashLength+8)
+	n := copy(buf, StateHistoryAccountIndexPrefix)
+	n += copy(buf[n:], accountHash.Bytes())
+	binary.BigEndian.PutUint64(buf[n:], last)
+	return buf
+}
+
+// storageHistoryIndexKey = StateHistoryStorageIndexPrefix + account hash + storage hash + last id (uint64 big endian)
+func storageHistoryIndexKey(accountHash, storageHash common.Hash, last uint64) []byte {
+	buf := make([]byte, len(StateHistoryStorageIndexPrefix)+2*common.HashLength+8)
+	n := copy(buf, StateHistoryStorageIndexPrefix)
+	n += copy(buf[n:], accountHash.Bytes())
+	n += copy(buf[n:], storageHash.Bytes())
+	binary.BigEndian.PutUint64(buf[n:], last)
+	return buf
+}
+
 // accountTrieNodeKey = TrieNodeAccountPrefix + nodePath.
 func accountTrieNodeKey(path []byte) []byte {
 	return append(TrieNodeAccountPrefix, path...)
@@ -123,10 +123,15 @@ func (s *StateDB) DumpToCollector(c DumpCollector, conf *DumpConfig) (nextKey []
 		start            = time.Now()
 		logged           = time.Now()
 	)
-	log.Info("Trie dumping started", "root", s.trie.Hash())
-	c.OnRoot(s.trie.Hash())
+	accTrie, err := s.getTrie()
+	if err != nil {
+		log.Error("Trie dumping error", "err", err)
+		return nil
+	}
+	log.Info("Trie dumping started", "root", accTrie.Hash())
+	c.OnRoot(accTrie.Hash())
 
-	trieIt, err := s.trie.NodeIterator(conf.Start)
+	trieIt, err := accTrie.NodeIterator(conf.Start)
 	if err != nil {
 		log.Error("Trie dumping error", "err", err)
 		return nil
@@ -147,7 +152,7 @@ func (s *StateDB) DumpToCollector(c DumpCollector, conf *DumpConfig) (nextKey []
 			}
 			address   *common.Address
 			addr      common.Address
-			addrBytes = s.trie.GetKey(it.Key)
+			addrBytes = accTrie.GetKey(it.Key)
 		)
 		if addrBytes == nil {
 			missingPreimages++
@@ -182,7 +187,7 @@ func (s *StateDB) DumpToCollector(c DumpCollector, conf *DumpConfig) (nextKey []
 					log.Error("Failed to decode the value returned by iterator", "error", err)
 					continue
 				}
-				account.Storage[common.BytesToHash(s.trie.GetKey(storageIt.Key))] = common.Bytes2Hex(content)
+				account.Storage[common.BytesToHash(accTrie.GetKey(storageIt.Key))] = common.Bytes2Hex(content)
 			}
 		}
 		c.OnAccount(address, account)
@@ -78,7 +78,11 @@ func (it *nodeIterator) step() error {
 	// Initialize the iterator if we've just started
 	var err error
 	if it.stateIt == nil {
-		it.stateIt, err = it.state.trie.NodeIterator(nil)
+		tr, err := it.state.getTrie()
+		if err != nil {
+			return err
+		}
+		it.stateIt, err = tr.NodeIterator(nil)
 		if err != nil {
 			return err
 		}
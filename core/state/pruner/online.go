@@ -0,0 +1,224 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pruner
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+// sweepThrottle is the pause between two batches of deletions in the online
+// pruning, leaving room for the block processing to access the database.
+const sweepThrottle = 50 * time.Millisecond
+
+// errPruningAborted is returned if the pruning is interrupted.
+var errPruningAborted = errors.New("pruning aborted")
+
+// OnlinePruner prunes the stale state of the hash-based scheme while the node
+// keeps running, as opposed to the offline Pruner. The workflow is:
+//
+//   - hook into the trie database, all the trie nodes flushed into the disk
+//     from now on are regarded as live
+//   - persist the state of the chain head as the pruning target, iterate it
+//     along with the genesis state and mark all the nodes as live
+//   - wait until all the in-memory states older than the target are released
+//     by the garbage collector of the trie database
+//   - iterate the database, delete all the trie nodes not marked as live in
+//     throttled batches
+//
+// Nothing is deleted from the state reachable from the target and any newer
+// state, so an interrupted pruning leaves some stale nodes behind at most, which
+// are picked up by the next run. Contract codes are not pruned as they are not
+// persisted through the trie database.
+type OnlinePruner struct {
+	db     ethdb.Database
+	triedb *triedb.Database
+	bloom  *stateBloom
+	lock   sync.RWMutex // Lock preventing node flushes during the deletion of a batch
+}
+
+// NewOnlinePruner creates the online pruner and attaches it to the trie
+// database. The bloom size is specified in megabytes.
+func NewOnlinePruner(db ethdb.Database, triedb *triedb.Database, bloomSize uint64) (*OnlinePruner, error) {
+	if triedb.Scheme() != rawdb.HashScheme {
+		return nil, errors.New("online pruning is only supported in hash scheme")
+	}
+	// Sanitize the bloom filter size if it's too small.
+	if bloomSize < 256 {
+		log.Warn("Sanitizing bloomfilter size", "provided(MB)", bloomSize, "updated(MB)", 256)
+		bloomSize = 256
+	}
+	bloom, err := newStateBloomWithSize(bloomSize)
+	if err != nil {
+		return nil, err
+	}
+	p := &OnlinePruner{
+		db:     db,
+		triedb: triedb,
+		bloom:  bloom,
+	}
+	if err := triedb.SetFlushHook(p.onFlush); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// onFlush marks the trie node being flushed into the disk as live.
+func (p *OnlinePruner) onFlush(hash common.Hash) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	p.bloom.Put(hash.Bytes(), nil)
+}
+
+// Mark iterates the state with the given root along with the genesis state,
+// and marks all the trie nodes as live. The state must be persisted in the
+// disk already.
+func (p *OnlinePruner) Mark(root common.Hash, abort <-chan struct{}) error {
+	if !rawdb.HasLegacyTrieNode(p.db, root) {
+		return fmt.Errorf("associated state[%x] is not present", root)
+	}
+	start := time.Now()
+	if err := extractState(p.db, root, p.bloom, abort); err != nil {
+		return err
+	}
+	if err := extractGenesis(p.db, p.bloom); err != nil {
+		return err
+	}
+	log.Info("Marked live state for pruning", "root", root, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// Sweep iterates the database and deletes all the trie nodes which are not
+// marked as live, in throttled batches. The states which are not reachable
+// from the marked one, or the ones flushed afterwards, must not be accessed
+// anymore.
+func (p *OnlinePruner) Sweep(abort <-chan struct{}) error {
+	var (
+		count, skipped int
+		size           common.StorageSize
+		keys           [][]byte
+		start          = time.Now()
+		logged         = time.Now()
+		iter           = p.db.NewIterator(nil, nil)
+	)
+	defer func() {
+		if iter != nil {
+			iter.Release()
+		}
+	}()
+
+	for iter.Next() {
+		key := iter.Key()
+		if len(key) != common.HashLength {
+			continue
+		}
+		if p.bloom.Contain(key) {
+			skipped += 1
+			continue
+		}
+		keys = append(keys, common.CopyBytes(key))
+		size += common.StorageSize(len(key) + len(iter.Value()))
+
+		if len(keys)*common.HashLength < ethdb.IdealBatchSize {
+			continue
+		}
+		deleted, err := p.delete(keys)
+		if err != nil {
+			return err
+		}
+		count += deleted
+		skipped += len(keys) - deleted
+		keys = keys[:0]
+
+		if time.Since(logged) > 8*time.Second {
+			var eta time.Duration // Realistically will never remain uninited
+			if done := binary.BigEndian.Uint64(key[:8]); done > 0 {
+				var (
+					left  = math.MaxUint64 - binary.BigEndian.Uint64(key[:8])
+					speed = done/uint64(time.Since(start)/time.Millisecond+1) + 1 // +1s to avoid division by zero
+				)
+				eta = time.Duration(left/speed) * time.Millisecond
+			}
+			log.Info("Pruning state data", "nodes", count, "skipped", skipped, "size", size,
+				"elapsed", common.PrettyDuration(time.Since(start)), "eta", common.PrettyDuration(eta))
+			logged = time.Now()
+		}
+		// Recreate the iterator after every batch in order to allow the
+		// underlying compactor to delete the entries, and give way to the
+		// block processing.
+		next := common.CopyBytes(key)
+		iter.Release()
+		iter = nil
+
+		select {
+		case <-abort:
+			return errPruningAborted
+		case <-time.After(sweepThrottle):
+		}
+		iter = p.db.NewIterator(nil, next)
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if len(keys) > 0 {
+		deleted, err := p.delete(keys)
+		if err != nil {
+			return err
+		}
+		count += deleted
+		skipped += len(keys) - deleted
+	}
+	log.Info("Pruned state data", "nodes", count, "skipped", skipped, "size", size, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// delete removes the given trie nodes from the database, unless they have been
+// marked as live in the meantime. Node flushes are blocked until the deletion
+// is written, so a node can't be resurrected and deleted in between.
+func (p *OnlinePruner) delete(keys [][]byte) (int, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var (
+		deleted int
+		batch   = p.db.NewBatch()
+	)
+	for _, key := range keys {
+		if p.bloom.Contain(key) {
+			continue
+		}
+		batch.Delete(key)
+		deleted += 1
+	}
+	return deleted, batch.Write()
+}
+
+// Close detaches the pruner from the trie database.
+func (p *OnlinePruner) Close() {
+	p.triedb.SetFlushHook(nil)
+}
@@ -409,7 +409,14 @@ func extractGenesis(db ethdb.Database, stateBloom *stateBloom) error {
 	if genesis == nil {
 		return errors.New("missing genesis block")
 	}
-	t, err := trie.NewStateTrie(trie.StateTrieID(genesis.Root()), triedb.NewDatabase(db, triedb.HashDefaults))
+	return extractState(db, genesis.Root(), stateBloom, nil)
+}
+
+// extractState iterates the state with the given root, including all the
+// storage tries, and commits all the state entries into the given bloomfilter.
+// The iteration can be interrupted by closing the abort channel.
+func extractState(db ethdb.Database, root common.Hash, stateBloom *stateBloom, abort <-chan struct{}) error {
+	t, err := trie.NewStateTrie(trie.StateTrieID(root), triedb.NewDatabase(db, triedb.HashDefaults))
 	if err != nil {
 		return err
 	}
@@ -418,6 +425,11 @@ func extractGenesis(db ethdb.Database, stateBloom *stateBloom) error {
 		return err
 	}
 	for accIter.Next(true) {
+		select {
+		case <-abort:
+			return errPruningAborted
+		default:
+		}
 		hash := accIter.Hash()
 
 		// Embedded nodes don't have hash.
@@ -432,7 +444,7 @@ func extractGenesis(db ethdb.Database, stateBloom *stateBloom) error {
 				return err
 			}
 			if acc.Root != types.EmptyRootHash {
-				id := trie.StorageTrieID(genesis.Root(), common.BytesToHash(accIter.LeafKey()), acc.Root)
+				id := trie.StorageTrieID(root, common.BytesToHash(accIter.LeafKey()), acc.Root)
 				storageTrie, err := trie.NewStateTrie(id, triedb.NewDatabase(db, triedb.HashDefaults))
 				if err != nil {
 					return err
@@ -28,6 +28,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -94,6 +95,136 @@ func GenerateTrie(snaptree *Tree, root common.Hash, src ethdb.Database, dst ethd
 	return nil
 }
 
+// ConvertState regenerates the state with the given root from the snapshot and
+// writes the trie nodes in the path-based scheme, converting a database in the
+// hash-based scheme in place. The contract codes referenced are rewritten with
+// the prefixed scheme as well, so the legacy entries can be dropped along with
+// the hash-keyed trie nodes once the conversion is finished.
+//
+// The root node of the account trie is written at the very end together with
+// the metadata of the path database, switching the scheme of the persistent
+// state atomically. The conversion is resumable, the storage tries converted
+// in a previous run are skipped, as long as the target state is not changed.
+func ConvertState(snaptree *Tree, root common.Hash, db ethdb.Database) error {
+	if snaptree.triedb.Scheme() != rawdb.HashScheme {
+		return errors.New("state is not in hash scheme")
+	}
+	// Discard the leftovers of a previous conversion targeting another state,
+	// they can't be resumed from.
+	if rawdb.ReadStateConversion(db) != root {
+		if err := deletePathTrieNodes(db); err != nil {
+			return err
+		}
+		rawdb.WriteStateConversion(db, root)
+	}
+	acctIt, err := snaptree.AccountIterator(root, common.Hash{})
+	if err != nil {
+		return err // The required snapshot might not exist.
+	}
+	defer acctIt.Release()
+
+	var rootBlob []byte
+	generate := func(_ ethdb.KeyValueWriter, _ string, owner common.Hash, in chan trieKV, out chan common.Hash) {
+		batch := db.NewBatch()
+		t := trie.NewStackTrie(func(path []byte, hash common.Hash, blob []byte) {
+			// Withhold the root node of the account trie, the presence of
+			// it marks the state in path scheme.
+			if owner == (common.Hash{}) && len(path) == 0 {
+				rootBlob = common.CopyBytes(blob)
+				return
+			}
+			rawdb.WriteTrieNode(batch, owner, path, hash, blob, rawdb.PathScheme)
+			if batch.ValueSize() > ethdb.IdealBatchSize {
+				if err := batch.Write(); err != nil {
+					log.Crit("Failed to write trie nodes", "err", err)
+				}
+				batch.Reset()
+			}
+		})
+		for leaf := range in {
+			t.Update(leaf.key[:], leaf.value)
+		}
+		hash := t.Hash()
+
+		// The root node of the storage trie is written in the last batch, the
+		// storage trie is regarded as converted if it's present.
+		if err := batch.Write(); err != nil {
+			log.Crit("Failed to write trie nodes", "err", err)
+		}
+		out <- hash
+	}
+	got, err := generateTrieRoot(db, rawdb.PathScheme, acctIt, common.Hash{}, generate, func(_ ethdb.KeyValueWriter, accountHash, codeHash common.Hash, stat *generateStats) (common.Hash, error) {
+		// Migrate the code into the prefixed scheme if it's still stored in
+		// the legacy one.
+		if codeHash != types.EmptyCodeHash && !rawdb.HasCodeWithPrefix(db, codeHash) {
+			code := rawdb.ReadCode(db, codeHash)
+			if len(code) == 0 {
+				return common.Hash{}, errors.New("failed to read contract code")
+			}
+			rawdb.WriteCode(db, codeHash, code)
+		}
+		// Skip the storage trie if it's already converted.
+		if blob := rawdb.ReadStorageTrieNode(db, accountHash, nil); len(blob) > 0 {
+			return crypto.Keccak256Hash(blob), nil
+		}
+		storageIt, err := snaptree.StorageIterator(root, accountHash, common.Hash{})
+		if err != nil {
+			return common.Hash{}, err
+		}
+		defer storageIt.Release()
+
+		return generateTrieRoot(db, rawdb.PathScheme, storageIt, accountHash, generate, nil, stat, false)
+	}, newGenerateStats(), true)
+
+	if err != nil {
+		return err
+	}
+	if got != root {
+		return fmt.Errorf("state root hash mismatch: got %x, want %x", got, root)
+	}
+	if rootBlob == nil {
+		return errors.New("empty state")
+	}
+	// Initialize the path database with the converted state as the persistent
+	// state without any history.
+	batch := db.NewBatch()
+	rawdb.WriteAccountTrieNode(batch, nil, rootBlob)
+	rawdb.WritePersistentStateID(batch, 0)
+	rawdb.DeleteTrieJournal(batch)
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	log.Info("Converted state into path scheme", "root", root)
+	return nil
+}
+
+// deletePathTrieNodes removes all the trie nodes in the path-based scheme.
+func deletePathTrieNodes(db ethdb.Database) error {
+	batch := db.NewBatch()
+	for _, prefix := range [][]byte{rawdb.TrieNodeAccountPrefix, rawdb.TrieNodeStoragePrefix} {
+		it := db.NewIterator(prefix, nil)
+		for it.Next() {
+			key := it.Key()
+			if !rawdb.IsAccountTrieNode(key) && !rawdb.IsStorageTrieNode(key) {
+				continue
+			}
+			batch.Delete(key)
+			if batch.ValueSize() > ethdb.IdealBatchSize {
+				if err := batch.Write(); err != nil {
+					it.Release()
+					return err
+				}
+				batch.Reset()
+			}
+		}
+		it.Release()
+		if err := it.Error(); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
 // generateStats is a collection of statistics gathered by the trie generator
 // for logging purposes.
 type generateStats struct {
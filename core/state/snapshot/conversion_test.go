@@ -0,0 +1,151 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/VictoriaMetrics/fastcache"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/ethereum/go-ethereum/triedb/pathdb"
+	"github.com/holiman/uint256"
+)
+
+// Tests that the state in hash scheme can be converted into path scheme, and
+// that the conversion can be resumed.
+func TestConvertState(t *testing.T) {
+	var (
+		helper   = newHelper(rawdb.HashScheme)
+		code     = []byte{0x60, 0x00, 0x60, 0x00, 0xf3}
+		codeHash = crypto.Keccak256Hash(code)
+	)
+	stRoot := helper.makeStorageTrie("", []string{"key-1", "key-2", "key-3"}, []string{"val-1", "val-2", "val-3"}, false)
+	helper.addAccount("acc-1", &types.StateAccount{Balance: uint256.NewInt(1), Root: stRoot, CodeHash: types.EmptyCodeHash.Bytes()})
+	helper.addAccount("acc-2", &types.StateAccount{Balance: uint256.NewInt(2), Root: types.EmptyRootHash, CodeHash: codeHash.Bytes()})
+	helper.addAccount("acc-3", &types.StateAccount{Balance: uint256.NewInt(3), Root: stRoot, CodeHash: types.EmptyCodeHash.Bytes()})
+
+	helper.makeStorageTrie("acc-1", []string{"key-1", "key-2", "key-3"}, []string{"val-1", "val-2", "val-3"}, true)
+	helper.makeStorageTrie("acc-3", []string{"key-1", "key-2", "key-3"}, []string{"val-1", "val-2", "val-3"}, true)
+	helper.addSnapStorage("acc-1", []string{"key-1", "key-2", "key-3"}, []string{"val-1", "val-2", "val-3"})
+	helper.addSnapStorage("acc-3", []string{"key-1", "key-2", "key-3"}, []string{"val-1", "val-2", "val-3"})
+
+	root := helper.Commit()
+	db := helper.diskdb
+
+	// Store the code in the legacy scheme
+	db.Put(codeHash.Bytes(), code)
+
+	rawdb.WriteSnapshotRoot(db, root)
+	snaps := &Tree{
+		triedb: helper.triedb,
+		layers: map[common.Hash]snapshot{
+			root: &diskLayer{
+				diskdb: db,
+				cache:  fastcache.New(500 * 1024),
+				root:   root,
+			},
+		},
+	}
+	// Leave some leftovers of a conversion targeting another state
+	rawdb.WriteStateConversion(db, common.Hash{0x1})
+	rawdb.WriteStorageTrieNode(db, common.Hash{0x1}, nil, []byte{0x1})
+
+	if err := ConvertState(snaps, root, db); err != nil {
+		t.Fatalf("Failed to convert state: %v", err)
+	}
+	if have := rawdb.ReadStateScheme(db); have != rawdb.PathScheme {
+		t.Fatalf("Unexpected state scheme, have %s, want %s", have, rawdb.PathScheme)
+	}
+	if rawdb.ReadStorageTrieNode(db, common.Hash{0x1}, nil) != nil {
+		t.Fatal("Leftover trie node is not deleted")
+	}
+	if rawdb.ReadStateConversion(db) != root {
+		t.Fatal("State conversion is not tracked")
+	}
+	if !rawdb.HasCodeWithPrefix(db, codeHash) {
+		t.Fatal("Contract code is not migrated")
+	}
+	// Resume the conversion as if it's interrupted before the final step, the
+	// storage trie already converted is expected to be skipped.
+	var key, val []byte
+	it := db.NewIterator(append(common.CopyBytes(rawdb.TrieNodeStoragePrefix), hashData([]byte("acc-1")).Bytes()...), nil)
+	for it.Next() {
+		if len(it.Key()) > len(rawdb.TrieNodeStoragePrefix)+common.HashLength {
+			key, val = common.CopyBytes(it.Key()), common.CopyBytes(it.Value())
+			break
+		}
+	}
+	it.Release()
+	if key == nil {
+		t.Fatal("Storage trie is not converted")
+	}
+	db.Delete(key)
+	rawdb.DeleteAccountTrieNode(db, nil)
+
+	if err := ConvertState(snaps, root, db); err != nil {
+		t.Fatalf("Failed to resume state conversion: %v", err)
+	}
+	if ok, _ := db.Has(key); ok {
+		t.Fatal("Converted storage trie is regenerated")
+	}
+	db.Put(key, val)
+
+	// Ensure the entire state is accessible in path scheme
+	tdb := triedb.NewDatabase(db, &triedb.Config{PathDB: pathdb.Defaults})
+	defer tdb.Close()
+
+	tr, err := trie.NewStateTrie(trie.StateTrieID(root), tdb)
+	if err != nil {
+		t.Fatalf("Failed to open converted state: %v", err)
+	}
+	var accounts, slots int
+	accIt := trie.NewIterator(tr.MustNodeIterator(nil))
+	for accIt.Next() {
+		accounts++
+
+		var acc types.StateAccount
+		if err := rlp.DecodeBytes(accIt.Value, &acc); err != nil {
+			t.Fatal(err)
+		}
+		if acc.Root == types.EmptyRootHash {
+			continue
+		}
+		st, err := trie.NewStateTrie(trie.StorageTrieID(root, common.BytesToHash(accIt.Key), acc.Root), tdb)
+		if err != nil {
+			t.Fatalf("Failed to open converted storage: %v", err)
+		}
+		sit := trie.NewIterator(st.MustNodeIterator(nil))
+		for sit.Next() {
+			slots++
+		}
+		if sit.Err != nil {
+			t.Fatalf("Failed to iterate storage: %v", sit.Err)
+		}
+	}
+	if accIt.Err != nil {
+		t.Fatalf("Failed to iterate accounts: %v", accIt.Err)
+	}
+	if accounts != 3 || slots != 6 {
+		t.Fatalf("Unexpected state, accounts %d, slots %d", accounts, slots)
+	}
+}
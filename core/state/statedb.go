@@ -79,7 +79,7 @@ func (m *mutation) isDelete() bool {
 type StateDB struct {
 	db         Database
 	prefetcher *triePrefetcher
-	trie       Trie
+	trie       Trie // The account trie, opened on first use
 	reader     Reader
 
 	// originalRoot is the pre-state root, before any changes were made.
@@ -138,6 +138,12 @@ type StateDB struct {
 	// State witness if cross validation is needed
 	witness *stateless.Witness
 
+	// Block access list recorder (EIP-7928) and the block access index the
+	// modifications are currently attributed to.
+	balBuilder *types.BlockAccessListBuilder
+	balReads   *types.BlockAccessListBuilder
+	balIndex   uint16
+
 	// Measurements gathered during execution for debugging purposes
 	AccountReads    time.Duration
 	AccountHashes   time.Duration
@@ -158,18 +164,22 @@ type StateDB struct {
 }
 
 // New creates a new state from a given trie.
+//
+// The account trie is only opened once it's needed for hashing or committing
+// the state, the state is read through the database reader.
 func New(root common.Hash, db Database) (*StateDB, error) {
-	tr, err := db.OpenTrie(root)
-	if err != nil {
-		return nil, err
-	}
 	reader, err := db.Reader(root)
 	if err != nil {
 		return nil, err
 	}
+	return newWithReader(root, db, reader), nil
+}
+
+// newWithReader creates a new state on top of the given root, accessed through
+// the given reader.
+func newWithReader(root common.Hash, db Database, reader Reader) *StateDB {
 	sdb := &StateDB{
 		db:                   db,
-		trie:                 tr,
 		originalRoot:         root,
 		reader:               reader,
 		stateObjects:         make(map[common.Address]*stateObject),
@@ -184,7 +194,7 @@ func New(root common.Hash, db Database) (*StateDB, error) {
 	if db.TrieDB().IsVerkle() {
 		sdb.accessEvents = NewAccessEvents(db.PointCache())
 	}
-	return sdb, nil
+	return sdb
 }
 
 // StartPrefetcher initializes a new trie prefetcher to pull in nodes from the
@@ -370,6 +380,7 @@ func (s *StateDB) GetCodeHash(addr common.Address) common.Hash {
 
 // GetState retrieves the value associated with the specific key.
 func (s *StateDB) GetState(addr common.Address, hash common.Hash) common.Hash {
+	s.recordStorageRead(addr, hash)
 	stateObject := s.getStateObject(addr)
 	if stateObject != nil {
 		return stateObject.GetState(hash)
@@ -380,6 +391,7 @@ func (s *StateDB) GetState(addr common.Address, hash common.Hash) common.Hash {
 // GetCommittedState retrieves the value associated with the specific key
 // without any mutations caused in the current execution.
 func (s *StateDB) GetCommittedState(addr common.Address, hash common.Hash) common.Hash {
+	s.recordStorageRead(addr, hash)
 	stateObject := s.getStateObject(addr)
 	if stateObject != nil {
 		return stateObject.GetCommittedState(hash)
@@ -448,6 +460,7 @@ func (s *StateDB) SetCode(addr common.Address, code []byte) (prev []byte) {
 }
 
 func (s *StateDB) SetState(addr common.Address, key, value common.Hash) common.Hash {
+	s.recordStorageRead(addr, key)
 	if stateObject := s.getOrNewStateObject(addr); stateObject != nil {
 		return stateObject.SetState(key, value)
 	}
@@ -551,17 +564,27 @@ func (s *StateDB) GetTransientState(addr common.Address, key common.Hash) common
 func (s *StateDB) updateStateObject(obj *stateObject) {
 	// Encode the account and update the account trie
 	addr := obj.Address()
-	if err := s.trie.UpdateAccount(addr, &obj.data, len(obj.code)); err != nil {
+	tr, err := s.getTrie()
+	if err != nil {
+		s.setError(fmt.Errorf("updateStateObject (%x) error: %v", addr[:], err))
+		return
+	}
+	if err := tr.UpdateAccount(addr, &obj.data, len(obj.code)); err != nil {
 		s.setError(fmt.Errorf("updateStateObject (%x) error: %v", addr[:], err))
 	}
 	if obj.dirtyCode {
-		s.trie.UpdateContractCode(obj.Address(), common.BytesToHash(obj.CodeHash()), obj.code)
+		tr.UpdateContractCode(obj.Address(), common.BytesToHash(obj.CodeHash()), obj.code)
 	}
 }
 
 // deleteStateObject removes the given object from the state trie.
 func (s *StateDB) deleteStateObject(addr common.Address) {
-	if err := s.trie.DeleteAccount(addr); err != nil {
+	tr, err := s.getTrie()
+	if err != nil {
+		s.setError(fmt.Errorf("deleteStateObject (%x) error: %v", addr[:], err))
+		return
+	}
+	if err := tr.DeleteAccount(addr); err != nil {
 		s.setError(fmt.Errorf("deleteStateObject (%x) error: %v", addr[:], err))
 	}
 }
@@ -569,6 +592,8 @@ func (s *StateDB) deleteStateObject(addr common.Address) {
 // getStateObject retrieves a state object given by the address, returning nil if
 // the object is not found or was deleted in this execution context.
 func (s *StateDB) getStateObject(addr common.Address) *stateObject {
+	s.recordAccountRead(addr)
+
 	// Prefer live objects if any is available
 	if obj := s.stateObjects[addr]; obj != nil {
 		return obj
@@ -653,7 +678,6 @@ func (s *StateDB) Copy() *StateDB {
 	reader, _ := s.db.Reader(s.originalRoot) // impossible to fail
 	state := &StateDB{
 		db:                   s.db,
-		trie:                 mustCopyTrie(s.trie),
 		reader:               reader,
 		originalRoot:         s.originalRoot,
 		stateObjects:         make(map[common.Address]*stateObject, len(s.stateObjects)),
@@ -677,12 +701,20 @@ func (s *StateDB) Copy() *StateDB {
 		transientStorage: s.transientStorage.Copy(),
 		journal:          s.journal.copy(),
 	}
+	if s.trie != nil {
+		state.trie = mustCopyTrie(s.trie)
+	}
 	if s.witness != nil {
 		state.witness = s.witness.Copy()
 	}
 	if s.accessEvents != nil {
 		state.accessEvents = s.accessEvents.Copy()
 	}
+	if s.balBuilder != nil {
+		state.balBuilder = s.balBuilder.Copy()
+		state.balReads = s.balReads.Copy()
+		state.balIndex = s.balIndex
+	}
 	// Deep copy cached state objects.
 	for addr, obj := range s.stateObjects {
 		state.stateObjects[addr] = obj.deepCopy(state)
@@ -739,6 +771,7 @@ func (s *StateDB) Finalise(deleteEmptyObjects bool) {
 			continue
 		}
 		if obj.selfDestructed || (deleteEmptyObjects && obj.empty()) {
+			s.recordChanges(obj, true)
 			delete(s.stateObjects, obj.address)
 			s.markDelete(addr)
 			// We need to maintain account deletions explicitly (will remain
@@ -748,6 +781,7 @@ func (s *StateDB) Finalise(deleteEmptyObjects bool) {
 				s.stateObjectsDestruct[obj.address] = obj
 			}
 		} else {
+			s.recordChanges(obj, false)
 			obj.finalise()
 			s.markUpdate(addr)
 		}
@@ -761,6 +795,8 @@ func (s *StateDB) Finalise(deleteEmptyObjects bool) {
 			log.Error("Failed to prefetch addresses", "addresses", len(addressesToPrefetch), "err", err)
 		}
 	}
+	s.finaliseReads()
+
 	// Invalidate journal because reverting across transactions is not allowed.
 	s.clearJournalAndRefund()
 }
@@ -781,6 +817,12 @@ func (s *StateDB) IntermediateRoot(deleteEmptyObjects bool) common.Hash {
 			s.prefetcher = nil // Pre-byzantium, unset any used up prefetcher
 		}()
 	}
+	// Open the account trie before updating the storage tries, as it's shared
+	// with them in verkle.
+	if _, err := s.getTrie(); err != nil {
+		s.setError(fmt.Errorf("failed to open account trie: %v", err))
+		return common.Hash{}
+	}
 	// Process all storage updates concurrently. The state object update root
 	// method will internally call a blocking trie fetch from the prefetcher,
 	// so there's no need to explicitly wait for the prefetchers to finish.
@@ -917,11 +959,14 @@ func (s *StateDB) IntermediateRoot(deleteEmptyObjects bool) common.Hash {
 }
 
 // SetTxContext sets the current transaction hash and index which are
-// used when the EVM emits new state logs. It should be invoked before
+// used when the EVM emits new state logs and when the modifications are
+// recorded in the block access list. It should be invoked before
 // transaction execution.
 func (s *StateDB) SetTxContext(thash common.Hash, ti int) {
 	s.thash = thash
 	s.txIndex = ti
+	s.balIndex = uint16(ti + 1)
+	s.resetReads()
 }
 
 func (s *StateDB) clearJournalAndRefund() {
@@ -1096,9 +1141,26 @@ func (s *StateDB) handleDestruction(noStorageWiping bool) (map[common.Hash]*acco
 	return deletes, nodes, nil
 }
 
-// GetTrie returns the account trie.
+// getTrie returns the account trie, opening it if it's not loaded yet.
+func (s *StateDB) getTrie() (Trie, error) {
+	if s.trie == nil {
+		tr, err := s.db.OpenTrie(s.originalRoot)
+		if err != nil {
+			return nil, err
+		}
+		s.trie = tr
+	}
+	return s.trie, nil
+}
+
+// GetTrie returns the account trie, or nil if it can't be opened.
 func (s *StateDB) GetTrie() Trie {
-	return s.trie
+	tr, err := s.getTrie()
+	if err != nil {
+		s.setError(fmt.Errorf("failed to open account trie: %v", err))
+		return nil
+	}
+	return tr
 }
 
 // commit gathers the state mutations accumulated along with the associated
@@ -0,0 +1,283 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"bytes"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/holiman/uint256"
+)
+
+// EnableBlockAccessList starts recording the block access list (EIP-7928) of
+// the state transition, discarding any previous recording. Every account and
+// storage slot accessed from now on is tracked, along with the net
+// modifications made at each block access index.
+func (s *StateDB) EnableBlockAccessList() {
+	s.balBuilder = types.NewBlockAccessListBuilder()
+	s.balReads = types.NewBlockAccessListBuilder()
+	s.balIndex = 0
+}
+
+// BlockAccessListEnabled reports whether the block access list is recorded.
+func (s *StateDB) BlockAccessListEnabled() bool {
+	return s.balBuilder != nil
+}
+
+// SetAccessListIndex sets the block access index the modifications finalised
+// from now on are attributed to. SetTxContext sets it implicitly to the index
+// of the transaction plus one, so this is only needed for the system calls
+// and operations following the last transaction. The accesses not finalised
+// yet are discarded.
+func (s *StateDB) SetAccessListIndex(index int) {
+	s.balIndex = uint16(index)
+	s.resetReads()
+}
+
+// BlockAccessList returns the block access list recorded so far, or nil if
+// recording is not enabled. The modifications not yet finalised are missing
+// from it.
+func (s *StateDB) BlockAccessList() types.BlockAccessList {
+	if s.balBuilder == nil {
+		return nil
+	}
+	return s.balBuilder.Build()
+}
+
+// MergeBlockAccessList merges the accesses recorded by another state into this
+// one, along with the modifications attributed to its current access index.
+// The modifications the other state applied from a block access list at the
+// preceding indices are left out.
+func (s *StateDB) MergeBlockAccessList(other *StateDB) {
+	if s.balBuilder != nil && other.balBuilder != nil {
+		s.balBuilder.MergeIndex(other.balBuilder, other.balIndex)
+	}
+}
+
+// Origin creates a fresh state at the pre-state root the state was opened at,
+// without any of its modifications, cached objects or witness. Block access
+// list recording is enabled if it is enabled on the state. It allows executing
+// multiple transactions independently of each other on top of the pre-state.
+//
+// Unlike Copy, the new state shares the database reader of the state, reusing
+// the data it already loaded, so the two must not be used concurrently.
+func (s *StateDB) Origin() *StateDB {
+	state := newWithReader(s.originalRoot, s.db, s.reader)
+	if s.balBuilder != nil {
+		state.EnableBlockAccessList()
+	}
+	return state
+}
+
+// recordAccountRead tracks an account access. Accesses are only added to the
+// block access list once finalised, so that the ones made by transactions
+// rejected from the block are discarded.
+func (s *StateDB) recordAccountRead(addr common.Address) {
+	if s.balReads != nil {
+		s.balReads.AccountRead(addr)
+	}
+}
+
+// recordStorageRead tracks a storage slot access. Accesses are only added to
+// the block access list once finalised.
+func (s *StateDB) recordStorageRead(addr common.Address, slot common.Hash) {
+	if s.balReads != nil {
+		s.balReads.StorageRead(addr, slot)
+	}
+}
+
+// resetReads discards the accesses tracked since the last finalisation.
+func (s *StateDB) resetReads() {
+	if s.balReads != nil {
+		s.balReads = types.NewBlockAccessListBuilder()
+	}
+}
+
+// finaliseReads adds the accesses tracked since the last finalisation to the
+// block access list.
+func (s *StateDB) finaliseReads() {
+	if s.balReads != nil {
+		s.balBuilder.Merge(s.balReads)
+		s.balReads = types.NewBlockAccessListBuilder()
+	}
+}
+
+// recordChanges tracks the net modifications of an object made since the last
+// finalisation in the block access list. It must be called before the dirty
+// storage of the object is finalised.
+func (s *StateDB) recordChanges(obj *stateObject, deleted bool) {
+	if s.balBuilder == nil {
+		return
+	}
+	addr := obj.address
+	s.balBuilder.AccountRead(addr)
+
+	// Resolve the values at the beginning of the current access index, either
+	// from a previous index or from the pre-block state.
+	var (
+		prevBalance  = new(uint256.Int)
+		prevNonce    uint64
+		prevCodeHash = types.EmptyCodeHash
+	)
+	if obj.origin != nil {
+		prevBalance, prevNonce, prevCodeHash = obj.origin.Balance, obj.origin.Nonce, common.BytesToHash(obj.origin.CodeHash)
+	}
+	if balance, ok := s.balBuilder.LatestBalance(addr); ok {
+		prevBalance = balance
+	}
+	if nonce, ok := s.balBuilder.LatestNonce(addr); ok {
+		prevNonce = nonce
+	}
+	if code, ok := s.balBuilder.LatestCode(addr); ok {
+		prevCodeHash = crypto.Keccak256Hash(code)
+	}
+	// Deleted accounts are recorded as having been emptied, their storage is
+	// either wiped along with them or untouched if they were created in the
+	// same transaction.
+	var (
+		balance  = obj.Balance()
+		nonce    = obj.Nonce()
+		codeHash = common.BytesToHash(obj.CodeHash())
+		code     []byte
+	)
+	if deleted {
+		balance, nonce, codeHash = new(uint256.Int), 0, types.EmptyCodeHash
+	} else {
+		for key, value := range obj.dirtyStorage {
+			prev, ok := s.balBuilder.LatestStorage(addr, key)
+			if !ok {
+				prev = obj.GetCommittedState(key)
+			}
+			if prev != value {
+				s.balBuilder.StorageWrite(s.balIndex, addr, key, value)
+			} else {
+				s.balBuilder.StorageRead(addr, key)
+			}
+		}
+		code = obj.Code()
+	}
+	if !balance.Eq(prevBalance) {
+		s.balBuilder.BalanceChange(s.balIndex, addr, balance)
+	}
+	if nonce != prevNonce {
+		s.balBuilder.NonceChange(s.balIndex, addr, nonce)
+	}
+	if codeHash != prevCodeHash {
+		s.balBuilder.CodeChange(s.balIndex, addr, code)
+	}
+}
+
+// ApplyBlockAccessList applies the latest modification of every account and
+// storage slot listed in the block access list with an index lower than the
+// given one, yielding the state the transaction at that index is executed on.
+// The applied modifications are recorded in the access list of the state if
+// recording is enabled.
+func (s *StateDB) ApplyBlockAccessList(bal types.BlockAccessList, until uint16) {
+	for _, account := range bal {
+		addr := account.Address
+		s.recordAccountRead(addr)
+
+		for _, slot := range account.StorageChanges {
+			for _, change := range slot.Changes {
+				if change.Index >= until {
+					break
+				}
+				s.SetState(addr, slot.Slot, change.Value)
+				if s.balBuilder != nil {
+					s.balBuilder.StorageWrite(change.Index, addr, slot.Slot, change.Value)
+				}
+			}
+		}
+		for _, change := range account.BalanceChanges {
+			if change.Index >= until {
+				break
+			}
+			s.getOrNewStateObject(addr).SetBalance(change.Balance)
+			if s.balBuilder != nil {
+				s.balBuilder.BalanceChange(change.Index, addr, change.Balance)
+			}
+		}
+		for _, change := range account.NonceChanges {
+			if change.Index >= until {
+				break
+			}
+			s.getOrNewStateObject(addr).SetNonce(change.Nonce)
+			if s.balBuilder != nil {
+				s.balBuilder.NonceChange(change.Index, addr, change.Nonce)
+			}
+		}
+		for _, change := range account.CodeChanges {
+			if change.Index >= until {
+				break
+			}
+			if !bytes.Equal(s.GetCode(addr), change.Code) {
+				s.SetCode(addr, change.Code)
+			}
+			if s.balBuilder != nil {
+				s.balBuilder.CodeChange(change.Index, addr, change.Code)
+			}
+		}
+	}
+	s.Finalise(true)
+}
+
+// ApplyBlockAccessListUnrecorded is like ApplyBlockAccessList, but leaves the
+// recorded access list untouched. It is meant for applying the modifications
+// of transactions whose accesses were recorded separately.
+func (s *StateDB) ApplyBlockAccessListUnrecorded(bal types.BlockAccessList, until uint16) {
+	builder, reads := s.balBuilder, s.balReads
+	s.balBuilder, s.balReads = nil, nil
+	s.ApplyBlockAccessList(bal, until)
+	s.balBuilder, s.balReads = builder, reads
+}
+
+// PrefetchBlockAccessList schedules all the accounts and storage slots listed
+// in the block access list for prefetching, if the prefetcher is running.
+func (s *StateDB) PrefetchBlockAccessList(bal types.BlockAccessList) {
+	if s.prefetcher == nil || len(bal) == 0 {
+		return
+	}
+	addrs := make([]common.Address, 0, len(bal))
+	for _, account := range bal {
+		addrs = append(addrs, account.Address)
+	}
+	if err := s.prefetcher.prefetch(common.Hash{}, s.originalRoot, common.Address{}, addrs, nil, true); err != nil {
+		log.Error("Failed to prefetch access list accounts", "accounts", len(addrs), "err", err)
+		return
+	}
+	for _, account := range bal {
+		if len(account.StorageChanges) == 0 && len(account.StorageReads) == 0 {
+			continue
+		}
+		acct, err := s.reader.Account(account.Address)
+		if err != nil || acct == nil {
+			continue
+		}
+		slots := make([]common.Hash, 0, len(account.StorageChanges)+len(account.StorageReads))
+		for _, slot := range account.StorageChanges {
+			slots = append(slots, slot.Slot)
+		}
+		slots = append(slots, account.StorageReads...)
+
+		if err := s.prefetcher.prefetch(crypto.Keccak256Hash(account.Address.Bytes()), acct.Root, account.Address, nil, slots, true); err != nil {
+			log.Error("Failed to prefetch access list slots", "addr", account.Address, "slots", len(slots), "err", err)
+		}
+	}
+}
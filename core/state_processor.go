@@ -19,6 +19,9 @@ package core
 import (
 	"fmt"
 	"math/big"
+	"runtime"
+	"sync"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/misc"
@@ -28,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
+	"golang.org/x/sync/errgroup"
 )
 
 // StateProcessor is a basic Processor, which takes care of transitioning
@@ -73,6 +77,11 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		context vm.BlockContext
 		signer  = types.MakeSigner(p.config, header.Number, header.Time)
 	)
+	// Record the block access list if Amsterdam is enabled (EIP-7928).
+	amsterdam := p.config.IsAmsterdam(block.Number(), block.Time())
+	if amsterdam {
+		statedb.EnableBlockAccessList()
+	}
 
 	// Apply pre-execution system calls.
 	var tracingStateDB = vm.StateDB(statedb)
@@ -89,20 +98,39 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		ProcessParentBlockHash(block.ParentHash(), evm)
 	}
 
-	// Iterate over and process the individual transactions
-	for i, tx := range block.Transactions() {
-		msg, err := TransactionToMessage(tx, signer, header.BaseFee)
-		if err != nil {
-			return nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+	// Iterate over and process the individual transactions, concurrently if
+	// the block access list is available and parallel execution is enabled.
+	// The state accessed by the concurrent executions is not collected, so a
+	// witness can only be built sequentially.
+	if bal := block.AccessList(); amsterdam && bal != nil && cfg.ParallelExecution && cfg.Tracer == nil && statedb.Witness() == nil {
+		var err error
+		if receipts, err = p.processParallel(block, bal, statedb, context, signer, cfg); err != nil {
+			return nil, err
+		}
+		for _, receipt := range receipts {
+			allLogs = append(allLogs, receipt.Logs...)
+		}
+		if len(receipts) > 0 {
+			*usedGas = receipts[len(receipts)-1].CumulativeGasUsed
 		}
-		statedb.SetTxContext(tx.Hash(), i)
+	} else {
+		for i, tx := range block.Transactions() {
+			msg, err := TransactionToMessage(tx, signer, header.BaseFee)
+			if err != nil {
+				return nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+			}
+			statedb.SetTxContext(tx.Hash(), i)
 
-		receipt, err := ApplyTransactionWithEVM(msg, gp, statedb, blockNumber, blockHash, tx, usedGas, evm)
-		if err != nil {
-			return nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+			receipt, err := ApplyTransactionWithEVM(msg, gp, statedb, blockNumber, blockHash, tx, usedGas, evm)
+			if err != nil {
+				return nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+			}
+			receipts = append(receipts, receipt)
+			allLogs = append(allLogs, receipt.Logs...)
 		}
-		receipts = append(receipts, receipt)
-		allLogs = append(allLogs, receipt.Logs...)
+	}
+	if amsterdam {
+		statedb.SetAccessListIndex(len(block.Transactions()) + 1)
 	}
 	// Read requests if Prague is enabled.
 	var requests [][]byte
@@ -133,6 +161,86 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 	}, nil
 }
 
+// processParallel executes the transactions of a block concurrently, each on top
+// of the state resulting from applying the modifications the block access list
+// attributes to the preceding ones. The modifications of all the transactions
+// are then applied to the given state, along with the recorded accesses. The
+// access list is not trusted: a wrong one yields a different recorded access
+// list, failing the block validation.
+func (p *StateProcessor) processParallel(block *types.Block, bal types.BlockAccessList, statedb *state.StateDB, context vm.BlockContext, signer types.Signer, cfg vm.Config) (types.Receipts, error) {
+	var (
+		txs         = block.Transactions()
+		header      = block.Header()
+		blockHash   = block.Hash()
+		blockNumber = block.Number()
+		receipts    = make(types.Receipts, len(txs))
+		next        atomic.Int64
+		lock        sync.Mutex
+		workers     errgroup.Group
+	)
+	// Every worker copies the state once and executes each of its transactions
+	// on a fresh pre-block state sharing the reader of the copy, merging the
+	// access list recorded by each of them into the given state.
+	for range min(runtime.NumCPU(), len(txs)) {
+		workers.Go(func() error {
+			origin := statedb.Copy()
+			for i := int(next.Add(1) - 1); i < len(txs); i = int(next.Add(1) - 1) {
+				tx := txs[i]
+				txstate := origin.Origin()
+				txstate.ApplyBlockAccessList(bal, uint16(i+1))
+
+				msg, err := TransactionToMessage(tx, signer, header.BaseFee)
+				if err != nil {
+					return fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+				}
+				txstate.SetTxContext(tx.Hash(), i)
+
+				var (
+					gp      = new(GasPool).AddGas(block.GasLimit())
+					usedGas uint64
+					evm     = vm.NewEVM(context, txstate, p.config, cfg)
+				)
+				receipt, err := ApplyTransactionWithEVM(msg, gp, txstate, blockNumber, blockHash, tx, &usedGas, evm)
+				if err != nil {
+					return fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+				}
+				if err := txstate.Error(); err != nil {
+					return fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+				}
+				receipts[i] = receipt
+
+				lock.Lock()
+				statedb.MergeBlockAccessList(txstate)
+				lock.Unlock()
+			}
+			return nil
+		})
+	}
+	if err := workers.Wait(); err != nil {
+		return nil, err
+	}
+	// Each transaction was executed against the entire block gas limit, verify
+	// that it would have fit in the remaining gas if executed sequentially, and
+	// fix up the block-wide counters.
+	var (
+		usedGas  uint64
+		logIndex uint
+	)
+	for i, receipt := range receipts {
+		if txs[i].Gas() > block.GasLimit()-usedGas {
+			return nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, txs[i].Hash().Hex(), ErrGasLimitReached)
+		}
+		usedGas += receipt.GasUsed
+		receipt.CumulativeGasUsed = usedGas
+		for _, l := range receipt.Logs {
+			l.Index = logIndex
+			logIndex++
+		}
+	}
+	statedb.ApplyBlockAccessListUnrecorded(bal, uint16(len(txs)+1))
+	return receipts, nil
+}
+
 // ApplyTransactionWithEVM attempts to apply a transaction to the given state database
 // and uses the input parameters for its environment similar to ApplyTransaction. However,
 // this method takes an already created EVM instance as input.
@@ -161,7 +269,7 @@ func ApplyTransactionWithEVM(msg *Message, gp *GasPool, statedb *state.StateDB,
 
 	// Merge the tx-local access event into the "block-local" one, in order to collect
 	// all values, so that the witness can be built.
-	if statedb.GetTrie().IsVerkle() {
+	if statedb.Database().TrieDB().IsVerkle() {
 		statedb.AccessEvents().Merge(evm.AccessEvents)
 	}
 
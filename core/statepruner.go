@@ -0,0 +1,157 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/state/pruner"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// statePruner is the module responsible for pruning the stale state of the
+// hash-based scheme in the background, once every configured number of blocks.
+// It complements the reference-counting garbage collector of the trie database,
+// which only releases the state never flushed into the disk.
+type statePruner struct {
+	interval  uint64 // number of blocks between two pruning runs
+	bloomSize uint64 // megabytes of memory allocated to the bloom filter
+	chain     *BlockChain
+	db        ethdb.Database
+	term      chan chan struct{}
+	closed    chan struct{}
+}
+
+// newStatePruner initializes the state pruner.
+func newStatePruner(interval uint64, bloomSize uint64, chain *BlockChain) *statePruner {
+	pruner := &statePruner{
+		interval:  interval,
+		bloomSize: bloomSize,
+		chain:     chain,
+		db:        chain.db,
+		term:      make(chan chan struct{}),
+		closed:    make(chan struct{}),
+	}
+	go pruner.loop()
+
+	log.Info("Initialized online state pruner", "interval", interval)
+	return pruner
+}
+
+// run prunes the state except for the one of the current chain head and the
+// newer ones. The done channel will be closed once the task is complete or
+// aborted.
+func (sp *statePruner) run(abort chan struct{}, done chan struct{}) {
+	defer close(done)
+
+	// The state synced by snap sync is written directly into the database, it
+	// must not be pruned before the sync is finished.
+	if rawdb.ReadSnapSyncStatusFlag(sp.db) == rawdb.StateSyncRunning {
+		log.Debug("Skipping state pruning during snap sync")
+		return
+	}
+	p, err := pruner.NewOnlinePruner(sp.db, sp.chain.triedb, sp.bloomSize)
+	if err != nil {
+		log.Error("Failed to initialize state pruner", "err", err)
+		return
+	}
+	defer p.Close()
+
+	// Persist the state of the current head as the pruning target. All the trie
+	// nodes flushed since the pruner has been attached are regarded as live.
+	target := sp.chain.CurrentBlock()
+	if err := sp.chain.triedb.Commit(target.Root, false); err != nil {
+		log.Error("Failed to persist state for pruning", "number", target.Number, "root", target.Root, "err", err)
+		return
+	}
+	log.Info("Started online state pruning", "number", target.Number, "root", target.Root)
+	if err := p.Mark(target.Root, abort); err != nil {
+		log.Error("Failed to mark live state", "err", err)
+		return
+	}
+	// The in-memory states older than the target might reference the nodes not
+	// marked, wait until they are all garbage collected.
+	if !sp.wait(target.Number.Uint64()+state.TriesInMemory, abort) {
+		return
+	}
+	if err := p.Sweep(abort); err != nil {
+		log.Error("Failed to prune state", "err", err)
+	}
+}
+
+// wait blocks until the chain head reaches the given number, returning false
+// if the pruning is aborted in the meantime.
+func (sp *statePruner) wait(number uint64, abort chan struct{}) bool {
+	headCh := make(chan ChainHeadEvent, 1)
+	sub := sp.chain.SubscribeChainHeadEvent(headCh)
+	defer sub.Unsubscribe()
+
+	for sp.chain.CurrentBlock().Number.Uint64() < number {
+		select {
+		case <-headCh:
+		case <-abort:
+			return false
+		}
+	}
+	return true
+}
+
+// loop is the scheduler of the pruner, launching the pruning task whenever the
+// chain head is advanced by the configured interval.
+func (sp *statePruner) loop() {
+	defer close(sp.closed)
+
+	var (
+		done   chan struct{} // Non-nil if background routine is active
+		abort  = make(chan struct{})
+		last   = sp.chain.CurrentBlock().Number.Uint64()
+		headCh = make(chan ChainHeadEvent)
+		sub    = sp.chain.SubscribeChainHeadEvent(headCh)
+	)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case h := <-headCh:
+			if number := h.Header.Number.Uint64(); done == nil && number >= last+sp.interval {
+				last = number
+				done = make(chan struct{})
+				go sp.run(abort, done)
+			}
+		case <-done:
+			done = nil
+		case ch := <-sp.term:
+			close(abort)
+			if done != nil {
+				<-done
+			}
+			close(ch)
+			return
+		}
+	}
+}
+
+// close shutdown the pruner. Safe to be called for multiple times.
+func (sp *statePruner) close() {
+	ch := make(chan struct{})
+	select {
+	case sp.term <- ch:
+		<-ch
+	case <-sp.closed:
+	}
+}
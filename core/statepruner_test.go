@@ -0,0 +1,126 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// TestStatePruner tests that the stale state is pruned while the blocks keep
+// being imported, without affecting the state of the chain head.
+func TestStatePruner(t *testing.T) {
+	var (
+		testBankKey, _  = crypto.GenerateKey()
+		testBankAddress = crypto.PubkeyToAddress(testBankKey.PublicKey)
+		testBankFunds   = big.NewInt(1000000000000000000)
+
+		gspec = &Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   types.GenesisAlloc{testBankAddress: {Balance: testBankFunds}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		engine = ethash.NewFaker()
+		signer = types.LatestSigner(gspec.Config)
+		start  = 150
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, start+2*state.TriesInMemory, func(i int, gen *BlockGen) {
+		tx, _ := types.SignNewTx(testBankKey, signer, &types.LegacyTx{
+			Nonce:    gen.TxNonce(testBankAddress),
+			To:       &common.Address{byte(i >> 8), byte(i)},
+			Value:    big.NewInt(1000),
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(10 * params.InitialBaseFee),
+		})
+		gen.AddTx(tx)
+	})
+	// Persist a matured state on every block, leaving plenty of stale state
+	// in the database.
+	db := rawdb.NewMemoryDatabase()
+	cacheConfig := *defaultCacheConfig
+	cacheConfig.TrieTimeLimit = time.Nanosecond
+	cacheConfig.SnapshotLimit = 0
+
+	chain, err := NewBlockChain(db, &cacheConfig, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks[:start]); err != nil {
+		t.Fatalf("Failed to import blocks: %v", err)
+	}
+	stale := blocks[10].Root()
+	if !rawdb.HasLegacyTrieNode(db, stale) {
+		t.Fatal("Stale state is not persisted")
+	}
+	target := blocks[start-1].Root()
+	if rawdb.HasLegacyTrieNode(db, target) {
+		t.Fatal("Head state is persisted")
+	}
+	// Launch the pruning, continue importing blocks after the pruning target
+	// has been persisted.
+	var (
+		sp    = &statePruner{bloomSize: 256, chain: chain, db: db}
+		abort = make(chan struct{})
+		done  = make(chan struct{})
+	)
+	defer close(abort)
+	go sp.run(abort, done)
+
+	for !rawdb.HasLegacyTrieNode(db, target) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, err := chain.InsertChain(blocks[start:]); err != nil {
+		t.Fatalf("Failed to import blocks: %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Minute):
+		t.Fatal("State pruning is not finished")
+	}
+	if rawdb.HasLegacyTrieNode(db, stale) {
+		t.Fatal("Stale state is not pruned")
+	}
+	// The state of the pruning target and all the later ones must be intact
+	for _, block := range blocks[start-1:] {
+		tr, err := trie.NewStateTrie(trie.StateTrieID(block.Root()), chain.triedb)
+		if err != nil {
+			t.Fatalf("Block %d: failed to open state: %v", block.NumberU64(), err)
+		}
+		it, err := tr.NodeIterator(nil)
+		if err != nil {
+			t.Fatalf("Block %d: failed to iterate state: %v", block.NumberU64(), err)
+		}
+		for it.Next(true) {
+		}
+		if err := it.Error(); err != nil {
+			t.Fatalf("Block %d: state is corrupted: %v", block.NumberU64(), err)
+		}
+	}
+}
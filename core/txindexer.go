@@ -48,7 +48,8 @@ type txIndexer struct {
 	limit uint64
 
 	// cutoff denotes the block number before which the chain segment should
-	// be pruned and not available locally.
+	// be pruned and not available locally. It's refreshed before each run as
+	// the chain history might be pruned progressively.
 	cutoff   uint64
 	db       ethdb.Database
 	progress chan chan TxIndexProgress
@@ -89,7 +90,9 @@ func newTxIndexer(limit uint64, chain *BlockChain) *txIndexer {
 // The done channel will be closed once the task is complete.
 //
 // Existing transaction indexes are assumed to be valid, with both the head
-// and tail above the configured cutoff.
+// and tail above the configured cutoff. The only exception is the tail lagging
+// behind a cutoff moved by the progressive history pruning, in which case the
+// indexes below the cutoff are removed first.
 func (indexer *txIndexer) run(head uint64, stop chan struct{}, done chan struct{}) {
 	defer func() { close(done) }()
 
@@ -113,6 +116,12 @@ func (indexer *txIndexer) run(head uint64, stop chan struct{}, done chan struct{
 		rawdb.IndexTransactions(indexer.db, from, head+1, stop, true)
 		return
 	}
+	// The chain segment below the cutoff point is being pruned, unindex the
+	// transactions within it while the block bodies are still available.
+	if *tail < indexer.cutoff {
+		rawdb.UnindexTransactions(indexer.db, *tail, min(indexer.cutoff, head+1), stop, false)
+		return
+	}
 	// The tail flag is existent (which means indexes in [tail, head] should be
 	// present), while the whole chain are requested for indexing.
 	if indexer.limit == 0 || head < indexer.limit {
@@ -239,6 +248,7 @@ func (indexer *txIndexer) loop(chain *BlockChain) {
 		select {
 		case h := <-headCh:
 			if done == nil {
+				indexer.cutoff, _ = chain.HistoryPruningCutoff()
 				stop = make(chan struct{})
 				done = make(chan struct{})
 				go indexer.run(h.Header.Number.Uint64(), stop, done)
@@ -25,6 +25,7 @@ import (
 	"math/big"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"sync"
 	"time"
@@ -326,6 +327,9 @@ type BlobPool struct {
 	txValidationFn txpool.ValidationFunction
 
 	lock sync.RWMutex // Mutex protecting the pool during reorg handling
+
+	quit chan struct{}  // Channel to signal termination of the background tasks
+	wg   sync.WaitGroup // Tracks the background tasks for a clean shutdown
 }
 
 // New creates a new blob transaction pool to gather, sort and filter inbound
@@ -344,6 +348,7 @@ func New(config Config, chain BlockChain, hasPendingAuth func(common.Address) bo
 		index:          make(map[common.Address][]*blobTxMeta),
 		spent:          make(map[common.Address]*uint256.Int),
 		txValidationFn: txpool.ValidateTransaction,
+		quit:           make(chan struct{}),
 	}
 }
 
@@ -449,6 +454,9 @@ func (p *BlobPool) Init(gasTip uint64, head *types.Header, reserver txpool.Reser
 
 // Close closes down the underlying persistent store.
 func (p *BlobPool) Close() error {
+	close(p.quit)
+	p.wg.Wait()
+
 	var errs []error
 	if p.limbo != nil { // Close might be invoked due to error in constructor, before p,limbo is set
 		if err := p.limbo.Close(); err != nil {
@@ -810,6 +818,14 @@ func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
 	p.head = newHead
 	p.state = statedb
 
+	// If the chain just transitioned into Osaka, convert all the pooled sidecars
+	// to cell proofs (EIP-7594), as the blob proofs are not accepted any more.
+	// Computing the proofs is expensive, so do it in the background; until done,
+	// the blobs are served with proofs computed on the fly.
+	if oldHead != nil && !p.chain.Config().IsOsaka(oldHead.Number, oldHead.Time) && p.chain.Config().IsOsaka(newHead.Number, newHead.Time) {
+		p.wg.Add(1)
+		go p.convertSidecars()
+	}
 	// Run the reorg between the old and new head and figure out which accounts
 	// need to be rechecked and which transactions need to be readded
 	if reinject, inclusions := p.reorg(oldHead, newHead); reinject != nil {
@@ -848,6 +864,154 @@ func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
 	p.updateStorageMetrics()
 }
 
+// convertSidecars replaces the version 0 sidecars of all the pooled transactions
+// with version 1 ones, computing the cell proofs of the blobs. Transactions that
+// fail the conversion are dropped from the pool.
+//
+// The conversion runs in the background and only holds the pool lock to load
+// and swap the individual transactions. Transactions dropped or replaced in the
+// meantime are skipped.
+func (p *BlobPool) convertSidecars() {
+	defer p.wg.Done()
+
+	type pending struct {
+		addr common.Address
+		meta *blobTxMeta
+	}
+	var (
+		start     = time.Now()
+		todo      []pending
+		converted int
+		dropped   int
+	)
+	p.lock.RLock()
+	for addr, txs := range p.index {
+		for _, meta := range txs {
+			todo = append(todo, pending{addr, meta})
+		}
+	}
+	p.lock.RUnlock()
+
+	for _, tx := range todo {
+		select {
+		case <-p.quit:
+			log.Info("Blob sidecar conversion interrupted", "converted", converted, "dropped", dropped, "left", len(todo)-converted-dropped)
+			return
+		default:
+		}
+		conv, drops := p.convertSidecar(tx.addr, tx.meta)
+		if conv {
+			converted++
+		}
+		dropped += drops
+	}
+	if dropped > 0 {
+		dropInvalidMeter.Mark(int64(dropped))
+	}
+	log.Info("Converted pooled blob sidecars to cell proofs", "converted", converted, "dropped", dropped, "elapsed", common.PrettyDuration(time.Since(start)))
+}
+
+// convertSidecar converts the sidecar of a single pooled transaction to version
+// 1 and updates its entry in the persistent store. It reports whether the
+// transaction was converted and the number of transactions dropped due to a
+// failed conversion.
+func (p *BlobPool) convertSidecar(addr common.Address, meta *blobTxMeta) (bool, int) {
+	// Load the transaction if it's still pooled
+	p.lock.RLock()
+	if !slices.Contains(p.index[addr], meta) {
+		p.lock.RUnlock()
+		return false, 0
+	}
+	data, err := p.store.Get(meta.id)
+	p.lock.RUnlock()
+
+	// Compute the cell proofs without holding the lock
+	var blob []byte
+	if err == nil {
+		blob, err = convertSidecarBlob(data)
+		if err == nil && blob == nil {
+			return false, 0 // already a version 1 sidecar
+		}
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	txs := p.index[addr]
+	i := slices.Index(txs, meta)
+	if i < 0 {
+		return false, 0 // dropped or replaced in the meantime
+	}
+	if err != nil {
+		// Conversion failed, drop the transaction and all subsequent ones from
+		// the account as they became gapped
+		log.Error("Failed to convert blob sidecar", "hash", meta.hash, "err", err)
+		for _, drop := range txs[i:] {
+			if err := p.store.Delete(drop.id); err != nil {
+				log.Error("Failed to delete unconvertible transaction", "id", drop.id, "err", err)
+			}
+			p.lookup.untrack(drop)
+			p.stored -= uint64(drop.storageSize)
+			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], drop.costCap)
+		}
+		if i == 0 {
+			delete(p.index, addr)
+			delete(p.spent, addr)
+			heap.Remove(p.evict, p.evict.index[addr])
+			p.reserver.Release(addr)
+		} else {
+			p.index[addr] = txs[:i]
+			heap.Fix(p.evict, p.evict.index[addr])
+		}
+		return false, len(txs) - i
+	}
+	id, err := p.store.Put(blob)
+	if err != nil {
+		log.Error("Failed to store converted transaction", "hash", meta.hash, "err", err)
+		return false, 0
+	}
+	if err := p.store.Delete(meta.id); err != nil {
+		log.Error("Failed to delete converted transaction", "id", meta.id, "err", err)
+	}
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(blob, tx); err != nil {
+		panic(err) // just encoded, cannot fail
+	}
+	conv := newBlobTxMeta(id, tx.Size(), p.store.Size(id), tx)
+
+	// Carry over the rolling eviction fields, they only depend on the fees
+	conv.evictionExecTip = meta.evictionExecTip
+	conv.evictionExecFeeJumps = meta.evictionExecFeeJumps
+	conv.evictionBlobFeeJumps = meta.evictionBlobFeeJumps
+
+	txs[i] = conv
+	p.lookup.untrack(meta)
+	p.lookup.track(conv)
+	p.stored += uint64(conv.storageSize) - uint64(meta.storageSize)
+	return true, 0
+}
+
+// convertSidecarBlob converts the sidecar of an encoded blob transaction to
+// version 1, returning the re-encoded transaction. The returned blob is nil if
+// the transaction already carried a version 1 sidecar.
+func convertSidecarBlob(data []byte) ([]byte, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(data, tx); err != nil {
+		return nil, err
+	}
+	sidecar := tx.BlobTxSidecar()
+	if sidecar == nil {
+		return nil, errors.New("missing blob sidecar")
+	}
+	if sidecar.Version == types.BlobSidecarVersion1 {
+		return nil, nil
+	}
+	sidecar = sidecar.Copy()
+	if err := sidecar.ToV1(); err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes(tx.WithBlobTxSidecar(sidecar))
+}
+
 // reorg assembles all the transactors and missing transactions between an old
 // and new head to figure out which account's tx set needs to be rechecked and
 // which transactions need to be requeued.
@@ -990,6 +1154,16 @@ func (p *BlobPool) reinject(addr common.Address, txhash common.Hash) error {
 		log.Error("Blobs unavailable, dropping reorged tx", "err", err)
 		return err
 	}
+	// If the transaction was included before the Osaka fork, but the new head
+	// is already past it, the blob proofs need to be converted to cell proofs
+	if sidecar := tx.BlobTxSidecar(); sidecar.Version == types.BlobSidecarVersion0 && p.chain.Config().IsOsaka(p.head.Number, p.head.Time) {
+		sidecar = sidecar.Copy()
+		if err := sidecar.ToV1(); err != nil {
+			log.Error("Failed to convert reorged blob sidecar", "hash", txhash, "err", err)
+			return err
+		}
+		tx = tx.WithBlobTxSidecar(sidecar)
+	}
 	// TODO: seems like an easy optimization here would be getting the serialized tx
 	// from limbo instead of re-serializing it here.
 
@@ -1295,14 +1469,18 @@ func (p *BlobPool) GetMetadata(hash common.Hash) *txpool.TxMetadata {
 	}
 }
 
-// GetBlobs returns a number of blobs are proofs for the given versioned hashes.
+// GetBlobs returns a number of blobs and proofs for the given versioned hashes.
+// The proofs are returned in the format of the requested sidecar version: a
+// single blob proof for version 0 and all the cell proofs for version 1. Pooled
+// sidecars of the other version are converted on the fly.
+//
 // This is a utility method for the engine API, enabling consensus clients to
 // retrieve blobs from the pools directly instead of the network.
-func (p *BlobPool) GetBlobs(vhashes []common.Hash) ([]*kzg4844.Blob, []*kzg4844.Proof) {
+func (p *BlobPool) GetBlobs(vhashes []common.Hash, version byte) ([]*kzg4844.Blob, [][]kzg4844.Proof) {
 	// Create a map of the blob hash to indices for faster fills
 	var (
 		blobs  = make([]*kzg4844.Blob, len(vhashes))
-		proofs = make([]*kzg4844.Proof, len(vhashes))
+		proofs = make([][]kzg4844.Proof, len(vhashes))
 	)
 	index := make(map[common.Hash]int)
 	for i, vhash := range vhashes {
@@ -1338,15 +1516,48 @@ func (p *BlobPool) GetBlobs(vhashes []common.Hash) ([]*kzg4844.Blob, []*kzg4844.
 		// Fill anything requested, not just the current versioned hash
 		sidecar := item.BlobTxSidecar()
 		for j, blobhash := range item.BlobHashes() {
-			if idx, ok := index[blobhash]; ok {
-				blobs[idx] = &sidecar.Blobs[j]
-				proofs[idx] = &sidecar.Proofs[j]
+			idx, ok := index[blobhash]
+			if !ok {
+				continue
 			}
+			blobProofs, err := sidecarProofs(sidecar, j, version)
+			if err != nil {
+				log.Error("Failed to retrieve blob proofs", "id", id, "version", version, "err", err)
+				continue
+			}
+			blobs[idx] = &sidecar.Blobs[j]
+			proofs[idx] = blobProofs
 		}
 	}
 	return blobs, proofs
 }
 
+// sidecarProofs returns the proofs of the blob at the given index of the sidecar
+// in the format of the requested sidecar version, computing them if the sidecar
+// was stored with the other proof type.
+func sidecarProofs(sidecar *types.BlobTxSidecar, idx int, version byte) ([]kzg4844.Proof, error) {
+	switch version {
+	case types.BlobSidecarVersion0:
+		if sidecar.Version == types.BlobSidecarVersion0 {
+			return []kzg4844.Proof{sidecar.Proofs[idx]}, nil
+		}
+		proof, err := kzg4844.ComputeBlobProof(&sidecar.Blobs[idx], sidecar.Commitments[idx])
+		if err != nil {
+			return nil, err
+		}
+		return []kzg4844.Proof{proof}, nil
+
+	case types.BlobSidecarVersion1:
+		if sidecar.Version == types.BlobSidecarVersion1 {
+			return sidecar.CellProofsAt(idx)
+		}
+		return kzg4844.ComputeCellProofs(&sidecar.Blobs[idx])
+
+	default:
+		return nil, fmt.Errorf("unknown sidecar version %d", version)
+	}
+}
+
 // Add inserts a set of blob transactions into the pool if they pass validation (both
 // consensus validity and pool restrictions).
 //
@@ -417,7 +417,7 @@ func verifyBlobRetrievals(t *testing.T, pool *BlobPool) {
 	for i := range testBlobVHashes {
 		copy(hashes[i][:], testBlobVHashes[i][:])
 	}
-	blobs, proofs := pool.GetBlobs(hashes)
+	blobs, proofs := pool.GetBlobs(hashes, types.BlobSidecarVersion0)
 
 	// Cross validate what we received vs what we wanted
 	if len(blobs) != len(hashes) || len(proofs) != len(hashes) {
@@ -433,7 +433,7 @@ func verifyBlobRetrievals(t *testing.T, pool *BlobPool) {
 			continue
 		}
 		// Item retrieved, make sure it matches the expectation
-		if *blobs[i] != *testBlobs[i] || *proofs[i] != testBlobProofs[i] {
+		if *blobs[i] != *testBlobs[i] || len(proofs[i]) != 1 || proofs[i][0] != testBlobProofs[i] {
 			t.Errorf("retrieved blob or proof mismatch: item %d, hash %x", i, hash)
 			continue
 		}
@@ -1142,6 +1142,105 @@ func TestChangingSlotterSize(t *testing.T) {
 	}
 }
 
+// Tests that the pooled blob sidecars are converted to cell proofs when the
+// chain transitions into Osaka, and that blobs can be retrieved with both proof
+// versions regardless of what is stored.
+func TestSidecarConversion(t *testing.T) {
+	//log.SetDefault(log.NewLogger(log.NewTerminalHandlerWithLevel(os.Stderr, log.LevelTrace, true)))
+
+	// Create a temporary folder for the persistent backend
+	storage := t.TempDir()
+
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+
+		tx1 = makeMultiBlobTx(0, 1, 1000, 100, 2, key)
+		tx2 = makeMultiBlobTx(1, 1, 1000, 100, 1, key)
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.AddBalance(addr, uint256.NewInt(1_000_000_000), tracing.BalanceChangeUnspecified)
+	statedb.Commit(0, true, false)
+
+	// Schedule Osaka right after the pool's starting head
+	config := *params.MainnetChainConfig
+	config.OsakaTime = new(uint64)
+	*config.OsakaTime = *config.CancunTime + 2
+
+	schedule := *config.BlobScheduleConfig
+	schedule.Osaka = params.DefaultOsakaBlobConfig
+	config.BlobScheduleConfig = &schedule
+
+	chain := &testBlockChain{
+		config:  &config,
+		basefee: uint256.NewInt(1050),
+		blobfee: uint256.NewInt(105),
+		statedb: statedb,
+	}
+	pool := New(Config{Datadir: storage}, chain, nil)
+	if err := pool.Init(1, chain.CurrentBlock(), newReserver()); err != nil {
+		t.Fatalf("failed to create blob pool: %v", err)
+	}
+	defer pool.Close()
+
+	for i, err := range pool.Add([]*types.Transaction{tx1, tx2}, true) {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	// Transition the pool into Osaka, all the sidecars should be converted
+	oldHead := chain.CurrentBlock()
+	newHead := types.CopyHeader(oldHead)
+	newHead.Number = new(big.Int).Add(oldHead.Number, common.Big1)
+	newHead.Time = *config.OsakaTime
+	pool.Reset(oldHead, newHead)
+
+	// The conversion runs in the background, blobs must be retrievable with
+	// cell proofs while it's in progress
+	blobs, proofs := pool.GetBlobs(tx1.BlobHashes(), types.BlobSidecarVersion1)
+	for i := range blobs {
+		if blobs[i] == nil || len(proofs[i]) != kzg4844.CellProofsPerBlob {
+			t.Fatalf("blob %d: missing blob or cell proofs during conversion", i)
+		}
+	}
+	pool.wg.Wait()
+
+	for _, tx := range []*types.Transaction{tx1, tx2} {
+		pooled := pool.Get(tx.Hash())
+		if pooled == nil {
+			t.Fatalf("transaction %x missing after conversion", tx.Hash())
+		}
+		sidecar := pooled.BlobTxSidecar()
+		if sidecar.Version != types.BlobSidecarVersion1 {
+			t.Fatalf("transaction %x: sidecar version mismatch: have %d, want %d", tx.Hash(), sidecar.Version, types.BlobSidecarVersion1)
+		}
+		if err := kzg4844.VerifyCellProofs(sidecar.Blobs, sidecar.Commitments, sidecar.Proofs); err != nil {
+			t.Fatalf("transaction %x: invalid cell proofs: %v", tx.Hash(), err)
+		}
+	}
+	verifyPoolInternals(t, pool)
+
+	// Retrieve the blobs with cell proofs and validate them
+	hashes := tx1.BlobHashes()
+	blobs, proofs = pool.GetBlobs(hashes, types.BlobSidecarVersion1)
+	for i := range hashes {
+		if blobs[i] == nil || len(proofs[i]) != kzg4844.CellProofsPerBlob {
+			t.Fatalf("blob %d: missing blob or cell proofs", i)
+		}
+		commitment := tx1.BlobTxSidecar().Commitments[i]
+		if err := kzg4844.VerifyCellProofs([]kzg4844.Blob{*blobs[i]}, []kzg4844.Commitment{commitment}, proofs[i]); err != nil {
+			t.Fatalf("blob %d: invalid cell proofs: %v", i, err)
+		}
+	}
+	// Blobs with cell proofs stored must be also servable with blob proofs
+	blobs, proofs = pool.GetBlobs(hashes, types.BlobSidecarVersion0)
+	for i := range hashes {
+		if blobs[i] == nil || len(proofs[i]) != 1 || proofs[i][0] != tx1.BlobTxSidecar().Proofs[i] {
+			t.Fatalf("blob %d: blob proof mismatch", i)
+		}
+	}
+}
+
 // Tests that adding transaction will correctly store it in the persistent store
 // and update all the indices.
 //
@@ -67,4 +67,16 @@ var (
 	// ErrInflightTxLimitReached is returned when the maximum number of in-flight
 	// transactions is reached for specific accounts.
 	ErrInflightTxLimitReached = errors.New("in-flight transaction limit reached for delegated accounts")
+
+	// ErrSidecarVersion is returned if a blob transaction carries a sidecar whose
+	// version doesn't match the proof type required by the active fork.
+	ErrSidecarVersion = errors.New("unexpected blob sidecar version")
+
+	// ErrConditionalUnsupported is returned if a transaction is submitted with
+	// conditions to a pool that cannot enforce them.
+	ErrConditionalUnsupported = errors.New("transaction conditionals not supported")
+
+	// ErrConditionalTooExpensive is returned if a transaction is submitted with
+	// more storage conditions than the pool is willing to check.
+	ErrConditionalTooExpensive = errors.New("transaction conditional too expensive")
 )
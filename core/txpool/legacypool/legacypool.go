@@ -19,6 +19,7 @@ package legacypool
 
 import (
 	"errors"
+	"fmt"
 	"maps"
 	"math"
 	"math/big"
@@ -56,6 +57,11 @@ const (
 	// more expensive to propagate; larger transactions also take more resources
 	// to validate whether they fit into the pool or not.
 	txMaxSize = 4 * txSlotSize // 128KB
+
+	// maxConditionalCost is the maximum number of storage checks the conditions
+	// of a single transaction may require. The conditions are re-checked on every
+	// head change and before inclusion, so they must be cheap to evaluate.
+	maxConditionalCost = 1000
 )
 
 var (
@@ -106,6 +112,9 @@ var (
 	// throttleTxMeter counts how many transactions are rejected due to too-many-changes between
 	// txpool reorgs.
 	throttleTxMeter = metrics.NewRegisteredMeter("txpool/throttle", nil)
+	// conditionalDropMeter counts how many conditional transactions are dropped
+	// because their conditions failed or expired.
+	conditionalDropMeter = metrics.NewRegisteredMeter("txpool/conditional/drop", nil)
 	// reorgDurationTimer measures how long time a txpool reorg takes.
 	reorgDurationTimer = metrics.NewRegisteredTimer("txpool/reorgtime", nil)
 	// dropBetweenReorgHistogram counts how many drops we experience between two reorg runs. It is expected
@@ -245,6 +254,8 @@ type LegacyPool struct {
 	all     *lookup                      // All transactions to allow lookups
 	priced  *pricedList                  // All transactions sorted by price
 
+	conditionals map[common.Hash]*types.TransactionConditional // Inclusion conditions of local transactions
+
 	reqResetCh      chan *txpoolResetRequest
 	reqPromoteCh    chan *accountSet
 	queueTxEventCh  chan *types.Transaction
@@ -276,6 +287,7 @@ func New(config Config, chain BlockChain) *LegacyPool {
 		queue:           make(map[common.Address]*list),
 		beats:           make(map[common.Address]time.Time),
 		all:             newLookup(),
+		conditionals:    make(map[common.Hash]*types.TransactionConditional),
 		reqResetCh:      make(chan *txpoolResetRequest),
 		reqPromoteCh:    make(chan *accountSet),
 		queueTxEventCh:  make(chan *types.Transaction),
@@ -551,6 +563,8 @@ func (pool *LegacyPool) Pending(filter txpool.PendingFilter) map[common.Address]
 					GasTipCap: uint256.MustFromBig(txs[i].GasTipCap()),
 					Gas:       txs[i].Gas(),
 					BlobGas:   txs[i].BlobGas(),
+
+					Conditional: pool.conditionals[txs[i].Hash()],
 				}
 			}
 			pending[addr] = lazies
@@ -986,6 +1000,50 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, sync bool) []error {
 	return errs
 }
 
+// AddConditional enqueues a local transaction into the pool if it is valid and
+// its conditions currently hold. The conditions are re-checked on every reset,
+// dropping the transaction as soon as they fail or can no longer be met.
+func (pool *LegacyPool) AddConditional(tx *types.Transaction, cond *types.TransactionConditional) error {
+	if err := cond.Validate(); err != nil {
+		return err
+	}
+	if cost := cond.Cost(); cost > maxConditionalCost {
+		return fmt.Errorf("%w: cost %d, limit %d", txpool.ErrConditionalTooExpensive, cost, maxConditionalCost)
+	}
+	if pool.all.Get(tx.Hash()) != nil {
+		knownTxMeter.Mark(1)
+		return txpool.ErrAlreadyKnown
+	}
+	if err := pool.ValidateTxBasics(tx); err != nil {
+		invalidTxMeter.Mark(1)
+		return err
+	}
+	pool.mu.Lock()
+	head := pool.currentHead.Load()
+	if cond.Expired(head.Number, head.Time) {
+		pool.mu.Unlock()
+		return fmt.Errorf("%w: expired at head %d", types.ErrConditionalBlockNumber, head.Number)
+	}
+	if err := txpool.ValidateConditional(cond, pool.currentState); err != nil {
+		pool.mu.Unlock()
+		return err
+	}
+	// Track the conditions before the transaction becomes pending, so that it
+	// is never handed out without them.
+	pool.conditionals[tx.Hash()] = cond
+	errs, dirtyAddrs := pool.addTxsLocked([]*types.Transaction{tx})
+	if errs[0] != nil {
+		delete(pool.conditionals, tx.Hash())
+	}
+	pool.mu.Unlock()
+
+	if errs[0] != nil {
+		return errs[0]
+	}
+	pool.requestPromoteExecutables(dirtyAddrs)
+	return nil
+}
+
 // addTxsLocked attempts to queue a batch of transactions if they are valid.
 // The transaction pool lock must be held.
 func (pool *LegacyPool) addTxsLocked(txs []*types.Transaction) ([]error, *accountSet) {
@@ -1057,15 +1115,20 @@ func (pool *LegacyPool) GetMetadata(hash common.Hash) *txpool.TxMetadata {
 	if tx == nil {
 		return nil
 	}
+	pool.mu.RLock()
+	cond := pool.conditionals[hash]
+	pool.mu.RUnlock()
+
 	return &txpool.TxMetadata{
-		Type: tx.Type(),
-		Size: tx.Size(),
+		Type:        tx.Type(),
+		Size:        tx.Size(),
+		Conditional: cond,
 	}
 }
 
 // GetBlobs is not supported by the legacy transaction pool, it is just here to
 // implement the txpool.SubPool interface.
-func (pool *LegacyPool) GetBlobs(vhashes []common.Hash) ([]*kzg4844.Blob, []*kzg4844.Proof) {
+func (pool *LegacyPool) GetBlobs(vhashes []common.Hash, version byte) ([]*kzg4844.Blob, [][]kzg4844.Proof) {
 	return nil, nil
 }
 
@@ -1108,6 +1171,7 @@ func (pool *LegacyPool) removeTx(hash common.Hash, outofbound bool, unreserve bo
 	}
 	// Remove it from the list of known transactions
 	pool.all.Remove(hash)
+	delete(pool.conditionals, hash)
 	if outofbound {
 		pool.priced.Removed(1)
 	}
@@ -1420,12 +1484,36 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 	pool.currentState = statedb
 	pool.pendingNonces = newNoncer(statedb)
 
+	// Drop any conditional transactions that can no longer be included
+	pool.filterConditionals(newHead)
+
 	// Inject any transactions discarded due to reorgs
 	log.Debug("Reinjecting stale transactions", "count", len(reinject))
 	core.SenderCacher().Recover(pool.signer, reinject)
 	pool.addTxsLocked(reinject)
 }
 
+// filterConditionals drops the transactions whose conditions expired with the
+// given head or whose known accounts don't match its state anymore. Conditions
+// of transactions already evicted from the pool are discarded too.
+func (pool *LegacyPool) filterConditionals(head *types.Header) {
+	for hash, cond := range pool.conditionals {
+		if pool.all.Get(hash) == nil {
+			delete(pool.conditionals, hash)
+			continue
+		}
+		err := txpool.ValidateConditional(cond, pool.currentState)
+		if err == nil && cond.Expired(head.Number, head.Time) {
+			err = fmt.Errorf("expired at head %d", head.Number)
+		}
+		if err != nil {
+			log.Trace("Removed conditional transaction", "hash", hash, "err", err)
+			pool.removeTx(hash, true, true)
+			conditionalDropMeter.Mark(1)
+		}
+	}
+}
+
 // promoteExecutables moves transactions that have become processable from the
 // future queue to the set of pending transactions. During this process, all
 // invalidated transactions (low nonce, low balance) are deleted.
@@ -1938,6 +2026,7 @@ func (pool *LegacyPool) Clear() {
 	pool.pending = make(map[common.Address]*list)
 	pool.queue = make(map[common.Address]*list)
 	pool.pendingNonces = newNoncer(pool.currentState)
+	pool.conditionals = make(map[common.Hash]*types.TransactionConditional)
 }
 
 // HasPendingAuth returns a flag indicating whether there are pending
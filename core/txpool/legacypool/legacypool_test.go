@@ -2687,3 +2687,87 @@ func BenchmarkMultiAccountBatchInsert(b *testing.B) {
 		pool.addRemotesSync([]*types.Transaction{tx})
 	}
 }
+
+// Tests that conditional transactions are only accepted if their conditions
+// hold and that they are dropped once the conditions fail or expire.
+func TestConditionalTransactions(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	var (
+		account  = crypto.PubkeyToAddress(key.PublicKey)
+		contract = common.HexToAddress("0xc0de")
+		slot     = common.HexToHash("0x01")
+		maxBlock = big.NewInt(5)
+	)
+	testAddBalance(pool, account, big.NewInt(1000000))
+
+	// Malformed and already failing conditions must be rejected
+	bad := &types.TransactionConditional{BlockNumberMin: big.NewInt(2), BlockNumberMax: big.NewInt(1)}
+	if err := pool.AddConditional(transaction(0, 100000, key), bad); err == nil {
+		t.Fatal("conditional with inverted block range accepted")
+	}
+	expired := &types.TransactionConditional{BlockNumberMax: common.Big0}
+	if err := pool.AddConditional(transaction(0, 100000, key), expired); !errors.Is(err, types.ErrConditionalBlockNumber) {
+		t.Fatalf("expired conditional error mismatch: have %v, want %v", err, types.ErrConditionalBlockNumber)
+	}
+	failing := &types.TransactionConditional{KnownAccounts: types.KnownAccounts{
+		contract: {StorageSlots: map[common.Hash]common.Hash{slot: {0x01}}},
+	}}
+	if err := pool.AddConditional(transaction(0, 100000, key), failing); !errors.Is(err, types.ErrConditionalKnownAccount) {
+		t.Fatalf("failing conditional error mismatch: have %v, want %v", err, types.ErrConditionalKnownAccount)
+	}
+	// Add a conditional transaction whose conditions currently hold
+	cond := &types.TransactionConditional{
+		KnownAccounts: types.KnownAccounts{
+			contract: {StorageSlots: map[common.Hash]common.Hash{slot: {}}},
+		},
+		BlockNumberMax: maxBlock,
+	}
+	tx := transaction(0, 100000, key)
+	if err := pool.AddConditional(tx, cond); err != nil {
+		t.Fatalf("failed to add conditional transaction: %v", err)
+	}
+	<-pool.requestPromoteExecutables(newAccountSet(pool.signer, account))
+	if meta := pool.GetMetadata(tx.Hash()); meta == nil || meta.Conditional != cond {
+		t.Fatalf("conditional missing from metadata: %v", meta)
+	}
+	pending := pool.Pending(txpool.PendingFilter{})
+	if len(pending[account]) != 1 || pending[account][0].Conditional != cond {
+		t.Fatalf("conditional missing from pending transactions: %v", pending[account])
+	}
+	// Reset to a head before the expiry, the transaction should remain
+	head := func(number *big.Int) *types.Header {
+		return &types.Header{Number: number, Difficulty: common.Big0, GasLimit: 10000000, BaseFee: common.Big1}
+	}
+	<-pool.requestReset(nil, head(big.NewInt(4)))
+	if pool.Get(tx.Hash()) == nil {
+		t.Fatal("conditional transaction dropped before expiry")
+	}
+	// Reset to the expiry head, the transaction should be dropped
+	<-pool.requestReset(nil, head(maxBlock))
+	if pool.Get(tx.Hash()) != nil {
+		t.Fatal("expired conditional transaction not dropped")
+	}
+	if len(pool.conditionals) != 0 {
+		t.Fatalf("conditionals not cleaned up: %d left", len(pool.conditionals))
+	}
+	// Add it again without expiry and change the known storage slot
+	cond = &types.TransactionConditional{KnownAccounts: cond.KnownAccounts}
+	if err := pool.AddConditional(tx, cond); err != nil {
+		t.Fatalf("failed to re-add conditional transaction: %v", err)
+	}
+	pool.mu.Lock()
+	pool.currentState.SetState(contract, slot, common.Hash{0x02})
+	pool.mu.Unlock()
+
+	<-pool.requestReset(nil, nil)
+	if pool.Get(tx.Hash()) != nil {
+		t.Fatal("conditional transaction with mismatching storage not dropped")
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
@@ -42,6 +42,8 @@ type LazyTransaction struct {
 
 	Gas     uint64 // Amount of gas required by the transaction
 	BlobGas uint64 // Amount of blob gas required by the transaction
+
+	Conditional *types.TransactionConditional // Conditions the transaction must satisfy to be included
 }
 
 // Resolve retrieves the full transaction belonging to a lazy handle if it is still
@@ -86,6 +88,8 @@ type PendingFilter struct {
 type TxMetadata struct {
 	Type uint8  // The type of the transaction
 	Size uint64 // The length of the 'rlp encoding' of a transaction
+
+	Conditional *types.TransactionConditional // Conditions the transaction was submitted with, if any
 }
 
 // SubPool represents a specialized transaction pool that lives on its own (e.g.
@@ -133,10 +137,12 @@ type SubPool interface {
 	// given transaction hash.
 	GetMetadata(hash common.Hash) *TxMetadata
 
-	// GetBlobs returns a number of blobs are proofs for the given versioned hashes.
+	// GetBlobs returns a number of blobs and proofs for the given versioned hashes.
+	// The proofs are returned in the format of the requested sidecar version:
+	// a single blob proof for version 0 and all the cell proofs for version 1.
 	// This is a utility method for the engine API, enabling consensus clients to
 	// retrieve blobs from the pools directly instead of the network.
-	GetBlobs(vhashes []common.Hash) ([]*kzg4844.Blob, []*kzg4844.Proof)
+	GetBlobs(vhashes []common.Hash, version byte) ([]*kzg4844.Blob, [][]kzg4844.Proof)
 
 	// ValidateTxBasics checks whether a transaction is valid according to the consensus
 	// rules, but does not check state-dependent validation such as sufficient balance.
@@ -184,3 +190,14 @@ type SubPool interface {
 	// Clear removes all tracked transactions from the pool
 	Clear()
 }
+
+// ConditionalSubPool is a subpool that supports transactions that must only be
+// included if a set of conditions over the chain state still hold.
+type ConditionalSubPool interface {
+	SubPool
+
+	// AddConditional enqueues a local transaction along with the conditions it
+	// must satisfy to be included. The conditions are re-checked on every chain
+	// head change and the transaction is dropped as soon as they cannot be met.
+	AddConditional(tx *types.Transaction, cond *types.TransactionConditional) error
+}
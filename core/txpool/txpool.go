@@ -308,16 +308,17 @@ func (p *TxPool) GetMetadata(hash common.Hash) *TxMetadata {
 	return nil
 }
 
-// GetBlobs returns a number of blobs are proofs for the given versioned hashes.
+// GetBlobs returns a number of blobs and proofs for the given versioned hashes.
+// The proofs are returned in the format of the requested sidecar version.
 // This is a utility method for the engine API, enabling consensus clients to
 // retrieve blobs from the pools directly instead of the network.
-func (p *TxPool) GetBlobs(vhashes []common.Hash) ([]*kzg4844.Blob, []*kzg4844.Proof) {
+func (p *TxPool) GetBlobs(vhashes []common.Hash, version byte) ([]*kzg4844.Blob, [][]kzg4844.Proof) {
 	for _, subpool := range p.subpools {
 		// It's an ugly to assume that only one pool will be capable of returning
 		// anything meaningful for this call, but anythingh else requires merging
 		// partial responses and that's too annoying to do until we get a second
 		// blobpool (probably never).
-		if blobs, proofs := subpool.GetBlobs(vhashes); blobs != nil {
+		if blobs, proofs := subpool.GetBlobs(vhashes, version); blobs != nil {
 			return blobs, proofs
 		}
 	}
@@ -373,6 +374,23 @@ func (p *TxPool) Add(txs []*types.Transaction, sync bool) []error {
 	return errs
 }
 
+// AddConditional enqueues a local transaction into the pool, along with the
+// conditions it must satisfy to be included in a block. The transaction is
+// rejected if the subpool accepting it cannot enforce the conditions.
+func (p *TxPool) AddConditional(tx *types.Transaction, cond *types.TransactionConditional) error {
+	for _, subpool := range p.subpools {
+		if !subpool.Filter(tx) {
+			continue
+		}
+		pool, ok := subpool.(ConditionalSubPool)
+		if !ok {
+			return fmt.Errorf("%w: transaction type %d", ErrConditionalUnsupported, tx.Type())
+		}
+		return pool.AddConditional(tx, cond)
+	}
+	return fmt.Errorf("%w: received type %d", core.ErrTxTypeNotSupported, tx.Type())
+}
+
 // Pending retrieves all currently processable transactions, grouped by origin
 // account and sorted by nonce.
 //
@@ -152,6 +152,15 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 		if len(hashes) > maxBlobs {
 			return fmt.Errorf("too many blobs in transaction: have %d, permitted %d", len(hashes), maxBlobs)
 		}
+		// Ensure the sidecar version matches the active fork: cell proofs are
+		// required from Osaka (EIP-7594) onwards, blob proofs before it
+		if opts.Config.IsOsaka(head.Number, head.Time) {
+			if sidecar.Version != types.BlobSidecarVersion1 {
+				return fmt.Errorf("%w: have version %d, want %d", ErrSidecarVersion, sidecar.Version, types.BlobSidecarVersion1)
+			}
+		} else if sidecar.Version != types.BlobSidecarVersion0 {
+			return fmt.Errorf("%w: have version %d, want %d", ErrSidecarVersion, sidecar.Version, types.BlobSidecarVersion0)
+		}
 		// Ensure commitments, proofs and hashes are valid
 		if err := validateBlobSidecar(hashes, sidecar); err != nil {
 			return err
@@ -169,14 +178,23 @@ func validateBlobSidecar(hashes []common.Hash, sidecar *types.BlobTxSidecar) err
 	if len(sidecar.Blobs) != len(hashes) {
 		return fmt.Errorf("invalid number of %d blobs compared to %d blob hashes", len(sidecar.Blobs), len(hashes))
 	}
-	if len(sidecar.Proofs) != len(hashes) {
-		return fmt.Errorf("invalid number of %d blob proofs compared to %d blob hashes", len(sidecar.Proofs), len(hashes))
-	}
 	if err := sidecar.ValidateBlobCommitmentHashes(hashes); err != nil {
 		return err
 	}
 	// Blob commitments match with the hashes in the transaction, verify the
 	// blobs themselves via KZG
+	if sidecar.Version == types.BlobSidecarVersion1 {
+		if len(sidecar.Proofs) != len(hashes)*kzg4844.CellProofsPerBlob {
+			return fmt.Errorf("invalid number of %d cell proofs compared to %d blob hashes", len(sidecar.Proofs), len(hashes))
+		}
+		if err := kzg4844.VerifyCellProofs(sidecar.Blobs, sidecar.Commitments, sidecar.Proofs); err != nil {
+			return fmt.Errorf("invalid cell proofs: %v", err)
+		}
+		return nil
+	}
+	if len(sidecar.Proofs) != len(hashes) {
+		return fmt.Errorf("invalid number of %d blob proofs compared to %d blob hashes", len(sidecar.Proofs), len(hashes))
+	}
 	for i := range sidecar.Blobs {
 		if err := kzg4844.VerifyBlobProof(&sidecar.Blobs[i], sidecar.Commitments[i], sidecar.Proofs[i]); err != nil {
 			return fmt.Errorf("invalid blob %d: %v", i, err)
@@ -266,3 +284,28 @@ func ValidateTransactionWithState(tx *types.Transaction, signer types.Signer, op
 	}
 	return nil
 }
+
+// ValidateConditional checks whether the known accounts of a transaction
+// conditional match the given state. The block number and timestamp ranges are
+// left for the caller to check against the relevant header.
+func ValidateConditional(cond *types.TransactionConditional, state *state.StateDB) error {
+	for addr, account := range cond.KnownAccounts {
+		if account.StorageRoot != nil {
+			// Non-existent accounts have no storage, report the empty root for them
+			root := state.GetStorageRoot(addr)
+			if root == (common.Hash{}) {
+				root = types.EmptyRootHash
+			}
+			if root != *account.StorageRoot {
+				return fmt.Errorf("%w: account %x, storage root %x, want %x", types.ErrConditionalKnownAccount, addr, root, *account.StorageRoot)
+			}
+			continue
+		}
+		for slot, want := range account.StorageSlots {
+			if have := state.GetState(addr, slot); have != want {
+				return fmt.Errorf("%w: account %x, slot %x, value %x, want %x", types.ErrConditionalKnownAccount, addr, slot, have, want)
+			}
+		}
+	}
+	return nil
+}
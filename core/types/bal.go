@@ -0,0 +1,318 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"maps"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/holiman/uint256"
+)
+
+//go:generate go run github.com/fjl/gencodec -type StorageChange -field-override storageChangeMarshaling -out gen_storage_change_json.go
+//go:generate go run github.com/fjl/gencodec -type BalanceChange -field-override balanceChangeMarshaling -out gen_balance_change_json.go
+//go:generate go run github.com/fjl/gencodec -type NonceChange -field-override nonceChangeMarshaling -out gen_nonce_change_json.go
+//go:generate go run github.com/fjl/gencodec -type CodeChange -field-override codeChangeMarshaling -out gen_code_change_json.go
+
+// BlockAccessList is the list of all the accounts and storage slots accessed
+// during the execution of a block, along with the post-values of the modified
+// ones per transaction, as defined by EIP-7928.
+//
+// Changes are keyed by their block access index: zero for the system calls
+// preceding the transactions, i+1 for the transaction at index i and len(txs)+1
+// for the operations following them, e.g. the withdrawals.
+type BlockAccessList []AccountChanges
+
+// Hash returns the keccak256 hash of the RLP encoding of the access list, as
+// committed to by the block header.
+func (bal BlockAccessList) Hash() common.Hash {
+	return rlpHash(bal)
+}
+
+// AccountChanges is the set of accesses and modifications of a single account.
+type AccountChanges struct {
+	Address        common.Address  `json:"address"`
+	StorageChanges []SlotChanges   `json:"storageChanges"`
+	StorageReads   []common.Hash   `json:"storageReads"`
+	BalanceChanges []BalanceChange `json:"balanceChanges"`
+	NonceChanges   []NonceChange   `json:"nonceChanges"`
+	CodeChanges    []CodeChange    `json:"codeChanges"`
+}
+
+// SlotChanges is the list of modifications of a single storage slot.
+type SlotChanges struct {
+	Slot    common.Hash     `json:"slot"`
+	Changes []StorageChange `json:"changes"`
+}
+
+// StorageChange is the post-value of a storage slot modified at an index.
+type StorageChange struct {
+	Index uint16      `json:"blockAccessIndex"`
+	Value common.Hash `json:"postValue"`
+}
+
+type storageChangeMarshaling struct {
+	Index hexutil.Uint64
+}
+
+// BalanceChange is the post-balance of an account modified at an index.
+type BalanceChange struct {
+	Index   uint16       `json:"blockAccessIndex"`
+	Balance *uint256.Int `json:"postBalance"`
+}
+
+type balanceChangeMarshaling struct {
+	Index   hexutil.Uint64
+	Balance *hexutil.U256
+}
+
+// NonceChange is the post-nonce of an account modified at an index.
+type NonceChange struct {
+	Index uint16 `json:"blockAccessIndex"`
+	Nonce uint64 `json:"postNonce"`
+}
+
+type nonceChangeMarshaling struct {
+	Index hexutil.Uint64
+	Nonce hexutil.Uint64
+}
+
+// CodeChange is the post-code of an account modified at an index.
+type CodeChange struct {
+	Index uint16 `json:"blockAccessIndex"`
+	Code  []byte `json:"newCode"`
+}
+
+type codeChangeMarshaling struct {
+	Index hexutil.Uint64
+	Code  hexutil.Bytes
+}
+
+// accountAccess is the mutable counterpart of AccountChanges used while an
+// access list is being built.
+type accountAccess struct {
+	storageWrites map[common.Hash]map[uint16]common.Hash
+	storageReads  map[common.Hash]struct{}
+	balances      map[uint16]*uint256.Int
+	nonces        map[uint16]uint64
+	codes         map[uint16][]byte
+}
+
+func newAccountAccess() *accountAccess {
+	return &accountAccess{
+		storageWrites: make(map[common.Hash]map[uint16]common.Hash),
+		storageReads:  make(map[common.Hash]struct{}),
+		balances:      make(map[uint16]*uint256.Int),
+		nonces:        make(map[uint16]uint64),
+		codes:         make(map[uint16][]byte),
+	}
+}
+
+// BlockAccessListBuilder accumulates the accesses and modifications made while
+// executing a block into a block access list. Recording a modification at an
+// index replaces any previous one recorded at the same index. It is not safe
+// for concurrent use.
+type BlockAccessListBuilder struct {
+	accounts map[common.Address]*accountAccess
+}
+
+// NewBlockAccessListBuilder creates an empty access list builder.
+func NewBlockAccessListBuilder() *BlockAccessListBuilder {
+	return &BlockAccessListBuilder{accounts: make(map[common.Address]*accountAccess)}
+}
+
+func (b *BlockAccessListBuilder) account(addr common.Address) *accountAccess {
+	acc, ok := b.accounts[addr]
+	if !ok {
+		acc = newAccountAccess()
+		b.accounts[addr] = acc
+	}
+	return acc
+}
+
+// AccountRead records an access to the given account.
+func (b *BlockAccessListBuilder) AccountRead(addr common.Address) {
+	b.account(addr)
+}
+
+// StorageRead records an access to the given storage slot.
+func (b *BlockAccessListBuilder) StorageRead(addr common.Address, slot common.Hash) {
+	b.account(addr).storageReads[slot] = struct{}{}
+}
+
+// StorageWrite records the post-value of a storage slot modified at the index.
+func (b *BlockAccessListBuilder) StorageWrite(index uint16, addr common.Address, slot common.Hash, value common.Hash) {
+	acc := b.account(addr)
+	if acc.storageWrites[slot] == nil {
+		acc.storageWrites[slot] = make(map[uint16]common.Hash)
+	}
+	acc.storageWrites[slot][index] = value
+}
+
+// BalanceChange records the post-balance of an account modified at the index.
+func (b *BlockAccessListBuilder) BalanceChange(index uint16, addr common.Address, balance *uint256.Int) {
+	b.account(addr).balances[index] = balance.Clone()
+}
+
+// NonceChange records the post-nonce of an account modified at the index.
+func (b *BlockAccessListBuilder) NonceChange(index uint16, addr common.Address, nonce uint64) {
+	b.account(addr).nonces[index] = nonce
+}
+
+// CodeChange records the post-code of an account modified at the index.
+func (b *BlockAccessListBuilder) CodeChange(index uint16, addr common.Address, code []byte) {
+	b.account(addr).codes[index] = bytes.Clone(code)
+}
+
+// latest returns the value recorded at the highest index, if any.
+func latest[V any](changes map[uint16]V) (V, bool) {
+	var (
+		value V
+		found bool
+		index uint16
+	)
+	for i, v := range changes {
+		if !found || i > index {
+			value, index, found = v, i, true
+		}
+	}
+	return value, found
+}
+
+// LatestStorage returns the most recently recorded value of a storage slot.
+func (b *BlockAccessListBuilder) LatestStorage(addr common.Address, slot common.Hash) (common.Hash, bool) {
+	if acc, ok := b.accounts[addr]; ok {
+		return latest(acc.storageWrites[slot])
+	}
+	return common.Hash{}, false
+}
+
+// LatestBalance returns the most recently recorded balance of an account.
+func (b *BlockAccessListBuilder) LatestBalance(addr common.Address) (*uint256.Int, bool) {
+	if acc, ok := b.accounts[addr]; ok {
+		return latest(acc.balances)
+	}
+	return nil, false
+}
+
+// LatestNonce returns the most recently recorded nonce of an account.
+func (b *BlockAccessListBuilder) LatestNonce(addr common.Address) (uint64, bool) {
+	if acc, ok := b.accounts[addr]; ok {
+		return latest(acc.nonces)
+	}
+	return 0, false
+}
+
+// LatestCode returns the most recently recorded code of an account.
+func (b *BlockAccessListBuilder) LatestCode(addr common.Address) ([]byte, bool) {
+	if acc, ok := b.accounts[addr]; ok {
+		return latest(acc.codes)
+	}
+	return nil, false
+}
+
+// Copy returns a deep copy of the builder.
+func (b *BlockAccessListBuilder) Copy() *BlockAccessListBuilder {
+	cpy := NewBlockAccessListBuilder()
+	cpy.Merge(b)
+	return cpy
+}
+
+// Merge copies all the accesses and modifications recorded by another builder
+// into this one.
+func (b *BlockAccessListBuilder) Merge(other *BlockAccessListBuilder) {
+	for addr, src := range other.accounts {
+		dst := b.account(addr)
+		for slot, writes := range src.storageWrites {
+			for index, value := range writes {
+				b.StorageWrite(index, addr, slot, value)
+			}
+		}
+		maps.Copy(dst.storageReads, src.storageReads)
+		maps.Copy(dst.balances, src.balances)
+		maps.Copy(dst.nonces, src.nonces)
+		maps.Copy(dst.codes, src.codes)
+	}
+}
+
+// MergeIndex copies all the accesses recorded by another builder into this one,
+// but only the modifications recorded at the given index.
+func (b *BlockAccessListBuilder) MergeIndex(other *BlockAccessListBuilder, index uint16) {
+	for addr, src := range other.accounts {
+		dst := b.account(addr)
+		for slot, writes := range src.storageWrites {
+			if value, ok := writes[index]; ok {
+				b.StorageWrite(index, addr, slot, value)
+			}
+		}
+		maps.Copy(dst.storageReads, src.storageReads)
+		if balance, ok := src.balances[index]; ok {
+			dst.balances[index] = balance
+		}
+		if nonce, ok := src.nonces[index]; ok {
+			dst.nonces[index] = nonce
+		}
+		if code, ok := src.codes[index]; ok {
+			dst.codes[index] = code
+		}
+	}
+}
+
+// Build assembles the canonical block access list: accounts are sorted by
+// address, slots by key and changes by index. Slots that are modified are
+// not listed among the reads.
+func (b *BlockAccessListBuilder) Build() BlockAccessList {
+	bal := make(BlockAccessList, 0, len(b.accounts))
+	for _, addr := range slices.SortedFunc(maps.Keys(b.accounts), common.Address.Cmp) {
+		acc := b.accounts[addr]
+		changes := AccountChanges{
+			Address:        addr,
+			StorageChanges: []SlotChanges{},
+			StorageReads:   []common.Hash{},
+			BalanceChanges: []BalanceChange{},
+			NonceChanges:   []NonceChange{},
+			CodeChanges:    []CodeChange{},
+		}
+		for _, slot := range slices.SortedFunc(maps.Keys(acc.storageWrites), common.Hash.Cmp) {
+			writes := acc.storageWrites[slot]
+			slotChanges := SlotChanges{Slot: slot}
+			for _, index := range slices.Sorted(maps.Keys(writes)) {
+				slotChanges.Changes = append(slotChanges.Changes, StorageChange{Index: index, Value: writes[index]})
+			}
+			changes.StorageChanges = append(changes.StorageChanges, slotChanges)
+		}
+		for _, slot := range slices.SortedFunc(maps.Keys(acc.storageReads), common.Hash.Cmp) {
+			if _, written := acc.storageWrites[slot]; !written {
+				changes.StorageReads = append(changes.StorageReads, slot)
+			}
+		}
+		for _, index := range slices.Sorted(maps.Keys(acc.balances)) {
+			changes.BalanceChanges = append(changes.BalanceChanges, BalanceChange{Index: index, Balance: acc.balances[index]})
+		}
+		for _, index := range slices.Sorted(maps.Keys(acc.nonces)) {
+			changes.NonceChanges = append(changes.NonceChanges, NonceChange{Index: index, Nonce: acc.nonces[index]})
+		}
+		for _, index := range slices.Sorted(maps.Keys(acc.codes)) {
+			changes.CodeChanges = append(changes.CodeChanges, CodeChange{Index: index, Code: acc.codes[index]})
+		}
+		bal = append(bal, changes)
+	}
+	return bal
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
+)
+
+func makeTestAccessList() BlockAccessList {
+	var (
+		addr1 = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		addr2 = common.HexToAddress("0x2222222222222222222222222222222222222222")
+		addr3 = common.HexToAddress("0x3333333333333333333333333333333333333333")
+		slot1 = common.HexToHash("0x01")
+		slot2 = common.HexToHash("0x02")
+		b     = NewBlockAccessListBuilder()
+	)
+	// Record out of order to check the canonical ordering
+	b.AccountRead(addr3)
+	b.StorageWrite(2, addr2, slot2, common.HexToHash("0xbb"))
+	b.StorageWrite(1, addr2, slot2, common.HexToHash("0xaa"))
+	b.StorageRead(addr2, slot2)
+	b.StorageRead(addr2, slot1)
+	b.BalanceChange(1, addr1, uint256.NewInt(100))
+	b.BalanceChange(0, addr1, uint256.NewInt(200))
+	b.NonceChange(1, addr1, 1)
+	b.CodeChange(2, addr2, []byte{0x60, 0x00})
+	return b.Build()
+}
+
+func TestBlockAccessListBuild(t *testing.T) {
+	bal := makeTestAccessList()
+	if len(bal) != 3 {
+		t.Fatalf("account count mismatch: have %d, want 3", len(bal))
+	}
+	for i := 1; i < len(bal); i++ {
+		if bal[i-1].Address.Cmp(bal[i].Address) >= 0 {
+			t.Fatalf("accounts not sorted: %x before %x", bal[i-1].Address, bal[i].Address)
+		}
+	}
+	acc1, acc2, acc3 := bal[0], bal[1], bal[2]
+	if want := []BalanceChange{{0, uint256.NewInt(200)}, {1, uint256.NewInt(100)}}; !reflect.DeepEqual(acc1.BalanceChanges, want) {
+		t.Errorf("balance changes mismatch: have %v, want %v", acc1.BalanceChanges, want)
+	}
+	if want := []NonceChange{{1, 1}}; !reflect.DeepEqual(acc1.NonceChanges, want) {
+		t.Errorf("nonce changes mismatch: have %v, want %v", acc1.NonceChanges, want)
+	}
+	if len(acc2.StorageChanges) != 1 || len(acc2.StorageChanges[0].Changes) != 2 || acc2.StorageChanges[0].Changes[0].Index != 1 {
+		t.Errorf("storage changes mismatch: %v", acc2.StorageChanges)
+	}
+	// The written slot must not be listed among the reads
+	if want := []common.Hash{common.HexToHash("0x01")}; !reflect.DeepEqual(acc2.StorageReads, want) {
+		t.Errorf("storage reads mismatch: have %v, want %v", acc2.StorageReads, want)
+	}
+	if len(acc3.StorageChanges)+len(acc3.StorageReads)+len(acc3.BalanceChanges)+len(acc3.NonceChanges)+len(acc3.CodeChanges) != 0 {
+		t.Errorf("read-only account has changes: %v", acc3)
+	}
+}
+
+func TestBlockAccessListBuilderMerge(t *testing.T) {
+	var (
+		addr = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		a    = NewBlockAccessListBuilder()
+		b    = NewBlockAccessListBuilder()
+	)
+	a.BalanceChange(1, addr, uint256.NewInt(1))
+	b.BalanceChange(2, addr, uint256.NewInt(2))
+	b.StorageWrite(2, addr, common.Hash{}, common.HexToHash("0x01"))
+
+	merged := a.Copy()
+	merged.Merge(b)
+	if balance, _ := merged.LatestBalance(addr); balance.Uint64() != 2 {
+		t.Errorf("latest balance mismatch: have %v, want 2", balance)
+	}
+	if value, ok := merged.LatestStorage(addr, common.Hash{}); !ok || value != common.HexToHash("0x01") {
+		t.Errorf("latest storage mismatch: have %x, want 1", value)
+	}
+	// The copy must be independent of the original
+	if balance, _ := a.LatestBalance(addr); balance.Uint64() != 1 {
+		t.Errorf("original builder modified: balance %v", balance)
+	}
+}
+
+func TestBlockAccessListEncoding(t *testing.T) {
+	bal := makeTestAccessList()
+
+	// RLP round trip
+	enc, err := rlp.EncodeToBytes(bal)
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	var dec BlockAccessList
+	if err := rlp.DecodeBytes(enc, &dec); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	if dec.Hash() != bal.Hash() {
+		t.Fatalf("rlp round trip hash mismatch: have %x, want %x", dec.Hash(), bal.Hash())
+	}
+	// JSON round trip
+	blob, err := json.Marshal(bal)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	var jdec BlockAccessList
+	if err := json.Unmarshal(blob, &jdec); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if jdec.Hash() != bal.Hash() {
+		t.Fatalf("json round trip hash mismatch: have %x, want %x", jdec.Hash(), bal.Hash())
+	}
+}
+
+func TestBlockAccessListInBody(t *testing.T) {
+	var (
+		bal    = makeTestAccessList()
+		zero   uint64
+		header = &Header{
+			Number:           common.Big1,
+			Difficulty:       common.Big0,
+			BaseFee:          common.Big1,
+			BlobGasUsed:      &zero,
+			ExcessBlobGas:    &zero,
+			ParentBeaconRoot: &common.Hash{},
+			RequestsHash:     &EmptyRequestsHash,
+		}
+		block = NewBlock(header, &Body{Withdrawals: []*Withdrawal{}, AccessList: bal}, nil, nil)
+	)
+	if hash := block.BlockAccessListHash(); hash == nil || *hash != bal.Hash() {
+		t.Fatalf("header access list hash mismatch: have %v, want %x", hash, bal.Hash())
+	}
+	enc, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		t.Fatalf("failed to encode block: %v", err)
+	}
+	var dec Block
+	if err := rlp.DecodeBytes(enc, &dec); err != nil {
+		t.Fatalf("failed to decode block: %v", err)
+	}
+	if dec.Hash() != block.Hash() {
+		t.Fatalf("block hash mismatch: have %x, want %x", dec.Hash(), block.Hash())
+	}
+	if dec.AccessList().Hash() != bal.Hash() {
+		t.Fatalf("body access list mismatch")
+	}
+}
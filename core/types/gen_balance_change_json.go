@@ -0,0 +1,43 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/holiman/uint256"
+)
+
+var _ = (*balanceChangeMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (b BalanceChange) MarshalJSON() ([]byte, error) {
+	type BalanceChange struct {
+		Index   hexutil.Uint64 `json:"blockAccessIndex"`
+		Balance *hexutil.U256  `json:"postBalance"`
+	}
+	var enc BalanceChange
+	enc.Index = hexutil.Uint64(b.Index)
+	enc.Balance = (*hexutil.U256)(b.Balance)
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (b *BalanceChange) UnmarshalJSON(input []byte) error {
+	type BalanceChange struct {
+		Index   *hexutil.Uint64 `json:"blockAccessIndex"`
+		Balance *hexutil.U256   `json:"postBalance"`
+	}
+	var dec BalanceChange
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Index != nil {
+		b.Index = uint16(*dec.Index)
+	}
+	if dec.Balance != nil {
+		b.Balance = (*uint256.Int)(dec.Balance)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*nonceChangeMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (n NonceChange) MarshalJSON() ([]byte, error) {
+	type NonceChange struct {
+		Index hexutil.Uint64 `json:"blockAccessIndex"`
+		Nonce hexutil.Uint64 `json:"postNonce"`
+	}
+	var enc NonceChange
+	enc.Index = hexutil.Uint64(n.Index)
+	enc.Nonce = hexutil.Uint64(n.Nonce)
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (n *NonceChange) UnmarshalJSON(input []byte) error {
+	type NonceChange struct {
+		Index *hexutil.Uint64 `json:"blockAccessIndex"`
+		Nonce *hexutil.Uint64 `json:"postNonce"`
+	}
+	var dec NonceChange
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Index != nil {
+		n.Index = uint16(*dec.Index)
+	}
+	if dec.Nonce != nil {
+		n.Nonce = uint64(*dec.Nonce)
+	}
+	return nil
+}
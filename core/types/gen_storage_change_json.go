@@ -0,0 +1,43 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*storageChangeMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (s StorageChange) MarshalJSON() ([]byte, error) {
+	type StorageChange struct {
+		Index hexutil.Uint64 `json:"blockAccessIndex"`
+		Value common.Hash    `json:"postValue"`
+	}
+	var enc StorageChange
+	enc.Index = hexutil.Uint64(s.Index)
+	enc.Value = s.Value
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (s *StorageChange) UnmarshalJSON(input []byte) error {
+	type StorageChange struct {
+		Index *hexutil.Uint64 `json:"blockAccessIndex"`
+		Value *common.Hash    `json:"postValue"`
+	}
+	var dec StorageChange
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Index != nil {
+		s.Index = uint16(*dec.Index)
+	}
+	if dec.Value != nil {
+		s.Value = *dec.Value
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+//go:generate go run github.com/fjl/gencodec -type TransactionConditional -field-override transactionConditionalMarshaling -out gen_transaction_conditional_json.go
+
+var (
+	// ErrConditionalBlockNumber is returned if the block a transaction would be
+	// included in is outside of the block number range it was submitted with.
+	ErrConditionalBlockNumber = errors.New("block number out of conditional range")
+
+	// ErrConditionalTimestamp is returned if the block a transaction would be
+	// included in is outside of the timestamp range it was submitted with.
+	ErrConditionalTimestamp = errors.New("timestamp out of conditional range")
+
+	// ErrConditionalKnownAccount is returned if the storage of an account does
+	// not match the one a transaction was submitted with.
+	ErrConditionalKnownAccount = errors.New("known account storage mismatch")
+)
+
+// KnownAccount is the expected storage of an account, given either as the root
+// of its storage trie or as the values of a set of its storage slots.
+type KnownAccount struct {
+	StorageRoot  *common.Hash
+	StorageSlots map[common.Hash]common.Hash
+}
+
+// MarshalJSON encodes the storage root as a hash, or the slots as an object.
+func (ka KnownAccount) MarshalJSON() ([]byte, error) {
+	if ka.StorageRoot != nil {
+		return json.Marshal(ka.StorageRoot)
+	}
+	return json.Marshal(ka.StorageSlots)
+}
+
+// UnmarshalJSON decodes either a storage root hash or an object of slots.
+func (ka *KnownAccount) UnmarshalJSON(input []byte) error {
+	var root common.Hash
+	if err := json.Unmarshal(input, &root); err == nil {
+		*ka = KnownAccount{StorageRoot: &root}
+		return nil
+	}
+	var slots map[common.Hash]common.Hash
+	if err := json.Unmarshal(input, &slots); err != nil {
+		return errors.New("known account must be a storage root or an object of storage slots")
+	}
+	*ka = KnownAccount{StorageSlots: slots}
+	return nil
+}
+
+// KnownAccounts is the set of accounts whose storage is expected to match for
+// a conditional transaction to be included.
+type KnownAccounts map[common.Address]KnownAccount
+
+// TransactionConditional is the set of conditions a transaction was submitted
+// with, which must all hold for it to be included in a block.
+type TransactionConditional struct {
+	KnownAccounts  KnownAccounts `json:"knownAccounts"`
+	BlockNumberMin *big.Int      `json:"blockNumberMin,omitempty"`
+	BlockNumberMax *big.Int      `json:"blockNumberMax,omitempty"`
+	TimestampMin   *uint64       `json:"timestampMin,omitempty"`
+	TimestampMax   *uint64       `json:"timestampMax,omitempty"`
+}
+
+type transactionConditionalMarshaling struct {
+	BlockNumberMin *hexutil.Big
+	BlockNumberMax *hexutil.Big
+	TimestampMin   *hexutil.Uint64
+	TimestampMax   *hexutil.Uint64
+}
+
+// Cost returns the number of storage checks needed to verify the conditions.
+func (c *TransactionConditional) Cost() int {
+	var cost int
+	for _, account := range c.KnownAccounts {
+		if account.StorageRoot != nil {
+			cost++
+		} else {
+			cost += len(account.StorageSlots)
+		}
+	}
+	return cost
+}
+
+// Validate checks that the ranges of the conditions are well formed.
+func (c *TransactionConditional) Validate() error {
+	if c.BlockNumberMin != nil && c.BlockNumberMax != nil && c.BlockNumberMin.Cmp(c.BlockNumberMax) > 0 {
+		return fmt.Errorf("block number minimum %v above maximum %v", c.BlockNumberMin, c.BlockNumberMax)
+	}
+	if c.TimestampMin != nil && c.TimestampMax != nil && *c.TimestampMin > *c.TimestampMax {
+		return fmt.Errorf("timestamp minimum %d above maximum %d", *c.TimestampMin, *c.TimestampMax)
+	}
+	return nil
+}
+
+// CheckBlock checks whether the block number and timestamp conditions hold for
+// a block with the given number and timestamp.
+func (c *TransactionConditional) CheckBlock(number *big.Int, time uint64) error {
+	if c.BlockNumberMin != nil && number.Cmp(c.BlockNumberMin) < 0 {
+		return fmt.Errorf("%w: have %v, min %v", ErrConditionalBlockNumber, number, c.BlockNumberMin)
+	}
+	if c.BlockNumberMax != nil && number.Cmp(c.BlockNumberMax) > 0 {
+		return fmt.Errorf("%w: have %v, max %v", ErrConditionalBlockNumber, number, c.BlockNumberMax)
+	}
+	if c.TimestampMin != nil && time < *c.TimestampMin {
+		return fmt.Errorf("%w: have %d, min %d", ErrConditionalTimestamp, time, *c.TimestampMin)
+	}
+	if c.TimestampMax != nil && time > *c.TimestampMax {
+		return fmt.Errorf("%w: have %d, max %d", ErrConditionalTimestamp, time, *c.TimestampMax)
+	}
+	return nil
+}
+
+// Expired reports whether the block number or timestamp conditions can no longer
+// hold for any block following the one with the given number and timestamp.
+func (c *TransactionConditional) Expired(number *big.Int, time uint64) bool {
+	if c.BlockNumberMax != nil && number.Cmp(c.BlockNumberMax) >= 0 {
+		return true
+	}
+	return c.TimestampMax != nil && time >= *c.TimestampMax
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestTransactionConditionalJSON(t *testing.T) {
+	input := `{
+		"knownAccounts": {
+			"0x1111111111111111111111111111111111111111": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+			"0x2222222222222222222222222222222222222222": {
+				"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000002"
+			}
+		},
+		"blockNumberMin": "0x1",
+		"blockNumberMax": "0x10",
+		"timestampMax": "0x64"
+	}`
+	var cond TransactionConditional
+	if err := json.Unmarshal([]byte(input), &cond); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	var (
+		timestamp = uint64(100)
+		want      = TransactionConditional{
+			KnownAccounts: KnownAccounts{
+				common.HexToAddress("0x1111111111111111111111111111111111111111"): {StorageRoot: &EmptyRootHash},
+				common.HexToAddress("0x2222222222222222222222222222222222222222"): {StorageSlots: map[common.Hash]common.Hash{
+					common.HexToHash("0x01"): common.HexToHash("0x02"),
+				}},
+			},
+			BlockNumberMin: big.NewInt(1),
+			BlockNumberMax: big.NewInt(16),
+			TimestampMax:   &timestamp,
+		}
+	)
+	if !reflect.DeepEqual(cond, want) {
+		t.Fatalf("conditional mismatch: have %+v, want %+v", cond, want)
+	}
+	if cost := cond.Cost(); cost != 2 {
+		t.Fatalf("cost mismatch: have %d, want 2", cost)
+	}
+	// Round trip through the encoder
+	blob, err := json.Marshal(cond)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	var dec TransactionConditional
+	if err := json.Unmarshal(blob, &dec); err != nil {
+		t.Fatalf("failed to unmarshal encoded conditional: %v", err)
+	}
+	if !reflect.DeepEqual(dec, want) {
+		t.Fatalf("round trip mismatch: have %+v, want %+v", dec, want)
+	}
+	if err := json.Unmarshal([]byte(`{"knownAccounts": {"0x1111111111111111111111111111111111111111": 1}}`), &dec); err == nil {
+		t.Fatal("invalid known account accepted")
+	}
+}
+
+func TestTransactionConditionalCheckBlock(t *testing.T) {
+	var (
+		minTime = uint64(10)
+		maxTime = uint64(20)
+		cond    = &TransactionConditional{
+			BlockNumberMin: big.NewInt(5),
+			BlockNumberMax: big.NewInt(10),
+			TimestampMin:   &minTime,
+			TimestampMax:   &maxTime,
+		}
+	)
+	tests := []struct {
+		number  int64
+		time    uint64
+		err     error
+		expired bool
+	}{
+		{4, 15, ErrConditionalBlockNumber, false},
+		{5, 15, nil, false},
+		{10, 15, nil, true},
+		{11, 15, ErrConditionalBlockNumber, true},
+		{7, 9, ErrConditionalTimestamp, false},
+		{7, 20, nil, true},
+		{7, 21, ErrConditionalTimestamp, true},
+	}
+	for i, tt := range tests {
+		if err := cond.CheckBlock(big.NewInt(tt.number), tt.time); !errors.Is(err, tt.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+		if expired := cond.Expired(big.NewInt(tt.number), tt.time); expired != tt.expired {
+			t.Errorf("test %d: expiry mismatch: have %v, want %v", i, expired, tt.expired)
+		}
+	}
+}
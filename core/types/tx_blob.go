@@ -19,6 +19,7 @@ package types
 import (
 	"bytes"
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -53,13 +54,34 @@ type BlobTx struct {
 	S *uint256.Int
 }
 
+const (
+	// BlobSidecarVersion0 is the sidecar version introduced by EIP-4844, which
+	// carries a single KZG proof per blob.
+	BlobSidecarVersion0 = byte(0)
+
+	// BlobSidecarVersion1 is the sidecar version introduced by EIP-7594, which
+	// carries a KZG proof for each cell of the extended blob.
+	BlobSidecarVersion1 = byte(1)
+)
+
 // BlobTxSidecar contains the blobs of a blob transaction.
 type BlobTxSidecar struct {
+	Version     byte                 // Sidecar version, determines the proof type
 	Blobs       []kzg4844.Blob       // Blobs needed by the blob pool
 	Commitments []kzg4844.Commitment // Commitments needed by the blob pool
 	Proofs      []kzg4844.Proof      // Proofs needed by the blob pool
 }
 
+// NewBlobTxSidecar initialises the BlobTxSidecar object with the provided parameters.
+func NewBlobTxSidecar(version byte, blobs []kzg4844.Blob, commitments []kzg4844.Commitment, proofs []kzg4844.Proof) *BlobTxSidecar {
+	return &BlobTxSidecar{
+		Version:     version,
+		Blobs:       blobs,
+		Commitments: commitments,
+		Proofs:      proofs,
+	}
+}
+
 // BlobHashes computes the blob hashes of the given blobs.
 func (sc *BlobTxSidecar) BlobHashes() []common.Hash {
 	hasher := sha256.New()
@@ -83,7 +105,61 @@ func (sc *BlobTxSidecar) encodedSize() uint64 {
 	for i := range sc.Proofs {
 		proofs += rlp.BytesSize(sc.Proofs[i][:])
 	}
-	return rlp.ListSize(blobs) + rlp.ListSize(commitments) + rlp.ListSize(proofs)
+	size := rlp.ListSize(blobs) + rlp.ListSize(commitments) + rlp.ListSize(proofs)
+	if sc.Version != BlobSidecarVersion0 {
+		size += uint64(rlp.IntSize(uint64(sc.Version)))
+	}
+	return size
+}
+
+// CellProofsAt returns the cell proofs of the blob at the given index. It is
+// only available for version 1 sidecars.
+func (sc *BlobTxSidecar) CellProofsAt(idx int) ([]kzg4844.Proof, error) {
+	if sc.Version != BlobSidecarVersion1 {
+		return nil, fmt.Errorf("cell proofs unsupported, sidecar version %d", sc.Version)
+	}
+	if idx < 0 || idx >= len(sc.Blobs) {
+		return nil, fmt.Errorf("blob index %d out of range [0, %d)", idx, len(sc.Blobs))
+	}
+	index := idx * kzg4844.CellProofsPerBlob
+	if len(sc.Proofs) < index+kzg4844.CellProofsPerBlob {
+		return nil, fmt.Errorf("cell proofs for blob %d missing, have %d proofs", idx, len(sc.Proofs))
+	}
+	return sc.Proofs[index : index+kzg4844.CellProofsPerBlob], nil
+}
+
+// ToV1 converts the sidecar in place from version 0 to version 1, replacing
+// the blob proofs with freshly computed cell proofs. Version 1 sidecars are
+// left untouched.
+func (sc *BlobTxSidecar) ToV1() error {
+	switch sc.Version {
+	case BlobSidecarVersion1:
+		return nil
+	case BlobSidecarVersion0:
+	default:
+		return fmt.Errorf("unknown sidecar version %d", sc.Version)
+	}
+	proofs := make([]kzg4844.Proof, 0, len(sc.Blobs)*kzg4844.CellProofsPerBlob)
+	for i := range sc.Blobs {
+		cellProofs, err := kzg4844.ComputeCellProofs(&sc.Blobs[i])
+		if err != nil {
+			return err
+		}
+		proofs = append(proofs, cellProofs...)
+	}
+	sc.Version = BlobSidecarVersion1
+	sc.Proofs = proofs
+	return nil
+}
+
+// Copy returns a deep copy of the sidecar.
+func (sc *BlobTxSidecar) Copy() *BlobTxSidecar {
+	return &BlobTxSidecar{
+		Version:     sc.Version,
+		Blobs:       append([]kzg4844.Blob(nil), sc.Blobs...),
+		Commitments: append([]kzg4844.Commitment(nil), sc.Commitments...),
+		Proofs:      append([]kzg4844.Proof(nil), sc.Proofs...),
+	}
 }
 
 // ValidateBlobCommitmentHashes checks whether the given hashes correspond to the
@@ -110,6 +186,17 @@ type blobTxWithBlobs struct {
 	Proofs      []kzg4844.Proof
 }
 
+// blobTxWithBlobsV1 is used for encoding of transactions when blobs are present
+// with cell proofs. The network encoding is distinguished from the version 0
+// one by the wrapper version preceding the blobs.
+type blobTxWithBlobsV1 struct {
+	BlobTx      *BlobTx
+	Version     byte
+	Blobs       []kzg4844.Blob
+	Commitments []kzg4844.Commitment
+	Proofs      []kzg4844.Proof
+}
+
 // copy creates a deep copy of the transaction data and initializes all fields.
 func (tx *BlobTx) copy() TxData {
 	cpy := &BlobTx{
@@ -157,11 +244,7 @@ func (tx *BlobTx) copy() TxData {
 		cpy.S.Set(tx.S)
 	}
 	if tx.Sidecar != nil {
-		cpy.Sidecar = &BlobTxSidecar{
-			Blobs:       append([]kzg4844.Blob(nil), tx.Sidecar.Blobs...),
-			Commitments: append([]kzg4844.Commitment(nil), tx.Sidecar.Commitments...),
-			Proofs:      append([]kzg4844.Proof(nil), tx.Sidecar.Proofs...),
-		}
+		cpy.Sidecar = tx.Sidecar.Copy()
 	}
 	return cpy
 }
@@ -215,16 +298,30 @@ func (tx *BlobTx) withSidecar(sideCar *BlobTxSidecar) *BlobTx {
 }
 
 func (tx *BlobTx) encode(b *bytes.Buffer) error {
-	if tx.Sidecar == nil {
+	switch {
+	case tx.Sidecar == nil:
 		return rlp.Encode(b, tx)
+
+	case tx.Sidecar.Version == BlobSidecarVersion0:
+		return rlp.Encode(b, &blobTxWithBlobs{
+			BlobTx:      tx,
+			Blobs:       tx.Sidecar.Blobs,
+			Commitments: tx.Sidecar.Commitments,
+			Proofs:      tx.Sidecar.Proofs,
+		})
+
+	case tx.Sidecar.Version == BlobSidecarVersion1:
+		return rlp.Encode(b, &blobTxWithBlobsV1{
+			BlobTx:      tx,
+			Version:     tx.Sidecar.Version,
+			Blobs:       tx.Sidecar.Blobs,
+			Commitments: tx.Sidecar.Commitments,
+			Proofs:      tx.Sidecar.Proofs,
+		})
+
+	default:
+		return fmt.Errorf("%w: %d", errUnsupportedSidecarVersion, tx.Sidecar.Version)
 	}
-	inner := &blobTxWithBlobs{
-		BlobTx:      tx,
-		Blobs:       tx.Sidecar.Blobs,
-		Commitments: tx.Sidecar.Commitments,
-		Proofs:      tx.Sidecar.Proofs,
-	}
-	return rlp.Encode(b, inner)
 }
 
 func (tx *BlobTx) decode(input []byte) error {
@@ -246,20 +343,39 @@ func (tx *BlobTx) decode(input []byte) error {
 	if firstElemKind != rlp.List {
 		return rlp.DecodeBytes(input, tx)
 	}
-	// It's a tx with blobs.
-	var inner blobTxWithBlobs
+	// It's a tx with blobs. The version 0 encoding continues with the list of
+	// blobs, whereas later versions are tagged by a wrapper version first.
+	_, _, rest, err := rlp.Split(outerList)
+	if err != nil {
+		return err
+	}
+	secondElemKind, _, _, err := rlp.Split(rest)
+	if err != nil {
+		return err
+	}
+	if secondElemKind == rlp.List {
+		var inner blobTxWithBlobs
+		if err := rlp.DecodeBytes(input, &inner); err != nil {
+			return err
+		}
+		*tx = *inner.BlobTx
+		tx.Sidecar = NewBlobTxSidecar(BlobSidecarVersion0, inner.Blobs, inner.Commitments, inner.Proofs)
+		return nil
+	}
+	var inner blobTxWithBlobsV1
 	if err := rlp.DecodeBytes(input, &inner); err != nil {
 		return err
 	}
-	*tx = *inner.BlobTx
-	tx.Sidecar = &BlobTxSidecar{
-		Blobs:       inner.Blobs,
-		Commitments: inner.Commitments,
-		Proofs:      inner.Proofs,
+	if inner.Version != BlobSidecarVersion1 {
+		return fmt.Errorf("%w: %d", errUnsupportedSidecarVersion, inner.Version)
 	}
+	*tx = *inner.BlobTx
+	tx.Sidecar = NewBlobTxSidecar(inner.Version, inner.Blobs, inner.Commitments, inner.Proofs)
 	return nil
 }
 
+var errUnsupportedSidecarVersion = errors.New("unsupported blob sidecar version")
+
 func (tx *BlobTx) sigHash(chainID *big.Int) common.Hash {
 	return prefixedRlpHash(
 		BlobTxType,
@@ -74,6 +74,62 @@ func TestBlobTxSize(t *testing.T) {
 	}
 }
 
+// This test verifies that version 1 sidecars survive the network encoding and
+// that conversion from version 0 computes the cell proofs.
+func TestBlobTxSidecarV1(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	withBlobs := createEmptyBlobTx(key, true)
+
+	sidecar := withBlobs.BlobTxSidecar().Copy()
+	if _, err := sidecar.CellProofsAt(0); err == nil {
+		t.Fatal("cell proofs available on version 0 sidecar")
+	}
+	if err := sidecar.ToV1(); err != nil {
+		t.Fatalf("failed to convert sidecar: %v", err)
+	}
+	if sidecar.Version != BlobSidecarVersion1 {
+		t.Fatalf("wrong sidecar version: have %d, want %d", sidecar.Version, BlobSidecarVersion1)
+	}
+	proofs, err := sidecar.CellProofsAt(0)
+	if err != nil {
+		t.Fatalf("failed to retrieve cell proofs: %v", err)
+	}
+	if len(proofs) != kzg4844.CellProofsPerBlob {
+		t.Fatalf("wrong cell proof count: have %d, want %d", len(proofs), kzg4844.CellProofsPerBlob)
+	}
+	if err := kzg4844.VerifyCellProofs(sidecar.Blobs, sidecar.Commitments, sidecar.Proofs); err != nil {
+		t.Fatalf("failed to verify converted cell proofs: %v", err)
+	}
+	if withBlobs.BlobTxSidecar().Version != BlobSidecarVersion0 {
+		t.Fatal("conversion modified the original sidecar")
+	}
+	// Round-trip the converted transaction through the network encoding
+	converted := withBlobs.WithBlobTxSidecar(sidecar)
+	enc, err := converted.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to encode transaction: %v", err)
+	}
+	if size := converted.Size(); size != uint64(len(enc)) {
+		t.Errorf("wrong size with cell proofs: %d, encoded length: %d", size, len(enc))
+	}
+	var decoded Transaction
+	if err := decoded.UnmarshalBinary(enc); err != nil {
+		t.Fatalf("failed to decode transaction: %v", err)
+	}
+	if decoded.Hash() != withBlobs.Hash() {
+		t.Fatalf("wrong tx hash after decoding: %v", decoded.Hash())
+	}
+	have := decoded.BlobTxSidecar()
+	if have == nil || have.Version != BlobSidecarVersion1 || len(have.Proofs) != len(sidecar.Proofs) {
+		t.Fatalf("sidecar mismatch after decoding: %+v", have)
+	}
+	for i := range have.Proofs {
+		if have.Proofs[i] != sidecar.Proofs[i] {
+			t.Fatalf("cell proof %d mismatch after decoding", i)
+		}
+	}
+}
+
 var (
 	emptyBlob          = new(kzg4844.Blob)
 	emptyBlobCommit, _ = kzg4844.BlobToCommitment(emptyBlob)
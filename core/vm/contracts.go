@@ -35,6 +35,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto/blake2b"
 	"github.com/ethereum/go-ethereum/crypto/bn256"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/crypto/secp256r1"
 	"github.com/ethereum/go-ethereum/params"
 	"golang.org/x/crypto/ripemd160"
 )
@@ -137,11 +138,35 @@ var PrecompiledContractsPrague = PrecompiledContracts{
 	common.BytesToAddress([]byte{0x11}): &bls12381MapG2{},
 }
 
+// PrecompiledContractsOsaka contains the set of pre-compiled Ethereum
+// contracts used in the Osaka release.
+var PrecompiledContractsOsaka = PrecompiledContracts{
+	common.BytesToAddress([]byte{0x01}):       &ecrecover{},
+	common.BytesToAddress([]byte{0x02}):       &sha256hash{},
+	common.BytesToAddress([]byte{0x03}):       &ripemd160hash{},
+	common.BytesToAddress([]byte{0x04}):       &dataCopy{},
+	common.BytesToAddress([]byte{0x05}):       &bigModExp{eip2565: true, eip7823: true, eip7883: true},
+	common.BytesToAddress([]byte{0x06}):       &bn256AddIstanbul{},
+	common.BytesToAddress([]byte{0x07}):       &bn256ScalarMulIstanbul{},
+	common.BytesToAddress([]byte{0x08}):       &bn256PairingIstanbul{},
+	common.BytesToAddress([]byte{0x09}):       &blake2F{},
+	common.BytesToAddress([]byte{0x0a}):       &kzgPointEvaluation{},
+	common.BytesToAddress([]byte{0x0b}):       &bls12381G1Add{},
+	common.BytesToAddress([]byte{0x0c}):       &bls12381G1MultiExp{},
+	common.BytesToAddress([]byte{0x0d}):       &bls12381G2Add{},
+	common.BytesToAddress([]byte{0x0e}):       &bls12381G2MultiExp{},
+	common.BytesToAddress([]byte{0x0f}):       &bls12381Pairing{},
+	common.BytesToAddress([]byte{0x10}):       &bls12381MapG1{},
+	common.BytesToAddress([]byte{0x11}):       &bls12381MapG2{},
+	common.BytesToAddress([]byte{0x01, 0x00}): &p256Verify{},
+}
+
 var PrecompiledContractsBLS = PrecompiledContractsPrague
 
 var PrecompiledContractsVerkle = PrecompiledContractsPrague
 
 var (
+	PrecompiledAddressesOsaka     []common.Address
 	PrecompiledAddressesPrague    []common.Address
 	PrecompiledAddressesCancun    []common.Address
 	PrecompiledAddressesBerlin    []common.Address
@@ -169,12 +194,17 @@ func init() {
 	for k := range PrecompiledContractsPrague {
 		PrecompiledAddressesPrague = append(PrecompiledAddressesPrague, k)
 	}
+	for k := range PrecompiledContractsOsaka {
+		PrecompiledAddressesOsaka = append(PrecompiledAddressesOsaka, k)
+	}
 }
 
 func activePrecompiledContracts(rules params.Rules) PrecompiledContracts {
 	switch {
 	case rules.IsVerkle:
 		return PrecompiledContractsVerkle
+	case rules.IsOsaka:
+		return PrecompiledContractsOsaka
 	case rules.IsPrague:
 		return PrecompiledContractsPrague
 	case rules.IsCancun:
@@ -198,6 +228,8 @@ func ActivePrecompiledContracts(rules params.Rules) PrecompiledContracts {
 // ActivePrecompiles returns the precompile addresses enabled with the current configuration.
 func ActivePrecompiles(rules params.Rules) []common.Address {
 	switch {
+	case rules.IsOsaka:
+		return PrecompiledAddressesOsaka
 	case rules.IsPrague:
 		return PrecompiledAddressesPrague
 	case rules.IsCancun:
@@ -317,6 +349,8 @@ func (c *dataCopy) Run(in []byte) ([]byte, error) {
 // bigModExp implements a native big integer exponential modular operation.
 type bigModExp struct {
 	eip2565 bool
+	eip7823 bool
+	eip7883 bool
 }
 
 var (
@@ -328,6 +362,7 @@ var (
 	big64     = big.NewInt(64)
 	big96     = big.NewInt(96)
 	big480    = big.NewInt(480)
+	big500    = big.NewInt(500)
 	big1024   = big.NewInt(1024)
 	big3072   = big.NewInt(3072)
 	big199680 = big.NewInt(199680)
@@ -361,6 +396,8 @@ func modexpMultComplexity(x *big.Int) *big.Int {
 	return x
 }
 
+var errModExpInputTooLarge = errors.New("modexp input exceeds 1024 bytes")
+
 // RequiredGas returns the gas required to execute the pre-compiled contract.
 func (c *bigModExp) RequiredGas(input []byte) uint64 {
 	var (
@@ -392,7 +429,11 @@ func (c *bigModExp) RequiredGas(input []byte) uint64 {
 	adjExpLen := new(big.Int)
 	if expLen.Cmp(big32) > 0 {
 		adjExpLen.Sub(expLen, big32)
-		adjExpLen.Lsh(adjExpLen, 3)
+		if c.eip7883 {
+			adjExpLen.Lsh(adjExpLen, 4)
+		} else {
+			adjExpLen.Lsh(adjExpLen, 3)
+		}
 	}
 	adjExpLen.Add(adjExpLen, big.NewInt(int64(msb)))
 	// Calculate the gas cost of the operation
@@ -402,6 +443,35 @@ func (c *bigModExp) RequiredGas(input []byte) uint64 {
 	} else {
 		gas.Set(modLen)
 	}
+	if c.eip7883 {
+		// EIP-7883 reprices the operation on top of EIP-2565:
+		// 1. Different multComplexity (inlined here)
+		//
+		// def mult_complexity(x):
+		//    16 if x <= 32 else 2 * ceiling(x/8)^2
+		//
+		// where is x is max(length_of_MODULUS, length_of_BASE)
+		if gas.Cmp(big32) <= 0 {
+			gas.SetUint64(16)
+		} else {
+			gas.Add(gas, big7)
+			gas.Rsh(gas, 3)
+			gas.Mul(gas, gas)
+			gas.Lsh(gas, 1)
+		}
+		// 2. Doubled multiplier for exponents longer than 32 bytes (above)
+		if adjExpLen.Cmp(big1) > 0 {
+			gas.Mul(gas, adjExpLen)
+		}
+		// 3. No divisor and a minimum price of 500 gas
+		if gas.BitLen() > 64 {
+			return math.MaxUint64
+		}
+		if gas.Cmp(big500) < 0 {
+			return 500
+		}
+		return gas.Uint64()
+	}
 	if c.eip2565 {
 		// EIP-2565 has three changes
 		// 1. Different multComplexity (inlined here)
@@ -443,9 +513,20 @@ func (c *bigModExp) RequiredGas(input []byte) uint64 {
 
 func (c *bigModExp) Run(input []byte) ([]byte, error) {
 	var (
-		baseLen = new(big.Int).SetBytes(getData(input, 0, 32)).Uint64()
-		expLen  = new(big.Int).SetBytes(getData(input, 32, 32)).Uint64()
-		modLen  = new(big.Int).SetBytes(getData(input, 64, 32)).Uint64()
+		baseLenBig = new(big.Int).SetBytes(getData(input, 0, 32))
+		expLenBig  = new(big.Int).SetBytes(getData(input, 32, 32))
+		modLenBig  = new(big.Int).SetBytes(getData(input, 64, 32))
+	)
+	// EIP-7823 caps the length of every operand at 1024 bytes
+	if c.eip7823 {
+		if baseLenBig.Cmp(big1024) > 0 || expLenBig.Cmp(big1024) > 0 || modLenBig.Cmp(big1024) > 0 {
+			return nil, errModExpInputTooLarge
+		}
+	}
+	var (
+		baseLen = baseLenBig.Uint64()
+		expLen  = expLenBig.Uint64()
+		modLen  = modLenBig.Uint64()
 	)
 	if len(input) > 96 {
 		input = input[96:]
@@ -1182,3 +1263,36 @@ func kZGToVersionedHash(kzg kzg4844.Commitment) common.Hash {
 
 	return h
 }
+
+// p256Verify implements the secp256r1 signature verification precompile
+// introduced in EIP-7951.
+type p256Verify struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *p256Verify) RequiredGas(input []byte) uint64 {
+	return params.P256VerifyGas
+}
+
+// p256VerifyInputLength is the exact input length accepted by P256VERIFY:
+// the message hash, the signature (r, s) and the public key (x, y).
+const p256VerifyInputLength = 160
+
+func (c *p256Verify) Run(input []byte) ([]byte, error) {
+	// Invalid inputs are not errors: the precompile returns empty output and
+	// charges the full price, so callers can't tell format and signature
+	// failures apart.
+	if len(input) != p256VerifyInputLength {
+		return nil, nil
+	}
+	var (
+		hash = input[0:32]
+		r    = new(big.Int).SetBytes(input[32:64])
+		s    = new(big.Int).SetBytes(input[64:96])
+		x    = new(big.Int).SetBytes(input[96:128])
+		y    = new(big.Int).SetBytes(input[128:160])
+	)
+	if !secp256r1.Verify(hash, r, s, x, y) {
+		return nil, nil
+	}
+	return true32Byte, nil
+}
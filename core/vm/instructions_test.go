@@ -229,6 +229,35 @@ func TestAddMod(t *testing.T) {
 	}
 }
 
+func TestCLZ(t *testing.T) {
+	var (
+		evm   = NewEVM(BlockContext{}, nil, params.TestChainConfig, Config{})
+		stack = newstack()
+		pc    = uint64(0)
+	)
+	tests := []struct {
+		x        string
+		expected uint64
+	}{
+		{"0000000000000000000000000000000000000000000000000000000000000000", 256},
+		{"0000000000000000000000000000000000000000000000000000000000000001", 255},
+		{"0000000000000000000000000000000000000000000000000000000000000080", 248},
+		{"00000000000000000000000000000000000000000000000000000000ffffffff", 224},
+		{"0000000000000000000000000000000100000000000000000000000000000000", 127},
+		{"4000000000000000000000000000000000000000000000000000000000000000", 1},
+		{"7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", 1},
+		{"8000000000000000000000000000000000000000000000000000000000000000", 0},
+		{"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", 0},
+	}
+	for i, test := range tests {
+		stack.push(new(uint256.Int).SetBytes(common.Hex2Bytes(test.x)))
+		opCLZ(&pc, evm.interpreter, &ScopeContext{nil, stack, nil})
+		if actual := stack.pop(); !actual.IsUint64() || actual.Uint64() != test.expected {
+			t.Errorf("Testcase %d, expected %d, got %x", i, test.expected, actual)
+		}
+	}
+}
+
 // utility function to fill the json-file with testcases
 // Enable this test to generate the 'testcases_xx.json' files
 func TestWriteExpectedValues(t *testing.T) {
@@ -14,12 +14,13 @@
 // You should have received a copy of the GNU Lesser General Public License
 // along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
 
-// Package kzg4844 implements the KZG crypto for EIP-4844.
+// Package kzg4844 implements the KZG crypto for EIP-4844 and EIP-7594.
 package kzg4844
 
 import (
 	"embed"
 	"errors"
+	"fmt"
 	"hash"
 	"reflect"
 	"sync/atomic"
@@ -34,8 +35,13 @@ var (
 	blobT       = reflect.TypeOf(Blob{})
 	commitmentT = reflect.TypeOf(Commitment{})
 	proofT      = reflect.TypeOf(Proof{})
+	cellT       = reflect.TypeOf(Cell{})
 )
 
+// CellProofsPerBlob is the number of cells (and thus cell proofs) an extended
+// blob is split into under EIP-7594.
+const CellProofsPerBlob = 128
+
 // Blob represents a 4844 data blob.
 type Blob [131072]byte
 
@@ -75,6 +81,20 @@ func (p Proof) MarshalText() ([]byte, error) {
 	return hexutil.Bytes(p[:]).MarshalText()
 }
 
+// Cell is a serialized chunk of 64 field elements of a blob extended with
+// Reed-Solomon erasure coding.
+type Cell [2048]byte
+
+// UnmarshalJSON parses a cell in hex syntax.
+func (c *Cell) UnmarshalJSON(input []byte) error {
+	return hexutil.UnmarshalFixedJSON(cellT, input, c[:])
+}
+
+// MarshalText returns the hex representation of c.
+func (c Cell) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(c[:]).MarshalText()
+}
+
 // Point is a BLS field element.
 type Point [32]byte
 
@@ -149,6 +169,40 @@ func VerifyBlobProof(blob *Blob, commitment Commitment, proof Proof) error {
 	return gokzgVerifyBlobProof(blob, commitment, proof)
 }
 
+// ComputeCells returns the cells of the extended blobs, CellProofsPerBlob
+// consecutive cells for each of the given blobs.
+func ComputeCells(blobs []Blob) ([]Cell, error) {
+	if useCKZG.Load() {
+		return ckzgComputeCells(blobs)
+	}
+	return gokzgComputeCells(blobs)
+}
+
+// ComputeCellProofs returns the CellProofsPerBlob KZG proofs that are used to
+// verify the cells of the extended blob against the blob commitment.
+func ComputeCellProofs(blob *Blob) ([]Proof, error) {
+	if useCKZG.Load() {
+		return ckzgComputeCellProofs(blob)
+	}
+	return gokzgComputeCellProofs(blob)
+}
+
+// VerifyCellProofs verifies a batch of cell proofs against the blobs and their
+// commitments. The number of proofs must be CellProofsPerBlob times the number
+// of blobs, ordered by blob first and by cell index second.
+func VerifyCellProofs(blobs []Blob, commitments []Commitment, proofs []Proof) error {
+	if len(blobs) != len(commitments) {
+		return fmt.Errorf("mismatched number of blobs (%d) and commitments (%d)", len(blobs), len(commitments))
+	}
+	if len(proofs) != len(blobs)*CellProofsPerBlob {
+		return fmt.Errorf("invalid number of cell proofs: have %d, want %d", len(proofs), len(blobs)*CellProofsPerBlob)
+	}
+	if useCKZG.Load() {
+		return ckzgVerifyCellProofBatch(blobs, commitments, proofs)
+	}
+	return gokzgVerifyCellProofBatch(blobs, commitments, proofs)
+}
+
 // CalcBlobHashV1 calculates the 'versioned blob hash' of a commitment.
 // The given hasher must be a sha256 hash instance, otherwise the result will be invalid!
 func CalcBlobHashV1(hasher hash.Hash, commit *Commitment) (vh [32]byte) {
@@ -23,8 +23,8 @@ import (
 	"errors"
 	"sync"
 
-	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
-	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	gokzg4844 "github.com/crate-crypto/go-eth-kzg"
+	ckzg4844 "github.com/ethereum/c-kzg-4844/v2/bindings/go"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
@@ -47,15 +47,19 @@ func ckzgInit() {
 	if err = gokzg4844.CheckTrustedSetupIsWellFormed(params); err != nil {
 		panic(err)
 	}
-	g1s := make([]byte, len(params.SetupG1Lagrange)*(len(params.SetupG1Lagrange[0])-2)/2)
+	g1Monomials := make([]byte, len(params.SetupG1Monomial)*(len(params.SetupG1Monomial[0])-2)/2)
+	for i, g1 := range params.SetupG1Monomial {
+		copy(g1Monomials[i*(len(g1)-2)/2:], hexutil.MustDecode(g1))
+	}
+	g1Lagranges := make([]byte, len(params.SetupG1Lagrange)*(len(params.SetupG1Lagrange[0])-2)/2)
 	for i, g1 := range params.SetupG1Lagrange {
-		copy(g1s[i*(len(g1)-2)/2:], hexutil.MustDecode(g1))
+		copy(g1Lagranges[i*(len(g1)-2)/2:], hexutil.MustDecode(g1))
 	}
 	g2s := make([]byte, len(params.SetupG2)*(len(params.SetupG2[0])-2)/2)
 	for i, g2 := range params.SetupG2 {
 		copy(g2s[i*(len(g2)-2)/2:], hexutil.MustDecode(g2))
 	}
-	if err = ckzg4844.LoadTrustedSetup(g1s, g2s); err != nil {
+	if err = ckzg4844.LoadTrustedSetup(g1Monomials, g1Lagranges, g2s, 0); err != nil {
 		panic(err)
 	}
 }
@@ -125,3 +129,69 @@ func ckzgVerifyBlobProof(blob *Blob, commitment Commitment, proof Proof) error {
 	}
 	return nil
 }
+
+// ckzgComputeCells returns the cells of the extended blobs.
+func ckzgComputeCells(blobs []Blob) ([]Cell, error) {
+	ckzgIniter.Do(ckzgInit)
+
+	cells := make([]Cell, 0, CellProofsPerBlob*len(blobs))
+	for i := range blobs {
+		blobCells, err := ckzg4844.ComputeCells((*ckzg4844.Blob)(&blobs[i]))
+		if err != nil {
+			return nil, err
+		}
+		for _, cell := range blobCells {
+			cells = append(cells, Cell(cell))
+		}
+	}
+	return cells, nil
+}
+
+// ckzgComputeCellProofs returns the KZG cell proofs that are used to verify
+// the cells of the extended blob against the blob commitment.
+func ckzgComputeCellProofs(blob *Blob) ([]Proof, error) {
+	ckzgIniter.Do(ckzgInit)
+
+	_, proofs, err := ckzg4844.ComputeCellsAndKZGProofs((*ckzg4844.Blob)(blob))
+	if err != nil {
+		return nil, err
+	}
+	cellProofs := make([]Proof, 0, len(proofs))
+	for _, proof := range proofs {
+		cellProofs = append(cellProofs, Proof(proof))
+	}
+	return cellProofs, nil
+}
+
+// ckzgVerifyCellProofBatch verifies the cell proofs of all cells of all the
+// extended blobs against their commitments.
+func ckzgVerifyCellProofBatch(blobs []Blob, commitments []Commitment, proofs []Proof) error {
+	ckzgIniter.Do(ckzgInit)
+
+	var (
+		cellCommitments = make([]ckzg4844.Bytes48, 0, len(proofs))
+		cellIndices     = make([]uint64, 0, len(proofs))
+		cells           = make([]ckzg4844.Cell, 0, len(proofs))
+		cellProofs      = make([]ckzg4844.Bytes48, 0, len(proofs))
+	)
+	for i := range blobs {
+		blobCells, err := ckzg4844.ComputeCells((*ckzg4844.Blob)(&blobs[i]))
+		if err != nil {
+			return err
+		}
+		for j := range blobCells {
+			cellCommitments = append(cellCommitments, ckzg4844.Bytes48(commitments[i]))
+			cellIndices = append(cellIndices, uint64(j))
+			cellProofs = append(cellProofs, ckzg4844.Bytes48(proofs[i*CellProofsPerBlob+j]))
+		}
+		cells = append(cells, blobCells[:]...)
+	}
+	valid, err := ckzg4844.VerifyCellKZGProofBatch(cellCommitments, cellIndices, cells, cellProofs)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.New("invalid proof")
+	}
+	return nil
+}
@@ -60,3 +60,20 @@ func ckzgComputeBlobProof(blob *Blob, commitment Commitment) (Proof, error) {
 func ckzgVerifyBlobProof(blob *Blob, commitment Commitment, proof Proof) error {
 	panic("unsupported platform")
 }
+
+// ckzgComputeCells returns the cells of the extended blobs.
+func ckzgComputeCells(blobs []Blob) ([]Cell, error) {
+	panic("unsupported platform")
+}
+
+// ckzgComputeCellProofs returns the KZG cell proofs that are used to verify
+// the cells of the extended blob against the blob commitment.
+func ckzgComputeCellProofs(blob *Blob) ([]Proof, error) {
+	panic("unsupported platform")
+}
+
+// ckzgVerifyCellProofBatch verifies the cell proofs of all cells of all the
+// extended blobs against their commitments.
+func ckzgVerifyCellProofBatch(blobs []Blob, commitments []Commitment, proofs []Proof) error {
+	panic("unsupported platform")
+}
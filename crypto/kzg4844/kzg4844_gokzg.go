@@ -20,7 +20,7 @@ import (
 	"encoding/json"
 	"sync"
 
-	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	gokzg4844 "github.com/crate-crypto/go-eth-kzg"
 )
 
 // context is the crypto primitive pre-seeded with the trusted setup parameters.
@@ -96,3 +96,62 @@ func gokzgVerifyBlobProof(blob *Blob, commitment Commitment, proof Proof) error
 
 	return context.VerifyBlobKZGProof((*gokzg4844.Blob)(blob), (gokzg4844.KZGCommitment)(commitment), (gokzg4844.KZGProof)(proof))
 }
+
+// gokzgComputeCells returns the cells of the extended blobs.
+func gokzgComputeCells(blobs []Blob) ([]Cell, error) {
+	gokzgIniter.Do(gokzgInit)
+
+	cells := make([]Cell, 0, CellProofsPerBlob*len(blobs))
+	for i := range blobs {
+		blobCells, err := context.ComputeCells((*gokzg4844.Blob)(&blobs[i]), 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, cell := range blobCells {
+			cells = append(cells, Cell(*cell))
+		}
+	}
+	return cells, nil
+}
+
+// gokzgComputeCellProofs returns the KZG cell proofs that are used to verify
+// the cells of the extended blob against the blob commitment.
+func gokzgComputeCellProofs(blob *Blob) ([]Proof, error) {
+	gokzgIniter.Do(gokzgInit)
+
+	_, proofs, err := context.ComputeCellsAndKZGProofs((*gokzg4844.Blob)(blob), 0)
+	if err != nil {
+		return nil, err
+	}
+	cellProofs := make([]Proof, 0, len(proofs))
+	for _, proof := range proofs {
+		cellProofs = append(cellProofs, Proof(proof))
+	}
+	return cellProofs, nil
+}
+
+// gokzgVerifyCellProofBatch verifies the cell proofs of all cells of all the
+// extended blobs against their commitments.
+func gokzgVerifyCellProofBatch(blobs []Blob, commitments []Commitment, proofs []Proof) error {
+	gokzgIniter.Do(gokzgInit)
+
+	var (
+		cellCommitments = make([]gokzg4844.KZGCommitment, 0, len(proofs))
+		cellIndices     = make([]uint64, 0, len(proofs))
+		cells           = make([]*gokzg4844.Cell, 0, len(proofs))
+		cellProofs      = make([]gokzg4844.KZGProof, 0, len(proofs))
+	)
+	for i := range blobs {
+		blobCells, err := context.ComputeCells((*gokzg4844.Blob)(&blobs[i]), 0)
+		if err != nil {
+			return err
+		}
+		for j := range blobCells {
+			cellCommitments = append(cellCommitments, gokzg4844.KZGCommitment(commitments[i]))
+			cellIndices = append(cellIndices, uint64(j))
+			cellProofs = append(cellProofs, gokzg4844.KZGProof(proofs[i*CellProofsPerBlob+j]))
+		}
+		cells = append(cells, blobCells[:]...)
+	}
+	return context.VerifyCellKZGProofBatch(cellCommitments, cellIndices, cells, cellProofs)
+}
@@ -17,11 +17,12 @@
 package kzg4844
 
 import (
+	"bytes"
 	"crypto/rand"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
-	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	gokzg4844 "github.com/crate-crypto/go-eth-kzg"
 )
 
 func randFieldElement() [32]byte {
@@ -94,6 +95,68 @@ func testKZGWithBlob(t *testing.T, ckzg bool) {
 	}
 }
 
+func TestCKZGCells(t *testing.T)  { testKZGCells(t, true) }
+func TestGoKZGCells(t *testing.T) { testKZGCells(t, false) }
+func testKZGCells(t *testing.T, ckzg bool) {
+	if ckzg && !ckzgAvailable {
+		t.Skip("CKZG unavailable in this test build")
+	}
+	defer func(old bool) { useCKZG.Store(old) }(useCKZG.Load())
+	useCKZG.Store(ckzg)
+
+	blob1 := randBlob()
+	blob2 := randBlob()
+
+	commitment1, err := BlobToCommitment(blob1)
+	if err != nil {
+		t.Fatalf("failed to create KZG commitment from blob: %v", err)
+	}
+	commitment2, err := BlobToCommitment(blob2)
+	if err != nil {
+		t.Fatalf("failed to create KZG commitment from blob: %v", err)
+	}
+	proofs1, err := ComputeCellProofs(blob1)
+	if err != nil {
+		t.Fatalf("failed to create KZG cell proofs for blob: %v", err)
+	}
+	if len(proofs1) != CellProofsPerBlob {
+		t.Fatalf("cell proof count mismatch: have %d, want %d", len(proofs1), CellProofsPerBlob)
+	}
+	proofs2, err := ComputeCellProofs(blob2)
+	if err != nil {
+		t.Fatalf("failed to create KZG cell proofs for blob: %v", err)
+	}
+	var (
+		blobs       = []Blob{*blob1, *blob2}
+		commitments = []Commitment{commitment1, commitment2}
+		proofs      = append(proofs1, proofs2...)
+	)
+	cells, err := ComputeCells(blobs)
+	if err != nil {
+		t.Fatalf("failed to compute cells: %v", err)
+	}
+	if len(cells) != 2*CellProofsPerBlob {
+		t.Fatalf("cell count mismatch: have %d, want %d", len(cells), 2*CellProofsPerBlob)
+	}
+	// The first half of the extended blob is the original blob itself
+	for i := 0; i < CellProofsPerBlob/2; i++ {
+		if !bytes.Equal(cells[i][:], blob1[i*len(Cell{}):(i+1)*len(Cell{})]) {
+			t.Fatalf("cell %d mismatches original blob data", i)
+		}
+	}
+	if err := VerifyCellProofs(blobs, commitments, proofs); err != nil {
+		t.Fatalf("failed to verify KZG cell proofs: %v", err)
+	}
+	// Swapping the commitments around must fail verification
+	if err := VerifyCellProofs(blobs, []Commitment{commitment2, commitment1}, proofs); err == nil {
+		t.Fatal("verified cell proofs against mismatching commitments")
+	}
+	// Missing proofs must be rejected without verification
+	if err := VerifyCellProofs(blobs, commitments, proofs[1:]); err == nil {
+		t.Fatal("verified incomplete cell proofs")
+	}
+}
+
 func BenchmarkCKZGBlobToCommitment(b *testing.B)  { benchmarkBlobToCommitment(b, true) }
 func BenchmarkGoKZGBlobToCommitment(b *testing.B) { benchmarkBlobToCommitment(b, false) }
 func benchmarkBlobToCommitment(b *testing.B, ckzg bool) {
@@ -193,3 +256,41 @@ func benchmarkVerifyBlobProof(b *testing.B, ckzg bool) {
 		VerifyBlobProof(blob, commitment, proof)
 	}
 }
+
+func BenchmarkCKZGComputeCellProofs(b *testing.B)  { benchmarkComputeCellProofs(b, true) }
+func BenchmarkGoKZGComputeCellProofs(b *testing.B) { benchmarkComputeCellProofs(b, false) }
+func benchmarkComputeCellProofs(b *testing.B, ckzg bool) {
+	if ckzg && !ckzgAvailable {
+		b.Skip("CKZG unavailable in this test build")
+	}
+	defer func(old bool) { useCKZG.Store(old) }(useCKZG.Load())
+	useCKZG.Store(ckzg)
+
+	blob := randBlob()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ComputeCellProofs(blob)
+	}
+}
+
+func BenchmarkCKZGVerifyCellProofs(b *testing.B)  { benchmarkVerifyCellProofs(b, true) }
+func BenchmarkGoKZGVerifyCellProofs(b *testing.B) { benchmarkVerifyCellProofs(b, false) }
+func benchmarkVerifyCellProofs(b *testing.B, ckzg bool) {
+	if ckzg && !ckzgAvailable {
+		b.Skip("CKZG unavailable in this test build")
+	}
+	defer func(old bool) { useCKZG.Store(old) }(useCKZG.Load())
+	useCKZG.Store(ckzg)
+
+	var (
+		blob          = randBlob()
+		commitment, _ = BlobToCommitment(blob)
+		proofs, _     = ComputeCellProofs(blob)
+	)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifyCellProofs([]Blob{*blob}, []Commitment{commitment}, proofs)
+	}
+}
@@ -64,6 +64,11 @@ func (b *EthAPIBackend) CurrentBlock() *types.Header {
 func (b *EthAPIBackend) SetHead(number uint64) {
 	b.eth.handler.downloader.Cancel()
 	b.eth.blockchain.SetHead(number)
+
+	// The responses cached as immutable might be rewound along with the chain.
+	if b.eth.responseCache != nil {
+		b.eth.responseCache.Reset()
+	}
 }
 
 func (b *EthAPIBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
@@ -236,7 +241,12 @@ func (b *EthAPIBackend) StateAndHeaderByNumber(ctx context.Context, number rpc.B
 	}
 	stateDb, err := b.eth.BlockChain().StateAt(header.Root)
 	if err != nil {
-		return nil, nil, err
+		// Fall back to the historical state if it's indexed
+		historic, herr := b.eth.BlockChain().HistoricState(header.Root)
+		if herr != nil {
+			return nil, nil, err
+		}
+		stateDb = historic
 	}
 	return stateDb, header, nil
 }
@@ -258,7 +268,12 @@ func (b *EthAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockN
 		}
 		stateDb, err := b.eth.BlockChain().StateAt(header.Root)
 		if err != nil {
-			return nil, nil, err
+			// Fall back to the historical state if it's indexed
+			historic, herr := b.eth.BlockChain().HistoricState(header.Root)
+			if herr != nil {
+				return nil, nil, err
+			}
+			stateDb = historic
 		}
 		return stateDb, header, nil
 	}
@@ -329,6 +344,13 @@ func (b *EthAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction)
 	return nil
 }
 
+// SendConditionalTx adds a transaction to the pool along with the conditions it
+// must satisfy to be included. Conditional transactions are not tracked locally,
+// since resubmitting them after their conditions were invalidated is pointless.
+func (b *EthAPIBackend) SendConditionalTx(ctx context.Context, signedTx *types.Transaction, cond *types.TransactionConditional) error {
+	return b.eth.txPool.AddConditional(signedTx, cond)
+}
+
 func (b *EthAPIBackend) GetPoolTransactions() (types.Transactions, error) {
 	pending := b.eth.txPool.Pending(txpool.PendingFilter{})
 	var txs types.Transactions
@@ -397,6 +419,10 @@ func (b *EthAPIBackend) SyncProgress() ethereum.SyncProgress {
 		prog.TxIndexFinishedBlocks = txProg.Indexed
 		prog.TxIndexRemainingBlocks = txProg.Remaining
 	}
+	if pruneProg, err := b.eth.blockchain.HistoryPruneProgress(); err == nil {
+		prog.HistoryPrunedBlocks = pruneProg.Pruned
+		prog.HistoryPruneRemainingBlocks = pruneProg.Remaining
+	}
 	return prog
 }
 
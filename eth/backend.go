@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/filtermaps"
+	"github.com/ethereum/go-ethereum/core/history"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state/pruner"
 	"github.com/ethereum/go-ethereum/core/txpool"
@@ -59,6 +60,7 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
 	gethversion "github.com/ethereum/go-ethereum/version"
 )
 
@@ -95,6 +97,7 @@ type Ethereum struct {
 
 	networkID     uint64
 	netRPCService *ethapi.NetAPI
+	responseCache *rpc.ResponseCache // Cache of the immutable RPC responses, nil if disabled
 
 	p2pServer *p2p.Server
 
@@ -113,11 +116,15 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if !config.HistoryMode.IsValid() {
 		return nil, fmt.Errorf("invalid history mode %d", config.HistoryMode)
 	}
+	if config.HistoryMode == history.KeepRecent && config.HistoryRetention < history.MinRetention {
+		log.Warn("Sanitizing chain history retention", "provided", config.HistoryRetention, "updated", history.MinRetention)
+		config.HistoryRetention = history.MinRetention
+	}
 	if config.Miner.GasPrice == nil || config.Miner.GasPrice.Sign() <= 0 {
 		log.Warn("Sanitizing invalid miner gas price", "provided", config.Miner.GasPrice, "updated", ethconfig.Defaults.Miner.GasPrice)
 		config.Miner.GasPrice = new(big.Int).Set(ethconfig.Defaults.Miner.GasPrice)
 	}
-	if config.NoPruning && config.TrieDirtyCache > 0 {
+	if config.NoPruning && config.StateScheme != rawdb.PathScheme && config.TrieDirtyCache > 0 {
 		if config.SnapshotCache > 0 {
 			config.TrieCleanCache += config.TrieDirtyCache * 3 / 5
 			config.SnapshotCache += config.TrieDirtyCache * 2 / 5
@@ -149,6 +156,17 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Serve the pruned chain history from the Era1 archives if configured.
+	if config.HistoryEraDir != "" {
+		network := params.NetworkNames[chainConfig.ChainID.String()]
+		if network == "" {
+			return nil, fmt.Errorf("era1 archives are not supported on network %v", chainConfig.ChainID)
+		}
+		chainDb, err = rawdb.NewEraDatabase(chainDb, config.HistoryEraDir, network, trie.NewStackTrie(nil))
+		if err != nil {
+			return nil, err
+		}
+	}
 	engine, err := ethconfig.CreateConsensusEngine(chainConfig, chainDb)
 	if err != nil {
 		return nil, err
@@ -169,6 +187,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		networkID:       networkID,
 		gasPrice:        config.Miner.GasPrice,
 		p2pServer:       stack.Server(),
+		responseCache:   stack.ResponseCache(),
 		discmix:         enode.NewFairMix(0),
 		shutdownTracker: shutdowncheck.NewShutdownTracker(chainDb),
 	}
@@ -193,6 +212,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	var (
 		vmConfig = vm.Config{
 			EnablePreimageRecording: config.EnablePreimageRecording,
+			ParallelExecution:       config.ParallelExecution,
 		}
 		cacheConfig = &core.CacheConfig{
 			TrieCleanLimit:      config.TrieCleanCache,
@@ -205,6 +225,11 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			StateHistory:        config.StateHistory,
 			StateScheme:         scheme,
 			ChainHistoryMode:    config.HistoryMode,
+
+			ChainHistoryRetention: config.HistoryRetention,
+			StatePruneInterval:    config.StatePruneInterval,
+			StatePruneBloomSize:   config.StatePruneBloomSize,
+			SnapServeDepth:        config.SnapServeDepth,
 		}
 	)
 	if config.VMTrace != "" {
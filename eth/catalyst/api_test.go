@@ -20,11 +20,16 @@ import (
 	"bytes"
 	"context"
 	crand "crypto/rand"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"slices"
 	"sync"
 	"testing"
 	"time"
@@ -186,7 +191,7 @@ func TestEth2PrepareAndGetPayload(t *testing.T) {
 		SafeBlockHash:      common.Hash{},
 		FinalizedBlockHash: common.Hash{},
 	}
-	_, err := api.ForkchoiceUpdatedV1(fcState, &blockParams)
+	_, err := api.ForkchoiceUpdatedV1(context.Background(), fcState, &blockParams)
 	if err != nil {
 		t.Fatalf("error preparing payload, err=%v", err)
 	}
@@ -265,7 +270,7 @@ func TestInvalidPayloadTimestamp(t *testing.T) {
 				SafeBlockHash:      common.Hash{},
 				FinalizedBlockHash: common.Hash{},
 			}
-			_, err := api.ForkchoiceUpdatedV1(fcState, &params)
+			_, err := api.ForkchoiceUpdatedV1(context.Background(), fcState, &params)
 			if test.shouldErr && err == nil {
 				t.Fatalf("expected error preparing payload with invalid timestamp, err=%v", err)
 			} else if !test.shouldErr && err != nil {
@@ -309,7 +314,7 @@ func TestEth2NewBlock(t *testing.T) {
 		if err != nil {
 			t.Fatalf("Failed to convert executable data to block %v", err)
 		}
-		newResp, err := api.NewPayloadV1(*execData)
+		newResp, err := api.NewPayloadV1(context.Background(), *execData)
 		switch {
 		case err != nil:
 			t.Fatalf("Failed to insert block: %v", err)
@@ -324,7 +329,7 @@ func TestEth2NewBlock(t *testing.T) {
 			SafeBlockHash:      block.Hash(),
 			FinalizedBlockHash: block.Hash(),
 		}
-		if _, err := api.ForkchoiceUpdatedV1(fcState, nil); err != nil {
+		if _, err := api.ForkchoiceUpdatedV1(context.Background(), fcState, nil); err != nil {
 			t.Fatalf("Failed to insert block: %v", err)
 		}
 		if have, want := ethservice.BlockChain().CurrentBlock().Number.Uint64(), block.NumberU64(); have != want {
@@ -351,7 +356,7 @@ func TestEth2NewBlock(t *testing.T) {
 		if err != nil {
 			t.Fatalf("Failed to convert executable data to block %v", err)
 		}
-		newResp, err := api.NewPayloadV1(*execData)
+		newResp, err := api.NewPayloadV1(context.Background(), *execData)
 		if err != nil || newResp.Status != "VALID" {
 			t.Fatalf("Failed to insert block: %v", err)
 		}
@@ -364,7 +369,7 @@ func TestEth2NewBlock(t *testing.T) {
 			SafeBlockHash:      block.Hash(),
 			FinalizedBlockHash: block.Hash(),
 		}
-		if _, err := api.ForkchoiceUpdatedV1(fcState, nil); err != nil {
+		if _, err := api.ForkchoiceUpdatedV1(context.Background(), fcState, nil); err != nil {
 			t.Fatalf("Failed to insert block: %v", err)
 		}
 		if ethservice.BlockChain().CurrentBlock().Number.Uint64() != block.NumberU64() {
@@ -490,7 +495,7 @@ func setupBlocks(t *testing.T, ethservice *eth.Ethereum, n int, parent *types.He
 		}
 
 		envelope := getNewEnvelope(t, api, parent, w, h)
-		execResp, err := api.newPayload(*envelope.ExecutionPayload, []common.Hash{}, h, envelope.Requests, false)
+		execResp, err := api.newPayload(context.Background(), *envelope.ExecutionPayload, []common.Hash{}, h, envelope.Requests, false)
 		if err != nil {
 			t.Fatalf("can't execute payload: %v", err)
 		}
@@ -503,7 +508,7 @@ func setupBlocks(t *testing.T, ethservice *eth.Ethereum, n int, parent *types.He
 			SafeBlockHash:      payload.ParentHash,
 			FinalizedBlockHash: payload.ParentHash,
 		}
-		if _, err := api.ForkchoiceUpdatedV1(fcState, nil); err != nil {
+		if _, err := api.ForkchoiceUpdatedV1(context.Background(), fcState, nil); err != nil {
 			t.Fatalf("Failed to insert block: %v", err)
 		}
 		if ethservice.BlockChain().CurrentBlock().Number.Uint64() != payload.Number {
@@ -617,7 +622,7 @@ func TestNewPayloadOnInvalidChain(t *testing.T) {
 			err     error
 		)
 		for i := 0; ; i++ {
-			if resp, err = api.ForkchoiceUpdatedV1(fcState, &params); err != nil {
+			if resp, err = api.ForkchoiceUpdatedV1(context.Background(), fcState, &params); err != nil {
 				t.Fatalf("error preparing payload, err=%v", err)
 			}
 			if resp.PayloadStatus.Status != engine.VALID {
@@ -636,7 +641,7 @@ func TestNewPayloadOnInvalidChain(t *testing.T) {
 				t.Fatalf("payload should not be empty")
 			}
 		}
-		execResp, err := api.NewPayloadV1(*payload.ExecutionPayload)
+		execResp, err := api.NewPayloadV1(context.Background(), *payload.ExecutionPayload)
 		if err != nil {
 			t.Fatalf("can't execute payload: %v", err)
 		}
@@ -648,7 +653,7 @@ func TestNewPayloadOnInvalidChain(t *testing.T) {
 			SafeBlockHash:      payload.ExecutionPayload.ParentHash,
 			FinalizedBlockHash: payload.ExecutionPayload.ParentHash,
 		}
-		if _, err := api.ForkchoiceUpdatedV1(fcState, nil); err != nil {
+		if _, err := api.ForkchoiceUpdatedV1(context.Background(), fcState, nil); err != nil {
 			t.Fatalf("Failed to insert block: %v", err)
 		}
 		if ethservice.BlockChain().CurrentBlock().Number.Uint64() != payload.ExecutionPayload.Number {
@@ -696,7 +701,7 @@ func TestEmptyBlocks(t *testing.T) {
 	// (1) check LatestValidHash by sending a normal payload (P1'')
 	payload := getNewPayload(t, api, commonAncestor, nil, nil)
 
-	status, err := api.NewPayloadV1(*payload)
+	status, err := api.NewPayloadV1(context.Background(), *payload)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -712,7 +717,7 @@ func TestEmptyBlocks(t *testing.T) {
 	payload.GasUsed += 1
 	payload = setBlockhash(payload)
 	// Now latestValidHash should be the common ancestor
-	status, err = api.NewPayloadV1(*payload)
+	status, err = api.NewPayloadV1(context.Background(), *payload)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -730,7 +735,7 @@ func TestEmptyBlocks(t *testing.T) {
 	payload.ParentHash = common.Hash{1}
 	payload = setBlockhash(payload)
 	// Now latestValidHash should be the common ancestor
-	status, err = api.NewPayloadV1(*payload)
+	status, err = api.NewPayloadV1(context.Background(), *payload)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -847,7 +852,7 @@ func TestTrickRemoteBlockCache(t *testing.T) {
 
 	// feed the payloads to node B
 	for _, payload := range invalidChain {
-		status, err := apiB.NewPayloadV1(*payload)
+		status, err := apiB.NewPayloadV1(context.Background(), *payload)
 		if err != nil {
 			panic(err)
 		}
@@ -855,7 +860,7 @@ func TestTrickRemoteBlockCache(t *testing.T) {
 			t.Error("invalid status: VALID on an invalid chain")
 		}
 		// Now reorg to the head of the invalid chain
-		resp, err := apiB.ForkchoiceUpdatedV1(engine.ForkchoiceStateV1{HeadBlockHash: payload.BlockHash, SafeBlockHash: payload.BlockHash, FinalizedBlockHash: payload.ParentHash}, nil)
+		resp, err := apiB.ForkchoiceUpdatedV1(context.Background(), engine.ForkchoiceStateV1{HeadBlockHash: payload.BlockHash, SafeBlockHash: payload.BlockHash, FinalizedBlockHash: payload.ParentHash}, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -880,7 +885,7 @@ func TestInvalidBloom(t *testing.T) {
 	// (1) check LatestValidHash by sending a normal payload (P1'')
 	payload := getNewPayload(t, api, commonAncestor, nil, nil)
 	payload.LogsBloom = append(payload.LogsBloom, byte(1))
-	status, err := api.NewPayloadV1(*payload)
+	status, err := api.NewPayloadV1(context.Background(), *payload)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -919,7 +924,7 @@ func TestSimultaneousNewBlock(t *testing.T) {
 			for ii := 0; ii < 10; ii++ {
 				go func() {
 					defer wg.Done()
-					if newResp, err := api.NewPayloadV1(*execData); err != nil {
+					if newResp, err := api.NewPayloadV1(context.Background(), *execData); err != nil {
 						errMu.Lock()
 						testErr = fmt.Errorf("failed to insert block: %w", err)
 						errMu.Unlock()
@@ -958,7 +963,7 @@ func TestSimultaneousNewBlock(t *testing.T) {
 			for ii := 0; ii < 10; ii++ {
 				go func() {
 					defer wg.Done()
-					if _, err := api.ForkchoiceUpdatedV1(fcState, nil); err != nil {
+					if _, err := api.ForkchoiceUpdatedV1(context.Background(), fcState, nil); err != nil {
 						errMu.Lock()
 						testErr = fmt.Errorf("failed to insert block: %w", err)
 						errMu.Unlock()
@@ -999,7 +1004,7 @@ func TestWithdrawals(t *testing.T) {
 	fcState := engine.ForkchoiceStateV1{
 		HeadBlockHash: parent.Hash(),
 	}
-	resp, err := api.ForkchoiceUpdatedV2(fcState, &blockParams)
+	resp, err := api.ForkchoiceUpdatedV2(context.Background(), fcState, &blockParams)
 	if err != nil {
 		t.Fatalf("error preparing payload, err=%v", err)
 	}
@@ -1026,7 +1031,7 @@ func TestWithdrawals(t *testing.T) {
 	}
 
 	// 10: verify locally built block
-	if status, err := api.NewPayloadV2(*execData.ExecutionPayload); err != nil {
+	if status, err := api.NewPayloadV2(context.Background(), *execData.ExecutionPayload); err != nil {
 		t.Fatalf("error validating payload: %v", err)
 	} else if status.Status != engine.VALID {
 		t.Fatalf("invalid payload")
@@ -1051,7 +1056,7 @@ func TestWithdrawals(t *testing.T) {
 		},
 	}
 	fcState.HeadBlockHash = execData.ExecutionPayload.BlockHash
-	_, err = api.ForkchoiceUpdatedV2(fcState, &blockParams)
+	_, err = api.ForkchoiceUpdatedV2(context.Background(), fcState, &blockParams)
 	if err != nil {
 		t.Fatalf("error preparing payload, err=%v", err)
 	}
@@ -1070,7 +1075,7 @@ func TestWithdrawals(t *testing.T) {
 	if err != nil {
 		t.Fatalf("error getting payload, err=%v", err)
 	}
-	if status, err := api.NewPayloadV2(*execData.ExecutionPayload); err != nil {
+	if status, err := api.NewPayloadV2(context.Background(), *execData.ExecutionPayload); err != nil {
 		t.Fatalf("error validating payload: %v", err)
 	} else if status.Status != engine.VALID {
 		t.Fatalf("invalid payload")
@@ -1078,7 +1083,7 @@ func TestWithdrawals(t *testing.T) {
 
 	// 11: set block as head.
 	fcState.HeadBlockHash = execData.ExecutionPayload.BlockHash
-	_, err = api.ForkchoiceUpdatedV2(fcState, nil)
+	_, err = api.ForkchoiceUpdatedV2(context.Background(), fcState, nil)
 	if err != nil {
 		t.Fatalf("error preparing payload, err=%v", err)
 	}
@@ -1184,10 +1189,10 @@ func TestNilWithdrawals(t *testing.T) {
 		)
 		if !shanghai {
 			payloadVersion = engine.PayloadV1
-			_, err = api.ForkchoiceUpdatedV1(fcState, &test.blockParams)
+			_, err = api.ForkchoiceUpdatedV1(context.Background(), fcState, &test.blockParams)
 		} else {
 			payloadVersion = engine.PayloadV2
-			_, err = api.ForkchoiceUpdatedV2(fcState, &test.blockParams)
+			_, err = api.ForkchoiceUpdatedV2(context.Background(), fcState, &test.blockParams)
 		}
 		if test.wantErr {
 			if err == nil {
@@ -1213,9 +1218,9 @@ func TestNilWithdrawals(t *testing.T) {
 		}
 		var status engine.PayloadStatusV1
 		if !shanghai {
-			status, err = api.NewPayloadV1(*execData.ExecutionPayload)
+			status, err = api.NewPayloadV1(context.Background(), *execData.ExecutionPayload)
 		} else {
-			status, err = api.NewPayloadV2(*execData.ExecutionPayload)
+			status, err = api.NewPayloadV2(context.Background(), *execData.ExecutionPayload)
 		}
 		if err != nil {
 			t.Fatalf("error validating payload: %v", err.(*engine.EngineAPIError).ErrorData())
@@ -1567,7 +1572,7 @@ func TestParentBeaconBlockRoot(t *testing.T) {
 	fcState := engine.ForkchoiceStateV1{
 		HeadBlockHash: parent.Hash(),
 	}
-	resp, err := api.ForkchoiceUpdatedV3(fcState, &blockParams)
+	resp, err := api.ForkchoiceUpdatedV3(context.Background(), fcState, &blockParams)
 	if err != nil {
 		t.Fatalf("error preparing payload, err=%v", err.(*engine.EngineAPIError).ErrorData())
 	}
@@ -1591,14 +1596,14 @@ func TestParentBeaconBlockRoot(t *testing.T) {
 	}
 
 	// 11: verify locally built block
-	if status, err := api.NewPayloadV3(*execData.ExecutionPayload, []common.Hash{}, &common.Hash{42}); err != nil {
+	if status, err := api.NewPayloadV3(context.Background(), *execData.ExecutionPayload, []common.Hash{}, &common.Hash{42}); err != nil {
 		t.Fatalf("error validating payload: %v", err)
 	} else if status.Status != engine.VALID {
 		t.Fatalf("invalid payload")
 	}
 
 	fcState.HeadBlockHash = execData.ExecutionPayload.BlockHash
-	resp, err = api.ForkchoiceUpdatedV3(fcState, nil)
+	resp, err = api.ForkchoiceUpdatedV3(context.Background(), fcState, nil)
 	if err != nil {
 		t.Fatalf("error preparing payload, err=%v", err.(*engine.EngineAPIError).ErrorData())
 	}
@@ -1654,7 +1659,7 @@ func TestWitnessCreationAndConsumption(t *testing.T) {
 		SafeBlockHash:      common.Hash{},
 		FinalizedBlockHash: common.Hash{},
 	}
-	_, err := api.ForkchoiceUpdatedWithWitnessV3(fcState, &blockParams)
+	_, err := api.ForkchoiceUpdatedWithWitnessV3(context.Background(), fcState, &blockParams)
 	if err != nil {
 		t.Fatalf("error preparing payload, err=%v", err)
 	}
@@ -1698,7 +1703,7 @@ func TestWitnessCreationAndConsumption(t *testing.T) {
 	envelope.ExecutionPayload.StateRoot = wantStateRoot
 	envelope.ExecutionPayload.ReceiptsRoot = wantReceiptRoot
 
-	res2, err := api.NewPayloadWithWitnessV3(*envelope.ExecutionPayload, []common.Hash{}, &common.Hash{42})
+	res2, err := api.NewPayloadWithWitnessV3(context.Background(), *envelope.ExecutionPayload, []common.Hash{}, &common.Hash{42})
 	if err != nil {
 		t.Fatalf("error executing stateless payload witness: %v", err)
 	}
@@ -1804,3 +1809,212 @@ func TestValidateRequests(t *testing.T) {
 		})
 	}
 }
+
+// TestSSZTransport checks that the binary transport of the engine API gives the
+// same results as the JSON-RPC methods.
+func TestSSZTransport(t *testing.T) {
+	genesis, blocks := generateMergeChain(10, true)
+
+	// Set cancun time to last block + 5 seconds
+	time := blocks[len(blocks)-1].Time() + 5
+	genesis.Config.ShanghaiTime = &time
+	genesis.Config.CancunTime = &time
+	genesis.Config.BlobScheduleConfig = params.DefaultBlobSchedule
+
+	n, ethservice := startEthService(t, genesis, blocks)
+	defer n.Close()
+
+	// Admit the calls by an access policy denying one of the methods.
+	policy := rpc.NewServer()
+	defer policy.Stop()
+	policy.SetAccessPolicy(&rpc.AccessPolicy{Default: rpc.AccessRule{Deny: []string{"engine_getPayloadV1"}}})
+
+	var (
+		api    = NewConsensusAPI(ethservice)
+		srv    = httptest.NewServer(newSSZHandler(api, policy.Admit))
+		parent = ethservice.BlockChain().CurrentHeader()
+		signer = types.LatestSigner(ethservice.BlockChain().Config())
+	)
+	defer srv.Close()
+
+	tx, _ := types.SignTx(types.NewTransaction(ethservice.TxPool().Nonce(testAddr), testAddr, big.NewInt(1), params.TxGas, big.NewInt(2*params.InitialBaseFee), nil), signer, testKey)
+	ethservice.TxPool().Add([]*types.Transaction{tx}, true)
+
+	// Build a payload and wait for it to include the transaction.
+	args := &miner.BuildPayloadArgs{
+		Parent:      parent.Hash(),
+		Timestamp:   parent.Time + 5,
+		Withdrawals: []*types.Withdrawal{{Index: 1, Validator: 2, Address: common.Address{3}, Amount: 4}},
+		BeaconRoot:  &common.Hash{42},
+		Version:     engine.PayloadV3,
+	}
+	payload, err := ethservice.Miner().BuildPayload(args, false)
+	if err != nil {
+		t.Fatalf("error building payload: %v", err)
+	}
+	payload.ResolveFull()
+	id := args.Id()
+	api.localBlocks.put(id, payload)
+
+	post := func(method string, body []byte) (*http.Response, []byte) {
+		resp, err := http.Post(srv.URL+SSZPath+method, "application/octet-stream", bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("%s: request failed: %v", method, err)
+		}
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("%s: failed to read response: %v", method, err)
+		}
+		return resp, data
+	}
+
+	// Retrieve the payload through both transports.
+	want, err := api.GetPayloadV3(id)
+	if err != nil {
+		t.Fatalf("error getting payload: %v", err)
+	}
+	if len(want.ExecutionPayload.Transactions) != 1 {
+		t.Fatalf("invalid number of transactions, have %d want 1", len(want.ExecutionPayload.Transactions))
+	}
+	resp, data := post("getPayloadV3", id[:])
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("getPayloadV3: unexpected status %d: %s", resp.StatusCode, data)
+	}
+	var have engine.ExecutionPayloadEnvelope
+	if err := have.DecodeSSZ(3, data); err != nil {
+		t.Fatalf("error decoding payload: %v", err)
+	}
+	wantJSON, _ := json.Marshal(want)
+	haveJSON, _ := json.Marshal(&have)
+	if !bytes.Equal(haveJSON, wantJSON) {
+		t.Fatalf("payload mismatch\nhave %s\nwant %s", haveJSON, wantJSON)
+	}
+
+	// Import the payload through the binary transport, the JSON-RPC method must
+	// then report the same status.
+	req := engine.NewPayloadRequest{
+		ExecutionPayload: want.ExecutionPayload,
+		VersionedHashes:  []common.Hash{},
+		BeaconRoot:       args.BeaconRoot,
+	}
+	body, err := req.EncodeSSZ(3)
+	if err != nil {
+		t.Fatalf("error encoding request: %v", err)
+	}
+	resp, data = post("newPayloadV3", body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("newPayloadV3: unexpected status %d: %s", resp.StatusCode, data)
+	}
+	var status engine.PayloadStatusV1
+	if err := json.Unmarshal(data, &status); err != nil {
+		t.Fatalf("error decoding status: %v", err)
+	}
+	if status.Status != engine.VALID {
+		t.Fatalf("invalid payload status %s", status.Status)
+	}
+	wantStatus, err := api.NewPayloadV3(context.Background(), *want.ExecutionPayload, []common.Hash{}, args.BeaconRoot)
+	if err != nil {
+		t.Fatalf("error validating payload: %v", err)
+	}
+	if !reflect.DeepEqual(status, wantStatus) {
+		t.Fatalf("status mismatch: have %+v want %+v", status, wantStatus)
+	}
+
+	// Engine API errors are reported with their code.
+	for method, body := range map[string][]byte{
+		"getPayloadV3": {byte(engine.PayloadV3), 1, 2, 3, 4, 5, 6, 7},
+		"getPayloadV2": id[:],
+		"newPayloadV3": body[:100],
+	} {
+		resp, data := post(method, body)
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("%s: unexpected status %d", method, resp.StatusCode)
+		}
+		var rpcErr struct{ Code int }
+		if err := json.Unmarshal(data, &rpcErr); err != nil {
+			t.Fatalf("%s: error decoding error: %v", method, err)
+		}
+		var want engine.EngineAPIError
+		switch method {
+		case "getPayloadV3":
+			want = *engine.UnknownPayload
+		case "getPayloadV2":
+			want = *engine.UnsupportedFork
+		default:
+			want = *engine.InvalidParams
+		}
+		if rpcErr.Code != want.ErrorCode() {
+			t.Fatalf("%s: unexpected error code %d, want %d", method, rpcErr.Code, want.ErrorCode())
+		}
+	}
+	if resp, _ := post("newPayloadV9", nil); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("unexpected status %d for unknown method", resp.StatusCode)
+	}
+	if resp, _ := post("getPayloadV1", id[:]); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("unexpected status %d for denied method", resp.StatusCode)
+	}
+
+	// The binary transport is only advertised if it is mounted.
+	if slices.Contains(api.ExchangeCapabilities(nil), "engine_newPayloadV3+ssz") {
+		t.Fatal("unmounted binary transport advertised in capabilities")
+	}
+	api.sszServed = true
+	if !slices.Contains(api.ExchangeCapabilities(nil), "engine_newPayloadV3+ssz") {
+		t.Fatal("binary transport not advertised in capabilities")
+	}
+}
+
+// TestGetPayloadForkChecks checks that GetPayloadV4 only serves Prague payloads
+// and GetPayloadV5 only serves Osaka payloads.
+func TestGetPayloadForkChecks(t *testing.T) {
+	for _, osaka := range []bool{false, true} {
+		genesis, blocks := generateMergeChain(10, true)
+
+		time := blocks[len(blocks)-1].Time() + 5
+		genesis.Config.ShanghaiTime = &time
+		genesis.Config.CancunTime = &time
+		genesis.Config.PragueTime = &time
+		if osaka {
+			genesis.Config.OsakaTime = &time
+		}
+		genesis.Config.BlobScheduleConfig = params.DefaultBlobSchedule
+
+		n, ethservice := startEthService(t, genesis, blocks)
+		api := NewConsensusAPI(ethservice)
+
+		parent := ethservice.BlockChain().CurrentHeader()
+		args := &miner.BuildPayloadArgs{
+			Parent:      parent.Hash(),
+			Timestamp:   parent.Time + 5,
+			Withdrawals: []*types.Withdrawal{},
+			BeaconRoot:  &common.Hash{42},
+			Version:     engine.PayloadV3,
+		}
+		payload, err := ethservice.Miner().BuildPayload(args, false)
+		if err != nil {
+			t.Fatalf("error building payload: %v", err)
+		}
+		id := args.Id()
+		api.localBlocks.put(id, payload)
+
+		v4, err4 := api.GetPayloadV4(id)
+		v5, err5 := api.GetPayloadV5(id)
+		if osaka {
+			if err4 != engine.UnsupportedFork {
+				t.Errorf("GetPayloadV4 served Osaka payload: %v", err4)
+			}
+			if err5 != nil || v5 == nil {
+				t.Errorf("GetPayloadV5 failed for Osaka payload: %v", err5)
+			}
+		} else {
+			if err4 != nil || v4 == nil {
+				t.Errorf("GetPayloadV4 failed for Prague payload: %v", err4)
+			}
+			if err5 != engine.UnsupportedFork {
+				t.Errorf("GetPayloadV5 served Prague payload: %v", err5)
+			}
+		}
+		n.Close()
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package catalyst
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params/forks"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// SSZPath is the path of the binary engine API on the authenticated endpoint.
+	// Methods are served below it, e.g. POST /engine/ssz/newPayloadV3.
+	SSZPath = "/engine/ssz/"
+
+	// sszCapabilitySuffix marks the engine API methods which are also served
+	// over the binary transport in the exchanged capabilities.
+	sszCapabilitySuffix = "+ssz"
+
+	// maxSSZRequestSize is the maximum size of a binary request body, in line
+	// with the body limit of the JSON-RPC engine endpoint.
+	maxSSZRequestSize = 128 * 1024 * 1024
+)
+
+// sszCaps are the engine API methods provided over the binary transport.
+var sszCaps = []string{
+	"engine_getPayloadV1" + sszCapabilitySuffix,
+	"engine_getPayloadV2" + sszCapabilitySuffix,
+	"engine_getPayloadV3" + sszCapabilitySuffix,
+	"engine_getPayloadV4" + sszCapabilitySuffix,
+	"engine_getPayloadV5" + sszCapabilitySuffix,
+	"engine_newPayloadV1" + sszCapabilitySuffix,
+	"engine_newPayloadV2" + sszCapabilitySuffix,
+	"engine_newPayloadV3" + sszCapabilitySuffix,
+	"engine_newPayloadV4" + sszCapabilitySuffix,
+}
+
+// sszHandler serves the binary transport of the engine API. The payloads are
+// carried SSZ encoded in the request and response bodies, but the requests are
+// handled by the same methods as the JSON-RPC engine API:
+//
+//   - newPayloadVn takes the SSZ encoded parameters of engine_newPayloadVn and
+//     responds with the payload status as JSON.
+//   - getPayloadVn takes the 8-byte payload ID and responds with the SSZ encoded
+//     result of engine_getPayloadVn.
+//
+// Errors are returned as JSON objects with the code and message of the engine
+// API error.
+//
+// The calls are admitted under the name of the JSON-RPC method, so the access
+// policy and the rate limit of the authenticated endpoint apply to them too.
+type sszHandler struct {
+	api   *ConsensusAPI
+	admit func(r *http.Request, method string) error // nil admits all calls
+}
+
+// newSSZHandler creates the binary transport handler of the engine API.
+func newSSZHandler(api *ConsensusAPI, admit func(r *http.Request, method string) error) *sszHandler {
+	return &sszHandler{api: api, admit: admit}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *sszHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	method, version, ok := parseSSZMethod(strings.TrimPrefix(r.URL.Path, SSZPath))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if h.admit != nil {
+		if err := h.admit(r, fmt.Sprintf("engine_%sV%d", method, version)); err != nil {
+			writeSSZError(w, err)
+			return
+		}
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxSSZRequestSize+1))
+	if err != nil {
+		writeSSZError(w, engine.InvalidParams.With(err))
+		return
+	}
+	if len(body) > maxSSZRequestSize {
+		http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	switch method {
+	case "newPayload":
+		status, err := h.newPayload(r.Context(), version, body)
+		if err != nil {
+			writeSSZError(w, err)
+			return
+		}
+		w.Header().Set("content-type", "application/json")
+		json.NewEncoder(w).Encode(status)
+
+	case "getPayload":
+		result, err := h.getPayload(version, body)
+		if err != nil {
+			writeSSZError(w, err)
+			return
+		}
+		w.Header().Set("content-type", "application/octet-stream")
+		w.Write(result)
+	}
+}
+
+// parseSSZMethod splits the name of a binary engine API method into its name
+// and version, reporting whether the method is served.
+func parseSSZMethod(name string) (string, int, bool) {
+	method, suffix, ok := strings.Cut(name, "V")
+	if !ok {
+		return "", 0, false
+	}
+	version, err := strconv.Atoi(suffix)
+	if err != nil || version < 1 || strconv.Itoa(version) != suffix {
+		return "", 0, false
+	}
+	switch {
+	case method == "newPayload" && version <= 4:
+	case method == "getPayload" && version <= 5:
+	default:
+		return "", 0, false
+	}
+	return method, version, true
+}
+
+// newPayload decodes the parameters of the given version of engine_newPayload
+// and passes them to the consensus API.
+func (h *sszHandler) newPayload(ctx context.Context, version int, body []byte) (engine.PayloadStatusV1, error) {
+	var req engine.NewPayloadRequest
+	if err := req.DecodeSSZ(version, body); err != nil {
+		return engine.PayloadStatusV1{}, engine.InvalidParams.With(err)
+	}
+	params := *req.ExecutionPayload
+
+	switch version {
+	case 1:
+		return h.api.NewPayloadV1(ctx, params)
+	case 2:
+		// The Capella schema always carries the withdrawals, but the JSON-RPC
+		// method expects them to be omitted before Shanghai.
+		if len(params.Withdrawals) == 0 && h.api.eth.BlockChain().Config().LatestFork(params.Timestamp) < forks.Shanghai {
+			params.Withdrawals = nil
+		}
+		return h.api.NewPayloadV2(ctx, params)
+	case 3:
+		return h.api.NewPayloadV3(ctx, params, req.VersionedHashes, req.BeaconRoot)
+	default:
+		requests := make([]hexutil.Bytes, len(req.Requests))
+		for i, request := range req.Requests {
+			requests[i] = request
+		}
+		return h.api.NewPayloadV4(ctx, params, req.VersionedHashes, req.BeaconRoot, requests)
+	}
+}
+
+// getPayload retrieves the payload with the ID in the request body from the
+// consensus API and returns its SSZ encoding for the given version of
+// engine_getPayload.
+func (h *sszHandler) getPayload(version int, body []byte) ([]byte, error) {
+	var id engine.PayloadID
+	if len(body) != len(id) {
+		return nil, engine.InvalidParams.With(fmt.Errorf("invalid payload ID length %d", len(body)))
+	}
+	copy(id[:], body)
+
+	var (
+		envelope *engine.ExecutionPayloadEnvelope
+		err      error
+	)
+	switch version {
+	case 1:
+		var data *engine.ExecutableData
+		if data, err = h.api.GetPayloadV1(id); err == nil {
+			envelope = &engine.ExecutionPayloadEnvelope{ExecutionPayload: data, BlockValue: new(big.Int)}
+		}
+	case 2:
+		envelope, err = h.api.GetPayloadV2(id)
+	case 3:
+		envelope, err = h.api.GetPayloadV3(id)
+	case 4:
+		envelope, err = h.api.GetPayloadV4(id)
+	default:
+		envelope, err = h.api.GetPayloadV5(id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result, err := envelope.EncodeSSZ(version)
+	if err != nil {
+		log.Warn("Failed to encode payload", "id", id, "err", err)
+		return nil, engine.GenericServerError.With(err)
+	}
+	return result, nil
+}
+
+// sszError is the JSON object returned for failed binary engine API requests.
+type sszError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// writeSSZError writes the error response of a binary engine API request.
+// Engine API errors are returned with status 400, calls rejected by the access
+// policy or the rate limit with 403 and 429, and other failures with 500.
+func writeSSZError(w http.ResponseWriter, err error) {
+	var (
+		resp   = sszError{Code: engine.GenericServerError.ErrorCode(), Message: err.Error()}
+		status = http.StatusInternalServerError
+		rpcErr rpc.Error
+	)
+	if errors.As(err, &rpcErr) {
+		resp.Code = rpcErr.ErrorCode()
+		switch {
+		case resp.Code == engine.GenericServerError.ErrorCode():
+		case rpc.IsAccessDenied(err):
+			status = http.StatusForbidden
+		case rpc.IsLimitExceeded(err):
+			status = http.StatusTooManyRequests
+		default:
+			status = http.StatusBadRequest
+		}
+	}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		resp.Data = dataErr.ErrorData()
+	}
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -81,6 +81,10 @@ func (api *DownloaderAPI) eventLoop() {
 				prog.TxIndexFinishedBlocks = txProg.Indexed
 				prog.TxIndexRemainingBlocks = txProg.Remaining
 			}
+			if pruneProg, err := api.chain.HistoryPruneProgress(); err == nil {
+				prog.HistoryPrunedBlocks = pruneProg.Pruned
+				prog.HistoryPruneRemainingBlocks = pruneProg.Remaining
+			}
 			return prog
 		}
 	)
@@ -124,6 +124,26 @@ type downloadTesterPeer struct {
 	withholdBodies map[common.Hash]struct{}
 	id             string
 	chain          *core.BlockChain
+
+	blockRange     *eth.BlockRangeUpdatePacket // Announced block range, nil for full history
+	prunedRequests atomic.Int32                // Number of requested items outside the announced range
+}
+
+// BlockRange returns the block range announced by the peer.
+func (dlp *downloadTesterPeer) BlockRange() *eth.BlockRangeUpdatePacket {
+	return dlp.blockRange
+}
+
+// trackPruned counts the requested blocks that the peer announced to have pruned.
+func (dlp *downloadTesterPeer) trackPruned(hashes []common.Hash) {
+	if dlp.blockRange == nil {
+		return
+	}
+	for _, hash := range hashes {
+		if header := dlp.chain.GetHeaderByHash(hash); header != nil && header.Number.Uint64() < dlp.blockRange.EarliestBlock {
+			dlp.prunedRequests.Add(1)
+		}
+	}
 }
 
 func unmarshalRlpHeaders(rlpdata []rlp.RawValue) []*types.Header {
@@ -212,6 +232,7 @@ func (dlp *downloadTesterPeer) RequestHeadersByNumber(origin uint64, amount int,
 // peer in the download tester. The returned function can be used to retrieve
 // batches of block bodies from the particularly requested peer.
 func (dlp *downloadTesterPeer) RequestBodies(hashes []common.Hash, sink chan *eth.Response) (*eth.Request, error) {
+	dlp.trackPruned(hashes)
 	blobs := eth.ServiceGetBlockBodiesQuery(dlp.chain, hashes)
 
 	bodies := make([]*eth.BlockBody, len(blobs))
@@ -255,7 +276,8 @@ func (dlp *downloadTesterPeer) RequestBodies(hashes []common.Hash, sink chan *et
 // peer in the download tester. The returned function can be used to retrieve
 // batches of block receipts from the particularly requested peer.
 func (dlp *downloadTesterPeer) RequestReceipts(hashes []common.Hash, sink chan *eth.Response) (*eth.Request, error) {
-	blobs := eth.ServiceGetReceiptsQuery(dlp.chain, hashes)
+	dlp.trackPruned(hashes)
+	blobs := eth.ServiceGetReceiptsQuery68(dlp.chain, hashes)
 
 	receipts := make([][]*types.Receipt, len(blobs))
 	for i, blob := range blobs {
@@ -542,6 +564,7 @@ func testMultiProtoSync(t *testing.T, protocol uint, mode SyncMode) {
 
 	// Create peers of every type
 	tester.newPeer("peer 68", eth.ETH68, chain.blocks[1:])
+	tester.newPeer("peer 69", eth.ETH69, chain.blocks[1:])
 
 	if err := tester.downloader.BeaconSync(mode, chain.blocks[len(chain.blocks)-1].Header(), nil); err != nil {
 		t.Fatalf("failed to start beacon sync: #{err}")
@@ -555,7 +578,7 @@ func testMultiProtoSync(t *testing.T, protocol uint, mode SyncMode) {
 	assertOwnChain(t, tester, len(chain.blocks))
 
 	// Check that no peers have been dropped off
-	for _, version := range []int{68} {
+	for _, version := range []int{68, 69} {
 		peer := fmt.Sprintf("peer %d", version)
 		if _, ok := tester.peers[peer]; !ok {
 			t.Errorf("%s dropped", peer)
@@ -563,6 +586,47 @@ func testMultiProtoSync(t *testing.T, protocol uint, mode SyncMode) {
 	}
 }
 
+// Tests that peers announcing a pruned chain history are not asked for block
+// bodies and receipts they do not have.
+func TestPrunedPeerSynchronisation69Full(t *testing.T) { testPrunedPeerSync(t, eth.ETH69, FullSync) }
+func TestPrunedPeerSynchronisation69Snap(t *testing.T) { testPrunedPeerSync(t, eth.ETH69, SnapSync) }
+
+func testPrunedPeerSync(t *testing.T, protocol uint, mode SyncMode) {
+	complete := make(chan struct{})
+	success := func() {
+		close(complete)
+	}
+	tester := newTesterWithNotification(t, success)
+	defer tester.terminate()
+
+	// Create a small enough block chain to download, served by a full peer and
+	// a peer that pruned the first half of the chain
+	chain := testChainBase.shorten(blockCacheMaxItems - 15)
+	head := chain.blocks[len(chain.blocks)-1]
+
+	tester.newPeer("full", protocol, chain.blocks[1:])
+	pruned := tester.newPeer("pruned", protocol, chain.blocks[1:])
+	pruned.blockRange = &eth.BlockRangeUpdatePacket{
+		EarliestBlock:   uint64(len(chain.blocks) / 2),
+		LatestBlock:     head.NumberU64(),
+		LatestBlockHash: head.Hash(),
+	}
+	if err := tester.downloader.BeaconSync(mode, head.Header(), nil); err != nil {
+		t.Fatalf("failed to start beacon sync: %v", err)
+	}
+	select {
+	case <-complete:
+		break
+	case <-time.NewTimer(time.Second * 3).C:
+		t.Fatalf("Failed to sync chain in three seconds")
+	}
+	assertOwnChain(t, tester, len(chain.blocks))
+
+	if n := pruned.prunedRequests.Load(); n != 0 {
+		t.Errorf("pruned peer asked for %d unavailable items", n)
+	}
+}
+
 // Tests that if a block is empty (e.g. header only), no body request should be
 // made, and instead the header should be assembled into a whole block in itself.
 func TestEmptyShortCircuit68Full(t *testing.T) { testEmptyShortCircuit(t, eth.ETH68, FullSync) }
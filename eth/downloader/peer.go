@@ -61,6 +61,10 @@ type Peer interface {
 
 	RequestBodies([]common.Hash, chan *eth.Response) (*eth.Request, error)
 	RequestReceipts([]common.Hash, chan *eth.Response) (*eth.Request, error)
+
+	// BlockRange returns the range of blocks the peer announced to serve, or
+	// nil if it's unknown and the full chain history should be assumed.
+	BlockRange() *eth.BlockRangeUpdatePacket
 }
 
 // newPeerConnection creates a new downloader peer.
@@ -130,6 +134,14 @@ func (p *peerConnection) ReceiptCapacity(targetRTT time.Duration) int {
 	return cap
 }
 
+// ServesBlock reports whether the peer is expected to be able to serve the body
+// and receipts of the given block, based on the block range it announced. Peers
+// not announcing a range are assumed to retain the full chain history.
+func (p *peerConnection) ServesBlock(number uint64) bool {
+	blockRange := p.peer.BlockRange()
+	return blockRange == nil || number >= blockRange.EarliestBlock
+}
+
 // MarkLacking appends a new entity to the set of items (blocks, receipts, states)
 // that a peer is known not to have (i.e. have been requested before). If the
 // set reaches its maximum allowed capacity, items are randomly dropped off.
@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/trie"
@@ -85,10 +86,19 @@ func (chain *chainData) Len() int {
 	return len(chain.blocks)
 }
 
+// fullHistoryPeer is a retrieval peer that only announces serving the full
+// chain history. It must not be used for actual data retrievals.
+type fullHistoryPeer struct {
+	Peer
+}
+
+func (fullHistoryPeer) BlockRange() *eth.BlockRangeUpdatePacket { return nil }
+
 func dummyPeer(id string) *peerConnection {
 	p := &peerConnection{
 		id:      id,
 		lacking: make(map[common.Hash]struct{}),
+		peer:    fullHistoryPeer{},
 	}
 	return p
 }
@@ -205,6 +205,10 @@ func (p *skeletonTestPeer) RequestReceipts([]common.Hash, chan *eth.Response) (*
 	panic("skeleton sync must not request receipts")
 }
 
+func (p *skeletonTestPeer) BlockRange() *eth.BlockRangeUpdatePacket {
+	return nil
+}
+
 // Tests various sync initializations based on previous leftovers in the database
 // and announced heads.
 func TestSkeletonSyncInit(t *testing.T) {
@@ -49,26 +49,28 @@ var FullNodeGPO = gasprice.Config{
 
 // Defaults contains default settings for use on the Ethereum main net.
 var Defaults = Config{
-	HistoryMode:        history.KeepAll,
-	SyncMode:           SnapSync,
-	NetworkId:          0, // enable auto configuration of networkID == chainID
-	TxLookupLimit:      2350000,
-	TransactionHistory: 2350000,
-	LogHistory:         2350000,
-	StateHistory:       params.FullImmutabilityThreshold,
-	DatabaseCache:      512,
-	TrieCleanCache:     154,
-	TrieDirtyCache:     256,
-	TrieTimeout:        60 * time.Minute,
-	SnapshotCache:      102,
-	FilterLogCacheSize: 32,
-	Miner:              miner.DefaultConfig,
-	TxPool:             legacypool.DefaultConfig,
-	BlobPool:           blobpool.DefaultConfig,
-	RPCGasCap:          50000000,
-	RPCEVMTimeout:      5 * time.Second,
-	GPO:                FullNodeGPO,
-	RPCTxFeeCap:        1, // 1 ether
+	HistoryMode:         history.KeepAll,
+	HistoryRetention:    2350000,
+	SyncMode:            SnapSync,
+	NetworkId:           0, // enable auto configuration of networkID == chainID
+	TxLookupLimit:       2350000,
+	TransactionHistory:  2350000,
+	LogHistory:          2350000,
+	StateHistory:        params.FullImmutabilityThreshold,
+	StatePruneBloomSize: 2048,
+	DatabaseCache:       512,
+	TrieCleanCache:      154,
+	TrieDirtyCache:      256,
+	TrieTimeout:         60 * time.Minute,
+	SnapshotCache:       102,
+	FilterLogCacheSize:  32,
+	Miner:               miner.DefaultConfig,
+	TxPool:              legacypool.DefaultConfig,
+	BlobPool:            blobpool.DefaultConfig,
+	RPCGasCap:           50000000,
+	RPCEVMTimeout:       5 * time.Second,
+	GPO:                 FullNodeGPO,
+	RPCTxFeeCap:         1, // 1 ether
 }
 
 //go:generate go run github.com/fjl/gencodec -type Config -formats toml -out gen_config.go
@@ -87,6 +89,14 @@ type Config struct {
 	// HistoryMode configures chain history retention.
 	HistoryMode history.HistoryMode
 
+	// HistoryRetention is the number of recent blocks whose bodies and receipts
+	// are kept, only relevant in the "recent" history mode.
+	HistoryRetention uint64
+
+	// HistoryEraDir is the directory of verified Era1 archives, used to serve
+	// the chain history pruned from the local database.
+	HistoryEraDir string
+
 	// This can be set to list of enrtree:// URLs which will be queried for
 	// nodes to connect to.
 	EthDiscoveryURLs  []string
@@ -110,6 +120,16 @@ type Config struct {
 	// consistent with persistent state.
 	StateScheme string `toml:",omitempty"`
 
+	// StatePruneInterval is the number of blocks between two runs of the online
+	// state pruning, zero means disabled. Only supported in the hash scheme.
+	StatePruneInterval  uint64 `toml:",omitempty"`
+	StatePruneBloomSize uint64 `toml:",omitempty"` // Megabytes of memory allocated to the bloom filter of state pruning
+
+	// SnapServeDepth is the number of states below the persistent state which
+	// can be rolled back from the state histories for serving snap sync peers,
+	// zero means disabled. Only supported in the path scheme.
+	SnapServeDepth uint64 `toml:",omitempty"`
+
 	// RequiredBlocks is a set of block number -> hash mappings which must be in the
 	// canonical chain of all remote peers. Setting the option makes geth verify the
 	// presence of these blocks for every new peer connection.
@@ -143,6 +163,9 @@ type Config struct {
 	// Enables tracking of SHA3 preimages in the VM
 	EnablePreimageRecording bool
 
+	// Enables parallel execution of blocks carrying access lists (EIP-7928)
+	ParallelExecution bool
+
 	// Enables VM tracing
 	VMTrace           string
 	VMTraceJsonConfig string
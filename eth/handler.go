@@ -48,10 +48,17 @@ const (
 	// The number is referenced from the size of tx pool.
 	txChanSize = 4096
 
+	// chainHeadChanSize is the size of channel listening to ChainHeadEvent.
+	chainHeadChanSize = 10
+
 	// txMaxBroadcastSize is the max size of a transaction that will be broadcasted.
 	// All transactions with a higher size will be announced and need to be fetched
 	// by the peer.
 	txMaxBroadcastSize = 4096
+
+	// blockRangeUpdateInterval is the number of blocks the local chain head needs
+	// to advance before the served block range is re-announced to eth/69 peers.
+	blockRangeUpdateInterval = 32
 )
 
 var syncChallengeTimeout = 15 * time.Second // Time allowance for a node to reply to the sync progress challenge
@@ -123,6 +130,9 @@ type handler struct {
 	txsCh    chan core.NewTxsEvent
 	txsSub   event.Subscription
 
+	chainHeadCh  chan core.ChainHeadEvent
+	chainHeadSub event.Subscription
+
 	requiredBlocks map[uint64]common.Hash
 
 	// channels for fetcher, syncer, txsyncLoop
@@ -259,11 +269,9 @@ func (h *handler) runEthPeer(peer *eth.Peer, handler eth.Handler) error {
 	var (
 		genesis = h.chain.Genesis()
 		head    = h.chain.CurrentHeader()
-		hash    = head.Hash()
-		number  = head.Number.Uint64()
 	)
-	forkID := forkid.NewID(h.chain.Config(), genesis, number, head.Time)
-	if err := peer.Handshake(h.networkID, hash, genesis.Hash(), forkID, h.forkFilter); err != nil {
+	forkID := forkid.NewID(h.chain.Config(), genesis, head.Number.Uint64(), head.Time)
+	if err := peer.Handshake(h.networkID, genesis.Hash(), forkID, h.forkFilter, h.blockRange(head)); err != nil {
 		peer.Log().Debug("Ethereum handshake failed", "err", err)
 		return err
 	}
@@ -434,6 +442,12 @@ func (h *handler) Start(maxPeers int) {
 	h.txsSub = h.txpool.SubscribeTransactions(h.txsCh, false)
 	go h.txBroadcastLoop()
 
+	// announce changes in the served block range
+	h.wg.Add(1)
+	h.chainHeadCh = make(chan core.ChainHeadEvent, chainHeadChanSize)
+	h.chainHeadSub = h.chain.SubscribeChainHeadEvent(h.chainHeadCh)
+	go h.blockRangeLoop()
+
 	// start sync handlers
 	h.txFetcher.Start()
 
@@ -443,7 +457,8 @@ func (h *handler) Start(maxPeers int) {
 }
 
 func (h *handler) Stop() {
-	h.txsSub.Unsubscribe() // quits txBroadcastLoop
+	h.txsSub.Unsubscribe()       // quits txBroadcastLoop
+	h.chainHeadSub.Unsubscribe() // quits blockRangeLoop
 	h.txFetcher.Stop()
 	h.downloader.Terminate()
 
@@ -489,6 +504,11 @@ func (h *handler) BroadcastTransactions(txs types.Transactions) {
 		hash   = make([]byte, 32)
 	)
 	for _, tx := range txs {
+		// Conditional transactions are only valid for inclusion by this node,
+		// peers would not enforce the conditions.
+		if meta := h.txpool.GetMetadata(tx.Hash()); meta != nil && meta.Conditional != nil {
+			continue
+		}
 		var maybeDirect bool
 		switch {
 		case tx.Type() == types.BlobTxType:
@@ -552,6 +572,52 @@ func (h *handler) txBroadcastLoop() {
 	}
 }
 
+// blockRange assembles the range of blocks the local node is able to serve, with
+// the given header as the chain head. Blocks before the history pruning cutoff
+// are not available.
+func (h *handler) blockRange(head *types.Header) eth.BlockRangeUpdatePacket {
+	earliest, _ := h.chain.HistoryPruningCutoff()
+	return eth.BlockRangeUpdatePacket{
+		EarliestBlock:   min(earliest, head.Number.Uint64()),
+		LatestBlock:     head.Number.Uint64(),
+		LatestBlockHash: head.Hash(),
+	}
+}
+
+// blockRangeLoop announces changes in the served block range to connected eth/69
+// peers whenever the chain head advances enough, gets rewound or the history is
+// pruned further.
+func (h *handler) blockRangeLoop() {
+	defer h.wg.Done()
+
+	last := h.chain.CurrentHeader().Number.Uint64()
+	earliest, _ := h.chain.HistoryPruningCutoff()
+	for {
+		select {
+		case event := <-h.chainHeadCh:
+			number := event.Header.Number.Uint64()
+			cutoff, _ := h.chain.HistoryPruningCutoff()
+			if number >= last && number < last+blockRangeUpdateInterval && cutoff == earliest {
+				continue
+			}
+			last, earliest = number, cutoff
+
+			// Send to the peers concurrently to avoid a slow peer delaying the
+			// others, but track the sends so they're done before shutdown.
+			update := h.blockRange(event.Header)
+			for _, peer := range h.peers.all() {
+				h.wg.Add(1)
+				go func(peer *ethPeer) {
+					defer h.wg.Done()
+					peer.SendBlockRangeUpdate(update)
+				}(peer)
+			}
+		case <-h.chainHeadSub.Err():
+			return
+		}
+	}
+}
+
 // enableSyncedFeatures enables the post-sync functionalities when the initial
 // sync is finished.
 func (h *handler) enableSyncedFeatures() {
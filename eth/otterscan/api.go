@@ -0,0 +1,794 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package otterscan implements the ots RPC namespace used by the Otterscan
+// block explorer.
+package otterscan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"runtime"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/holiman/uint256"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// apiLevel is the version of the Otterscan API implemented, as expected by
+	// the Otterscan client.
+	apiLevel = 8
+
+	// searchWindow is the number of blocks scanned at once while searching for
+	// the transactions of an address.
+	searchWindow = 1000
+
+	// searchLimit is the maximum number of blocks scanned by a single search
+	// call. Searches reaching it return a partial page, to be continued by the
+	// next call.
+	searchLimit = 10 * searchWindow
+
+	// maxPageSize is the maximum number of transactions or blocks returned in a
+	// single page.
+	maxPageSize = 1000
+)
+
+var errPageSize = fmt.Errorf("page size must be between 1 and %d", maxPageSize)
+
+// Backend is the collection of chain accessors needed by the ots namespace.
+type Backend interface {
+	tracers.Backend
+	CurrentHeader() *types.Header
+	GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error)
+	StateAndHeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*state.StateDB, *types.Header, error)
+	StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error)
+}
+
+// API implements the ots namespace. Call traces are produced by re-executing
+// transactions with the built-in callTracer, address searches scan the senders
+// and recipients of the transactions and query the log index of the filter
+// system.
+type API struct {
+	backend     Backend
+	tracer      *tracers.API
+	filters     *filters.FilterSystem
+	searchLimit uint64 // Maximum number of blocks scanned per search call
+}
+
+// NewAPI creates the ots API on top of the given backend and filter system.
+func NewAPI(backend Backend, filterSystem *filters.FilterSystem) *API {
+	return &API{
+		backend:     backend,
+		tracer:      tracers.NewAPI(backend),
+		filters:     filterSystem,
+		searchLimit: searchLimit,
+	}
+}
+
+// APIs returns the collection of RPC services the otterscan package offers.
+func APIs(backend Backend, filterSystem *filters.FilterSystem) []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "ots",
+			Service:   NewAPI(backend, filterSystem),
+		},
+	}
+}
+
+// GetApiLevel returns the version of the Otterscan API implemented by the node.
+func (api *API) GetApiLevel() uint64 {
+	return apiLevel
+}
+
+// HasCode reports whether the given address holds contract code at the
+// given block.
+func (api *API) HasCode(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (bool, error) {
+	statedb, _, err := api.backend.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if statedb == nil || err != nil {
+		return false, err
+	}
+	return statedb.GetCodeSize(address) > 0, nil
+}
+
+// callFrame is the subset of the callTracer output used by the ots namespace.
+type callFrame struct {
+	Type   string          `json:"type"`
+	From   common.Address  `json:"from"`
+	To     *common.Address `json:"to"`
+	Value  *hexutil.Big    `json:"value"`
+	Input  hexutil.Bytes   `json:"input"`
+	Output hexutil.Bytes   `json:"output"`
+	Error  string          `json:"error"`
+	Calls  []callFrame     `json:"calls"`
+}
+
+// decodeCallFrame converts the result of a callTracer run into a call frame.
+func decodeCallFrame(result interface{}) (*callFrame, error) {
+	blob, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	var frame callFrame
+	if err := json.Unmarshal(blob, &frame); err != nil {
+		return nil, err
+	}
+	return &frame, nil
+}
+
+// traceTransaction re-executes the given transaction with the callTracer. The
+// trace is not allowed to mark the response of the calling method as cacheable.
+func (api *API) traceTransaction(ctx context.Context, hash common.Hash) (*callFrame, error) {
+	tracer := "callTracer"
+	result, err := api.tracer.TraceTransaction(rpc.WithoutResponseCache(ctx), hash, &tracers.TraceConfig{Tracer: &tracer})
+	if err != nil {
+		return nil, err
+	}
+	return decodeCallFrame(result)
+}
+
+// TraceEntry is a single call frame of a transaction trace, flattened in
+// execution order.
+type TraceEntry struct {
+	Type   string          `json:"type"`
+	Depth  int             `json:"depth"`
+	From   common.Address  `json:"from"`
+	To     *common.Address `json:"to"`
+	Value  *hexutil.Big    `json:"value"`
+	Input  hexutil.Bytes   `json:"input"`
+	Output hexutil.Bytes   `json:"output"`
+}
+
+// TraceTransaction returns the flattened call tree of the given transaction.
+func (api *API) TraceTransaction(ctx context.Context, hash common.Hash) ([]*TraceEntry, error) {
+	frame, err := api.traceTransaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		entries []*TraceEntry
+		walk    func(frame *callFrame, depth int)
+	)
+	walk = func(frame *callFrame, depth int) {
+		entries = append(entries, &TraceEntry{
+			Type:   frame.Type,
+			Depth:  depth,
+			From:   frame.From,
+			To:     frame.To,
+			Value:  frame.Value,
+			Input:  frame.Input,
+			Output: frame.Output,
+		})
+		for i := range frame.Calls {
+			walk(&frame.Calls[i], depth+1)
+		}
+	}
+	walk(frame, 0)
+	return entries, nil
+}
+
+// Types of the internal operations reported by GetInternalOperations.
+const (
+	OpTransfer     = 0
+	OpSelfDestruct = 1
+	OpCreate       = 2
+	OpCreate2      = 3
+)
+
+// InternalOperation is a value transfer, contract creation or self-destruct
+// performed by a contract while executing a transaction.
+type InternalOperation struct {
+	Type  int            `json:"type"`
+	From  common.Address `json:"from"`
+	To    common.Address `json:"to"`
+	Value *hexutil.Big   `json:"value"`
+}
+
+// GetInternalOperations returns the internal operations of the given
+// transaction. Operations within reverted call frames are omitted.
+func (api *API) GetInternalOperations(ctx context.Context, hash common.Hash) ([]*InternalOperation, error) {
+	frame, err := api.traceTransaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	ops := []*InternalOperation{}
+
+	var walk func(frame *callFrame, depth int)
+	walk = func(frame *callFrame, depth int) {
+		if frame.Error != "" {
+			return
+		}
+		if depth > 0 && frame.To != nil {
+			op := &InternalOperation{From: frame.From, To: *frame.To, Value: frame.Value}
+			if op.Value == nil {
+				op.Value = new(hexutil.Big)
+			}
+			switch frame.Type {
+			case "CALL":
+				if op.Value.ToInt().Sign() > 0 {
+					op.Type = OpTransfer
+					ops = append(ops, op)
+				}
+			case "SELFDESTRUCT":
+				op.Type = OpSelfDestruct
+				ops = append(ops, op)
+			case "CREATE":
+				op.Type = OpCreate
+				ops = append(ops, op)
+			case "CREATE2":
+				op.Type = OpCreate2
+				ops = append(ops, op)
+			}
+		}
+		for i := range frame.Calls {
+			walk(&frame.Calls[i], depth+1)
+		}
+	}
+	walk(frame, 0)
+	return ops, nil
+}
+
+// GetTransactionError returns the revert data of the given transaction, or
+// empty bytes if it executed successfully.
+func (api *API) GetTransactionError(ctx context.Context, hash common.Hash) (hexutil.Bytes, error) {
+	frame, err := api.traceTransaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if frame.Error == "" {
+		return hexutil.Bytes{}, nil
+	}
+	return frame.Output, nil
+}
+
+// blockByNumber retrieves the canonical block with the given number.
+func (api *API) blockByNumber(ctx context.Context, number uint64) (*types.Block, error) {
+	block, err := api.backend.BlockByNumber(ctx, rpc.BlockNumber(number))
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block #%d not found", number)
+	}
+	return block, nil
+}
+
+// GetBlockDetails returns the header level details of the given block along
+// with the issuance and the fees paid in it.
+func (api *API) GetBlockDetails(ctx context.Context, number uint64) (map[string]interface{}, error) {
+	block, err := api.blockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	return api.blockDetails(ctx, block)
+}
+
+// GetBlockDetailsByHash returns the header level details of the given block
+// along with the issuance and the fees paid in it.
+func (api *API) GetBlockDetailsByHash(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	block, err := api.backend.BlockByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %x not found", hash)
+	}
+	return api.blockDetails(ctx, block)
+}
+
+func (api *API) blockDetails(ctx context.Context, block *types.Block) (map[string]interface{}, error) {
+	receipts, err := api.backend.GetReceipts(ctx, block.Hash())
+	if err != nil {
+		return nil, err
+	}
+	fees := new(big.Int)
+	for _, receipt := range receipts {
+		if receipt.EffectiveGasPrice != nil {
+			fees.Add(fees, new(big.Int).Mul(receipt.EffectiveGasPrice, new(big.Int).SetUint64(receipt.GasUsed)))
+		}
+	}
+	fields := blockFields(block, false, api.backend.ChainConfig())
+
+	return map[string]interface{}{
+		"block":     fields,
+		"issuance":  blockIssuance(api.backend.ChainConfig(), block),
+		"totalFees": (*hexutil.Big)(fees),
+	}, nil
+}
+
+// blockFields marshals a block the way Otterscan expects it: the logs bloom is
+// dropped and the transaction count is added.
+func blockFields(block *types.Block, fullTx bool, config *params.ChainConfig) map[string]interface{} {
+	fields := ethapi.RPCMarshalBlock(block, fullTx, fullTx, config)
+	fields["logsBloom"] = nil
+	fields["transactionCount"] = hexutil.Uint64(len(block.Transactions()))
+	return fields
+}
+
+// Issuance is the amount of ether minted by a block.
+type Issuance struct {
+	BlockReward *hexutil.Big `json:"blockReward"`
+	UncleReward *hexutil.Big `json:"uncleReward"`
+	Issuance    *hexutil.Big `json:"issuance"`
+}
+
+// blockIssuance calculates the ether minted by the given block. Only proof-of-
+// work blocks mint ether, following the ethash reward schedule.
+func blockIssuance(config *params.ChainConfig, block *types.Block) *Issuance {
+	var (
+		header      = block.Header()
+		blockReward = new(uint256.Int)
+		uncleReward = new(uint256.Int)
+	)
+	if config.Ethash != nil && header.Difficulty != nil && header.Difficulty.Sign() > 0 {
+		reward := ethash.FrontierBlockReward
+		if config.IsByzantium(header.Number) {
+			reward = ethash.ByzantiumBlockReward
+		}
+		if config.IsConstantinople(header.Number) {
+			reward = ethash.ConstantinopleBlockReward
+		}
+		blockReward.Set(reward)
+
+		number, _ := uint256.FromBig(header.Number)
+		for _, uncle := range block.Uncles() {
+			r, _ := uint256.FromBig(uncle.Number)
+			r.AddUint64(r, 8)
+			r.Sub(r, number)
+			r.Mul(r, reward)
+			r.Rsh(r, 3)
+			uncleReward.Add(uncleReward, r)
+
+			blockReward.Add(blockReward, new(uint256.Int).Rsh(reward, 5))
+		}
+	}
+	return &Issuance{
+		BlockReward: (*hexutil.Big)(blockReward.ToBig()),
+		UncleReward: (*hexutil.Big)(uncleReward.ToBig()),
+		Issuance:    (*hexutil.Big)(new(uint256.Int).Add(blockReward, uncleReward).ToBig()),
+	}
+}
+
+// GetBlockTransactions returns a page of the transactions of the given block
+// with their receipts. Pages are counted from the end of the block, page zero
+// holding the last transactions.
+func (api *API) GetBlockTransactions(ctx context.Context, number uint64, pageNumber uint64, pageSize uint64) (map[string]interface{}, error) {
+	if pageSize == 0 || pageSize > maxPageSize {
+		return nil, errPageSize
+	}
+	block, err := api.blockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := api.backend.GetReceipts(ctx, block.Hash())
+	if err != nil {
+		return nil, err
+	}
+	txs := block.Transactions()
+	if len(txs) != len(receipts) {
+		return nil, fmt.Errorf("receipts length mismatch: %d vs %d", len(txs), len(receipts))
+	}
+	var (
+		end   = len(txs) - int(min(pageNumber*pageSize, uint64(len(txs))))
+		start = max(end-int(pageSize), 0)
+
+		config = api.backend.ChainConfig()
+		signer = types.MakeSigner(config, block.Number(), block.Time())
+	)
+	fields := blockFields(block, true, config)
+	fields["transactions"] = fields["transactions"].([]interface{})[start:end]
+
+	pageReceipts := make([]map[string]interface{}, 0, end-start)
+	for i := start; i < end; i++ {
+		receipt := ethapi.MarshalReceipt(receipts[i], block.Hash(), block.NumberU64(), signer, txs[i], i)
+		receipt["logs"] = nil
+		receipt["logsBloom"] = nil
+		pageReceipts = append(pageReceipts, receipt)
+	}
+	return map[string]interface{}{
+		"fullblock": fields,
+		"receipts":  pageReceipts,
+	}, nil
+}
+
+// TransactionsWithReceipts is a page of the transactions of an address, in
+// descending chain order.
+type TransactionsWithReceipts struct {
+	Txs       []*ethapi.RPCTransaction `json:"txs"`
+	Receipts  []map[string]interface{} `json:"receipts"`
+	FirstPage bool                     `json:"firstPage"` // Whether there are no newer transactions
+	LastPage  bool                     `json:"lastPage"`  // Whether there are no older transactions
+
+	// NextBlock is the block number to continue the search from, passed to the
+	// next call in the same direction. It is set unless the search reached the
+	// end of the chain.
+	NextBlock *uint64 `json:"nextBlock,omitempty"`
+}
+
+// SearchTransactionsBefore returns the transactions of the given address in the
+// blocks preceding the given block, newest first. Block number zero starts the
+// search at the chain head. Whole blocks are returned, so a page may exceed
+// the requested size.
+//
+// At most searchLimit blocks are scanned per call, the page may thus also be
+// smaller than requested without being the last one. The search then resumes
+// from the NextBlock of the result.
+//
+// A transaction is reported if it was sent from or to the address, deployed it,
+// or emitted a log from the address or with the address as a topic. Internal
+// calls and transfers not emitting such a log are not found.
+func (api *API) SearchTransactionsBefore(ctx context.Context, address common.Address, blockNumber uint64, pageSize uint64) (*TransactionsWithReceipts, error) {
+	if pageSize == 0 || pageSize > maxPageSize {
+		return nil, errPageSize
+	}
+	head := api.backend.CurrentHeader().Number.Uint64()
+
+	result := &TransactionsWithReceipts{FirstPage: blockNumber == 0 || blockNumber > head}
+	if blockNumber == 0 || blockNumber > head+1 {
+		blockNumber = head + 1
+	}
+	var scanned uint64
+	for end := blockNumber; end > 0; {
+		if scanned >= api.searchLimit {
+			result.NextBlock = &end
+			return result, nil
+		}
+		begin := end - min(end, searchWindow, api.searchLimit-scanned)
+		blocks, err := api.searchBlocks(ctx, address, begin, end-1)
+		if err != nil {
+			return nil, err
+		}
+		slices.Reverse(blocks)
+		for _, number := range blocks {
+			if err := api.appendTransactions(ctx, result, address, number, true); err != nil {
+				return nil, err
+			}
+			if uint64(len(result.Txs)) >= pageSize {
+				if number == 0 {
+					result.LastPage = true
+				} else {
+					result.NextBlock = &number
+				}
+				return result, nil
+			}
+		}
+		scanned += end - begin
+		end = begin
+	}
+	result.LastPage = true
+	return result, nil
+}
+
+// SearchTransactionsAfter returns the transactions of the given address in the
+// blocks following the given block, newest first. Block number zero starts the
+// search at genesis. Whole blocks are returned, so a page may exceed the
+// requested size.
+//
+// The same limitations apply as for SearchTransactionsBefore, including the
+// maximum number of blocks scanned per call.
+func (api *API) SearchTransactionsAfter(ctx context.Context, address common.Address, blockNumber uint64, pageSize uint64) (*TransactionsWithReceipts, error) {
+	if pageSize == 0 || pageSize > maxPageSize {
+		return nil, errPageSize
+	}
+	head := api.backend.CurrentHeader().Number.Uint64()
+
+	result := &TransactionsWithReceipts{LastPage: blockNumber == 0}
+	begin := blockNumber + 1
+	if blockNumber == 0 {
+		begin = 0
+	}
+	var scanned uint64
+	for begin <= head {
+		if scanned >= api.searchLimit {
+			next := begin - 1
+			result.NextBlock = &next
+			break
+		}
+		end := min(begin+min(searchWindow, api.searchLimit-scanned)-1, head)
+		blocks, err := api.searchBlocks(ctx, address, begin, end)
+		if err != nil {
+			return nil, err
+		}
+		for _, number := range blocks {
+			if err := api.appendTransactions(ctx, result, address, number, false); err != nil {
+				return nil, err
+			}
+			if uint64(len(result.Txs)) >= pageSize {
+				result.NextBlock = &number
+				break
+			}
+		}
+		if result.NextBlock != nil {
+			break
+		}
+		scanned += end - begin + 1
+		begin = end + 1
+	}
+	slices.Reverse(result.Txs)
+	slices.Reverse(result.Receipts)
+	result.FirstPage = result.NextBlock == nil
+	return result, nil
+}
+
+// searchBlocks returns the numbers of the blocks within [begin, end] holding a
+// transaction related to the address, in ascending order. Transactions sent
+// from or to the address are found by scanning the blocks, the ones emitting a
+// log from the address or carrying it as a topic through the log index.
+func (api *API) searchBlocks(ctx context.Context, address common.Address, begin, end uint64) ([]uint64, error) {
+	blocks, err := api.scanBlocks(ctx, address, begin, end)
+	if err != nil {
+		return nil, err
+	}
+	topic := common.BytesToHash(address.Bytes())
+	queries := []struct {
+		addresses []common.Address
+		topics    [][]common.Hash
+	}{
+		{addresses: []common.Address{address}},
+		{topics: [][]common.Hash{nil, {topic}}},
+		{topics: [][]common.Hash{nil, nil, {topic}}},
+		{topics: [][]common.Hash{nil, nil, nil, {topic}}},
+	}
+	for _, query := range queries {
+		logs, err := api.filters.NewRangeFilter(int64(begin), int64(end), query.addresses, query.topics).Logs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, log := range logs {
+			blocks = append(blocks, log.BlockNumber)
+		}
+	}
+	slices.Sort(blocks)
+	return slices.Compact(blocks), nil
+}
+
+// scanBlocks returns the numbers of the blocks within [begin, end] holding a
+// transaction sent from or to the address, or deploying it. The blocks are
+// scanned concurrently as recovering the senders is expensive. Blocks missing
+// due to history pruning are skipped.
+func (api *API) scanBlocks(ctx context.Context, address common.Address, begin, end uint64) ([]uint64, error) {
+	var (
+		config  = api.backend.ChainConfig()
+		found   = make([]bool, end-begin+1)
+		g, gctx = errgroup.WithContext(ctx)
+	)
+	g.SetLimit(runtime.NumCPU())
+	for number := begin; number <= end; number++ {
+		g.Go(func() error {
+			block, err := api.backend.BlockByNumber(gctx, rpc.BlockNumber(number))
+			if err != nil || block == nil {
+				return err
+			}
+			signer := types.MakeSigner(config, block.Number(), block.Time())
+			for _, tx := range block.Transactions() {
+				to := tx.To()
+				if to != nil && *to == address {
+					found[number-begin] = true
+					return nil
+				}
+				from, err := types.Sender(signer, tx)
+				if err != nil {
+					continue
+				}
+				if from == address || (to == nil && crypto.CreateAddress(from, tx.Nonce()) == address) {
+					found[number-begin] = true
+					return nil
+				}
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	var blocks []uint64
+	for i, ok := range found {
+		if ok {
+			blocks = append(blocks, begin+uint64(i))
+		}
+	}
+	return blocks, nil
+}
+
+// appendTransactions appends the transactions of the given block related to the
+// address to the result, in descending or ascending order.
+func (api *API) appendTransactions(ctx context.Context, result *TransactionsWithReceipts, address common.Address, number uint64, descending bool) error {
+	block, err := api.blockByNumber(ctx, number)
+	if err != nil {
+		return err
+	}
+	receipts, err := api.backend.GetReceipts(ctx, block.Hash())
+	if err != nil {
+		return err
+	}
+	txs := block.Transactions()
+	if len(txs) != len(receipts) {
+		return fmt.Errorf("receipts length mismatch: %d vs %d", len(txs), len(receipts))
+	}
+	var (
+		config = api.backend.ChainConfig()
+		signer = types.MakeSigner(config, block.Number(), block.Time())
+	)
+	for i := range txs {
+		if descending {
+			i = len(txs) - 1 - i
+		}
+		tx, receipt := txs[i], receipts[i]
+		if !related(address, signer, tx, receipt) {
+			continue
+		}
+		fields := ethapi.MarshalReceipt(receipt, block.Hash(), block.NumberU64(), signer, tx, i)
+		fields["timestamp"] = hexutil.Uint64(block.Time())
+
+		result.Txs = append(result.Txs, ethapi.NewRPCTransactionFromBlockIndex(block, uint64(i), config))
+		result.Receipts = append(result.Receipts, fields)
+	}
+	return nil
+}
+
+// related reports whether the transaction was sent from or to the address, or
+// emitted a log related to it.
+func related(address common.Address, signer types.Signer, tx *types.Transaction, receipt *types.Receipt) bool {
+	if from, _ := types.Sender(signer, tx); from == address {
+		return true
+	}
+	if to := tx.To(); to != nil && *to == address {
+		return true
+	}
+	if receipt.ContractAddress == address {
+		return true
+	}
+	topic := common.BytesToHash(address.Bytes())
+	for _, log := range receipt.Logs {
+		if log.Address == address || slices.Contains(log.Topics, topic) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchState returns the first block in [0, head] whose post-state satisfies
+// the given condition, assuming that the condition holds for all the blocks
+// following it. It requires the historical states to be available.
+func (api *API) searchState(ctx context.Context, cond func(*state.StateDB) bool) (uint64, bool, error) {
+	head := api.backend.CurrentHeader().Number.Uint64()
+
+	check := func(number uint64) (bool, error) {
+		statedb, _, err := api.backend.StateAndHeaderByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return false, err
+		}
+		if statedb == nil {
+			return false, fmt.Errorf("state #%d not found", number)
+		}
+		return cond(statedb), nil
+	}
+	if ok, err := check(head); err != nil || !ok {
+		return 0, false, err
+	}
+	lo, hi := uint64(0), head
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		ok, err := check(mid)
+		if err != nil {
+			return 0, false, err
+		}
+		if ok {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo, true, nil
+}
+
+// GetTransactionBySenderAndNonce returns the hash of the transaction sent by the
+// given account with the given nonce, or nil if there is none. The search runs
+// over the historical states, which thus need to be available.
+func (api *API) GetTransactionBySenderAndNonce(ctx context.Context, sender common.Address, nonce uint64) (*common.Hash, error) {
+	number, found, err := api.searchState(ctx, func(statedb *state.StateDB) bool {
+		return statedb.GetNonce(sender) > nonce
+	})
+	if err != nil || !found {
+		return nil, err
+	}
+	block, err := api.blockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	signer := types.MakeSigner(api.backend.ChainConfig(), block.Number(), block.Time())
+	for _, tx := range block.Transactions() {
+		if tx.Nonce() != nonce {
+			continue
+		}
+		if from, _ := types.Sender(signer, tx); from == sender {
+			hash := tx.Hash()
+			return &hash, nil
+		}
+	}
+	// The nonce was consumed by something other than a transaction of the
+	// sender, e.g. a set code authorization.
+	return nil, nil
+}
+
+// ContractCreator is the deployer of a contract.
+type ContractCreator struct {
+	Hash    common.Hash    `json:"hash"`    // Transaction deploying the contract
+	Creator common.Address `json:"creator"` // Account executing the creation
+}
+
+// GetContractCreator returns the transaction that deployed the given contract
+// and the account that created it, or nil if the address holds no code. The
+// search runs over the historical states, which thus need to be available.
+func (api *API) GetContractCreator(ctx context.Context, address common.Address) (*ContractCreator, error) {
+	number, found, err := api.searchState(ctx, func(statedb *state.StateDB) bool {
+		return statedb.GetCodeSize(address) > 0
+	})
+	if err != nil || !found {
+		return nil, err
+	}
+	if number == 0 {
+		return nil, nil // Contract allocated in genesis
+	}
+	tracer := "callTracer"
+	results, err := api.tracer.TraceBlockByNumber(ctx, rpc.BlockNumber(number), &tracers.TraceConfig{Tracer: &tracer})
+	if err != nil {
+		return nil, err
+	}
+	var find func(frame *callFrame) *common.Address
+	find = func(frame *callFrame) *common.Address {
+		if frame.Error != "" {
+			return nil
+		}
+		if (frame.Type == "CREATE" || frame.Type == "CREATE2") && frame.To != nil && *frame.To == address {
+			return &frame.From
+		}
+		for i := range frame.Calls {
+			if creator := find(&frame.Calls[i]); creator != nil {
+				return creator
+			}
+		}
+		return nil
+	}
+	for _, result := range results {
+		if result.Error != "" {
+			return nil, errors.New(result.Error)
+		}
+		frame, err := decodeCallFrame(result.Result)
+		if err != nil {
+			return nil, err
+		}
+		if creator := find(frame); creator != nil {
+			return &ContractCreator{Hash: result.TxHash, Creator: *creator}, nil
+		}
+	}
+	// The code was not deployed by a creation, e.g. a set code authorization.
+	return nil, nil
+}
@@ -0,0 +1,389 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package otterscan
+
+import (
+	"context"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	// Force-load the native tracers to trigger registration
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
+)
+
+var (
+	testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr   = crypto.PubkeyToAddress(testKey.PublicKey)
+
+	// otherKey sends a plain ether transfer, emitting no log, to recipientAddr.
+	otherKey, _   = crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+	otherAddr     = crypto.PubkeyToAddress(otherKey.PublicKey)
+	recipientAddr = common.HexToAddress("0x4444444444444444444444444444444444444444")
+
+	// tokenAddr emits a log with the caller as topic, then forwards 1 wei to calleeAddr.
+	tokenAddr = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	tokenCode = append(append([]byte{
+		byte(vm.CALLER), byte(vm.PUSH1), 0xdd, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.LOG2),
+		byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 1, byte(vm.PUSH20),
+	}, calleeAddr.Bytes()...), byte(vm.GAS), byte(vm.CALL), byte(vm.STOP))
+
+	calleeAddr = common.HexToAddress("0x2222222222222222222222222222222222222222")
+
+	// revertAddr reverts with the 32 byte word 42.
+	revertAddr = common.HexToAddress("0x3333333333333333333333333333333333333333")
+	revertCode = []byte{byte(vm.PUSH1), 42, byte(vm.PUSH1), 0, byte(vm.MSTORE), byte(vm.PUSH1), 32, byte(vm.PUSH1), 0, byte(vm.REVERT)}
+
+	// deployCode is an init code deploying a single STOP opcode.
+	deployCode = []byte{byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.MSTORE8), byte(vm.PUSH1), 1, byte(vm.PUSH1), 0, byte(vm.RETURN)}
+)
+
+// newTestAPI creates a node importing a chain with a token call in every block,
+// a contract deployment in block 2, a reverting transaction in block 3 and a
+// plain transfer between two other accounts in block 4.
+func newTestAPI(t *testing.T) (*API, []*types.Block) {
+	genesis := &core.Genesis{
+		Config: params.MergedTestChainConfig,
+		Alloc: types.GenesisAlloc{
+			testAddr:   {Balance: big.NewInt(params.Ether)},
+			otherAddr:  {Balance: big.NewInt(params.Ether)},
+			tokenAddr:  {Code: tokenCode, Balance: big.NewInt(params.Ether)},
+			revertAddr: {Code: revertCode},
+		},
+	}
+	signer := types.LatestSigner(genesis.Config)
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, beacon.New(ethash.NewFaker()), 4, func(i int, b *core.BlockGen) {
+		call := func(to *common.Address, data []byte) {
+			tx, _ := types.SignNewTx(testKey, signer, &types.DynamicFeeTx{
+				Nonce:     b.TxNonce(testAddr),
+				To:        to,
+				Gas:       100000,
+				GasFeeCap: b.BaseFee(),
+				Data:      data,
+			})
+			b.AddTx(tx)
+		}
+		call(&tokenAddr, nil)
+		switch i {
+		case 1:
+			call(nil, deployCode)
+		case 2:
+			call(&revertAddr, nil)
+		case 3:
+			tx, _ := types.SignNewTx(otherKey, signer, &types.DynamicFeeTx{
+				Nonce:     b.TxNonce(otherAddr),
+				To:        &recipientAddr,
+				Gas:       params.TxGas,
+				GasFeeCap: b.BaseFee(),
+				Value:     big.NewInt(1),
+			})
+			b.AddTx(tx)
+		}
+	})
+	stack, err := node.New(new(node.Config))
+	if err != nil {
+		t.Fatalf("failed to create node: %v", err)
+	}
+	t.Cleanup(func() { stack.Close() })
+
+	ethservice, err := eth.New(stack, &ethconfig.Config{Genesis: genesis, RPCGasCap: 1000000})
+	if err != nil {
+		t.Fatalf("failed to create ethereum service: %v", err)
+	}
+	if err := stack.Start(); err != nil {
+		t.Fatalf("failed to start node: %v", err)
+	}
+	if _, err := ethservice.BlockChain().InsertChain(blocks); err != nil {
+		t.Fatalf("failed to import chain: %v", err)
+	}
+	for ; ; time.Sleep(10 * time.Millisecond) {
+		progress, err := ethservice.BlockChain().TxIndexProgress()
+		if err == nil && progress.Done() {
+			break
+		}
+	}
+	filterSystem := filters.NewFilterSystem(ethservice.APIBackend, filters.Config{})
+	return NewAPI(ethservice.APIBackend, filterSystem), blocks
+}
+
+func TestTransactionTracing(t *testing.T) {
+	api, blocks := newTestAPI(t)
+	ctx := context.Background()
+
+	if level := api.GetApiLevel(); level != apiLevel {
+		t.Errorf("api level mismatch: have %d, want %d", level, apiLevel)
+	}
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if ok, err := api.HasCode(ctx, tokenAddr, latest); err != nil || !ok {
+		t.Errorf("token code not found: %v", err)
+	}
+	if ok, err := api.HasCode(ctx, testAddr, latest); err != nil || ok {
+		t.Errorf("sender code found: %v", err)
+	}
+	// Check the traces of the token call
+	call := blocks[0].Transactions()[0].Hash()
+	entries, err := api.TraceTransaction(ctx, call)
+	if err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("trace entry count mismatch: have %d, want 2", len(entries))
+	}
+	if entries[1].Type != "CALL" || entries[1].Depth != 1 || entries[1].From != tokenAddr || *entries[1].To != calleeAddr {
+		t.Errorf("inner call mismatch: %+v", entries[1])
+	}
+	ops, err := api.GetInternalOperations(ctx, call)
+	if err != nil {
+		t.Fatalf("failed to retrieve internal operations: %v", err)
+	}
+	if len(ops) != 1 || ops[0].Type != OpTransfer || ops[0].To != calleeAddr || ops[0].Value.ToInt().Cmp(common.Big1) != 0 {
+		t.Errorf("internal operations mismatch: %+v", ops)
+	}
+	if data, err := api.GetTransactionError(ctx, call); err != nil || len(data) != 0 {
+		t.Errorf("unexpected error of successful transaction: %x, %v", data, err)
+	}
+	// Check the error of the reverting transaction
+	data, err := api.GetTransactionError(ctx, blocks[2].Transactions()[1].Hash())
+	if err != nil {
+		t.Fatalf("failed to retrieve transaction error: %v", err)
+	}
+	if want := common.BigToHash(big.NewInt(42)).Bytes(); string(data) != string(want) {
+		t.Errorf("revert data mismatch: have %x, want %x", data, want)
+	}
+}
+
+func TestBlockDetails(t *testing.T) {
+	api, blocks := newTestAPI(t)
+	ctx := context.Background()
+
+	details, err := api.GetBlockDetails(ctx, 2)
+	if err != nil {
+		t.Fatalf("failed to retrieve block details: %v", err)
+	}
+	block := details["block"].(map[string]interface{})
+	if count := block["transactionCount"].(hexutil.Uint64); count != 2 {
+		t.Errorf("transaction count mismatch: have %d, want 2", count)
+	}
+	if fees := details["totalFees"].(*hexutil.Big); fees.ToInt().Sign() <= 0 {
+		t.Errorf("total fees not accumulated: %v", fees)
+	}
+	if issuance := details["issuance"].(*Issuance); issuance.Issuance.ToInt().Sign() != 0 {
+		t.Errorf("post-merge issuance reported: %v", issuance.Issuance)
+	}
+	// Page zero holds the last transactions of the block
+	page, err := api.GetBlockTransactions(ctx, 2, 0, 1)
+	if err != nil {
+		t.Fatalf("failed to retrieve block transactions: %v", err)
+	}
+	txs := page["fullblock"].(map[string]interface{})["transactions"].([]interface{})
+	if len(txs) != 1 || txs[0].(*ethapi.RPCTransaction).Hash != blocks[1].Transactions()[1].Hash() {
+		t.Fatalf("first page mismatch: %v", txs)
+	}
+	page, err = api.GetBlockTransactions(ctx, 2, 1, 1)
+	if err != nil {
+		t.Fatalf("failed to retrieve block transactions: %v", err)
+	}
+	txs = page["fullblock"].(map[string]interface{})["transactions"].([]interface{})
+	if len(txs) != 1 || txs[0].(*ethapi.RPCTransaction).Hash != blocks[1].Transactions()[0].Hash() {
+		t.Fatalf("second page mismatch: %v", txs)
+	}
+}
+
+func TestSearchTransactions(t *testing.T) {
+	api, blocks := newTestAPI(t)
+	ctx := context.Background()
+
+	// All the transactions of the sender, newest first
+	var (
+		want     []common.Hash
+		transfer = blocks[3].Transactions()[1]
+	)
+	for i := len(blocks) - 1; i >= 0; i-- {
+		txs := blocks[i].Transactions()
+		for j := len(txs) - 1; j >= 0; j-- {
+			if txs[j] != transfer {
+				want = append(want, txs[j].Hash())
+			}
+		}
+	}
+	check := func(result *TransactionsWithReceipts, want []common.Hash, firstPage, lastPage bool) {
+		t.Helper()
+
+		if len(result.Txs) != len(want) || len(result.Receipts) != len(want) {
+			t.Fatalf("transaction count mismatch: have %d/%d, want %d", len(result.Txs), len(result.Receipts), len(want))
+		}
+		for i, tx := range result.Txs {
+			if tx.Hash != want[i] {
+				t.Errorf("transaction %d mismatch: have %x, want %x", i, tx.Hash, want[i])
+			}
+		}
+		if result.FirstPage != firstPage || result.LastPage != lastPage {
+			t.Errorf("page flags mismatch: have %v/%v, want %v/%v", result.FirstPage, result.LastPage, firstPage, lastPage)
+		}
+	}
+	result, err := api.SearchTransactionsBefore(ctx, testAddr, 0, 2)
+	if err != nil {
+		t.Fatalf("failed to search transactions: %v", err)
+	}
+	check(result, want[:3], true, false) // block 4 and 3 in full
+
+	result, err = api.SearchTransactionsBefore(ctx, testAddr, 3, 100)
+	if err != nil {
+		t.Fatalf("failed to search transactions: %v", err)
+	}
+	check(result, want[3:], false, true)
+
+	result, err = api.SearchTransactionsAfter(ctx, testAddr, 0, 100)
+	if err != nil {
+		t.Fatalf("failed to search transactions: %v", err)
+	}
+	check(result, want, true, true)
+
+	result, err = api.SearchTransactionsAfter(ctx, testAddr, 2, 1)
+	if err != nil {
+		t.Fatalf("failed to search transactions: %v", err)
+	}
+	check(result, want[1:3], false, false) // block 3 in full
+
+	// Searching the callee finds no logs nor direct transactions
+	result, err = api.SearchTransactionsAfter(ctx, calleeAddr, 0, 100)
+	if err != nil {
+		t.Fatalf("failed to search transactions: %v", err)
+	}
+	check(result, nil, true, true)
+
+	// The plain transfer emits no log, but is found for both of its parties
+	for _, addr := range []common.Address{otherAddr, recipientAddr} {
+		result, err = api.SearchTransactionsBefore(ctx, addr, 0, 100)
+		if err != nil {
+			t.Fatalf("failed to search transactions: %v", err)
+		}
+		check(result, []common.Hash{transfer.Hash()}, true, true)
+	}
+}
+
+// Tests that searches stop after scanning the limit of blocks, returning a
+// partial page and the block to continue from.
+func TestSearchTransactionsLimit(t *testing.T) {
+	api, blocks := newTestAPI(t)
+	api.searchLimit = 2
+	ctx := context.Background()
+
+	// The transactions of the sender in each block, newest first
+	related := func(numbers ...uint64) []common.Hash {
+		var hashes []common.Hash
+		for _, number := range numbers {
+			txs := blocks[number-1].Transactions()
+			for i := len(txs) - 1; i >= 0; i-- {
+				if from, _ := types.Sender(types.LatestSigner(params.MergedTestChainConfig), txs[i]); from == testAddr {
+					hashes = append(hashes, txs[i].Hash())
+				}
+			}
+		}
+		return hashes
+	}
+	type page struct {
+		want                []common.Hash
+		firstPage, lastPage bool
+		next                *uint64
+	}
+	check := func(result *TransactionsWithReceipts, err error, want page) {
+		t.Helper()
+
+		if err != nil {
+			t.Fatalf("failed to search transactions: %v", err)
+		}
+		have := make([]common.Hash, len(result.Txs))
+		for i, tx := range result.Txs {
+			have[i] = tx.Hash
+		}
+		if !slices.Equal(have, want.want) {
+			t.Fatalf("transactions mismatch: have %x, want %x", have, want.want)
+		}
+		if result.FirstPage != want.firstPage || result.LastPage != want.lastPage {
+			t.Errorf("page flags mismatch: have %v/%v, want %v/%v", result.FirstPage, result.LastPage, want.firstPage, want.lastPage)
+		}
+		if (result.NextBlock == nil) != (want.next == nil) || (want.next != nil && *result.NextBlock != *want.next) {
+			t.Errorf("next block mismatch: have %v, want %v", result.NextBlock, want.next)
+		}
+	}
+	next := func(n uint64) *uint64 { return &n }
+
+	// Backwards from the head, two blocks at a time
+	result, err := api.SearchTransactionsBefore(ctx, testAddr, 0, 100)
+	check(result, err, page{want: related(4, 3), firstPage: true, next: next(3)})
+	result, err = api.SearchTransactionsBefore(ctx, testAddr, 3, 100)
+	check(result, err, page{want: related(2, 1), next: next(1)})
+	result, err = api.SearchTransactionsBefore(ctx, testAddr, 1, 100)
+	check(result, err, page{lastPage: true})
+
+	// Forwards from genesis, two blocks at a time
+	result, err = api.SearchTransactionsAfter(ctx, testAddr, 0, 100)
+	check(result, err, page{want: related(1), lastPage: true, next: next(1)})
+	result, err = api.SearchTransactionsAfter(ctx, testAddr, 1, 100)
+	check(result, err, page{want: related(3, 2), next: next(3)})
+	result, err = api.SearchTransactionsAfter(ctx, testAddr, 3, 100)
+	check(result, err, page{want: related(4), firstPage: true})
+
+	// Full pages continue from the last returned block
+	result, err = api.SearchTransactionsBefore(ctx, testAddr, 0, 1)
+	check(result, err, page{want: related(4), firstPage: true, next: next(4)})
+}
+
+func TestStateSearches(t *testing.T) {
+	api, blocks := newTestAPI(t)
+	ctx := context.Background()
+
+	deploy := blocks[1].Transactions()[1]
+	hash, err := api.GetTransactionBySenderAndNonce(ctx, testAddr, deploy.Nonce())
+	if err != nil {
+		t.Fatalf("failed to search transaction: %v", err)
+	}
+	if hash == nil || *hash != deploy.Hash() {
+		t.Errorf("transaction mismatch: have %v, want %x", hash, deploy.Hash())
+	}
+	if hash, err := api.GetTransactionBySenderAndNonce(ctx, testAddr, 100); err != nil || hash != nil {
+		t.Errorf("unused nonce found: %v, %v", hash, err)
+	}
+	creator, err := api.GetContractCreator(ctx, crypto.CreateAddress(testAddr, deploy.Nonce()))
+	if err != nil {
+		t.Fatalf("failed to search contract creator: %v", err)
+	}
+	if creator == nil || creator.Hash != deploy.Hash() || creator.Creator != testAddr {
+		t.Errorf("contract creator mismatch: %+v", creator)
+	}
+	if creator, err := api.GetContractCreator(ctx, tokenAddr); err != nil || creator != nil {
+		t.Errorf("genesis contract creator found: %+v, %v", creator, err)
+	}
+}
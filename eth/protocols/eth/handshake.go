@@ -35,25 +35,44 @@ const (
 )
 
 // Handshake executes the eth protocol handshake, negotiating version number,
-// network IDs, difficulties, head and genesis blocks.
-func (p *Peer) Handshake(network uint64, head common.Hash, genesis common.Hash, forkID forkid.ID, forkFilter forkid.Filter) error {
-	// Send out own handshake in a new thread
-	errc := make(chan error, 2)
-
-	var status StatusPacket // safe to read after two values have been received from errc
-
-	go func() {
-		errc <- p2p.Send(p.rw, StatusMsg, &StatusPacket{
+// network IDs, head and genesis blocks and, on eth/69 and newer, the range of
+// blocks available for retrieval.
+func (p *Peer) Handshake(network uint64, genesis common.Hash, forkID forkid.ID, forkFilter forkid.Filter, blockRange BlockRangeUpdatePacket) error {
+	// Assemble the status message matching the negotiated protocol version
+	var (
+		status Packet
+		read   func() error
+	)
+	if p.version >= ETH69 {
+		status = &StatusPacket69{
+			ProtocolVersion: uint32(p.version),
+			NetworkID:       network,
+			Genesis:         genesis,
+			ForkID:          forkID,
+			EarliestBlock:   blockRange.EarliestBlock,
+			LatestBlock:     blockRange.LatestBlock,
+			LatestBlockHash: blockRange.LatestBlockHash,
+		}
+		read = func() error { return p.readStatus69(network, genesis, forkFilter) }
+	} else {
+		status = &StatusPacket68{
 			ProtocolVersion: uint32(p.version),
 			NetworkID:       network,
 			TD:              new(big.Int), // unknown for post-merge tail=pruned networks
-			Head:            head,
+			Head:            blockRange.LatestBlockHash,
 			Genesis:         genesis,
 			ForkID:          forkID,
-		})
+		}
+		read = func() error { return p.readStatus68(network, genesis, forkFilter) }
+	}
+	// Send out own handshake in a new thread
+	errc := make(chan error, 2)
+
+	go func() {
+		errc <- p2p.Send(p.rw, StatusMsg, status)
 	}()
 	go func() {
-		errc <- p.readStatus(network, &status, genesis, forkFilter)
+		errc <- read()
 	}()
 	timeout := time.NewTimer(handshakeTimeout)
 	defer timeout.Stop()
@@ -72,8 +91,39 @@ func (p *Peer) Handshake(network uint64, head common.Hash, genesis common.Hash,
 	return nil
 }
 
-// readStatus reads the remote handshake message.
-func (p *Peer) readStatus(network uint64, status *StatusPacket, genesis common.Hash, forkFilter forkid.Filter) error {
+// readStatus68 reads and validates the remote eth/68 handshake message.
+func (p *Peer) readStatus68(network uint64, genesis common.Hash, forkFilter forkid.Filter) error {
+	var status StatusPacket68
+	if err := p.readStatus(&status); err != nil {
+		return err
+	}
+	return p.validateStatus(network, status.NetworkID, status.ProtocolVersion, genesis, status.Genesis, forkFilter, status.ForkID)
+}
+
+// readStatus69 reads and validates the remote eth/69 handshake message, tracking
+// the block range announced by the remote peer.
+func (p *Peer) readStatus69(network uint64, genesis common.Hash, forkFilter forkid.Filter) error {
+	var status StatusPacket69
+	if err := p.readStatus(&status); err != nil {
+		return err
+	}
+	if err := p.validateStatus(network, status.NetworkID, status.ProtocolVersion, genesis, status.Genesis, forkFilter, status.ForkID); err != nil {
+		return err
+	}
+	blockRange := &BlockRangeUpdatePacket{
+		EarliestBlock:   status.EarliestBlock,
+		LatestBlock:     status.LatestBlock,
+		LatestBlockHash: status.LatestBlockHash,
+	}
+	if err := blockRange.Validate(); err != nil {
+		return err
+	}
+	p.lastRange.Store(blockRange)
+	return nil
+}
+
+// readStatus reads the remote handshake message and decodes it into status.
+func (p *Peer) readStatus(status Packet) error {
 	msg, err := p.rw.ReadMsg()
 	if err != nil {
 		return err
@@ -85,19 +135,24 @@ func (p *Peer) readStatus(network uint64, status *StatusPacket, genesis common.H
 		return fmt.Errorf("%w: %v > %v", errMsgTooLarge, msg.Size, maxMessageSize)
 	}
 	// Decode the handshake and make sure everything matches
-	if err := msg.Decode(&status); err != nil {
+	if err := msg.Decode(status); err != nil {
 		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
 	}
-	if status.NetworkID != network {
-		return fmt.Errorf("%w: %d (!= %d)", errNetworkIDMismatch, status.NetworkID, network)
+	return nil
+}
+
+// validateStatus checks the version independent fields of the remote handshake.
+func (p *Peer) validateStatus(network, remoteNetwork uint64, remoteVersion uint32, genesis, remoteGenesis common.Hash, forkFilter forkid.Filter, remoteForkID forkid.ID) error {
+	if remoteNetwork != network {
+		return fmt.Errorf("%w: %d (!= %d)", errNetworkIDMismatch, remoteNetwork, network)
 	}
-	if uint(status.ProtocolVersion) != p.version {
-		return fmt.Errorf("%w: %d (!= %d)", errProtocolVersionMismatch, status.ProtocolVersion, p.version)
+	if uint(remoteVersion) != p.version {
+		return fmt.Errorf("%w: %d (!= %d)", errProtocolVersionMismatch, remoteVersion, p.version)
 	}
-	if status.Genesis != genesis {
-		return fmt.Errorf("%w: %x (!= %x)", errGenesisMismatch, status.Genesis, genesis)
+	if remoteGenesis != genesis {
+		return fmt.Errorf("%w: %x (!= %x)", errGenesisMismatch, remoteGenesis, genesis)
 	}
-	if err := forkFilter(status.ForkID); err != nil {
+	if err := forkFilter(remoteForkID); err != nil {
 		return fmt.Errorf("%w: %v", errForkIDRejected, err)
 	}
 	return nil
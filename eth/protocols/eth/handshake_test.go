@@ -29,6 +29,7 @@ import (
 
 // Tests that handshake failures are detected and reported correctly.
 func TestHandshake68(t *testing.T) { testHandshake(t, ETH68) }
+func TestHandshake69(t *testing.T) { testHandshake(t, ETH69) }
 
 func testHandshake(t *testing.T, protocol uint) {
 	t.Parallel()
@@ -42,6 +43,13 @@ func testHandshake(t *testing.T, protocol uint) {
 		head    = backend.chain.CurrentBlock()
 		forkID  = forkid.NewID(backend.chain.Config(), backend.chain.Genesis(), backend.chain.CurrentHeader().Number.Uint64(), backend.chain.CurrentHeader().Time)
 	)
+	// status assembles a version specific status message
+	status := func(version uint32, network uint64, genesis common.Hash, forkID forkid.ID) interface{} {
+		if protocol >= ETH69 {
+			return StatusPacket69{version, network, genesis, forkID, 0, head.Number.Uint64(), head.Hash()}
+		}
+		return StatusPacket68{version, network, new(big.Int), head.Hash(), genesis, forkID}
+	}
 	tests := []struct {
 		code uint64
 		data interface{}
@@ -52,22 +60,32 @@ func testHandshake(t *testing.T, protocol uint) {
 			want: errNoStatusMsg,
 		},
 		{
-			code: StatusMsg, data: StatusPacket{10, 1, new(big.Int), head.Hash(), genesis.Hash(), forkID},
+			code: StatusMsg, data: status(10, 1, genesis.Hash(), forkID),
 			want: errProtocolVersionMismatch,
 		},
 		{
-			code: StatusMsg, data: StatusPacket{uint32(protocol), 999, new(big.Int), head.Hash(), genesis.Hash(), forkID},
+			code: StatusMsg, data: status(uint32(protocol), 999, genesis.Hash(), forkID),
 			want: errNetworkIDMismatch,
 		},
 		{
-			code: StatusMsg, data: StatusPacket{uint32(protocol), 1, new(big.Int), head.Hash(), common.Hash{3}, forkID},
+			code: StatusMsg, data: status(uint32(protocol), 1, common.Hash{3}, forkID),
 			want: errGenesisMismatch,
 		},
 		{
-			code: StatusMsg, data: StatusPacket{uint32(protocol), 1, new(big.Int), head.Hash(), genesis.Hash(), forkid.ID{Hash: [4]byte{0x00, 0x01, 0x02, 0x03}}},
+			code: StatusMsg, data: status(uint32(protocol), 1, genesis.Hash(), forkid.ID{Hash: [4]byte{0x00, 0x01, 0x02, 0x03}}),
 			want: errForkIDRejected,
 		},
 	}
+	if protocol >= ETH69 {
+		tests = append(tests, struct {
+			code uint64
+			data interface{}
+			want error
+		}{
+			code: StatusMsg, data: StatusPacket69{uint32(protocol), 1, genesis.Hash(), forkID, head.Number.Uint64() + 1, head.Number.Uint64(), head.Hash()},
+			want: errInvalidBlockRange,
+		})
+	}
 	for i, test := range tests {
 		// Create the two peers to shake with each other
 		app, net := p2p.MsgPipe()
@@ -80,7 +98,10 @@ func testHandshake(t *testing.T, protocol uint) {
 		// Send the junk test with one peer, check the handshake failure
 		go p2p.Send(app, test.code, test.data)
 
-		err := peer.Handshake(1, head.Hash(), genesis.Hash(), forkID, forkid.NewFilter(backend.chain))
+		err := peer.Handshake(1, genesis.Hash(), forkID, forkid.NewFilter(backend.chain), BlockRangeUpdatePacket{
+			LatestBlock:     head.Number.Uint64(),
+			LatestBlockHash: head.Hash(),
+		})
 		if err == nil {
 			t.Errorf("test %d: protocol returned nil error, want %q", i, test.want)
 		} else if !errors.Is(err, test.want) {
@@ -18,6 +18,7 @@ package eth
 
 import (
 	"math/rand"
+	"sync/atomic"
 
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/ethereum/go-ethereum/common"
@@ -48,6 +49,8 @@ type Peer struct {
 	rw        p2p.MsgReadWriter // Input/output streams for snap
 	version   uint              // Protocol version negotiated
 
+	lastRange atomic.Pointer[BlockRangeUpdatePacket] // Block range last announced by the peer (eth/69+)
+
 	txpool      TxPool             // Transaction pool used by the broadcasters for liveness checks
 	knownTxs    *knownCache        // Set of transaction hashes known to be known by this peer
 	txBroadcast chan []common.Hash // Channel used to queue transaction propagation requests
@@ -102,6 +105,23 @@ func (p *Peer) Version() uint {
 	return p.version
 }
 
+// BlockRange retrieves the range of blocks the peer last announced to be able
+// to serve. Nil is returned for peers not announcing it (pre eth/69), which
+// should be assumed to serve the full chain history.
+func (p *Peer) BlockRange() *BlockRangeUpdatePacket {
+	return p.lastRange.Load()
+}
+
+// SendBlockRangeUpdate announces a change in the locally served block range to
+// the remote peer. It is a noop for peers on protocol versions not supporting
+// the message.
+func (p *Peer) SendBlockRangeUpdate(update BlockRangeUpdatePacket) error {
+	if p.version < ETH69 {
+		return nil
+	}
+	return p2p.Send(p.rw, BlockRangeUpdateMsg, &update)
+}
+
 // KnownTransaction returns whether peer is known to already have a transaction.
 func (p *Peer) KnownTransaction(hash common.Hash) bool {
 	return p.knownTxs.Contains(hash)
@@ -0,0 +1,104 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	receiptStatusFailedRLP     = []byte{}
+	receiptStatusSuccessfulRLP = []byte{0x01}
+)
+
+// Receipt69 is the network representation of a receipt on eth/69. Compared to
+// the consensus encoding, the bloom filter is omitted as it can be recomputed
+// from the logs, and the transaction type is always present in the list instead
+// of being used as a typed envelope prefix.
+type Receipt69 struct {
+	TxType            uint8
+	PostStateOrStatus []byte
+	CumulativeGasUsed uint64
+	Logs              []*types.Log
+}
+
+// newReceipt69 converts a consensus receipt into its eth/69 network form.
+func newReceipt69(r *types.Receipt) *Receipt69 {
+	status := r.PostState
+	if len(status) == 0 {
+		status = receiptStatusFailedRLP
+		if r.Status == types.ReceiptStatusSuccessful {
+			status = receiptStatusSuccessfulRLP
+		}
+	}
+	logs := r.Logs
+	if logs == nil {
+		logs = []*types.Log{}
+	}
+	return &Receipt69{
+		TxType:            r.Type,
+		PostStateOrStatus: status,
+		CumulativeGasUsed: r.CumulativeGasUsed,
+		Logs:              logs,
+	}
+}
+
+// toReceipt converts the network receipt back into a consensus receipt, deriving
+// the bloom filter from the contained logs.
+func (r *Receipt69) toReceipt() (*types.Receipt, error) {
+	receipt := &types.Receipt{
+		Type:              r.TxType,
+		CumulativeGasUsed: r.CumulativeGasUsed,
+		Logs:              r.Logs,
+	}
+	switch {
+	case bytes.Equal(r.PostStateOrStatus, receiptStatusSuccessfulRLP):
+		receipt.Status = types.ReceiptStatusSuccessful
+	case bytes.Equal(r.PostStateOrStatus, receiptStatusFailedRLP):
+		receipt.Status = types.ReceiptStatusFailed
+	case len(r.PostStateOrStatus) == len(common.Hash{}):
+		receipt.PostState = r.PostStateOrStatus
+	default:
+		return nil, fmt.Errorf("invalid receipt status %x", r.PostStateOrStatus)
+	}
+	receipt.Bloom = types.CreateBloom(receipt)
+	return receipt, nil
+}
+
+// Unpack converts the eth/69 receipts into consensus receipts, recomputing the
+// bloom filters omitted from the network encoding.
+func (p *ReceiptsResponse69) Unpack() (ReceiptsResponse, error) {
+	res := make(ReceiptsResponse, len(*p))
+	for i, receipts := range *p {
+		res[i] = make([]*types.Receipt, len(receipts))
+		for j, receipt := range receipts {
+			if receipt == nil {
+				return nil, fmt.Errorf("receipt %d of block %d is nil", j, i)
+			}
+			converted, err := receipt.toReceipt()
+			if err != nil {
+				return nil, fmt.Errorf("receipt %d of block %d: %v", j, i, err)
+			}
+			res[i][j] = converted
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// Tests that receipts survive the conversion into the eth/69 network format and
+// back, with the bloom filters and receipt root reconstructed correctly.
+func TestReceipt69Conversion(t *testing.T) {
+	receipts := types.Receipts{
+		&types.Receipt{
+			Type:              types.LegacyTxType,
+			PostState:         common.Hash{0x01}.Bytes(),
+			CumulativeGasUsed: 21000,
+		},
+		&types.Receipt{
+			Type:              types.DynamicFeeTxType,
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: 42000,
+			Logs: []*types.Log{
+				{
+					Address: common.Address{0x11},
+					Topics:  []common.Hash{{0x22}, {0x33}},
+					Data:    []byte{0x44, 0x55},
+				},
+			},
+		},
+		&types.Receipt{
+			Type:              types.BlobTxType,
+			Status:            types.ReceiptStatusFailed,
+			CumulativeGasUsed: 63000,
+		},
+	}
+	for _, receipt := range receipts {
+		receipt.Bloom = types.CreateBloom(receipt)
+	}
+	// Convert the receipts into the network format and round trip them
+	network := make([]*Receipt69, len(receipts))
+	for i, receipt := range receipts {
+		network[i] = newReceipt69(receipt)
+	}
+	blob, err := rlp.EncodeToBytes(ReceiptsResponse69{network})
+	if err != nil {
+		t.Fatalf("failed to encode receipts: %v", err)
+	}
+	var decoded ReceiptsResponse69
+	if err := rlp.DecodeBytes(blob, &decoded); err != nil {
+		t.Fatalf("failed to decode receipts: %v", err)
+	}
+	unpacked, err := decoded.Unpack()
+	if err != nil {
+		t.Fatalf("failed to unpack receipts: %v", err)
+	}
+	// Ensure the receipts hash to the same root as the originals
+	hasher := trie.NewStackTrie(nil)
+	if have, want := types.DeriveSha(types.Receipts(unpacked[0]), hasher), types.DeriveSha(receipts, hasher); have != want {
+		t.Fatalf("receipt root mismatch: have %x, want %x", have, want)
+	}
+	for i, receipt := range unpacked[0] {
+		if receipt.Bloom != receipts[i].Bloom {
+			t.Errorf("receipt %d: bloom mismatch", i)
+		}
+	}
+	// Ensure invalid statuses are rejected
+	network[0].PostStateOrStatus = []byte{0x02}
+	if _, err := (&ReceiptsResponse69{network}).Unpack(); err == nil {
+		t.Fatalf("invalid receipt status accepted")
+	}
+}
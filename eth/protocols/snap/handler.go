@@ -275,11 +275,11 @@ func ServiceGetAccountRangeQuery(chain *core.BlockChain, req *GetAccountRangePac
 		req.Bytes = softResponseLimit
 	}
 	// Retrieve the requested state and bail out if non existent
-	tr, err := trie.New(trie.StateTrieID(req.Root), chain.TrieDB())
+	tr, err := trie.New(trie.StateTrieID(req.Root), newNodeDatabase(chain))
 	if err != nil {
 		return nil, nil
 	}
-	it, err := chain.Snapshots().AccountIterator(req.Root, req.Origin)
+	it, err := accountIterator(chain, tr, req.Root, req.Origin)
 	if err != nil {
 		return nil, nil
 	}
@@ -342,6 +342,7 @@ func ServiceGetStorageRangesQuery(chain *core.BlockChain, req *GetStorageRangesP
 		slots  [][]*StorageData
 		proofs [][]byte
 		size   uint64
+		db     = newNodeDatabase(chain)
 	)
 	for _, account := range req.Accounts {
 		// If we've exceeded the requested data limit, abort without opening
@@ -359,7 +360,7 @@ func ServiceGetStorageRangesQuery(chain *core.BlockChain, req *GetStorageRangesP
 			limit, req.Limit = common.BytesToHash(req.Limit), nil
 		}
 		// Retrieve the requested state and bail out if non existent
-		it, err := chain.Snapshots().StorageIterator(req.Root, account, origin)
+		it, err := storageIterator(chain, db, req.Root, account, origin)
 		if err != nil {
 			return nil, nil
 		}
@@ -401,7 +402,7 @@ func ServiceGetStorageRangesQuery(chain *core.BlockChain, req *GetStorageRangesP
 		if origin != (common.Hash{}) || (abort && len(storage) > 0) {
 			// Request started at a non-zero hash or was capped prematurely, add
 			// the endpoint Merkle proofs
-			accTrie, err := trie.NewStateTrie(trie.StateTrieID(req.Root), chain.TrieDB())
+			accTrie, err := trie.NewStateTrie(trie.StateTrieID(req.Root), db)
 			if err != nil {
 				return nil, nil
 			}
@@ -410,7 +411,7 @@ func ServiceGetStorageRangesQuery(chain *core.BlockChain, req *GetStorageRangesP
 				return nil, nil
 			}
 			id := trie.StorageTrieID(req.Root, account, acc.Root)
-			stTrie, err := trie.NewStateTrie(id, chain.TrieDB())
+			stTrie, err := trie.NewStateTrie(id, db)
 			if err != nil {
 				return nil, nil
 			}
@@ -472,7 +473,7 @@ func ServiceGetTrieNodesQuery(chain *core.BlockChain, req *GetTrieNodesPacket, s
 		req.Bytes = softResponseLimit
 	}
 	// Make sure we have the state associated with the request
-	triedb := chain.TrieDB()
+	triedb := newNodeDatabase(chain)
 
 	accTrie, err := trie.NewStateTrie(trie.StateTrieID(req.Root), triedb)
 	if err != nil {
@@ -0,0 +1,176 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/ethereum/go-ethereum/triedb/database"
+)
+
+// nodeDatabase wraps the trie database for serving the state, falling back to
+// the historic state rolled back from the state histories if the requested one
+// is no longer available. It allows the peers syncing towards a stale pivot to
+// keep going instead of restarting the sync cycle.
+type nodeDatabase struct {
+	db *triedb.Database
+}
+
+// newNodeDatabase constructs the node database for serving the chain state.
+func newNodeDatabase(chain *core.BlockChain) *nodeDatabase {
+	return &nodeDatabase{db: chain.TrieDB()}
+}
+
+// NodeReader implements database.NodeDatabase, returning the node reader of the
+// given state.
+func (db *nodeDatabase) NodeReader(root common.Hash) (database.NodeReader, error) {
+	reader, err := db.db.NodeReader(root)
+	if err == nil {
+		return reader, nil
+	}
+	if historic, herr := db.db.HistoricNodeReader(root); herr == nil {
+		return historic, nil
+	}
+	return nil, err
+}
+
+// accountIterator returns an iterator over the accounts of the given state,
+// starting from the given origin. The accounts are resolved from the snapshot
+// if available, or from the given account trie otherwise in path scheme.
+func accountIterator(chain *core.BlockChain, tr *trie.Trie, root common.Hash, origin common.Hash) (snapshot.AccountIterator, error) {
+	it, err := chain.Snapshots().AccountIterator(root, origin)
+	if err == nil || chain.TrieDB().Scheme() != rawdb.PathScheme {
+		return it, err
+	}
+	nodeIt, err := tr.NodeIterator(origin[:])
+	if err != nil {
+		return nil, err
+	}
+	return &trieAccountIterator{it: trie.NewIterator(nodeIt)}, nil
+}
+
+// storageIterator returns an iterator over the storage slots of the given
+// account at the given state, starting from the given origin. The slots are
+// resolved from the snapshot if available, or from the storage trie otherwise
+// in path scheme.
+func storageIterator(chain *core.BlockChain, db *nodeDatabase, root common.Hash, account common.Hash, origin common.Hash) (snapshot.StorageIterator, error) {
+	it, err := chain.Snapshots().StorageIterator(root, account, origin)
+	if err == nil || chain.TrieDB().Scheme() != rawdb.PathScheme {
+		return it, err
+	}
+	accTrie, err := trie.NewStateTrie(trie.StateTrieID(root), db)
+	if err != nil {
+		return nil, err
+	}
+	acc, err := accTrie.GetAccountByHash(account)
+	if err != nil {
+		return nil, err
+	}
+	stRoot := types.EmptyRootHash
+	if acc != nil {
+		stRoot = acc.Root
+	}
+	stTrie, err := trie.New(trie.StorageTrieID(root, account, stRoot), db)
+	if err != nil {
+		return nil, err
+	}
+	nodeIt, err := stTrie.NodeIterator(origin[:])
+	if err != nil {
+		return nil, err
+	}
+	return &trieStorageIterator{it: trie.NewIterator(nodeIt)}, nil
+}
+
+// trieAccountIterator is an account iterator stepping over the account trie,
+// converting the accounts into the slim format.
+type trieAccountIterator struct {
+	it      *trie.Iterator
+	account []byte
+	err     error
+}
+
+// Next implements snapshot.Iterator, stepping to the next account.
+func (it *trieAccountIterator) Next() bool {
+	if it.err != nil || !it.it.Next() {
+		return false
+	}
+	var account types.StateAccount
+	if err := rlp.DecodeBytes(it.it.Value, &account); err != nil {
+		it.err = err
+		return false
+	}
+	it.account = types.SlimAccountRLP(account)
+	return true
+}
+
+// Error implements snapshot.Iterator, returning the failure occurred during
+// the iteration.
+func (it *trieAccountIterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.it.Err
+}
+
+// Hash implements snapshot.Iterator, returning the hash of the current account.
+func (it *trieAccountIterator) Hash() common.Hash {
+	return common.BytesToHash(it.it.Key)
+}
+
+// Account implements snapshot.AccountIterator, returning the current account
+// in the slim format.
+func (it *trieAccountIterator) Account() []byte {
+	return it.account
+}
+
+// Release implements snapshot.Iterator, it's a noop.
+func (it *trieAccountIterator) Release() {}
+
+// trieStorageIterator is a storage iterator stepping over the storage trie.
+type trieStorageIterator struct {
+	it *trie.Iterator
+}
+
+// Next implements snapshot.Iterator, stepping to the next storage slot.
+func (it *trieStorageIterator) Next() bool {
+	return it.it.Next()
+}
+
+// Error implements snapshot.Iterator, returning the failure occurred during
+// the iteration.
+func (it *trieStorageIterator) Error() error {
+	return it.it.Err
+}
+
+// Hash implements snapshot.Iterator, returning the hash of the current slot.
+func (it *trieStorageIterator) Hash() common.Hash {
+	return common.BytesToHash(it.it.Key)
+}
+
+// Slot implements snapshot.StorageIterator, returning the current storage slot.
+func (it *trieStorageIterator) Slot() []byte {
+	return it.it.Value
+}
+
+// Release implements snapshot.Iterator, it's a noop.
+func (it *trieStorageIterator) Release() {}
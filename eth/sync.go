@@ -27,6 +27,9 @@ func (h *handler) syncTransactions(p *eth.Peer) {
 	var hashes []common.Hash
 	for _, batch := range h.txpool.Pending(txpool.PendingFilter{OnlyPlainTxs: true}) {
 		for _, tx := range batch {
+			if tx.Conditional != nil {
+				continue // conditions are only enforced locally
+			}
 			hashes = append(hashes, tx.Hash)
 		}
 	}
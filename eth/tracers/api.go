@@ -858,17 +858,34 @@ func containsTx(block *types.Block, hash common.Hash) bool {
 // TraceTransaction returns the structured logs created during the execution of EVM
 // and returns them as a JSON object.
 func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *TraceConfig) (interface{}, error) {
+	result, blockNumber, err := api.traceTransaction(ctx, hash, config)
+	if err != nil {
+		return nil, err
+	}
+	// The traces of the finalized transactions never change, allow them to
+	// be served from the RPC response cache.
+	if finalized, _ := api.backend.HeaderByNumber(ctx, rpc.FinalizedBlockNumber); finalized != nil && blockNumber <= finalized.Number.Uint64() {
+		rpc.CacheResponse(ctx)
+	}
+	return result, nil
+}
+
+// traceTransaction traces the transaction with the given hash, returning the
+// result along with the number of the block containing the transaction. It's
+// the entry point of the internal callers, whose responses are not cacheable
+// as a whole.
+func (api *API) traceTransaction(ctx context.Context, hash common.Hash, config *TraceConfig) (interface{}, uint64, error) {
 	found, _, blockHash, blockNumber, index, err := api.backend.GetTransaction(ctx, hash)
 	if err != nil {
-		return nil, ethapi.NewTxIndexingError()
+		return nil, 0, ethapi.NewTxIndexingError()
 	}
 	// Only mined txes are supported
 	if !found {
-		return nil, errTxNotFound
+		return nil, 0, errTxNotFound
 	}
 	// It shouldn't happen in practice.
 	if blockNumber == 0 {
-		return nil, errors.New("genesis is not traceable")
+		return nil, 0, errors.New("genesis is not traceable")
 	}
 	reexec := defaultTraceReexec
 	if config != nil && config.Reexec != nil {
@@ -876,16 +893,16 @@ func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *
 	}
 	block, err := api.blockByNumberAndHash(ctx, rpc.BlockNumber(blockNumber), blockHash)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	tx, vmctx, statedb, release, err := api.backend.StateAtTransaction(ctx, block, int(index), reexec)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer release()
 	msg, err := core.TransactionToMessage(tx, types.MakeSigner(api.backend.ChainConfig(), block.Number(), block.Time()), block.BaseFee())
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	txctx := &Context{
@@ -894,7 +911,8 @@ func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *
 		TxIndex:     int(index),
 		TxHash:      hash,
 	}
-	return api.traceTx(ctx, tx, msg, txctx, vmctx, statedb, config, nil)
+	result, err := api.traceTx(ctx, tx, msg, txctx, vmctx, statedb, config, nil)
+	return result, blockNumber, err
 }
 
 // TraceCall lets you trace a given eth_call. It collects the structured logs
@@ -1044,13 +1062,18 @@ func (api *API) traceTx(ctx context.Context, tx *types.Transaction, message *cor
 
 // APIs return the collection of RPC services the tracer package offers.
 func APIs(backend Backend) []rpc.API {
-	// Append all the local APIs and return
-	return []rpc.API{
+	// Append all the local APIs and those of the live tracers, and return
+	apis := []rpc.API{
 		{
 			Namespace: "debug",
 			Service:   NewAPI(backend),
 		},
+		{
+			Namespace: "trace",
+			Service:   NewTraceAPI(backend),
+		},
 	}
+	return append(apis, LiveDirectory.APIs(backend)...)
 }
 
 // overrideConfig returns a copy of original with forks enabled by override enabled,
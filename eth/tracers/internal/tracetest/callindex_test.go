@@ -0,0 +1,145 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracetest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/eth/tracers/live"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// callIndexBackend serves the chain data needed by the call index API.
+type callIndexBackend struct {
+	tracers.Backend
+	chain *core.BlockChain
+}
+
+func (b *callIndexBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	if number == rpc.LatestBlockNumber {
+		return b.chain.CurrentBlock(), nil
+	}
+	return b.chain.GetHeaderByNumber(uint64(number)), nil
+}
+
+func (b *callIndexBackend) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return b.chain.GetBlockByHash(hash), nil
+}
+
+// Tests that the call index tracks internal calls across imports, drops blocks
+// leaving the retention window and ignores blocks reorged out.
+func TestCallIndex(t *testing.T) {
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender = crypto.PubkeyToAddress(key.PublicKey)
+		caller = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		callee = common.HexToAddress("0x2222222222222222222222222222222222222222")
+		engine = beacon.New(ethash.NewFaker())
+
+		gspec = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				sender: {Balance: big.NewInt(params.Ether)},
+				caller: {
+					// CALL(gas, callee, 1 wei, 0, 0, 0, 0)
+					Code:    append(append([]byte{byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 1, byte(vm.PUSH20)}, callee.Bytes()...), byte(vm.GAS), byte(vm.CALL), byte(vm.STOP)),
+					Balance: big.NewInt(params.Ether),
+				},
+			},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, engine, 4, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			Nonce:     uint64(i),
+			To:        &caller,
+			Gas:       100000,
+			GasFeeCap: b.BaseFee(),
+		})
+		b.AddTx(tx)
+	})
+	_, fork, _ := core.GenerateChainWithGenesis(gspec, engine, 5, func(i int, b *core.BlockGen) {
+		b.SetExtra([]byte("fork"))
+	})
+
+	query := func(retention int, imports ...[]*types.Block) ([]*live.CallIndexResult, error) {
+		config := fmt.Sprintf(`{"path":%q,"retention":%d}`, t.TempDir(), retention)
+		tracer, err := tracers.LiveDirectory.New("callindex", json.RawMessage(config))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create call index tracer: %v", err)
+		}
+		chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, engine, vm.Config{Tracer: tracer}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create tester chain: %v", err)
+		}
+		defer chain.Stop()
+
+		for _, blocks := range imports {
+			if n, err := chain.InsertChain(blocks); err != nil {
+				return nil, fmt.Errorf("block %d: failed to insert into chain: %v", n, err)
+			}
+		}
+		backend := &callIndexBackend{chain: chain}
+		apis := tracers.LiveDirectory.APIs(backend)
+		api := apis[len(apis)-1].Service.(*live.CallIndexAPI)
+		return api.GetInternalTransactions(context.Background(), callee, 0, rpc.LatestBlockNumber)
+	}
+	// Ensure all the internal calls are indexed
+	results, err := query(0, blocks)
+	if err != nil {
+		t.Fatalf("failed to query call index: %v", err)
+	}
+	if len(results) != len(blocks) {
+		t.Fatalf("result count mismatch: have %d, want %d", len(results), len(blocks))
+	}
+	for i, result := range results {
+		block := blocks[i]
+		if result.BlockHash != block.Hash() || result.TransactionHash != block.Transactions()[0].Hash() {
+			t.Errorf("result %d: mismatch: have %x/%x, want %x/%x", i, result.BlockHash, result.TransactionHash, block.Hash(), block.Transactions()[0].Hash())
+		}
+	}
+	// Ensure blocks are pruned outside of the retention window
+	results, err = query(2, blocks)
+	if err != nil {
+		t.Fatalf("failed to query call index: %v", err)
+	}
+	if len(results) != 2 || uint64(results[0].BlockNumber) != 3 || uint64(results[1].BlockNumber) != 4 {
+		t.Fatalf("retained results mismatch: have %d results", len(results))
+	}
+	// Ensure reorged blocks are not reported
+	results, err = query(0, blocks, fork)
+	if err != nil {
+		t.Fatalf("failed to query call index: %v", err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("reorged results reported: have %d results", len(results))
+	}
+}
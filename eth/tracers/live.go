@@ -19,18 +19,26 @@ package tracers
 import (
 	"encoding/json"
 	"errors"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 type ctorFunc func(config json.RawMessage) (*tracing.Hooks, error)
 
+// apiFunc creates the RPC APIs serving the data collected by a live tracer.
+type apiFunc func(backend Backend) []rpc.API
+
 // LiveDirectory is the collection of tracers which can be used
 // during normal block import operations.
 var LiveDirectory = liveDirectory{elems: make(map[string]ctorFunc)}
 
 type liveDirectory struct {
 	elems map[string]ctorFunc
+
+	apis []apiFunc // RPC APIs of the instantiated tracers
+	lock sync.Mutex
 }
 
 // Register registers a tracer constructor by name.
@@ -48,3 +56,24 @@ func (d *liveDirectory) New(name string, config json.RawMessage) (*tracing.Hooks
 	}
 	return nil, errors.New("not found")
 }
+
+// RegisterAPIs registers the RPC APIs of an instantiated tracer. It is meant to
+// be called by the constructors of tracers whose collected data can be queried.
+func (d *liveDirectory) RegisterAPIs(f apiFunc) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.apis = append(d.apis, f)
+}
+
+// APIs returns the RPC APIs of all the instantiated tracers.
+func (d *liveDirectory) APIs(backend Backend) []rpc.API {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	var apis []rpc.API
+	for _, f := range d.apis {
+		apis = append(apis, f(backend)...)
+	}
+	return apis
+}
@@ -0,0 +1,370 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package live
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/pebble"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func init() {
+	tracers.LiveDirectory.Register("callindex", newCallIndexTracer)
+}
+
+const (
+	// callIndexCache is the memory allowance of the index database in megabytes.
+	callIndexCache = 16
+
+	// callIndexHandles is the number of files the index database may keep open.
+	callIndexHandles = 64
+
+	// maxCallIndexResults is the maximum number of transactions a single query
+	// is allowed to return.
+	maxCallIndexResults = 10000
+)
+
+var (
+	// callIndexEntryPrefix + address + number + hash + tx index -> nil
+	callIndexEntryPrefix = []byte("e")
+
+	// callIndexBlockPrefix + number + hash -> RLP([]callIndexEntry)
+	callIndexBlockPrefix = []byte("b")
+)
+
+// callIndexEntry records that an address was called or received value within a
+// transaction of the block.
+type callIndexEntry struct {
+	Address common.Address
+	TxIndex uint32
+}
+
+// callIndexEntryKey = callIndexEntryPrefix + address + number (uint64 big endian)
+// + hash + tx index (uint32 big endian)
+func callIndexEntryKey(addr common.Address, number uint64, hash common.Hash, txIndex uint32) []byte {
+	key := make([]byte, 0, len(callIndexEntryPrefix)+common.AddressLength+8+common.HashLength+4)
+	key = append(key, callIndexEntryPrefix...)
+	key = append(key, addr.Bytes()...)
+	key = binary.BigEndian.AppendUint64(key, number)
+	key = append(key, hash.Bytes()...)
+	return binary.BigEndian.AppendUint32(key, txIndex)
+}
+
+// callIndexBlockKey = callIndexBlockPrefix + number (uint64 big endian) + hash
+func callIndexBlockKey(number uint64, hash common.Hash) []byte {
+	key := make([]byte, 0, len(callIndexBlockPrefix)+8+common.HashLength)
+	key = append(key, callIndexBlockPrefix...)
+	key = binary.BigEndian.AppendUint64(key, number)
+	return append(key, hash.Bytes()...)
+}
+
+type callIndexTracerConfig struct {
+	Path      string `json:"path"`      // Path to the directory where the index database will be stored
+	Retention uint64 `json:"retention"` // Number of recent blocks to keep indexed, 0 to keep all
+}
+
+// callIndex is a live tracer maintaining an index from addresses to the
+// transactions which called them internally or transferred value to or from
+// them.
+//
+// Blocks are indexed by hash, so entries of blocks which are not part of the
+// canonical chain, either due to a reorg or because they were never chosen
+// by the consensus client, are filtered out when queried and deleted once
+// they fall outside the retention window.
+type callIndex struct {
+	db        ethdb.KeyValueStore
+	retention uint64
+
+	block   *types.Block                // Block currently being indexed
+	entries []callIndexEntry            // Entries collected from the current block
+	txIndex uint32                      // Index of the current transaction within the block
+	touched map[common.Address]struct{} // Addresses touched by the current transaction
+	system  bool                        // Whether a system call is being executed
+}
+
+func newCallIndexTracer(cfg json.RawMessage) (*tracing.Hooks, error) {
+	var config callIndexTracerConfig
+	if err := json.Unmarshal(cfg, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %v", err)
+	}
+	if config.Path == "" {
+		return nil, errors.New("call index tracer output path is required")
+	}
+	db, err := pebble.New(config.Path, callIndexCache, callIndexHandles, "eth/tracers/callindex/", false, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open call index database: %v", err)
+	}
+	t := &callIndex{
+		db:        db,
+		retention: config.Retention,
+	}
+	tracers.LiveDirectory.RegisterAPIs(func(backend tracers.Backend) []rpc.API {
+		return []rpc.API{{
+			Namespace: "debug",
+			Service:   newCallIndexAPI(t, backend),
+		}}
+	})
+	return &tracing.Hooks{
+		OnBlockStart:        t.onBlockStart,
+		OnBlockEnd:          t.onBlockEnd,
+		OnTxStart:           t.onTxStart,
+		OnTxEnd:             t.onTxEnd,
+		OnEnter:             t.onEnter,
+		OnSystemCallStartV2: t.onSystemCallStart,
+		OnSystemCallEnd:     t.onSystemCallEnd,
+		OnClose:             t.onClose,
+	}, nil
+}
+
+func (t *callIndex) onBlockStart(ev tracing.BlockEvent) {
+	t.block = ev.Block
+	t.entries = t.entries[:0]
+	t.txIndex = 0
+}
+
+func (t *callIndex) onBlockEnd(err error) {
+	// Blocks failing to import are not indexed
+	if err != nil || t.block == nil {
+		t.block = nil
+		return
+	}
+	var (
+		number = t.block.NumberU64()
+		hash   = t.block.Hash()
+		batch  = t.db.NewBatch()
+	)
+	for _, entry := range t.entries {
+		batch.Put(callIndexEntryKey(entry.Address, number, hash, entry.TxIndex), nil)
+	}
+	blob, err := rlp.EncodeToBytes(t.entries)
+	if err != nil {
+		log.Crit("Failed to encode call index entries", "err", err)
+	}
+	batch.Put(callIndexBlockKey(number, hash), blob)
+
+	if t.retention > 0 && number >= t.retention {
+		if err := t.prune(batch, number-t.retention+1); err != nil {
+			log.Warn("Failed to prune call index", "err", err)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		log.Warn("Failed to write call index", "number", number, "hash", hash, "err", err)
+	}
+	t.block = nil
+}
+
+// prune deletes the index entries of all the blocks below the given number.
+func (t *callIndex) prune(batch ethdb.Batch, limit uint64) error {
+	it := t.db.NewIterator(callIndexBlockPrefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(callIndexBlockPrefix)+8+common.HashLength {
+			continue
+		}
+		number := binary.BigEndian.Uint64(key[len(callIndexBlockPrefix):])
+		if number >= limit {
+			break
+		}
+		hash := common.BytesToHash(key[len(callIndexBlockPrefix)+8:])
+
+		var entries []callIndexEntry
+		if err := rlp.DecodeBytes(it.Value(), &entries); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			batch.Delete(callIndexEntryKey(entry.Address, number, hash, entry.TxIndex))
+		}
+		batch.Delete(key)
+	}
+	return it.Error()
+}
+
+func (t *callIndex) onTxStart(vm *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	t.touched = make(map[common.Address]struct{})
+}
+
+func (t *callIndex) onTxEnd(receipt *types.Receipt, err error) {
+	// Invalid transactions abort the block, nothing to do
+	if err != nil || t.block == nil {
+		return
+	}
+	for addr := range t.touched {
+		t.entries = append(t.entries, callIndexEntry{Address: addr, TxIndex: t.txIndex})
+	}
+	t.txIndex++
+}
+
+func (t *callIndex) onEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if t.system || t.touched == nil {
+		return
+	}
+	// The top level call is already known from the transaction itself, only
+	// record it if value is being transferred.
+	if depth > 0 || (value != nil && value.Sign() > 0) {
+		t.touched[from] = struct{}{}
+		t.touched[to] = struct{}{}
+	}
+}
+
+func (t *callIndex) onSystemCallStart(ctx *tracing.VMContext) {
+	t.system = true
+}
+
+func (t *callIndex) onSystemCallEnd() {
+	t.system = false
+}
+
+func (t *callIndex) onClose() {
+	if err := t.db.Close(); err != nil {
+		log.Warn("Failed to close call index database", "err", err)
+	}
+}
+
+// CallIndexBackend provides the chain access needed to resolve the entries of
+// the call index.
+type CallIndexBackend interface {
+	HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error)
+	BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error)
+}
+
+// CallIndexAPI provides access to the call index over the debug namespace.
+type CallIndexAPI struct {
+	index   *callIndex
+	backend CallIndexBackend
+}
+
+// newCallIndexAPI creates a new API serving the given call index.
+func newCallIndexAPI(index *callIndex, backend CallIndexBackend) *CallIndexAPI {
+	return &CallIndexAPI{index: index, backend: backend}
+}
+
+// CallIndexResult is a transaction found in the call index.
+type CallIndexResult struct {
+	BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+	BlockHash        common.Hash    `json:"blockHash"`
+	TransactionIndex hexutil.Uint   `json:"transactionIndex"`
+	TransactionHash  common.Hash    `json:"transactionHash"`
+}
+
+// GetInternalTransactions returns the canonical transactions within the block
+// range which internally called the given address or transferred value to or
+// from it.
+func (api *CallIndexAPI) GetInternalTransactions(ctx context.Context, address common.Address, fromBlock, toBlock rpc.BlockNumber) ([]*CallIndexResult, error) {
+	from, err := api.resolveNumber(ctx, fromBlock)
+	if err != nil {
+		return nil, err
+	}
+	to, err := api.resolveNumber(ctx, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, fmt.Errorf("invalid block range %d-%d", from, to)
+	}
+	var (
+		prefix    = append(common.CopyBytes(callIndexEntryPrefix), address.Bytes()...)
+		start     = binary.BigEndian.AppendUint64(nil, from)
+		canonical = make(map[uint64]common.Hash)
+		blocks    = make(map[common.Hash]*types.Block)
+		results   = []*CallIndexResult{}
+	)
+	it := api.index.db.NewIterator(prefix, start)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8+common.HashLength+4 {
+			continue
+		}
+		var (
+			number  = binary.BigEndian.Uint64(key[len(prefix):])
+			hash    = common.BytesToHash(key[len(prefix)+8 : len(prefix)+8+common.HashLength])
+			txIndex = binary.BigEndian.Uint32(key[len(prefix)+8+common.HashLength:])
+		)
+		if number > to {
+			break
+		}
+		// Skip the entries of non-canonical blocks
+		if _, ok := canonical[number]; !ok {
+			header, err := api.backend.HeaderByNumber(ctx, rpc.BlockNumber(number))
+			if err != nil {
+				return nil, err
+			}
+			if header != nil {
+				canonical[number] = header.Hash()
+			}
+		}
+		if canonical[number] != hash {
+			continue
+		}
+		block := blocks[hash]
+		if block == nil {
+			if block, err = api.backend.BlockByHash(ctx, hash); err != nil {
+				return nil, err
+			}
+			if block == nil {
+				return nil, fmt.Errorf("block %s not found", hash.Hex())
+			}
+			blocks[hash] = block
+		}
+		txs := block.Transactions()
+		if int(txIndex) >= len(txs) {
+			return nil, fmt.Errorf("transaction %d of block %d not found", txIndex, number)
+		}
+		if len(results) >= maxCallIndexResults {
+			return nil, fmt.Errorf("too many results, more than %d transactions", maxCallIndexResults)
+		}
+		results = append(results, &CallIndexResult{
+			BlockNumber:      hexutil.Uint64(number),
+			BlockHash:        hash,
+			TransactionIndex: hexutil.Uint(txIndex),
+			TransactionHash:  txs[txIndex].Hash(),
+		})
+	}
+	return results, it.Error()
+}
+
+// resolveNumber converts a possibly symbolic block number into a concrete one.
+func (api *CallIndexAPI) resolveNumber(ctx context.Context, number rpc.BlockNumber) (uint64, error) {
+	if number == rpc.PendingBlockNumber {
+		return 0, errors.New("pending block is not indexed")
+	}
+	header, err := api.backend.HeaderByNumber(ctx, number)
+	if err != nil {
+		return 0, err
+	}
+	if header == nil {
+		return 0, fmt.Errorf("block #%d not found", number)
+	}
+	return header.Number.Uint64(), nil
+}
@@ -0,0 +1,262 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("vmTracer", newVMTracer, false)
+}
+
+// vmTrace is the Parity-style trace of the instructions executed in a single
+// call frame.
+type vmTrace struct {
+	Code hexutil.Bytes `json:"code"`
+	Ops  []*vmTraceOp  `json:"ops"`
+}
+
+// vmTraceOp is a single executed instruction along with its effects.
+type vmTraceOp struct {
+	Cost uint64     `json:"cost"`
+	Ex   *vmTraceEx `json:"ex"`
+	Pc   uint64     `json:"pc"`
+	Sub  *vmTrace   `json:"sub"`
+	Op   string     `json:"op"`
+}
+
+// vmTraceEx contains the execution results of an instruction: the items it
+// pushed onto the stack and the memory and storage it modified.
+type vmTraceEx struct {
+	Mem   *vmTraceMem    `json:"mem"`
+	Push  []hexutil.U256 `json:"push"`
+	Store *vmTraceStore  `json:"store"`
+	Used  uint64         `json:"used"`
+}
+
+type vmTraceMem struct {
+	Off  uint64        `json:"off"`
+	Data hexutil.Bytes `json:"data"`
+}
+
+type vmTraceStore struct {
+	Key hexutil.U256 `json:"key"`
+	Val hexutil.U256 `json:"val"`
+}
+
+// vmTraceFrame tracks an executing call frame. The effects of an instruction
+// are only visible after it was executed, so the last instruction is kept as
+// pending until the next one is reached in the same frame.
+type vmTraceFrame struct {
+	trace   *vmTrace
+	pending *vmTraceOp
+	gas     uint64 // Gas available before the pending instruction
+	pushes  int    // Number of stack items pushed by the pending instruction
+	memOff  uint64 // Offset of the memory written by the pending instruction
+	memSize uint64 // Size of the memory written by the pending instruction
+
+	store *vmTraceStore // Storage slot written by the pending instruction
+}
+
+// vmTracer reports the executed instructions of a transaction in the format
+// of Parity's vmTrace.
+type vmTracer struct {
+	root      *vmTrace
+	frames    []*vmTraceFrame
+	interrupt atomic.Bool // Atomic flag to signal execution interruption
+	reason    error       // Textual reason for the interruption
+}
+
+// newVMTracer returns a new vmTracer.
+func newVMTracer(ctx *tracers.Context, cfg json.RawMessage, chainConfig *params.ChainConfig) (*tracers.Tracer, error) {
+	t := new(vmTracer)
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnEnter:  t.OnEnter,
+			OnExit:   t.OnExit,
+			OnOpcode: t.OnOpcode,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+// OnEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *vmTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if t.interrupt.Load() {
+		return
+	}
+	trace := &vmTrace{Code: []byte{}, Ops: []*vmTraceOp{}}
+	switch op := vm.OpCode(typ); {
+	case op == vm.CREATE || op == vm.CREATE2:
+		trace.Code = common.CopyBytes(input)
+	case op == vm.SELFDESTRUCT:
+		// Selfdestructs are reported as frames, but they execute no code and
+		// are not part of the trace. Track them only to pair up the exit.
+		t.frames = append(t.frames, &vmTraceFrame{trace: trace})
+		return
+	}
+	if depth == 0 {
+		t.root = trace
+	} else if len(t.frames) > 0 {
+		if parent := t.frames[len(t.frames)-1]; parent.pending != nil {
+			parent.pending.Sub = trace
+		}
+	}
+	t.frames = append(t.frames, &vmTraceFrame{trace: trace})
+}
+
+// OnExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *vmTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if t.interrupt.Load() || len(t.frames) == 0 {
+		return
+	}
+	// The frame is left without executing further instructions, so report
+	// the last instruction without any stack or memory effects.
+	frame := t.frames[len(t.frames)-1]
+	if frame.pending != nil && frame.pending.Ex == nil {
+		frame.pending.Ex = &vmTraceEx{Push: []hexutil.U256{}, Store: frame.store}
+		if frame.gas >= frame.pending.Cost {
+			frame.pending.Ex.Used = frame.gas - frame.pending.Cost
+		}
+	}
+	t.frames = t.frames[:len(t.frames)-1]
+}
+
+// OnOpcode records the instruction about to be executed and completes the
+// previously executed one with its results.
+func (t *vmTracer) OnOpcode(pc uint64, opcode byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	if err != nil || t.interrupt.Load() || len(t.frames) == 0 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	if len(frame.trace.Ops) == 0 && len(frame.trace.Code) == 0 {
+		frame.trace.Code = common.CopyBytes(scope.ContractCode())
+	}
+	stack := scope.StackData()
+	if prev := frame.pending; prev != nil && prev.Ex == nil {
+		// The previous instruction completed, report its effects
+		ex := &vmTraceEx{Push: []hexutil.U256{}, Used: gas, Store: frame.store}
+		if n := frame.pushes; n > 0 && n <= len(stack) {
+			for _, item := range stack[len(stack)-n:] {
+				ex.Push = append(ex.Push, hexutil.U256(item))
+			}
+		}
+		if frame.memSize > 0 {
+			mem := scope.MemoryData()
+			if end := frame.memOff + frame.memSize; end >= frame.memOff && end <= uint64(len(mem)) {
+				ex.Mem = &vmTraceMem{Off: frame.memOff, Data: common.CopyBytes(mem[frame.memOff:end])}
+			}
+		}
+		prev.Ex = ex
+	}
+	// Record the new instruction and everything about its effects that can
+	// be deduced before executing it.
+	op := vm.OpCode(opcode)
+	next := &vmTraceOp{Cost: cost, Pc: pc, Op: op.String()}
+	frame.trace.Ops = append(frame.trace.Ops, next)
+	frame.pending, frame.gas = next, gas
+	frame.pushes = vmTracePushes(op)
+	frame.memOff, frame.memSize = vmTraceMemoryWrite(op, stack)
+
+	frame.store = nil
+	if op == vm.SSTORE && len(stack) >= 2 {
+		frame.store = &vmTraceStore{
+			Key: hexutil.U256(stack[len(stack)-1]),
+			Val: hexutil.U256(stack[len(stack)-2]),
+		}
+	}
+}
+
+// GetResult returns the json-encoded instruction trace of the transaction, and
+// any error arising from the encoding or forceful termination (via `Stop`).
+func (t *vmTracer) GetResult() (json.RawMessage, error) {
+	res, err := json.Marshal(t.root)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *vmTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
+
+// vmTracePushes returns the number of stack items reported as pushed by the
+// given instruction. Following Parity, stack manipulating instructions report
+// all the items they touched.
+func vmTracePushes(op vm.OpCode) int {
+	switch {
+	case op >= vm.DUP1 && op <= vm.DUP16:
+		return int(op-vm.DUP1) + 2
+	case op >= vm.SWAP1 && op <= vm.SWAP16:
+		return int(op-vm.SWAP1) + 2
+	case op >= vm.LOG0 && op <= vm.LOG4:
+		return 0
+	}
+	switch op {
+	case vm.STOP, vm.POP, vm.MSTORE, vm.MSTORE8, vm.SSTORE, vm.TSTORE, vm.JUMP, vm.JUMPI,
+		vm.JUMPDEST, vm.CALLDATACOPY, vm.CODECOPY, vm.EXTCODECOPY, vm.RETURNDATACOPY,
+		vm.MCOPY, vm.RETURN, vm.REVERT, vm.SELFDESTRUCT, vm.INVALID:
+		return 0
+	}
+	return 1
+}
+
+// vmTraceMemoryWrite returns the memory region written by the given instruction,
+// based on the stack before its execution.
+func vmTraceMemoryWrite(op vm.OpCode, stack []uint256.Int) (uint64, uint64) {
+	peek := func(n int) uint64 {
+		if n >= len(stack) {
+			return 0
+		}
+		item := &stack[len(stack)-1-n]
+		if !item.IsUint64() {
+			return 0
+		}
+		return item.Uint64()
+	}
+	switch op {
+	case vm.MSTORE:
+		return peek(0), 32
+	case vm.MSTORE8:
+		return peek(0), 1
+	case vm.CALLDATACOPY, vm.CODECOPY, vm.RETURNDATACOPY, vm.MCOPY:
+		return peek(0), peek(2)
+	case vm.EXTCODECOPY:
+		return peek(1), peek(3)
+	case vm.CALL, vm.CALLCODE:
+		return peek(5), peek(6)
+	case vm.DELEGATECALL, vm.STATICCALL:
+		return peek(4), peek(5)
+	}
+	return 0, 0
+}
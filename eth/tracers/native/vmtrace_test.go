@@ -0,0 +1,84 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+// Tests that the vmTracer reports the stack, memory and storage effects of the
+// executed instructions.
+func TestVMTracer(t *testing.T) {
+	tracer, err := tracers.DefaultDirectory.New("vmTracer", &tracers.Context{}, nil, params.MainnetChainConfig)
+	require.NoError(t, err)
+
+	code := []byte{
+		byte(vm.PUSH1), 0x2a, byte(vm.PUSH1), 0x00, byte(vm.SSTORE),
+		byte(vm.PUSH1), 0x2a, byte(vm.PUSH1), 0x00, byte(vm.MSTORE),
+		byte(vm.PUSH1), 0x20, byte(vm.PUSH1), 0x00, byte(vm.RETURN),
+	}
+	_, _, err = runtime.Execute(code, nil, &runtime.Config{EVMConfig: vm.Config{Tracer: tracer.Hooks}})
+	require.NoError(t, err)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	var trace struct {
+		Code string `json:"code"`
+		Ops  []struct {
+			Op string `json:"op"`
+			Pc uint64 `json:"pc"`
+			Ex struct {
+				Mem *struct {
+					Off  uint64 `json:"off"`
+					Data string `json:"data"`
+				} `json:"mem"`
+				Push  []string `json:"push"`
+				Store *struct {
+					Key string `json:"key"`
+					Val string `json:"val"`
+				} `json:"store"`
+			} `json:"ex"`
+		} `json:"ops"`
+	}
+	require.NoError(t, json.Unmarshal(res, &trace))
+	require.Equal(t, "0x602a600055602a60005260206000f3", trace.Code)
+	require.Len(t, trace.Ops, 9)
+
+	require.Equal(t, "PUSH1", trace.Ops[0].Op)
+	require.Equal(t, []string{"0x2a"}, trace.Ops[0].Ex.Push)
+
+	require.Equal(t, "SSTORE", trace.Ops[2].Op)
+	require.NotNil(t, trace.Ops[2].Ex.Store)
+	require.Equal(t, "0x0", trace.Ops[2].Ex.Store.Key)
+	require.Equal(t, "0x2a", trace.Ops[2].Ex.Store.Val)
+	require.Empty(t, trace.Ops[2].Ex.Push)
+
+	require.Equal(t, "MSTORE", trace.Ops[5].Op)
+	require.Equal(t, uint64(9), trace.Ops[5].Pc)
+	require.NotNil(t, trace.Ops[5].Ex.Mem)
+	require.Equal(t, "0x000000000000000000000000000000000000000000000000000000000000002a", trace.Ops[5].Ex.Mem.Data)
+
+	require.Equal(t, "RETURN", trace.Ops[8].Op)
+}
@@ -0,0 +1,492 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// maxTraceFilterRange is the maximum number of blocks a single trace_filter
+	// request is allowed to re-execute.
+	maxTraceFilterRange = 10000
+
+	// Trace types which can be requested from the replay methods.
+	traceTypeTrace     = "trace"
+	traceTypeStateDiff = "stateDiff"
+	traceTypeVMTrace   = "vmTrace"
+)
+
+// flatCallConfig is the configuration of the flatCallTracer producing the call
+// frames of the trace namespace.
+var flatCallConfig = json.RawMessage(`{"convertParityErrors":true}`)
+
+// TraceAPI is the collection of Parity-compatible tracing APIs exposed over the
+// trace namespace. All of them are built on top of the native flatCallTracer,
+// prestateTracer and vmTracer.
+//
+// Note, block and uncle reward traces are not generated.
+type TraceAPI struct {
+	api *API
+}
+
+// NewTraceAPI creates a new API definition for the trace namespace.
+func NewTraceAPI(backend Backend) *TraceAPI {
+	return &TraceAPI{api: NewAPI(backend)}
+}
+
+// ParityTrace is a single call frame of a transaction in the flat format of the
+// Parity trace namespace.
+type ParityTrace struct {
+	Action              json.RawMessage `json:"action"`
+	BlockHash           *common.Hash    `json:"blockHash"`
+	BlockNumber         uint64          `json:"blockNumber"`
+	Error               string          `json:"error,omitempty"`
+	Result              json.RawMessage `json:"result,omitempty"`
+	Subtraces           int             `json:"subtraces"`
+	TraceAddress        []int           `json:"traceAddress"`
+	TransactionHash     *common.Hash    `json:"transactionHash"`
+	TransactionPosition uint64          `json:"transactionPosition"`
+	Type                string          `json:"type"`
+}
+
+// parityTraceAddresses are the fields of a call frame which filters can match.
+type parityTraceAddresses struct {
+	From          *common.Address `json:"from"`
+	To            *common.Address `json:"to"`
+	Address       *common.Address `json:"address"`
+	RefundAddress *common.Address `json:"refundAddress"`
+}
+
+// TraceFilterArgs are the criteria of a trace_filter request.
+type TraceFilterArgs struct {
+	FromBlock   *rpc.BlockNumber `json:"fromBlock"`
+	ToBlock     *rpc.BlockNumber `json:"toBlock"`
+	FromAddress []common.Address `json:"fromAddress"`
+	ToAddress   []common.Address `json:"toAddress"`
+	After       *uint64          `json:"after"`
+	Count       *uint64          `json:"count"`
+}
+
+// matches returns whether the call frame satisfies the address criteria. A
+// frame matches if its sender is in the from set and its recipient is in the
+// to set, where an empty set matches everything.
+func (args *TraceFilterArgs) matches(trace *ParityTrace) (bool, error) {
+	if len(args.FromAddress) == 0 && len(args.ToAddress) == 0 {
+		return true, nil
+	}
+	var action, result parityTraceAddresses
+	if err := json.Unmarshal(trace.Action, &action); err != nil {
+		return false, err
+	}
+	if len(trace.Result) > 0 {
+		if err := json.Unmarshal(trace.Result, &result); err != nil {
+			return false, err
+		}
+	}
+	var from, to *common.Address
+	switch trace.Type {
+	case "create":
+		from, to = action.From, result.Address
+	case "suicide":
+		from, to = action.Address, action.RefundAddress
+	default:
+		from, to = action.From, action.To
+	}
+	if len(args.FromAddress) > 0 && (from == nil || !slices.Contains(args.FromAddress, *from)) {
+		return false, nil
+	}
+	if len(args.ToAddress) > 0 && (to == nil || !slices.Contains(args.ToAddress, *to)) {
+		return false, nil
+	}
+	return true, nil
+}
+
+// TraceResults is the outcome of replaying a transaction, containing the traces
+// requested by the user.
+type TraceResults struct {
+	Output          hexutil.Bytes                   `json:"output"`
+	StateDiff       map[common.Address]*AccountDiff `json:"stateDiff"`
+	Trace           []*ParityTrace                  `json:"trace"`
+	VMTrace         json.RawMessage                 `json:"vmTrace"`
+	TransactionHash *common.Hash                    `json:"transactionHash,omitempty"`
+}
+
+// Block returns the call frames of all the transactions in the given block.
+func (api *TraceAPI) Block(ctx context.Context, number rpc.BlockNumber) ([]*ParityTrace, error) {
+	block, err := api.api.blockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	return api.traceBlock(ctx, block)
+}
+
+// Transaction returns the call frames of the given transaction.
+func (api *TraceAPI) Transaction(ctx context.Context, hash common.Hash) ([]*ParityTrace, error) {
+	tracer := "flatCallTracer"
+	res, _, err := api.api.traceTransaction(ctx, hash, &TraceConfig{Tracer: &tracer, TracerConfig: flatCallConfig})
+	if err != nil {
+		return nil, err
+	}
+	var traces []*ParityTrace
+	if err := json.Unmarshal(res.(json.RawMessage), &traces); err != nil {
+		return nil, err
+	}
+	return traces, nil
+}
+
+// Filter returns the call frames within the requested block range matching the
+// given address criteria.
+func (api *TraceAPI) Filter(ctx context.Context, args TraceFilterArgs) ([]*ParityTrace, error) {
+	from, err := api.resolveNumber(ctx, args.FromBlock)
+	if err != nil {
+		return nil, err
+	}
+	to, err := api.resolveNumber(ctx, args.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, fmt.Errorf("invalid block range %d-%d", from, to)
+	}
+	if to-from >= maxTraceFilterRange {
+		return nil, fmt.Errorf("block range %d-%d exceeds limit of %d blocks", from, to, maxTraceFilterRange)
+	}
+	var (
+		skip    uint64
+		results = []*ParityTrace{}
+	)
+	if args.After != nil {
+		skip = *args.After
+	}
+	if args.Count != nil && *args.Count == 0 {
+		return results, nil
+	}
+	for number := max(from, 1); number <= to; number++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		block, err := api.api.blockByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return nil, err
+		}
+		traces, err := api.traceBlock(ctx, block)
+		if err != nil {
+			return nil, err
+		}
+		for _, trace := range traces {
+			match, err := args.matches(trace)
+			if err != nil {
+				return nil, err
+			}
+			if !match {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			results = append(results, trace)
+			if args.Count != nil && uint64(len(results)) >= *args.Count {
+				return results, nil
+			}
+		}
+	}
+	return results, nil
+}
+
+// ReplayTransaction re-executes the given transaction, returning the requested
+// trace types out of trace, stateDiff and vmTrace.
+func (api *TraceAPI) ReplayTransaction(ctx context.Context, hash common.Hash, traceTypes []string) (*TraceResults, error) {
+	config, err := replayConfig(traceTypes)
+	if err != nil {
+		return nil, err
+	}
+	res, _, err := api.api.traceTransaction(ctx, hash, config)
+	if err != nil {
+		return nil, err
+	}
+	return newTraceResults(res.(json.RawMessage), traceTypes)
+}
+
+// ReplayBlockTransactions re-executes all the transactions in the given block,
+// returning the requested trace types out of trace, stateDiff and vmTrace.
+func (api *TraceAPI) ReplayBlockTransactions(ctx context.Context, number rpc.BlockNumber, traceTypes []string) ([]*TraceResults, error) {
+	config, err := replayConfig(traceTypes)
+	if err != nil {
+		return nil, err
+	}
+	block, err := api.api.blockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	if block.NumberU64() == 0 {
+		return []*TraceResults{}, nil
+	}
+	txs, err := api.api.traceBlock(ctx, block, config)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*TraceResults, len(txs))
+	for i, tx := range txs {
+		if tx.Error != "" {
+			return nil, fmt.Errorf("tracing transaction %x failed: %s", tx.TxHash, tx.Error)
+		}
+		if results[i], err = newTraceResults(tx.Result.(json.RawMessage), traceTypes); err != nil {
+			return nil, err
+		}
+		results[i].TransactionHash = &tx.TxHash
+	}
+	return results, nil
+}
+
+// traceBlock returns the call frames of all the transactions in the block.
+func (api *TraceAPI) traceBlock(ctx context.Context, block *types.Block) ([]*ParityTrace, error) {
+	if block.NumberU64() == 0 {
+		return []*ParityTrace{}, nil
+	}
+	tracer := "flatCallTracer"
+	txs, err := api.api.traceBlock(ctx, block, &TraceConfig{Tracer: &tracer, TracerConfig: flatCallConfig})
+	if err != nil {
+		return nil, err
+	}
+	results := []*ParityTrace{}
+	for _, tx := range txs {
+		if tx.Error != "" {
+			return nil, fmt.Errorf("tracing transaction %x failed: %s", tx.TxHash, tx.Error)
+		}
+		var traces []*ParityTrace
+		if err := json.Unmarshal(tx.Result.(json.RawMessage), &traces); err != nil {
+			return nil, err
+		}
+		results = append(results, traces...)
+	}
+	return results, nil
+}
+
+// resolveNumber converts a possibly symbolic block number into a concrete one,
+// defaulting to the latest block.
+func (api *TraceAPI) resolveNumber(ctx context.Context, number *rpc.BlockNumber) (uint64, error) {
+	if number == nil {
+		latest := rpc.LatestBlockNumber
+		number = &latest
+	}
+	if *number == rpc.PendingBlockNumber {
+		return 0, errors.New("tracing on top of pending is not supported")
+	}
+	header, err := api.api.backend.HeaderByNumber(ctx, *number)
+	if err != nil {
+		return 0, err
+	}
+	if header == nil {
+		return 0, fmt.Errorf("block #%d not found", *number)
+	}
+	return header.Number.Uint64(), nil
+}
+
+// replayConfig assembles the tracer producing the requested trace types. The
+// call frames are always collected as they carry the transaction output.
+func replayConfig(traceTypes []string) (*TraceConfig, error) {
+	config := map[string]json.RawMessage{
+		"flatCallTracer": flatCallConfig,
+	}
+	for _, typ := range traceTypes {
+		switch typ {
+		case traceTypeTrace:
+		case traceTypeStateDiff:
+			config["prestateTracer"] = json.RawMessage(`{"diffMode":true}`)
+		case traceTypeVMTrace:
+			config["vmTracer"] = json.RawMessage(`{}`)
+		default:
+			return nil, fmt.Errorf("invalid trace type %q", typ)
+		}
+	}
+	blob, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	tracer := "muxTracer"
+	return &TraceConfig{Tracer: &tracer, TracerConfig: blob}, nil
+}
+
+// newTraceResults converts the output of the replay tracers into the results
+// of the trace types requested by the user.
+func newTraceResults(res json.RawMessage, traceTypes []string) (*TraceResults, error) {
+	var outputs struct {
+		Calls    []*ParityTrace  `json:"flatCallTracer"`
+		Prestate *prestateDiff   `json:"prestateTracer"`
+		VMTrace  json.RawMessage `json:"vmTracer"`
+	}
+	if err := json.Unmarshal(res, &outputs); err != nil {
+		return nil, err
+	}
+	results := &TraceResults{Output: []byte{}, Trace: []*ParityTrace{}}
+	if len(outputs.Calls) > 0 && len(outputs.Calls[0].Result) > 0 {
+		var result struct {
+			Code   hexutil.Bytes `json:"code"`
+			Output hexutil.Bytes `json:"output"`
+		}
+		if err := json.Unmarshal(outputs.Calls[0].Result, &result); err != nil {
+			return nil, err
+		}
+		if outputs.Calls[0].Type == "create" {
+			results.Output = result.Code
+		} else if result.Output != nil {
+			results.Output = result.Output
+		}
+	}
+	if slices.Contains(traceTypes, traceTypeTrace) {
+		results.Trace = outputs.Calls
+	}
+	if outputs.Prestate != nil {
+		results.StateDiff = outputs.Prestate.stateDiff()
+	}
+	if len(outputs.VMTrace) > 0 {
+		results.VMTrace = outputs.VMTrace
+	}
+	return results, nil
+}
+
+// prestateAccount is an account as reported by the prestateTracer.
+type prestateAccount struct {
+	Balance *hexutil.Big                `json:"balance"`
+	Code    hexutil.Bytes               `json:"code"`
+	Nonce   uint64                      `json:"nonce"`
+	Storage map[common.Hash]common.Hash `json:"storage"`
+}
+
+// exists returns whether the account was present in the state.
+func (a *prestateAccount) exists() bool {
+	return a.Nonce > 0 || len(a.Code) > 0 || len(a.Storage) > 0 || (a.Balance != nil && a.Balance.ToInt().Sign() != 0)
+}
+
+// balance returns the balance of the account, zero if it was omitted.
+func (a *prestateAccount) balance() *hexutil.Big {
+	if a.Balance == nil {
+		return new(hexutil.Big)
+	}
+	return a.Balance
+}
+
+// prestateDiff is the output of the prestateTracer in diff mode. Accounts in
+// the pre state carry all their fields, whereas the post state only contains
+// the modified ones.
+type prestateDiff struct {
+	Pre  map[common.Address]*prestateAccount `json:"pre"`
+	Post map[common.Address]*prestateAccount `json:"post"`
+}
+
+// DiffValue is the change of a single account field, encoded in the notation of
+// Parity: "=" if unchanged, {"+": to} if created, {"-": from} if deleted and
+// {"*": {"from": from, "to": to}} if modified.
+type DiffValue struct {
+	From any
+	To   any
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d *DiffValue) MarshalJSON() ([]byte, error) {
+	switch {
+	case d.From == nil && d.To == nil:
+		return json.Marshal("=")
+	case d.From == nil:
+		return json.Marshal(map[string]any{"+": d.To})
+	case d.To == nil:
+		return json.Marshal(map[string]any{"-": d.From})
+	default:
+		return json.Marshal(map[string]any{"*": map[string]any{"from": d.From, "to": d.To}})
+	}
+}
+
+// AccountDiff is the change of a single account during the execution of a
+// transaction.
+type AccountDiff struct {
+	Balance *DiffValue                 `json:"balance"`
+	Code    *DiffValue                 `json:"code"`
+	Nonce   *DiffValue                 `json:"nonce"`
+	Storage map[common.Hash]*DiffValue `json:"storage"`
+}
+
+// stateDiff converts the pre and post states into Parity's state diff format.
+func (d *prestateDiff) stateDiff() map[common.Address]*AccountDiff {
+	diff := make(map[common.Address]*AccountDiff)
+	for addr := range d.Pre {
+		diff[addr] = nil
+	}
+	for addr := range d.Post {
+		diff[addr] = nil
+	}
+	for addr := range diff {
+		pre, post := d.Pre[addr], d.Post[addr]
+		if pre != nil && !pre.exists() {
+			pre = nil
+		}
+		account := &AccountDiff{Storage: make(map[common.Hash]*DiffValue)}
+		switch {
+		case pre == nil && post == nil:
+			delete(diff, addr)
+			continue
+
+		case pre == nil:
+			account.Balance = &DiffValue{To: post.balance()}
+			account.Nonce = &DiffValue{To: hexutil.Uint64(post.Nonce)}
+			account.Code = &DiffValue{To: post.Code}
+			for key, val := range post.Storage {
+				account.Storage[key] = &DiffValue{To: val}
+			}
+
+		case post == nil:
+			account.Balance = &DiffValue{From: pre.balance()}
+			account.Nonce = &DiffValue{From: hexutil.Uint64(pre.Nonce)}
+			account.Code = &DiffValue{From: pre.Code}
+			for key, val := range pre.Storage {
+				account.Storage[key] = &DiffValue{From: val}
+			}
+
+		default:
+			account.Balance, account.Nonce, account.Code = new(DiffValue), new(DiffValue), new(DiffValue)
+			if post.Balance != nil && post.Balance.ToInt().Cmp(pre.balance().ToInt()) != 0 {
+				account.Balance = &DiffValue{From: pre.balance(), To: post.Balance}
+			}
+			if post.Nonce != 0 && post.Nonce != pre.Nonce {
+				account.Nonce = &DiffValue{From: hexutil.Uint64(pre.Nonce), To: hexutil.Uint64(post.Nonce)}
+			}
+			if len(post.Code) > 0 {
+				account.Code = &DiffValue{From: pre.Code, To: post.Code}
+			}
+			// Slots cleared during execution are omitted from the post state
+			// and slots empty before it are omitted from the pre state.
+			for key, val := range pre.Storage {
+				account.Storage[key] = &DiffValue{From: val, To: post.Storage[key]}
+			}
+			for key, val := range post.Storage {
+				account.Storage[key] = &DiffValue{From: pre.Storage[key], To: val}
+			}
+		}
+		diff[addr] = account
+	}
+	return diff
+}
@@ -0,0 +1,251 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// traceTestChain creates a chain with a single block containing a plain value
+// transfer and a call into a contract, which in turn calls a second contract
+// writing a storage slot.
+func traceTestChain(t *testing.T) (api *tracers.TraceAPI, sender, outer, inner common.Address, txs []common.Hash) {
+	var (
+		key, _    = crypto.GenerateKey()
+		recipient = common.HexToAddress("0xdead")
+
+		// inner stores 0x2a into slot 1
+		innerCode = common.FromHex("602a60015500")
+	)
+	sender = crypto.PubkeyToAddress(key.PublicKey)
+	inner = common.HexToAddress("0x1000")
+	outer = common.HexToAddress("0x2000")
+
+	// outer calls inner without arguments and value
+	outerCode := append(common.FromHex("60006000600060006000"+"73"), inner.Bytes()...)
+	outerCode = append(outerCode, common.FromHex("5af100")...)
+
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			sender: {Balance: big.NewInt(params.Ether)},
+			inner:  {Code: innerCode},
+			outer:  {Code: outerCode},
+		},
+	}
+	signer := types.LatestSigner(genesis.Config)
+	backend := tracers.NewTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		for j, to := range []common.Address{recipient, outer} {
+			tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+				Nonce:    uint64(j),
+				To:       &to,
+				Value:    big.NewInt(1000),
+				Gas:      100000,
+				GasPrice: b.BaseFee(),
+			}), signer, key)
+			b.AddTx(tx)
+			txs = append(txs, tx.Hash())
+		}
+	})
+	return tracers.NewTraceAPI(backend), sender, outer, inner, txs
+}
+
+// traceSummary is the structure of a call frame, used to compare the results.
+type traceSummary struct {
+	Type         string
+	From, To     common.Address
+	TraceAddress []int
+	Subtraces    int
+	Position     uint64
+}
+
+func summarizeTraces(t *testing.T, traces []*tracers.ParityTrace) []traceSummary {
+	t.Helper()
+
+	summary := make([]traceSummary, len(traces))
+	for i, trace := range traces {
+		var action struct {
+			From common.Address `json:"from"`
+			To   common.Address `json:"to"`
+		}
+		if err := json.Unmarshal(trace.Action, &action); err != nil {
+			t.Fatalf("trace %d: invalid action: %v", i, err)
+		}
+		summary[i] = traceSummary{trace.Type, action.From, action.To, trace.TraceAddress, trace.Subtraces, trace.TransactionPosition}
+	}
+	return summary
+}
+
+func TestTraceAPITransactionAndBlock(t *testing.T) {
+	t.Parallel()
+
+	api, sender, outer, inner, txs := traceTestChain(t)
+	var (
+		transfer = traceSummary{"call", sender, common.HexToAddress("0xdead"), []int{}, 0, 0}
+		top      = traceSummary{"call", sender, outer, []int{}, 1, 1}
+		nested   = traceSummary{"call", outer, inner, []int{0}, 0, 1}
+	)
+	traces, err := api.Transaction(context.Background(), txs[1])
+	if err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	if have, want := summarizeTraces(t, traces), []traceSummary{top, nested}; !reflect.DeepEqual(have, want) {
+		t.Errorf("transaction traces mismatch:\nhave %+v\nwant %+v", have, want)
+	}
+	for _, trace := range traces {
+		if trace.TransactionHash == nil || *trace.TransactionHash != txs[1] || trace.BlockNumber != 1 {
+			t.Errorf("trace not annotated with its transaction: %+v", trace)
+		}
+	}
+	traces, err = api.Block(context.Background(), rpc.BlockNumber(1))
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	if have, want := summarizeTraces(t, traces), []traceSummary{transfer, top, nested}; !reflect.DeepEqual(have, want) {
+		t.Errorf("block traces mismatch:\nhave %+v\nwant %+v", have, want)
+	}
+}
+
+func TestTraceAPIFilter(t *testing.T) {
+	t.Parallel()
+
+	api, sender, outer, inner, _ := traceTestChain(t)
+	var (
+		from  = rpc.BlockNumber(0)
+		to    = rpc.LatestBlockNumber
+		one   = uint64(1)
+		tests = []struct {
+			args tracers.TraceFilterArgs
+			want []common.Address // recipients of the matching frames
+		}{
+			{tracers.TraceFilterArgs{FromAddress: []common.Address{sender}}, []common.Address{common.HexToAddress("0xdead"), outer}},
+			{tracers.TraceFilterArgs{FromAddress: []common.Address{sender}, After: &one}, []common.Address{outer}},
+			{tracers.TraceFilterArgs{FromAddress: []common.Address{sender}, Count: &one}, []common.Address{common.HexToAddress("0xdead")}},
+			{tracers.TraceFilterArgs{ToAddress: []common.Address{inner}}, []common.Address{inner}},
+			{tracers.TraceFilterArgs{FromAddress: []common.Address{inner}}, nil},
+		}
+	)
+	for i, tt := range tests {
+		tt.args.FromBlock, tt.args.ToBlock = &from, &to
+		traces, err := api.Filter(context.Background(), tt.args)
+		if err != nil {
+			t.Fatalf("test %d: failed to filter traces: %v", i, err)
+		}
+		var have []common.Address
+		for _, trace := range summarizeTraces(t, traces) {
+			have = append(have, trace.To)
+		}
+		if !reflect.DeepEqual(have, tt.want) {
+			t.Errorf("test %d: recipients mismatch: have %v, want %v", i, have, tt.want)
+		}
+	}
+}
+
+// vmTraceSummary mirrors the Parity vmTrace output.
+type vmTraceSummary struct {
+	Code hexutil.Bytes `json:"code"`
+	Ops  []struct {
+		Op  string          `json:"op"`
+		Sub *vmTraceSummary `json:"sub"`
+		Ex  *struct {
+			Store *struct {
+				Key hexutil.Big `json:"key"`
+				Val hexutil.Big `json:"val"`
+			} `json:"store"`
+		} `json:"ex"`
+	} `json:"ops"`
+}
+
+func TestTraceAPIReplay(t *testing.T) {
+	t.Parallel()
+
+	api, _, _, inner, txs := traceTestChain(t)
+	traceTypes := []string{"trace", "vmTrace", "stateDiff"}
+
+	res, err := api.ReplayTransaction(context.Background(), txs[1], traceTypes)
+	if err != nil {
+		t.Fatalf("failed to replay transaction: %v", err)
+	}
+	if len(res.Trace) != 2 || res.Trace[1].Subtraces != 0 || !reflect.DeepEqual(res.Trace[1].TraceAddress, []int{0}) {
+		t.Errorf("unexpected call traces: %+v", summarizeTraces(t, res.Trace))
+	}
+	// The vmTrace must contain the nested call with the storage write
+	var vm vmTraceSummary
+	if err := json.Unmarshal(res.VMTrace, &vm); err != nil {
+		t.Fatalf("invalid vmTrace: %v", err)
+	}
+	var sub *vmTraceSummary
+	for _, op := range vm.Ops {
+		if op.Op == "CALL" {
+			sub = op.Sub
+		}
+	}
+	if sub == nil {
+		t.Fatalf("vmTrace missing nested call: %+v", vm)
+	}
+	var stored bool
+	for _, op := range sub.Ops {
+		if op.Op == "SSTORE" && op.Ex != nil && op.Ex.Store != nil {
+			stored = op.Ex.Store.Key.ToInt().Uint64() == 1 && op.Ex.Store.Val.ToInt().Uint64() == 0x2a
+		}
+	}
+	if !stored {
+		t.Errorf("vmTrace missing storage write of the nested call")
+	}
+	// The state diff must contain the written slot
+	diff := res.StateDiff[inner]
+	if diff == nil {
+		t.Fatalf("state diff missing called contract")
+	}
+	slot := diff.Storage[common.BigToHash(big.NewInt(1))]
+	if slot == nil || slot.To != common.BigToHash(big.NewInt(0x2a)) {
+		t.Errorf("unexpected storage diff: %+v", slot)
+	}
+	// Replaying the block must return the same results for each transaction
+	results, err := api.ReplayBlockTransactions(context.Background(), rpc.BlockNumber(1), []string{"trace"})
+	if err != nil {
+		t.Fatalf("failed to replay block: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("unexpected number of results: have %d, want 2", len(results))
+	}
+	for i, result := range results {
+		if result.TransactionHash == nil || *result.TransactionHash != txs[i] {
+			t.Errorf("result %d: transaction hash mismatch", i)
+		}
+		if result.VMTrace != nil || result.StateDiff != nil {
+			t.Errorf("result %d: unrequested trace types returned", i)
+		}
+	}
+	if len(results[0].Trace) != 1 || len(results[1].Trace) != 2 {
+		t.Errorf("unexpected number of traces: have %d and %d, want 1 and 2", len(results[0].Trace), len(results[1].Trace))
+	}
+}
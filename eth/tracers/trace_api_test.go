@@ -0,0 +1,101 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Tests that trace filters match the senders and recipients of the various
+// call frame types.
+func TestTraceFilterMatch(t *testing.T) {
+	var (
+		alice = common.HexToAddress("0xa11ce")
+		bob   = common.HexToAddress("0xb0b")
+		carol = common.HexToAddress("0xca201")
+	)
+	traces := []*ParityTrace{
+		{Type: "call", Action: json.RawMessage(`{"from":"` + alice.Hex() + `","to":"` + bob.Hex() + `"}`)},
+		{Type: "create", Action: json.RawMessage(`{"from":"` + bob.Hex() + `"}`), Result: json.RawMessage(`{"address":"` + carol.Hex() + `"}`)},
+		{Type: "suicide", Action: json.RawMessage(`{"address":"` + carol.Hex() + `","refundAddress":"` + alice.Hex() + `"}`)},
+	}
+	tests := []struct {
+		args TraceFilterArgs
+		want []bool
+	}{
+		{TraceFilterArgs{}, []bool{true, true, true}},
+		{TraceFilterArgs{FromAddress: []common.Address{alice}}, []bool{true, false, false}},
+		{TraceFilterArgs{FromAddress: []common.Address{bob, carol}}, []bool{false, true, true}},
+		{TraceFilterArgs{ToAddress: []common.Address{carol}}, []bool{false, true, false}},
+		{TraceFilterArgs{ToAddress: []common.Address{alice}}, []bool{false, false, true}},
+		{TraceFilterArgs{FromAddress: []common.Address{alice}, ToAddress: []common.Address{carol}}, []bool{false, false, false}},
+	}
+	for i, tt := range tests {
+		for j, trace := range traces {
+			match, err := tt.args.matches(trace)
+			if err != nil {
+				t.Fatalf("test %d, trace %d: failed to match: %v", i, j, err)
+			}
+			if match != tt.want[j] {
+				t.Errorf("test %d, trace %d: match mismatch: have %v, want %v", i, j, match, tt.want[j])
+			}
+		}
+	}
+}
+
+// Tests the conversion of the prestate tracer's diff into Parity's state diff.
+func TestStateDiff(t *testing.T) {
+	var diff prestateDiff
+	err := json.Unmarshal([]byte(`{
+		"pre": {
+			"0x000000000000000000000000000000000000000a": {"balance": "0x10", "nonce": 1, "storage": {
+				"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000001",
+				"0x0000000000000000000000000000000000000000000000000000000000000002": "0x0000000000000000000000000000000000000000000000000000000000000002"
+			}},
+			"0x000000000000000000000000000000000000000b": {"balance": "0x5", "code": "0x00"},
+			"0x000000000000000000000000000000000000000c": {"balance": "0x0"}
+		},
+		"post": {
+			"0x000000000000000000000000000000000000000a": {"balance": "0x8", "nonce": 2, "storage": {
+				"0x0000000000000000000000000000000000000000000000000000000000000002": "0x0000000000000000000000000000000000000000000000000000000000000003",
+				"0x0000000000000000000000000000000000000000000000000000000000000004": "0x0000000000000000000000000000000000000000000000000000000000000004"
+			}},
+			"0x000000000000000000000000000000000000000c": {"balance": "0x8"}
+		}
+	}`), &diff)
+	if err != nil {
+		t.Fatalf("failed to decode prestate diff: %v", err)
+	}
+	have, err := json.Marshal(diff.stateDiff())
+	if err != nil {
+		t.Fatalf("failed to encode state diff: %v", err)
+	}
+	var want = `{` +
+		`"0x000000000000000000000000000000000000000a":{"balance":{"*":{"from":"0x10","to":"0x8"}},"code":"=","nonce":{"*":{"from":"0x1","to":"0x2"}},"storage":{` +
+		`"0x0000000000000000000000000000000000000000000000000000000000000001":{"*":{"from":"0x0000000000000000000000000000000000000000000000000000000000000001","to":"0x0000000000000000000000000000000000000000000000000000000000000000"}},` +
+		`"0x0000000000000000000000000000000000000000000000000000000000000002":{"*":{"from":"0x0000000000000000000000000000000000000000000000000000000000000002","to":"0x0000000000000000000000000000000000000000000000000000000000000003"}},` +
+		`"0x0000000000000000000000000000000000000000000000000000000000000004":{"*":{"from":"0x0000000000000000000000000000000000000000000000000000000000000000","to":"0x0000000000000000000000000000000000000000000000000000000000000004"}}}},` +
+		`"0x000000000000000000000000000000000000000b":{"balance":{"-":"0x5"},"code":{"-":"0x00"},"nonce":{"-":"0x0"},"storage":{}},` +
+		`"0x000000000000000000000000000000000000000c":{"balance":{"+":"0x8"},"code":{"+":"0x"},"nonce":{"+":"0x0"},"storage":{}}` +
+		`}`
+	if string(have) != want {
+		t.Errorf("state diff mismatch:\nhave %s\nwant %s", have, want)
+	}
+}
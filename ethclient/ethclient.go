@@ -783,6 +783,9 @@ type rpcProgress struct {
 	HealingBytecode        hexutil.Uint64
 	TxIndexFinishedBlocks  hexutil.Uint64
 	TxIndexRemainingBlocks hexutil.Uint64
+
+	HistoryPrunedBlocks         hexutil.Uint64
+	HistoryPruneRemainingBlocks hexutil.Uint64
 }
 
 func (p *rpcProgress) toSyncProgress() *ethereum.SyncProgress {
@@ -809,5 +812,8 @@ func (p *rpcProgress) toSyncProgress() *ethereum.SyncProgress {
 		HealingBytecode:        uint64(p.HealingBytecode),
 		TxIndexFinishedBlocks:  uint64(p.TxIndexFinishedBlocks),
 		TxIndexRemainingBlocks: uint64(p.TxIndexRemainingBlocks),
+
+		HistoryPrunedBlocks:         uint64(p.HistoryPrunedBlocks),
+		HistoryPruneRemainingBlocks: uint64(p.HistoryPruneRemainingBlocks),
 	}
 }
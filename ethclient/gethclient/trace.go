@@ -0,0 +1,309 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package gethclient
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// TraceConfig specifies the options of the debug_trace* family of methods.
+type TraceConfig struct {
+	// Tracer is the name of the tracer to run, or the JavaScript source of a
+	// custom tracer. The struct logger is used if it is left empty.
+	Tracer string
+	// TracerConfig is the tracer specific configuration.
+	TracerConfig json.RawMessage
+	// Timeout overrides the default timeout of a single transaction trace.
+	// It is ignored when zero.
+	Timeout time.Duration
+	// Reexec is the number of blocks the node is allowed to re-execute to
+	// regenerate missing historical state.
+	Reexec *uint64
+
+	// Options of the struct logger, ignored by all the other tracers.
+	EnableMemory     bool
+	DisableStack     bool
+	DisableStorage   bool
+	EnableReturnData bool
+}
+
+func (c TraceConfig) MarshalJSON() ([]byte, error) {
+	type config struct {
+		Tracer           string          `json:"tracer,omitempty"`
+		TracerConfig     json.RawMessage `json:"tracerConfig,omitempty"`
+		Timeout          string          `json:"timeout,omitempty"`
+		Reexec           *uint64         `json:"reexec,omitempty"`
+		EnableMemory     bool            `json:"enableMemory,omitempty"`
+		DisableStack     bool            `json:"disableStack,omitempty"`
+		DisableStorage   bool            `json:"disableStorage,omitempty"`
+		EnableReturnData bool            `json:"enableReturnData,omitempty"`
+	}
+	output := config{
+		Tracer:           c.Tracer,
+		TracerConfig:     c.TracerConfig,
+		Reexec:           c.Reexec,
+		EnableMemory:     c.EnableMemory,
+		DisableStack:     c.DisableStack,
+		DisableStorage:   c.DisableStorage,
+		EnableReturnData: c.EnableReturnData,
+	}
+	if c.Timeout != 0 {
+		output.Timeout = c.Timeout.String()
+	}
+	return json.Marshal(output)
+}
+
+// TraceCallConfig specifies the options of debug_traceCall. On top of the
+// regular tracing options, it allows overriding the state and the block
+// fields the call is executed with.
+type TraceCallConfig struct {
+	TraceConfig
+	StateOverrides map[common.Address]OverrideAccount
+	BlockOverrides *BlockOverrides
+}
+
+func (c TraceCallConfig) MarshalJSON() ([]byte, error) {
+	blob, err := c.TraceConfig.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var output map[string]interface{}
+	if err := json.Unmarshal(blob, &output); err != nil {
+		return nil, err
+	}
+	if c.StateOverrides != nil {
+		output["stateOverrides"] = c.StateOverrides
+	}
+	if c.BlockOverrides != nil {
+		output["blockOverrides"] = c.BlockOverrides
+	}
+	return json.Marshal(output)
+}
+
+// CallTracerConfig specifies the options of the built-in callTracer.
+type CallTracerConfig struct {
+	OnlyTopCall bool `json:"onlyTopCall,omitempty"` // If true, sub-calls are not traced
+	WithLog     bool `json:"withLog,omitempty"`     // If true, the emitted logs are included
+}
+
+// CallLog is a log emitted within a call frame traced by the callTracer.
+type CallLog struct {
+	Address  common.Address `json:"address"`
+	Topics   []common.Hash  `json:"topics"`
+	Data     hexutil.Bytes  `json:"data"`
+	Position hexutil.Uint   `json:"position"` // Position of the log relative to the sub-calls
+}
+
+// CallFrame is a single call frame as reported by the callTracer.
+type CallFrame struct {
+	Type         string
+	From         common.Address
+	Gas          uint64
+	GasUsed      uint64
+	To           *common.Address
+	Input        []byte
+	Output       []byte
+	Error        string
+	RevertReason string
+	Calls        []CallFrame
+	Logs         []CallLog
+	Value        *big.Int
+}
+
+func (f *CallFrame) UnmarshalJSON(input []byte) error {
+	type callFrame struct {
+		Type         string          `json:"type"`
+		From         common.Address  `json:"from"`
+		Gas          hexutil.Uint64  `json:"gas"`
+		GasUsed      hexutil.Uint64  `json:"gasUsed"`
+		To           *common.Address `json:"to"`
+		Input        hexutil.Bytes   `json:"input"`
+		Output       hexutil.Bytes   `json:"output"`
+		Error        string          `json:"error"`
+		RevertReason string          `json:"revertReason"`
+		Calls        []CallFrame     `json:"calls"`
+		Logs         []CallLog       `json:"logs"`
+		Value        *hexutil.Big    `json:"value"`
+	}
+	var dec callFrame
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*f = CallFrame{
+		Type:         dec.Type,
+		From:         dec.From,
+		Gas:          uint64(dec.Gas),
+		GasUsed:      uint64(dec.GasUsed),
+		To:           dec.To,
+		Input:        dec.Input,
+		Output:       dec.Output,
+		Error:        dec.Error,
+		RevertReason: dec.RevertReason,
+		Calls:        dec.Calls,
+		Logs:         dec.Logs,
+		Value:        (*big.Int)(dec.Value),
+	}
+	return nil
+}
+
+// PrestateAccount is the state of an account as reported by the prestateTracer.
+type PrestateAccount struct {
+	Balance *big.Int
+	Code    []byte
+	Nonce   uint64
+	Storage map[common.Hash]common.Hash
+}
+
+func (a *PrestateAccount) UnmarshalJSON(input []byte) error {
+	type account struct {
+		Balance *hexutil.Big                `json:"balance"`
+		Code    hexutil.Bytes               `json:"code"`
+		Nonce   uint64                      `json:"nonce"`
+		Storage map[common.Hash]common.Hash `json:"storage"`
+	}
+	var dec account
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*a = PrestateAccount{
+		Balance: (*big.Int)(dec.Balance),
+		Code:    dec.Code,
+		Nonce:   dec.Nonce,
+		Storage: dec.Storage,
+	}
+	return nil
+}
+
+// PrestateDiff is the result of the prestateTracer running in diff mode. Pre
+// holds the touched accounts before the execution, Post the modified fields of
+// the same accounts after it.
+type PrestateDiff struct {
+	Pre  map[common.Address]*PrestateAccount `json:"pre"`
+	Post map[common.Address]*PrestateAccount `json:"post"`
+}
+
+// TxTraceResult is the trace of a single transaction within a block.
+type TxTraceResult struct {
+	TxHash common.Hash     `json:"txHash"`           // Hash of the traced transaction
+	Result json.RawMessage `json:"result,omitempty"` // Trace produced by the tracer
+	Error  string          `json:"error,omitempty"`  // Failure produced by the tracer
+}
+
+// BlockTraceResult is the trace of a block delivered by a chain trace subscription.
+type BlockTraceResult struct {
+	Block  hexutil.Uint64   `json:"block"`  // Number of the traced block
+	Hash   common.Hash      `json:"hash"`   // Hash of the traced block
+	Traces []*TxTraceResult `json:"traces"` // Traces of the transactions in the block
+}
+
+// TraceTransaction re-executes the given transaction and returns the raw output
+// of the configured tracer. The config can be nil, in which case the struct
+// logger is used.
+func (ec *Client) TraceTransaction(ctx context.Context, hash common.Hash, config *TraceConfig) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := ec.c.CallContext(ctx, &result, "debug_traceTransaction", hash, config)
+	return result, err
+}
+
+// TraceCall executes the given call on top of the state at the given block and
+// returns the raw output of the configured tracer. The block number can be nil,
+// in which case the latest known block is used.
+func (ec *Client) TraceCall(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int, config *TraceCallConfig) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := ec.c.CallContext(ctx, &result, "debug_traceCall", toCallArg(msg), toBlockNumArg(blockNumber), config)
+	return result, err
+}
+
+// TraceBlockByNumber re-executes all the transactions of the given block and
+// returns the output of the configured tracer for each of them.
+func (ec *Client) TraceBlockByNumber(ctx context.Context, number *big.Int, config *TraceConfig) ([]*TxTraceResult, error) {
+	var result []*TxTraceResult
+	err := ec.c.CallContext(ctx, &result, "debug_traceBlockByNumber", toBlockNumArg(number), config)
+	return result, err
+}
+
+// TraceTransactionWithCallTracer re-executes the given transaction with the
+// callTracer and returns the resulting call tree.
+func (ec *Client) TraceTransactionWithCallTracer(ctx context.Context, hash common.Hash, config *CallTracerConfig) (*CallFrame, error) {
+	traceConfig, err := callTraceConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	var result CallFrame
+	if err := ec.c.CallContext(ctx, &result, "debug_traceTransaction", hash, traceConfig); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// TraceCallWithCallTracer executes the given call with the callTracer on top of
+// the state at the given block and returns the resulting call tree.
+func (ec *Client) TraceCallWithCallTracer(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int, config *CallTracerConfig) (*CallFrame, error) {
+	traceConfig, err := callTraceConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	var result CallFrame
+	if err := ec.c.CallContext(ctx, &result, "debug_traceCall", toCallArg(msg), toBlockNumArg(blockNumber), TraceCallConfig{TraceConfig: *traceConfig}); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// TraceTransactionWithPrestateDiff re-executes the given transaction with the
+// prestateTracer in diff mode and returns the state modifications made by it.
+func (ec *Client) TraceTransactionWithPrestateDiff(ctx context.Context, hash common.Hash) (*PrestateDiff, error) {
+	config := &TraceConfig{
+		Tracer:       "prestateTracer",
+		TracerConfig: json.RawMessage(`{"diffMode":true}`),
+	}
+	var result PrestateDiff
+	if err := ec.c.CallContext(ctx, &result, "debug_traceTransaction", hash, config); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SubscribeTraceChain subscribes to the traces of the blocks between start
+// (exclusive) and end (inclusive). The subscription ends once all the blocks
+// have been delivered. Chain tracing requires a connection supporting
+// subscriptions, i.e. websocket or IPC.
+func (ec *Client) SubscribeTraceChain(ctx context.Context, ch chan<- *BlockTraceResult, start, end *big.Int, config *TraceConfig) (*rpc.ClientSubscription, error) {
+	return ec.c.Subscribe(ctx, "debug", ch, "traceChain", toBlockNumArg(start), toBlockNumArg(end), config)
+}
+
+// callTraceConfig assembles the trace config running the callTracer with the
+// given options.
+func callTraceConfig(config *CallTracerConfig) (*TraceConfig, error) {
+	traceConfig := &TraceConfig{Tracer: "callTracer"}
+	if config != nil {
+		blob, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		traceConfig.TracerConfig = blob
+	}
+	return traceConfig, nil
+}
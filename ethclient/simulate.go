@@ -0,0 +1,135 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// SimulateBlock is a batch of calls to be simulated sequentially on top of
+// an optionally overridden state and block context.
+type SimulateBlock struct {
+	BlockOverrides *ethereum.BlockOverrides
+	StateOverrides map[common.Address]ethereum.OverrideAccount
+	Calls          []ethereum.CallMsg
+}
+
+func (b SimulateBlock) MarshalJSON() ([]byte, error) {
+	type block struct {
+		BlockOverrides *ethereum.BlockOverrides                    `json:"blockOverrides,omitempty"`
+		StateOverrides map[common.Address]ethereum.OverrideAccount `json:"stateOverrides,omitempty"`
+		Calls          []interface{}                               `json:"calls"`
+	}
+	output := block{
+		BlockOverrides: b.BlockOverrides,
+		StateOverrides: b.StateOverrides,
+		Calls:          make([]interface{}, len(b.Calls)),
+	}
+	for i, call := range b.Calls {
+		output.Calls[i] = toCallArg(call)
+	}
+	return json.Marshal(output)
+}
+
+// SimulateOptions are the inputs of eth_simulateV1.
+type SimulateOptions struct {
+	BlockStateCalls        []SimulateBlock `json:"blockStateCalls"`
+	TraceTransfers         bool            `json:"traceTransfers"`         // Emit ETH transfers as logs
+	Validation             bool            `json:"validation"`             // Enforce the regular transaction validity rules
+	ReturnFullTransactions bool            `json:"returnFullTransactions"` // Return full transactions in the blocks
+}
+
+// SimulateCallError is the error of a failed simulated call.
+type SimulateCallError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
+
+// Error implements the error interface.
+func (e *SimulateCallError) Error() string {
+	return e.Message
+}
+
+// SimulateCallResult is the result of a single simulated call.
+type SimulateCallResult struct {
+	ReturnValue []byte
+	Logs        []*types.Log
+	GasUsed     uint64
+	Status      uint64
+	Error       *SimulateCallError
+}
+
+func (r *SimulateCallResult) UnmarshalJSON(input []byte) error {
+	type callResult struct {
+		ReturnValue hexutil.Bytes      `json:"returnData"`
+		Logs        []*types.Log       `json:"logs"`
+		GasUsed     hexutil.Uint64     `json:"gasUsed"`
+		Status      hexutil.Uint64     `json:"status"`
+		Error       *SimulateCallError `json:"error"`
+	}
+	var dec callResult
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*r = SimulateCallResult{
+		ReturnValue: dec.ReturnValue,
+		Logs:        dec.Logs,
+		GasUsed:     uint64(dec.GasUsed),
+		Status:      uint64(dec.Status),
+		Error:       dec.Error,
+	}
+	return nil
+}
+
+// SimulateBlockResult is the result of a simulated block: the header of the
+// assembled block and the results of the calls it contains.
+type SimulateBlockResult struct {
+	Header *types.Header
+	Calls  []SimulateCallResult
+}
+
+func (r *SimulateBlockResult) UnmarshalJSON(input []byte) error {
+	var header types.Header
+	if err := json.Unmarshal(input, &header); err != nil {
+		return err
+	}
+	var body struct {
+		Calls []SimulateCallResult `json:"calls"`
+	}
+	if err := json.Unmarshal(input, &body); err != nil {
+		return err
+	}
+	*r = SimulateBlockResult{Header: &header, Calls: body.Calls}
+	return nil
+}
+
+// SimulateV1 executes the given batches of calls in a sequence of blocks built
+// on top of the given block, without creating transactions on the blockchain.
+// The block number can be nil, in which case the latest known block is used.
+func (ec *Client) SimulateV1(ctx context.Context, opts SimulateOptions, blockNumber *big.Int) ([]*SimulateBlockResult, error) {
+	var result []*SimulateBlockResult
+	err := ec.c.CallContext(ctx, &result, "eth_simulateV1", opts, toBlockNumArg(blockNumber))
+	return result, err
+}
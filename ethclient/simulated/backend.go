@@ -28,11 +28,15 @@ import (
 	"github.com/ethereum/go-ethereum/eth/catalyst"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
+
+	// Force-load the native tracers to trigger registration
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
 )
 
 // Client exposes the methods provided by the Ethereum RPC client.
@@ -115,6 +119,8 @@ func newWithNode(stack *node.Node, conf *eth.Config, blockPeriod uint64) (*Backe
 		Namespace: "eth",
 		Service:   filters.NewFilterAPI(filterSystem),
 	}})
+	// Register the tracing APIs
+	stack.RegisterAPIs(tracers.APIs(backend.APIBackend))
 	// Start the node
 	if err := stack.Start(); err != nil {
 		return nil, err
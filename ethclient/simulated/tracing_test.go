@@ -0,0 +1,261 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package simulated
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	// tracingCaller forwards 1 wei to tracingCallee on every invocation.
+	tracingCaller     = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	tracingCallerCode = append(append([]byte{
+		byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 1, byte(vm.PUSH20),
+	}, tracingCallee.Bytes()...), byte(vm.GAS), byte(vm.CALL), byte(vm.STOP))
+
+	// tracingCallee sets its first storage slot to 1.
+	tracingCallee     = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	tracingCalleeCode = []byte{byte(vm.PUSH1), 1, byte(vm.PUSH1), 0, byte(vm.SSTORE), byte(vm.STOP)}
+)
+
+// newTracingBackend creates a simulated backend with a single mined transaction
+// invoking tracingCaller.
+func newTracingBackend(t *testing.T) (*Backend, *types.Transaction) {
+	sim := NewBackend(types.GenesisAlloc{
+		testAddr:      {Balance: big.NewInt(params.Ether)},
+		tracingCaller: {Code: tracingCallerCode, Balance: big.NewInt(params.Ether)},
+		tracingCallee: {Code: tracingCalleeCode},
+	})
+	t.Cleanup(func() { sim.Close() })
+
+	client := sim.Client()
+	head, _ := client.HeaderByNumber(context.Background(), nil)
+	chainid, _ := client.ChainID(context.Background())
+
+	tx, err := types.SignNewTx(testKey, types.LatestSignerForChainID(chainid), &types.DynamicFeeTx{
+		ChainID:   chainid,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: new(big.Int).Add(head.BaseFee, big.NewInt(params.GWei)),
+		Gas:       100000,
+		To:        &tracingCaller,
+	})
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	if err := client.SendTransaction(context.Background(), tx); err != nil {
+		t.Fatalf("failed to send transaction: %v", err)
+	}
+	sim.Commit()
+	return sim, tx
+}
+
+// checkCallFrame verifies the call tree produced by invoking tracingCaller.
+func checkCallFrame(t *testing.T, frame *gethclient.CallFrame) {
+	t.Helper()
+
+	if frame.Type != "CALL" || frame.From != testAddr || frame.To == nil || *frame.To != tracingCaller {
+		t.Fatalf("top call mismatch: type %s, from %x, to %v", frame.Type, frame.From, frame.To)
+	}
+	if len(frame.Calls) != 1 {
+		t.Fatalf("sub-call count mismatch: have %d, want 1", len(frame.Calls))
+	}
+	call := frame.Calls[0]
+	if call.Type != "CALL" || call.From != tracingCaller || call.To == nil || *call.To != tracingCallee {
+		t.Fatalf("sub-call mismatch: type %s, from %x, to %v", call.Type, call.From, call.To)
+	}
+	if call.Value == nil || call.Value.Cmp(common.Big1) != 0 {
+		t.Fatalf("sub-call value mismatch: have %v, want 1", call.Value)
+	}
+}
+
+func TestTraceTransaction(t *testing.T) {
+	sim, tx := newTracingBackend(t)
+	client := gethclient.New(sim.node.Attach())
+
+	// Trace with the default struct logger
+	raw, err := client.TraceTransaction(context.Background(), tx.Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	var logs struct {
+		Failed     bool              `json:"failed"`
+		StructLogs []json.RawMessage `json:"structLogs"`
+	}
+	if err := json.Unmarshal(raw, &logs); err != nil {
+		t.Fatalf("failed to decode struct logs: %v", err)
+	}
+	if logs.Failed || len(logs.StructLogs) == 0 {
+		t.Fatalf("unexpected struct logs: failed %v, steps %d", logs.Failed, len(logs.StructLogs))
+	}
+	// Trace with the call tracer
+	frame, err := client.TraceTransactionWithCallTracer(context.Background(), tx.Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to call-trace transaction: %v", err)
+	}
+	checkCallFrame(t, frame)
+
+	// Trace with the prestate tracer in diff mode
+	diff, err := client.TraceTransactionWithPrestateDiff(context.Background(), tx.Hash())
+	if err != nil {
+		t.Fatalf("failed to diff-trace transaction: %v", err)
+	}
+	post := diff.Post[tracingCallee]
+	if post == nil || post.Storage[common.Hash{}] != common.BigToHash(common.Big1) {
+		t.Fatalf("callee storage not modified: %v", post)
+	}
+	if pre := diff.Pre[tracingCallee]; pre == nil || len(pre.Code) == 0 {
+		t.Fatalf("callee pre-state missing")
+	}
+}
+
+func TestTraceCall(t *testing.T) {
+	sim, _ := newTracingBackend(t)
+	client := gethclient.New(sim.node.Attach())
+
+	msg := ethereum.CallMsg{From: testAddr, To: &tracingCaller, Gas: 100000}
+	frame, err := client.TraceCallWithCallTracer(context.Background(), msg, nil, &gethclient.CallTracerConfig{WithLog: true})
+	if err != nil {
+		t.Fatalf("failed to call-trace call: %v", err)
+	}
+	checkCallFrame(t, frame)
+
+	// Override the callee to a no-op and ensure the storage is left untouched
+	config := &gethclient.TraceCallConfig{
+		TraceConfig: gethclient.TraceConfig{
+			Tracer:       "prestateTracer",
+			TracerConfig: json.RawMessage(`{"diffMode":true}`),
+			Timeout:      time.Minute,
+		},
+		StateOverrides: map[common.Address]gethclient.OverrideAccount{
+			tracingCallee: {Code: []byte{byte(vm.STOP)}},
+		},
+	}
+	raw, err := client.TraceCall(context.Background(), msg, nil, config)
+	if err != nil {
+		t.Fatalf("failed to trace call: %v", err)
+	}
+	var diff gethclient.PrestateDiff
+	if err := json.Unmarshal(raw, &diff); err != nil {
+		t.Fatalf("failed to decode prestate diff: %v", err)
+	}
+	if post := diff.Post[tracingCallee]; post != nil && len(post.Storage) != 0 {
+		t.Fatalf("overridden callee modified storage: %v", post.Storage)
+	}
+}
+
+func TestTraceBlock(t *testing.T) {
+	sim, tx := newTracingBackend(t)
+	client := gethclient.New(sim.node.Attach())
+
+	results, err := client.TraceBlockByNumber(context.Background(), big.NewInt(1), &gethclient.TraceConfig{Tracer: "callTracer"})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	if len(results) != 1 || results[0].TxHash != tx.Hash() || results[0].Error != "" {
+		t.Fatalf("unexpected block trace: %v", results)
+	}
+	var frame gethclient.CallFrame
+	if err := json.Unmarshal(results[0].Result, &frame); err != nil {
+		t.Fatalf("failed to decode call frame: %v", err)
+	}
+	checkCallFrame(t, &frame)
+
+	// Trace the same block through a chain trace subscription
+	ch := make(chan *gethclient.BlockTraceResult)
+	sub, err := client.SubscribeTraceChain(context.Background(), ch, big.NewInt(0), big.NewInt(1), &gethclient.TraceConfig{Tracer: "callTracer"})
+	if err != nil {
+		t.Fatalf("failed to subscribe to chain traces: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	select {
+	case res := <-ch:
+		if uint64(res.Block) != 1 || len(res.Traces) != 1 || res.Traces[0].TxHash != tx.Hash() {
+			t.Fatalf("unexpected chain trace: block %d, traces %d", res.Block, len(res.Traces))
+		}
+	case err := <-sub.Err():
+		t.Fatalf("chain trace subscription failed: %v", err)
+	case <-time.After(10 * time.Second):
+		t.Fatalf("chain trace timed out")
+	}
+}
+
+func TestSimulateV1(t *testing.T) {
+	sim, _ := newTracingBackend(t)
+	client := ethclient.NewClient(sim.node.Attach())
+
+	head, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve head: %v", err)
+	}
+	opts := ethclient.SimulateOptions{
+		BlockStateCalls: []ethclient.SimulateBlock{
+			{
+				Calls: []ethereum.CallMsg{{From: testAddr, To: &tracingCaller, Gas: 100000}},
+			},
+			{
+				BlockOverrides: &ethereum.BlockOverrides{Time: head.Time + 100},
+				StateOverrides: map[common.Address]ethereum.OverrideAccount{
+					tracingCallee: {Code: []byte{byte(vm.PUSH1), 0, byte(vm.DUP1), byte(vm.REVERT)}},
+				},
+				Calls: []ethereum.CallMsg{{From: testAddr, To: &tracingCaller, Gas: 100000}},
+			},
+		},
+		TraceTransfers: true,
+	}
+	results, err := client.SimulateV1(context.Background(), opts, nil)
+	if err != nil {
+		t.Fatalf("failed to simulate: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("block count mismatch: have %d, want 2", len(results))
+	}
+	for i, res := range results {
+		if want := head.Number.Uint64() + uint64(i) + 1; res.Header.Number.Uint64() != want {
+			t.Errorf("block %d: number mismatch: have %d, want %d", i, res.Header.Number, want)
+		}
+		if len(res.Calls) != 1 {
+			t.Fatalf("block %d: call count mismatch: have %d, want 1", i, len(res.Calls))
+		}
+	}
+	if ts := results[1].Header.Time; ts != head.Time+100 {
+		t.Errorf("overridden time mismatch: have %d, want %d", ts, head.Time+100)
+	}
+	// The first call succeeds and transfers 1 wei to the callee
+	if call := results[0].Calls[0]; call.Status != types.ReceiptStatusSuccessful || call.Error != nil || call.GasUsed == 0 {
+		t.Errorf("first call failed: status %d, error %v", call.Status, call.Error)
+	}
+	if logs := results[0].Calls[0].Logs; len(logs) != 1 {
+		t.Errorf("transfer log count mismatch: have %d, want 1", len(logs))
+	}
+	// The second call succeeds too, but the reverting callee undoes the transfer
+	if call := results[1].Calls[0]; call.Status != types.ReceiptStatusSuccessful || len(call.Logs) != 0 {
+		t.Errorf("second call mismatch: status %d, logs %d", call.Status, len(call.Logs))
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package remotedb
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// iterator is an iterator over a key range of the remote database. The entries
+// are fetched page by page on demand, so there is no consistency guarantee if
+// the remote database is modified during the iteration.
+type iterator struct {
+	remote  *rpc.Client
+	prefix  []byte
+	next    []byte // Start position of the next page, nil if exhausted
+	entries []iteratorEntry
+	pos     int
+	err     error
+}
+
+// newIterator creates an iterator over the remote key range with the given
+// prefix, starting at the given position relative to the prefix.
+func newIterator(remote *rpc.Client, prefix []byte, start []byte) *iterator {
+	next := start
+	if next == nil {
+		next = []byte{}
+	}
+	return &iterator{
+		remote: remote,
+		prefix: prefix,
+		next:   next,
+		pos:    -1,
+	}
+}
+
+// Next moves the iterator to the next key/value pair. It returns whether the
+// iterator is exhausted.
+func (it *iterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	it.pos++
+	for it.pos >= len(it.entries) {
+		if it.next == nil {
+			return false
+		}
+		var page iteratorPage
+		if err := it.remote.Call(&page, "remotedb_iterate", hexutil.Bytes(it.prefix), hexutil.Bytes(it.next), 0); err != nil {
+			it.err = err
+			return false
+		}
+		it.entries, it.pos = page.Entries, 0
+		it.next = nil
+		if len(page.Next) > 0 {
+			it.next = page.Next
+		}
+	}
+	return true
+}
+
+// Error returns any accumulated error. Exhausting all the key/value pairs
+// is not considered to be an error.
+func (it *iterator) Error() error {
+	return it.err
+}
+
+// Key returns the key of the current key/value pair, or nil if done.
+func (it *iterator) Key() []byte {
+	if it.pos < 0 || it.pos >= len(it.entries) {
+		return nil
+	}
+	return it.entries[it.pos].Key
+}
+
+// Value returns the value of the current key/value pair, or nil if done.
+func (it *iterator) Value() []byte {
+	if it.pos < 0 || it.pos >= len(it.entries) {
+		return nil
+	}
+	return it.entries[it.pos].Value
+}
+
+// Release releases associated resources.
+func (it *iterator) Release() {
+	it.entries = nil
+}
@@ -15,24 +15,50 @@
 // along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
 
 // Package remotedb implements the key-value database layer based on a remote geth
-// node. Under the hood, it utilises the `debug_dbGet` method to implement a
-// read-only database.
+// node. Under the hood, it utilises the `remotedb` RPC namespace, served on the
+// authenticated endpoint of the remote node, to implement a read-only database.
+// Nodes not serving it are accessed through the `debug_db*` methods instead,
+// which only support basic lookups.
 // There really are no guarantees in this database, since the local geth does not
-// exclusive access, but it can be used for basic diagnostics of a remote node.
+// exclusive access, but it can be used for basic diagnostics and analytics of a
+// remote node.
 package remotedb
 
 import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
-// Database is a key-value lookup for a remote database via debug_dbGet.
+// Database is a key-value lookup for a remote database via the remotedb API.
 type Database struct {
 	remote *rpc.Client
+	legacy atomic.Bool // Flag whether the remote node only serves the debug_db* methods
+}
+
+// served reports whether the error of a remotedb call allows for the result to
+// be used. If the remote node doesn't serve the remotedb API, the database
+// switches to the legacy debug_db* methods.
+func (db *Database) served(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32601 {
+		db.legacy.Store(true)
+		return false
+	}
+	return true
 }
 
 func (db *Database) Has(key []byte) (bool, error) {
+	if !db.legacy.Load() {
+		var resp bool
+		if err := db.remote.Call(&resp, "remotedb_has", hexutil.Bytes(key)); db.served(err) {
+			return resp, err
+		}
+	}
 	if _, err := db.Get(key); err != nil {
 		return false, err
 	}
@@ -41,13 +67,49 @@ func (db *Database) Has(key []byte) (bool, error) {
 
 func (db *Database) Get(key []byte) ([]byte, error) {
 	var resp hexutil.Bytes
-	err := db.remote.Call(&resp, "debug_dbGet", hexutil.Bytes(key))
-	if err != nil {
+	if !db.legacy.Load() {
+		if err := db.remote.Call(&resp, "remotedb_get", hexutil.Bytes(key)); db.served(err) {
+			if err != nil {
+				return nil, err
+			}
+			return resp, nil
+		}
+	}
+	if err := db.remote.Call(&resp, "debug_dbGet", hexutil.Bytes(key)); err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
 
+// GetBatch retrieves the values of the given keys in batches, the value is nil
+// if the key is not present.
+func (db *Database) GetBatch(keys [][]byte) ([][]byte, error) {
+	values := make([][]byte, 0, len(keys))
+	for len(keys) > 0 {
+		n := min(len(keys), maxBatchKeys)
+		req := make([]hexutil.Bytes, n)
+		for i := range req {
+			req[i] = keys[i]
+		}
+		var resp []*hexutil.Bytes
+		if err := db.remote.Call(&resp, "remotedb_getBatch", req); err != nil {
+			return nil, err
+		}
+		if len(resp) != n {
+			return nil, fmt.Errorf("unexpected number of values, have %d, want %d", len(resp), n)
+		}
+		for _, value := range resp {
+			if value == nil {
+				values = append(values, nil)
+			} else {
+				values = append(values, *value)
+			}
+		}
+		keys = keys[n:]
+	}
+	return values, nil
+}
+
 func (db *Database) HasAncient(kind string, number uint64) (bool, error) {
 	if _, err := db.Ancient(kind, number); err != nil {
 		return false, err
@@ -57,29 +119,55 @@ func (db *Database) HasAncient(kind string, number uint64) (bool, error) {
 
 func (db *Database) Ancient(kind string, number uint64) ([]byte, error) {
 	var resp hexutil.Bytes
-	err := db.remote.Call(&resp, "debug_dbAncient", kind, number)
-	if err != nil {
+	if !db.legacy.Load() {
+		if err := db.remote.Call(&resp, "remotedb_ancient", kind, hexutil.Uint64(number)); db.served(err) {
+			if err != nil {
+				return nil, err
+			}
+			return resp, nil
+		}
+	}
+	if err := db.remote.Call(&resp, "debug_dbAncient", kind, number); err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
 
 func (db *Database) AncientRange(kind string, start, count, maxBytes uint64) ([][]byte, error) {
-	panic("not supported")
+	var resp []hexutil.Bytes
+	err := db.remote.Call(&resp, "remotedb_ancientRange", kind, hexutil.Uint64(start), hexutil.Uint64(count), hexutil.Uint64(maxBytes))
+	if err != nil {
+		return nil, err
+	}
+	items := make([][]byte, len(resp))
+	for i, item := range resp {
+		items[i] = item
+	}
+	return items, nil
 }
 
 func (db *Database) Ancients() (uint64, error) {
+	if !db.legacy.Load() {
+		var resp hexutil.Uint64
+		if err := db.remote.Call(&resp, "remotedb_ancients"); db.served(err) {
+			return uint64(resp), err
+		}
+	}
 	var resp uint64
 	err := db.remote.Call(&resp, "debug_dbAncients")
 	return resp, err
 }
 
 func (db *Database) Tail() (uint64, error) {
-	panic("not supported")
+	var resp hexutil.Uint64
+	err := db.remote.Call(&resp, "remotedb_tail")
+	return uint64(resp), err
 }
 
 func (db *Database) AncientSize(kind string) (uint64, error) {
-	panic("not supported")
+	var resp hexutil.Uint64
+	err := db.remote.Call(&resp, "remotedb_ancientSize", kind)
+	return uint64(resp), err
 }
 
 func (db *Database) ReadAncients(fn func(op ethdb.AncientReaderOp) error) (err error) {
@@ -123,11 +211,13 @@ func (db *Database) NewBatchWithSize(size int) ethdb.Batch {
 }
 
 func (db *Database) NewIterator(prefix []byte, start []byte) ethdb.Iterator {
-	panic("not supported")
+	return newIterator(db.remote, prefix, start)
 }
 
 func (db *Database) Stat() (string, error) {
-	return "", nil
+	var resp string
+	err := db.remote.Call(&resp, "remotedb_stat")
+	return resp, err
 }
 
 func (db *Database) AncientDatadir() (string, error) {
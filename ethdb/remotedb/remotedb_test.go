@@ -0,0 +1,207 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package remotedb
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func newTestDatabase(t *testing.T) (ethdb.Database, ethdb.Database) {
+	db, err := rawdb.NewDatabaseWithFreezer(memorydb.New(), "", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := rpc.NewServer()
+	for _, api := range APIs(db) {
+		if err := server.RegisterName(api.Namespace, api.Service); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() {
+		server.Stop()
+		db.Close()
+	})
+	return db, New(rpc.DialInProc(server))
+}
+
+func TestKeyValue(t *testing.T) {
+	local, remote := newTestDatabase(t)
+
+	var keys [][]byte
+	for i := 0; i < 100; i++ {
+		key := []byte(fmt.Sprintf("key-%03d", i))
+		local.Put(key, []byte(fmt.Sprintf("val-%03d", i)))
+		keys = append(keys, key)
+	}
+	local.Put([]byte("empty"), []byte{})
+
+	if ok, err := remote.Has(keys[0]); err != nil || !ok {
+		t.Fatalf("Key is not present, err: %v", err)
+	}
+	if ok, err := remote.Has([]byte("missing")); err != nil || ok {
+		t.Fatalf("Missing key is present, err: %v", err)
+	}
+	if val, err := remote.Get(keys[1]); err != nil || string(val) != "val-001" {
+		t.Fatalf("Unexpected value %q, err: %v", val, err)
+	}
+	if _, err := remote.Get([]byte("missing")); err == nil {
+		t.Fatal("Missing key is retrieved")
+	}
+	vals, err := remote.(*Database).GetBatch([][]byte{keys[2], []byte("missing"), []byte("empty")})
+	if err != nil {
+		t.Fatalf("Failed to retrieve batch: %v", err)
+	}
+	if string(vals[0]) != "val-002" || vals[1] != nil || vals[2] == nil || len(vals[2]) != 0 {
+		t.Fatalf("Unexpected batch values %q", vals)
+	}
+}
+
+func TestIterator(t *testing.T) {
+	maxResponseSize = 64
+	defer func() {
+		maxResponseSize = 4 * 1024 * 1024
+	}()
+	local, remote := newTestDatabase(t)
+
+	for i := 0; i < 100; i++ {
+		local.Put([]byte(fmt.Sprintf("a-%03d", i)), []byte(fmt.Sprintf("val-%03d", i)))
+		local.Put([]byte(fmt.Sprintf("b-%03d", i)), []byte(fmt.Sprintf("val-%03d", i)))
+	}
+	for _, start := range []int{0, 1, 50, 99} {
+		var (
+			want = start
+			it   = remote.NewIterator([]byte("a-"), []byte(fmt.Sprintf("%03d", start)))
+		)
+		for it.Next() {
+			if !bytes.Equal(it.Key(), []byte(fmt.Sprintf("a-%03d", want))) {
+				t.Fatalf("Unexpected key %q, want a-%03d", it.Key(), want)
+			}
+			if !bytes.Equal(it.Value(), []byte(fmt.Sprintf("val-%03d", want))) {
+				t.Fatalf("Unexpected value %q, want val-%03d", it.Value(), want)
+			}
+			want++
+		}
+		if err := it.Error(); err != nil {
+			t.Fatalf("Iteration failed: %v", err)
+		}
+		it.Release()
+		if want != 100 {
+			t.Fatalf("Iteration from %d stopped at %d", start, want)
+		}
+	}
+}
+
+func TestAncient(t *testing.T) {
+	local, remote := newTestDatabase(t)
+
+	var headers []*types.Header
+	for i := 0; i < 10; i++ {
+		headers = append(headers, &types.Header{Number: big.NewInt(int64(i)), Difficulty: big.NewInt(1)})
+	}
+	if _, err := rawdb.WriteAncientHeaderChain(local, headers); err != nil {
+		t.Fatalf("Failed to write headers: %v", err)
+	}
+	if n, err := remote.Ancients(); err != nil || n != 10 {
+		t.Fatalf("Unexpected number of ancients %d, err: %v", n, err)
+	}
+	if n, err := remote.Tail(); err != nil || n != 0 {
+		t.Fatalf("Unexpected tail %d, err: %v", n, err)
+	}
+	if size, err := remote.AncientSize(rawdb.ChainFreezerHeaderTable); err != nil || size == 0 {
+		t.Fatalf("Unexpected ancient size %d, err: %v", size, err)
+	}
+	// Access the remote ancient store with the rawdb accessors
+	for _, header := range headers {
+		have := rawdb.ReadHeader(remote, header.Hash(), header.Number.Uint64())
+		if have == nil || have.Hash() != header.Hash() {
+			t.Fatalf("Header %d is not accessible", header.Number)
+		}
+	}
+	rlps := rawdb.ReadHeaderRange(remote, 9, 10)
+	if len(rlps) != 10 {
+		t.Fatalf("Unexpected number of headers, have %d, want 10", len(rlps))
+	}
+}
+
+// legacyAPI mimics the database methods of the debug namespace, served by
+// nodes without the remotedb API.
+type legacyAPI struct {
+	db ethdb.Database
+}
+
+func (api *legacyAPI) DbGet(key string) (hexutil.Bytes, error) {
+	blob, err := common.ParseHexOrString(key)
+	if err != nil {
+		return nil, err
+	}
+	return api.db.Get(blob)
+}
+
+func (api *legacyAPI) DbAncient(kind string, number uint64) (hexutil.Bytes, error) {
+	return api.db.Ancient(kind, number)
+}
+
+func (api *legacyAPI) DbAncients() (uint64, error) {
+	return api.db.Ancients()
+}
+
+// Tests that the database falls back to the debug_db* methods if the remote
+// node doesn't serve the remotedb API.
+func TestLegacyFallback(t *testing.T) {
+	db, err := rawdb.NewDatabaseWithFreezer(memorydb.New(), "", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("debug", &legacyAPI{db}); err != nil {
+		t.Fatal(err)
+	}
+	remote := New(rpc.DialInProc(server))
+
+	db.Put([]byte("key"), []byte("val"))
+	if ok, err := remote.Has([]byte("key")); err != nil || !ok {
+		t.Fatalf("Key is not present, err: %v", err)
+	}
+	if !remote.(*Database).legacy.Load() {
+		t.Fatal("Database not switched to the legacy methods")
+	}
+	if val, err := remote.Get([]byte("key")); err != nil || string(val) != "val" {
+		t.Fatalf("Unexpected value %q, err: %v", val, err)
+	}
+	if ok, err := remote.Has([]byte("missing")); err == nil || ok {
+		t.Fatal("Missing key is present")
+	}
+	if n, err := remote.Ancients(); err != nil || n != 0 {
+		t.Fatalf("Unexpected ancients %d, err: %v", n, err)
+	}
+	if _, err := remote.Ancient(rawdb.ChainFreezerHeaderTable, 0); err == nil {
+		t.Fatal("Missing ancient is retrieved")
+	}
+}
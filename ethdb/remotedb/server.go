@@ -0,0 +1,191 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package remotedb
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// maxBatchKeys is the maximum number of keys allowed in a batched read.
+	maxBatchKeys = 1024
+
+	// maxIterateItems is the maximum number of entries returned in a single
+	// iteration page.
+	maxIterateItems = 16384
+)
+
+// maxResponseSize is the soft limit of the total data size returned in a single
+// iteration page or ancient range. It's a variable for testing purposes.
+var maxResponseSize = 4 * 1024 * 1024
+
+// iteratorEntry is a key-value pair returned by the iteration.
+type iteratorEntry struct {
+	Key   hexutil.Bytes `json:"key"`
+	Value hexutil.Bytes `json:"value"`
+}
+
+// iteratorPage is a chunk of the key-value pairs returned by the iteration.
+// Next is the start position of the following page relative to the prefix,
+// or empty if the iteration is exhausted.
+type iteratorPage struct {
+	Entries []iteratorEntry `json:"entries"`
+	Next    hexutil.Bytes   `json:"next,omitempty"`
+}
+
+// API exposes a database to the remote clients. It is meant to be registered
+// on the authenticated RPC endpoint, as the entire content of the database is
+// accessible through it.
+type API struct {
+	db ethdb.Database
+}
+
+// NewAPI creates the API serving the given database.
+func NewAPI(db ethdb.Database) *API {
+	return &API{db: db}
+}
+
+// APIs returns the remote database APIs, which are only served on the endpoint
+// authenticated with JWT.
+func APIs(db ethdb.Database) []rpc.API {
+	return []rpc.API{
+		{
+			Namespace:     "remotedb",
+			Service:       NewAPI(db),
+			Authenticated: true,
+		},
+	}
+}
+
+// Has returns whether the given key is present in the key-value store.
+func (api *API) Has(key hexutil.Bytes) (bool, error) {
+	return api.db.Has(key)
+}
+
+// Get returns the value of the given key from the key-value store.
+func (api *API) Get(key hexutil.Bytes) (hexutil.Bytes, error) {
+	return api.db.Get(key)
+}
+
+// GetBatch returns the values of the given keys from the key-value store, the
+// value is null if the key is not present.
+func (api *API) GetBatch(keys []hexutil.Bytes) ([]*hexutil.Bytes, error) {
+	if len(keys) > maxBatchKeys {
+		return nil, fmt.Errorf("too many keys, have %d, max %d", len(keys), maxBatchKeys)
+	}
+	values := make([]*hexutil.Bytes, len(keys))
+	for i, key := range keys {
+		ok, err := api.db.Has(key)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		blob, err := api.db.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		value := hexutil.Bytes(blob)
+		values[i] = &value
+	}
+	return values, nil
+}
+
+// Iterate returns a page of the key-value pairs with the given key prefix,
+// starting at the given position relative to the prefix. The page is capped
+// by both the given limit and the response size.
+func (api *API) Iterate(prefix hexutil.Bytes, start hexutil.Bytes, limit int) (*iteratorPage, error) {
+	if limit <= 0 || limit > maxIterateItems {
+		limit = maxIterateItems
+	}
+	it := api.db.NewIterator(prefix, start)
+	defer it.Release()
+
+	var (
+		page iteratorPage
+		size int
+	)
+	for it.Next() {
+		if len(page.Entries) >= limit || size >= maxResponseSize {
+			last := page.Entries[len(page.Entries)-1].Key
+			page.Next = append(last[len(prefix):len(last):len(last)], 0x00)
+			break
+		}
+		key, value := it.Key(), it.Value()
+		page.Entries = append(page.Entries, iteratorEntry{
+			Key:   append([]byte{}, key...),
+			Value: append([]byte{}, value...),
+		})
+		size += len(key) + len(value)
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// Ancient returns the ancient item numbered by the given number from the
+// specified table.
+func (api *API) Ancient(kind string, number hexutil.Uint64) (hexutil.Bytes, error) {
+	return api.db.Ancient(kind, uint64(number))
+}
+
+// AncientRange returns the ancient items in the given range from the specified
+// table. The maxBytes is capped by the response size limit, at least one item
+// is returned if it's available.
+func (api *API) AncientRange(kind string, start, count, maxBytes hexutil.Uint64) ([]hexutil.Bytes, error) {
+	if maxBytes == 0 || maxBytes > hexutil.Uint64(maxResponseSize) {
+		maxBytes = hexutil.Uint64(maxResponseSize)
+	}
+	items, err := api.db.AncientRange(kind, uint64(start), uint64(count), uint64(maxBytes))
+	if err != nil {
+		return nil, err
+	}
+	blobs := make([]hexutil.Bytes, len(items))
+	for i, item := range items {
+		blobs[i] = item
+	}
+	return blobs, nil
+}
+
+// Ancients returns the number of ancient items in the ancient store.
+func (api *API) Ancients() (hexutil.Uint64, error) {
+	n, err := api.db.Ancients()
+	return hexutil.Uint64(n), err
+}
+
+// Tail returns the number of the first stored item in the ancient store.
+func (api *API) Tail() (hexutil.Uint64, error) {
+	n, err := api.db.Tail()
+	return hexutil.Uint64(n), err
+}
+
+// AncientSize returns the size of the specified table in the ancient store.
+func (api *API) AncientSize(kind string) (hexutil.Uint64, error) {
+	n, err := api.db.AncientSize(kind)
+	return hexutil.Uint64(n), err
+}
+
+// Stat returns the statistics of the key-value store.
+func (api *API) Stat() (string, error) {
+	return api.db.Stat()
+}
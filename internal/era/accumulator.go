@@ -47,6 +47,20 @@ func ComputeAccumulator(hashes []common.Hash, tds []*big.Int) (common.Hash, erro
 	return hh.HashRoot()
 }
 
+// ComputeBlockHashesRoot calculates the SSZ hash tree root of the block hashes
+// archived in an era-E file.
+func ComputeBlockHashesRoot(hashes []common.Hash) (common.Hash, error) {
+	if len(hashes) > MaxEra1Size {
+		return common.Hash{}, fmt.Errorf("too many records: have %d, max %d", len(hashes), MaxEra1Size)
+	}
+	hh := ssz.NewHasher()
+	for i := range hashes {
+		hh.Append(hashes[i][:])
+	}
+	hh.MerkleizeWithMixin(0, uint64(len(hashes)), uint64(MaxEra1Size))
+	return hh.HashRoot()
+}
+
 // headerRecord is an individual record for a historical header.
 //
 // See https://github.com/ethereum/portal-network-specs/blob/master/history-network.md#the-header-accumulator
@@ -71,31 +71,57 @@ import (
 //
 // Due to the accumulator size limit of 8192, the maximum number of blocks in
 // an Era1 batch is also 8192.
+//
+// The post-merge history is archived in era-E files instead, which drop the
+// total difficulty that's meaningless after the merge. Instead, every block is
+// followed by the mandatory proof of its inclusion in the beacon chain's
+// historical_summaries, which is what makes the archive verifiable:
+//
+//	erae        := Version | block-tuple* | other-entries* | BlockHashesRoot | BlockIndexE
+//	block-tuple := CompressedHeader | CompressedBody | CompressedReceipts | Proof
+//
+//	Proof           = { type: [0x0b, 0x00], data: rlp(block-proof) }
+//	BlockHashesRoot = { type: [0x08, 0x00], data: hash_tree_root(List[Bytes32, 8192]) }
+//	BlockIndexE     = { type: [0x32, 0x67], data: block-index }
+//
+// The block index has the same format as in Era1 files.
 type Builder struct {
 	w        *e2store.Writer
+	format   Format
 	startNum *uint64
 	startTd  *big.Int
 	indexes  []uint64
 	hashes   []common.Hash
 	tds      []*big.Int
+	proven   bool // Flag whether the last block has its proof written
 	written  int
 
 	buf    *bytes.Buffer
 	snappy *snappy.Writer
 }
 
-// NewBuilder returns a new Builder instance.
+// NewBuilder returns a new Builder instance for Era1 archives.
 func NewBuilder(w io.Writer) *Builder {
+	return newBuilder(w, FormatEra1)
+}
+
+// NewBuilderE returns a new Builder instance for era-E archives.
+func NewBuilderE(w io.Writer) *Builder {
+	return newBuilder(w, FormatEraE)
+}
+
+func newBuilder(w io.Writer, format Format) *Builder {
 	buf := bytes.NewBuffer(nil)
 	return &Builder{
 		w:      e2store.NewWriter(w),
+		format: format,
 		buf:    buf,
 		snappy: snappy.NewBufferedWriter(buf),
 	}
 }
 
 // Add writes a compressed block entry and compressed receipts entry to the
-// underlying e2store file.
+// underlying e2store file. The total difficulty is ignored in era-E archives.
 func (b *Builder) Add(block *types.Block, receipts types.Receipts, td *big.Int) error {
 	eh, err := rlp.EncodeToBytes(block.Header())
 	if err != nil {
@@ -115,6 +141,9 @@ func (b *Builder) Add(block *types.Block, receipts types.Receipts, td *big.Int)
 // AddRLP writes a compressed block entry and compressed receipts entry to the
 // underlying e2store file.
 func (b *Builder) AddRLP(header, body, receipts []byte, number uint64, hash common.Hash, td, difficulty *big.Int) error {
+	if b.format == FormatEra1 && td == nil {
+		return errors.New("total difficulty is required in era1 archives")
+	}
 	// Write Era1 version entry before first block.
 	if b.startNum == nil {
 		n, err := b.w.Write(TypeVersion, nil)
@@ -123,16 +152,24 @@ func (b *Builder) AddRLP(header, body, receipts []byte, number uint64, hash comm
 		}
 		startNum := number
 		b.startNum = &startNum
-		b.startTd = new(big.Int).Sub(td, difficulty)
+		if b.format == FormatEra1 {
+			b.startTd = new(big.Int).Sub(td, difficulty)
+		}
 		b.written += n
 	}
 	if len(b.indexes) >= MaxEra1Size {
 		return fmt.Errorf("exceeds maximum batch size of %d", MaxEra1Size)
 	}
-
+	if want := *b.startNum + uint64(len(b.indexes)); number != want {
+		return fmt.Errorf("non-contiguous block %d, want %d", number, want)
+	}
+	if err := b.checkProven(); err != nil {
+		return err
+	}
 	b.indexes = append(b.indexes, uint64(b.written))
 	b.hashes = append(b.hashes, hash)
 	b.tds = append(b.tds, td)
+	b.proven = false
 
 	// Write block data.
 	if err := b.snappyWrite(TypeCompressedHeader, header); err != nil {
@@ -145,6 +182,9 @@ func (b *Builder) AddRLP(header, body, receipts []byte, number uint64, hash comm
 		return err
 	}
 
+	if b.format == FormatEraE {
+		return nil
+	}
 	// Also write total difficulty, but don't snappy encode.
 	btd := bigToBytes32(td)
 	n, err := b.w.Write(TypeTotalDifficulty, btd[:])
@@ -156,18 +196,71 @@ func (b *Builder) AddRLP(header, body, receipts []byte, number uint64, hash comm
 	return nil
 }
 
+// AddProof writes the beacon chain proof of the last added block. It's only
+// supported in era-E archives, where every block must be proven before the next
+// one is added or the archive is finalized.
+func (b *Builder) AddProof(proof *BlockProof) error {
+	if b.format != FormatEraE {
+		return errors.New("proofs are only supported in era-E archives")
+	}
+	if len(b.hashes) == 0 {
+		return errors.New("no block to prove")
+	}
+	if b.proven {
+		return fmt.Errorf("duplicate proof for block %d", *b.startNum+uint64(len(b.hashes))-1)
+	}
+	if err := proof.verifyBlock(b.hashes[len(b.hashes)-1]); err != nil {
+		return err
+	}
+	enc, err := rlp.EncodeToBytes(proof)
+	if err != nil {
+		return err
+	}
+	n, err := b.w.Write(TypeProof, enc)
+	b.written += n
+	if err != nil {
+		return err
+	}
+	b.proven = true
+	return nil
+}
+
+// checkProven returns an error if the last added block of an era-E archive
+// lacks its proof.
+func (b *Builder) checkProven() error {
+	if b.format != FormatEraE || len(b.hashes) == 0 || b.proven {
+		return nil
+	}
+	return fmt.Errorf("missing proof for block %d", *b.startNum+uint64(len(b.hashes))-1)
+}
+
 // Finalize computes the accumulator and block index values, then writes the
-// corresponding e2store entries.
+// corresponding e2store entries. In era-E archives, the root of the block
+// hashes is computed instead of the accumulator.
 func (b *Builder) Finalize() (common.Hash, error) {
 	if b.startNum == nil {
 		return common.Hash{}, errors.New("finalize called on empty builder")
 	}
+	if err := b.checkProven(); err != nil {
+		return common.Hash{}, err
+	}
+	var (
+		root      common.Hash
+		err       error
+		rootType  = TypeAccumulator
+		indexType = TypeBlockIndex
+	)
+	if b.format == FormatEraE {
+		rootType, indexType = TypeBlockHashesRoot, TypeBlockIndexE
+		root, err = ComputeBlockHashesRoot(b.hashes)
+	} else {
+		root, err = ComputeAccumulator(b.hashes, b.tds)
+	}
 	// Compute accumulator root and write entry.
-	root, err := ComputeAccumulator(b.hashes, b.tds)
 	if err != nil {
 		return common.Hash{}, fmt.Errorf("error calculating accumulator root: %w", err)
 	}
-	n, err := b.w.Write(TypeAccumulator, root[:])
+	n, err := b.w.Write(rootType, root[:])
 	b.written += n
 	if err != nil {
 		return common.Hash{}, fmt.Errorf("error writing accumulator: %w", err)
@@ -196,7 +289,7 @@ func (b *Builder) Finalize() (common.Hash, error) {
 	binary.LittleEndian.PutUint64(index[8+count*8:], uint64(count))
 
 	// Finally, write the block index entry.
-	if _, err := b.w.Write(TypeBlockIndex, index); err != nil {
+	if _, err := b.w.Write(indexType, index); err != nil {
 		return common.Hash{}, fmt.Errorf("unable to write block index: %w", err)
 	}
 
@@ -42,20 +42,80 @@ var (
 	TypeCompressedReceipts uint16 = 0x05
 	TypeTotalDifficulty    uint16 = 0x06
 	TypeAccumulator        uint16 = 0x07
+	TypeBlockHashesRoot    uint16 = 0x08
+	TypeProof              uint16 = 0x0b
 	TypeBlockIndex         uint16 = 0x3266
+	TypeBlockIndexE        uint16 = 0x3267
 
 	MaxEra1Size = 8192
 )
 
+// Format is the flavor of an archive.
+type Format int
+
+const (
+	FormatEra1 Format = iota // Pre-merge archive, accumulating total difficulties
+	FormatEraE               // Post-merge archive, proven against the beacon chain
+)
+
+// Ext returns the file extension of the archive format.
+func (f Format) Ext() string {
+	if f == FormatEraE {
+		return ".erae"
+	}
+	return ".era1"
+}
+
+// String implements fmt.Stringer.
+func (f Format) String() string {
+	if f == FormatEraE {
+		return "era-E"
+	}
+	return "era1"
+}
+
 // Filename returns a recognizable Era1-formatted file name for the specified
 // epoch and network.
 func Filename(network string, epoch int, root common.Hash) string {
-	return fmt.Sprintf("%s-%05d-%s.era1", network, epoch, root.Hex()[2:10])
+	return fmt.Sprintf("%s-%05d-%s%s", network, epoch, root.Hex()[2:10], FormatEra1.Ext())
+}
+
+// FilenameE returns a recognizable era-E-formatted file name for the specified
+// epoch and network.
+func FilenameE(network string, epoch int, root common.Hash) string {
+	return fmt.Sprintf("%s-%05d-%s%s", network, epoch, root.Hex()[2:10], FormatEraE.Ext())
+}
+
+// Epoch returns the epoch number encoded in an archive file name.
+func Epoch(filename string) (uint64, error) {
+	parts := strings.Split(path.Base(filename), "-")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("malformed era filename: %s", filename)
+	}
+	epoch, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("malformed era filename: %s", filename)
+	}
+	return epoch, nil
 }
 
 // ReadDir reads all the era1 files in a directory for a given network.
 // Format: <network>-<epoch>-<hexroot>.era1
 func ReadDir(dir, network string) ([]string, error) {
+	return readDir(dir, network, FormatEra1)
+}
+
+// ReadDirE reads all the era-E files in a directory for a given network. As
+// the post-merge history doesn't start from genesis, the first epoch can be
+// arbitrary, but the epochs must be contiguous from there on.
+// Format: <network>-<epoch>-<hexroot>.erae
+func ReadDirE(dir, network string) ([]string, error) {
+	return readDir(dir, network, FormatEraE)
+}
+
+// readDir reads all the archives of the given format in a directory for a
+// given network.
+func readDir(dir, network string, format Format) ([]string, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, fmt.Errorf("error reading directory %s: %w", dir, err)
@@ -65,17 +125,22 @@ func ReadDir(dir, network string) ([]string, error) {
 		eras []string
 	)
 	for _, entry := range entries {
-		if path.Ext(entry.Name()) != ".era1" {
+		if path.Ext(entry.Name()) != format.Ext() {
 			continue
 		}
 		parts := strings.Split(entry.Name(), "-")
 		if len(parts) != 3 || parts[0] != network {
-			// Invalid era1 filename, skip.
+			// Invalid era filename, skip.
 			continue
 		}
-		if epoch, err := strconv.ParseUint(parts[1], 10, 64); err != nil {
-			return nil, fmt.Errorf("malformed era1 filename: %s", entry.Name())
-		} else if epoch != next {
+		epoch, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed %s filename: %s", format, entry.Name())
+		}
+		if format == FormatEraE && len(eras) == 0 {
+			next = epoch
+		}
+		if epoch != next {
 			return nil, fmt.Errorf("missing epoch %d", next)
 		}
 		next += 1
@@ -90,11 +155,11 @@ type ReadAtSeekCloser interface {
 	io.Closer
 }
 
-// Era reads and Era1 file.
+// Era reads an Era1 or era-E file.
 type Era struct {
-	f   ReadAtSeekCloser // backing era1 file
+	f   ReadAtSeekCloser // backing era file
 	s   *e2store.Reader  // e2store reader over f
-	m   metadata         // start, count, length info
+	m   metadata         // start, count, length, format info
 	mu  *sync.Mutex      // lock for buf
 	buf [8]byte          // buffer reading entry offsets
 }
@@ -205,9 +270,43 @@ func (e *Era) GetReceiptsByNumber(num uint64) (types.Receipts, error) {
 	return receipts, nil
 }
 
-// Accumulator reads the accumulator entry in the Era1 file.
+// GetProofByNumber returns the beacon chain proof for the given block number.
+// Only era-E archives contain proofs, nil is returned for Era1 archives.
+func (e *Era) GetProofByNumber(num uint64) (*BlockProof, error) {
+	if e.m.start > num || e.m.start+e.m.count <= num {
+		return nil, errors.New("out-of-bounds")
+	}
+	if e.m.format != FormatEraE {
+		return nil, nil
+	}
+	off, err := e.readOffset(num)
+	if err != nil {
+		return nil, err
+	}
+	// Skip over header, body and receipts.
+	off, err = e.s.SkipN(off, 3)
+	if err != nil {
+		return nil, err
+	}
+	r, _, err := e.s.ReaderAt(TypeProof, off)
+	if err != nil {
+		return nil, err
+	}
+	var proof BlockProof
+	if err := rlp.Decode(r, &proof); err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
+
+// Accumulator reads the accumulator entry in the Era1 file. For era-E files,
+// the root of the archived block hashes is returned instead.
 func (e *Era) Accumulator() (common.Hash, error) {
-	entry, err := e.s.Find(TypeAccumulator)
+	typ := TypeAccumulator
+	if e.m.format == FormatEraE {
+		typ = TypeBlockHashesRoot
+	}
+	entry, err := e.s.Find(typ)
 	if err != nil {
 		return common.Hash{}, err
 	}
@@ -215,8 +314,11 @@ func (e *Era) Accumulator() (common.Hash, error) {
 }
 
 // InitialTD returns initial total difficulty before the difficulty of the
-// first block of the Era1 is applied.
+// first block of the Era1 is applied. It's not available in era-E files.
 func (e *Era) InitialTD() (*big.Int, error) {
+	if e.m.format == FormatEraE {
+		return nil, errors.New("total difficulty is not archived in era-E files")
+	}
 	var (
 		r      io.Reader
 		header types.Header
@@ -266,6 +368,11 @@ func (e *Era) Count() uint64 {
 	return e.m.count
 }
 
+// Format returns the format of the archive.
+func (e *Era) Format() Format {
+	return e.m.format
+}
+
 // readOffset reads a specific block's offset from the block index. The value n
 // is the absolute block number desired.
 func (e *Era) readOffset(n uint64) (int64, error) {
@@ -301,6 +408,7 @@ type metadata struct {
 	start  uint64
 	count  uint64
 	length int64
+	format Format
 }
 
 // readMetadata reads the metadata stored in an Era1 file's block index.
@@ -321,5 +429,19 @@ func readMetadata(f ReadAtSeekCloser) (m metadata, err error) {
 		return
 	}
 	m.start = binary.LittleEndian.Uint64(b[8:])
+
+	// Determine the format by the type of the block index entry.
+	typ, _, err := e2store.NewReader(f).ReadMetadataAt(m.length - 24 - int64(m.count*8))
+	if err != nil {
+		return
+	}
+	switch typ {
+	case TypeBlockIndex:
+		m.format = FormatEra1
+	case TypeBlockIndexE:
+		m.format = FormatEraE
+	default:
+		err = fmt.Errorf("unknown block index type %#x", typ)
+	}
 	return
 }
@@ -18,12 +18,15 @@ package era
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"math/big"
+	"math/bits"
 	"os"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/beacon/params"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -154,6 +157,170 @@ func TestEra1Builder(t *testing.T) {
 	}
 }
 
+func TestEraEBuilder(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.CreateTemp(t.TempDir(), "erae-test")
+	if err != nil {
+		t.Fatalf("error creating temp file: %v", err)
+	}
+	defer f.Close()
+
+	var (
+		builder   = NewBuilderE(f)
+		start     = uint64(1000)
+		blocks    []*types.Block
+		summaries = make(map[uint64]common.Hash)
+	)
+	for i := 0; i < 64; i++ {
+		header := &types.Header{Number: new(big.Int).SetUint64(start + uint64(i)), Difficulty: new(big.Int)}
+		block := types.NewBlockWithHeader(header).WithBody(types.Body{Transactions: []*types.Transaction{types.NewTransaction(0, common.Address{byte(i)}, nil, 0, nil, nil)}})
+		receipts := types.Receipts{{CumulativeGasUsed: uint64(i)}}
+		if err := builder.Add(block, receipts, nil); err != nil {
+			t.Fatalf("error adding entry: %v", err)
+		}
+		// Prove every block, alternating between the fork layouts
+		proof, root := makeTestProof(block.Hash(), uint64(i)*SlotsPerHistoricalRoot, i%2 == 0)
+		if err := builder.AddProof(proof); err != nil {
+			t.Fatalf("error adding proof: %v", err)
+		}
+		if err := builder.AddProof(proof); err == nil {
+			t.Fatal("duplicate proof accepted")
+		}
+		summaries[proof.Period()] = root
+		blocks = append(blocks, block)
+	}
+	// Proofs of other blocks must be rejected
+	if proof, _ := makeTestProof(common.Hash{0xff}, 0, true); builder.AddProof(proof) == nil {
+		t.Fatal("invalid proof accepted")
+	}
+	root, err := builder.Finalize()
+	if err != nil {
+		t.Fatalf("error finalizing era-E: %v", err)
+	}
+	hashes := make([]common.Hash, len(blocks))
+	for i, block := range blocks {
+		hashes[i] = block.Hash()
+	}
+	if want, _ := ComputeBlockHashesRoot(hashes); root != want {
+		t.Fatalf("block hashes root mismatch: want %x, got %x", want, root)
+	}
+
+	// Verify era-E contents.
+	e, err := Open(f.Name())
+	if err != nil {
+		t.Fatalf("failed to open era: %v", err)
+	}
+	defer e.Close()
+	if e.Format() != FormatEraE {
+		t.Fatalf("unexpected format %v", e.Format())
+	}
+	if e.Start() != start || e.Count() != uint64(len(blocks)) {
+		t.Fatalf("unexpected range: start %d, count %d", e.Start(), e.Count())
+	}
+	if acc, err := e.Accumulator(); err != nil || acc != root {
+		t.Fatalf("unexpected root: %x, %v", acc, err)
+	}
+	if _, err := e.InitialTD(); err == nil {
+		t.Fatal("total difficulty available in era-E")
+	}
+	it, err := NewIterator(e)
+	if err != nil {
+		t.Fatalf("failed to make iterator: %s", err)
+	}
+	for i := 0; it.Next(); i++ {
+		if it.Error() != nil {
+			t.Fatalf("unexpected error %v", it.Error())
+		}
+		block, receipts, err := it.BlockAndReceipts()
+		if err != nil {
+			t.Fatalf("error reading block %d: %v", i, err)
+		}
+		if block.Hash() != hashes[i] || receipts[0].CumulativeGasUsed != uint64(i) {
+			t.Fatalf("block %d mismatch", i)
+		}
+		if _, err := it.TotalDifficulty(); err == nil {
+			t.Fatal("total difficulty available in era-E")
+		}
+		proof, err := it.Proof()
+		if err != nil {
+			t.Fatalf("error reading proof %d: %v", i, err)
+		}
+		if err := proof.Verify(block.Hash(), summaries[proof.Period()]); err != nil {
+			t.Fatalf("block %d: invalid proof: %v", i, err)
+		}
+		if err := proof.Verify(block.Hash(), common.Hash{}); err == nil {
+			t.Fatalf("block %d: proof verified against wrong summary", i)
+		}
+		stored, err := e.GetProofByNumber(block.NumberU64())
+		if err != nil {
+			t.Fatalf("error reading proof %d: %v", i, err)
+		}
+		if stored.BeaconBlockRoot != proof.BeaconBlockRoot {
+			t.Fatalf("block %d: proof mismatch", i)
+		}
+		header, err := e.GetHeaderByNumber(block.NumberU64())
+		if err != nil || header.Hash() != hashes[i] {
+			t.Fatalf("block %d: header mismatch, %v", i, err)
+		}
+	}
+	if it.Error() != nil {
+		t.Fatalf("unexpected error %v", it.Error())
+	}
+}
+
+// Tests that era-E archives can't be built with unproven blocks.
+func TestEraEBuilderMissingProof(t *testing.T) {
+	t.Parallel()
+
+	var (
+		builder = NewBuilderE(io.Discard)
+		first   = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0), Difficulty: new(big.Int)})
+		second  = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1), Difficulty: new(big.Int)})
+	)
+	if err := builder.Add(first, nil, nil); err != nil {
+		t.Fatalf("error adding entry: %v", err)
+	}
+	if err := builder.Add(second, nil, nil); err == nil {
+		t.Fatal("block added after an unproven block")
+	}
+	if _, err := builder.Finalize(); err == nil {
+		t.Fatal("archive finalized with an unproven block")
+	}
+}
+
+// makeTestProof creates a proof of the block hash with random siblings, along
+// with the resulting block summary root.
+func makeTestProof(hash common.Hash, slot uint64, deneb bool) (*BlockProof, common.Hash) {
+	index := uint64(params.BlockIndexExecHashCapella)
+	if deneb {
+		index = params.BlockIndexExecHashDeneb
+	}
+	proof := &BlockProof{Slot: slot}
+	proof.BeaconBlockRoot, proof.ExecutionBlockProof = makeTestBranch(hash, index)
+
+	root, branch := makeTestBranch(proof.BeaconBlockRoot, SlotsPerHistoricalRoot+slot%SlotsPerHistoricalRoot)
+	proof.BeaconBlockProof = branch
+	return proof, root
+}
+
+// makeTestBranch creates a merkle branch of the leaf at the generalized index
+// with random siblings, returning the root and the branch.
+func makeTestBranch(leaf common.Hash, index uint64) (common.Hash, []common.Hash) {
+	branch := make([]common.Hash, bits.Len64(index)-1)
+	node := leaf
+	for i := range branch {
+		branch[i] = sha256.Sum256([]byte{byte(i), byte(index)})
+		if index&1 == 0 {
+			node = sha256.Sum256(append(node[:], branch[i][:]...))
+		} else {
+			node = sha256.Sum256(append(branch[i][:], node[:]...))
+		}
+		index >>= 1
+	}
+	return node, branch
+}
+
 func TestEraFilename(t *testing.T) {
 	t.Parallel()
 
@@ -170,6 +337,12 @@ func TestEraFilename(t *testing.T) {
 			t.Errorf("test %d: invalid filename: want %s, got %s", i, tt.expected, got)
 		}
 	}
+	if got, want := FilenameE("mainnet", 1896, common.Hash{1}), "mainnet-01896-01000000.erae"; got != want {
+		t.Errorf("invalid era-E filename: want %s, got %s", want, got)
+	}
+	if epoch, err := Epoch("mainnet-01896-01000000.erae"); err != nil || epoch != 1896 {
+		t.Errorf("invalid epoch: want 1896, got %d (%v)", epoch, err)
+	}
 }
 
 func mustEncode(obj any) []byte {
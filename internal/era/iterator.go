@@ -101,8 +101,11 @@ func (it *Iterator) BlockAndReceipts() (*types.Block, types.Receipts, error) {
 }
 
 // TotalDifficulty returns the total difficulty for the iterator's current
-// position.
+// position. It's not available in era-E archives.
 func (it *Iterator) TotalDifficulty() (*big.Int, error) {
+	if it.inner.TotalDifficulty == nil {
+		return nil, errors.New("total difficulty must be non-nil")
+	}
 	td, err := io.ReadAll(it.inner.TotalDifficulty)
 	if err != nil {
 		return nil, err
@@ -110,16 +113,30 @@ func (it *Iterator) TotalDifficulty() (*big.Int, error) {
 	return new(big.Int).SetBytes(reverseOrder(td)), nil
 }
 
-// RawIterator reads an RLP-encode Era1 entries.
+// Proof returns the beacon chain proof for the iterator's current position,
+// or nil in Era1 archives, which carry no proofs.
+func (it *Iterator) Proof() (*BlockProof, error) {
+	if it.inner.Proof == nil {
+		return nil, nil
+	}
+	var proof BlockProof
+	if err := rlp.Decode(it.inner.Proof, &proof); err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
+
+// RawIterator reads an RLP-encode Era1 or era-E entries.
 type RawIterator struct {
-	e    *Era   // backing Era1
+	e    *Era   // backing archive
 	next uint64 // next block to read
 	err  error  // last error
 
 	Header          io.Reader
 	Body            io.Reader
 	Receipts        io.Reader
-	TotalDifficulty io.Reader
+	TotalDifficulty io.Reader // Only available in Era1 archives
+	Proof           io.Reader // Only available in era-E archives
 }
 
 // NewRawIterator returns a new RawIterator instance. Next must be immediately
@@ -133,8 +150,8 @@ func NewRawIterator(e *Era) (*RawIterator, error) {
 
 // Next moves the iterator to the next block entry. It returns false when all
 // items have been read or an error has halted its progress. Header, Body,
-// Receipts, TotalDifficulty will be set to nil in the case returning false or
-// finding an error and should therefore no longer be read from.
+// Receipts, TotalDifficulty and Proof will be set to nil in the case returning
+// false or finding an error and should therefore no longer be read from.
 func (it *RawIterator) Next() bool {
 	// Clear old errors.
 	it.err = nil
@@ -166,7 +183,12 @@ func (it *RawIterator) Next() bool {
 		return true
 	}
 	off += n
-	if it.TotalDifficulty, _, it.err = it.e.s.ReaderAt(TypeTotalDifficulty, off); it.err != nil {
+	if it.e.m.format == FormatEraE {
+		if it.Proof, _, it.err = it.e.s.ReaderAt(TypeProof, off); it.err != nil {
+			it.clear()
+			return true
+		}
+	} else if it.TotalDifficulty, _, it.err = it.e.s.ReaderAt(TypeTotalDifficulty, off); it.err != nil {
 		it.clear()
 		return true
 	}
@@ -194,4 +216,5 @@ func (it *RawIterator) clear() {
 	it.Body = nil
 	it.Receipts = nil
 	it.TotalDifficulty = nil
+	it.Proof = nil
 }
@@ -0,0 +1,128 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package era
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/bits"
+
+	"github.com/ethereum/go-ethereum/beacon/merkle"
+	"github.com/ethereum/go-ethereum/beacon/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// SlotsPerHistoricalRoot is the number of beacon block roots summarized in an
+// entry of the beacon state's historical_summaries.
+const SlotsPerHistoricalRoot = 8192
+
+// ProofSource provides the beacon chain proofs of post-merge blocks, which are
+// mandatory in era-E archives.
+type ProofSource interface {
+	// BlockProof returns the proof of the block with the given header.
+	BlockProof(header *types.Header) (*BlockProof, error)
+}
+
+// BlockProof is the proof of an execution block's inclusion in the beacon
+// chain, against the block_summary_root of an historical_summaries entry.
+type BlockProof struct {
+	Slot                uint64        // Slot of the beacon block carrying the execution block
+	BeaconBlockRoot     common.Hash   // Root of the beacon block carrying the execution block
+	ExecutionBlockProof []common.Hash // Branch from the execution block hash to the beacon block root
+	BeaconBlockProof    []common.Hash // Branch from the beacon block root to the block summary root
+}
+
+// Period returns the index of the historical roots period containing the
+// proven block. The historical_summaries entry of the period is required to
+// verify the proof.
+func (p *BlockProof) Period() uint64 {
+	return p.Slot / SlotsPerHistoricalRoot
+}
+
+// Verify checks that the execution block with the given hash is included in
+// the beacon chain, with the given block_summary_root of the historical
+// summary covering the proven slot.
+func (p *BlockProof) Verify(hash common.Hash, summaryRoot common.Hash) error {
+	if err := p.verifyBlock(hash); err != nil {
+		return err
+	}
+	index := SlotsPerHistoricalRoot + p.Slot%SlotsPerHistoricalRoot
+	if err := merkle.VerifyProof(summaryRoot, index, toValues(p.BeaconBlockProof), merkle.Value(p.BeaconBlockRoot)); err != nil {
+		return fmt.Errorf("invalid beacon block proof for slot %d: %w", p.Slot, err)
+	}
+	return nil
+}
+
+// verifyBlock checks that the execution block with the given hash is included
+// in the beacon block of the proof.
+func (p *BlockProof) verifyBlock(hash common.Hash) error {
+	var index uint64
+	switch len(p.ExecutionBlockProof) {
+	case bits.Len64(params.BlockIndexExecHashCapella) - 1:
+		index = params.BlockIndexExecHashCapella
+	case bits.Len64(params.BlockIndexExecHashDeneb) - 1:
+		index = params.BlockIndexExecHashDeneb
+	default:
+		return fmt.Errorf("invalid execution block proof length %d", len(p.ExecutionBlockProof))
+	}
+	if err := merkle.VerifyProof(p.BeaconBlockRoot, index, toValues(p.ExecutionBlockProof), merkle.Value(hash)); err != nil {
+		return fmt.Errorf("invalid execution block proof for %x: %w", hash, err)
+	}
+	return nil
+}
+
+// ProvePeriod fills in the beacon block proofs of the blocks within a
+// historical roots period, given the roots of all the period's slots as found
+// in the beacon state's block_roots, and returns the block summary root.
+func ProvePeriod(roots []common.Hash, proofs []*BlockProof) (common.Hash, error) {
+	if len(roots) != SlotsPerHistoricalRoot {
+		return common.Hash{}, fmt.Errorf("invalid number of block roots %d", len(roots))
+	}
+	layers := [][]common.Hash{roots}
+	for len(layers[len(layers)-1]) > 1 {
+		var (
+			below = layers[len(layers)-1]
+			layer = make([]common.Hash, len(below)/2)
+		)
+		for i := range layer {
+			layer[i] = sha256.Sum256(append(below[2*i][:], below[2*i+1][:]...))
+		}
+		layers = append(layers, layer)
+	}
+	for _, proof := range proofs {
+		index := proof.Slot % SlotsPerHistoricalRoot
+		if roots[index] != proof.BeaconBlockRoot {
+			return common.Hash{}, fmt.Errorf("beacon block root mismatch at slot %d", proof.Slot)
+		}
+		proof.BeaconBlockProof = proof.BeaconBlockProof[:0]
+		for _, layer := range layers[:len(layers)-1] {
+			proof.BeaconBlockProof = append(proof.BeaconBlockProof, layer[index^1])
+			index /= 2
+		}
+	}
+	return layers[len(layers)-1][0], nil
+}
+
+// toValues converts the hashes into merkle tree values.
+func toValues(hashes []common.Hash) merkle.Values {
+	values := make(merkle.Values, len(hashes))
+	for i, hash := range hashes {
+		values[i] = merkle.Value(hash)
+	}
+	return values
+}
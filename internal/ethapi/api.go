@@ -170,6 +170,9 @@ func (api *EthereumAPI) Syncing() (interface{}, error) {
 		"healingBytecode":        hexutil.Uint64(progress.HealingBytecode),
 		"txIndexFinishedBlocks":  hexutil.Uint64(progress.TxIndexFinishedBlocks),
 		"txIndexRemainingBlocks": hexutil.Uint64(progress.TxIndexRemainingBlocks),
+
+		"historyPrunedBlocks":         hexutil.Uint64(progress.HistoryPrunedBlocks),
+		"historyPruneRemainingBlocks": hexutil.Uint64(progress.HistoryPruneRemainingBlocks),
 	}, nil
 }
 
@@ -513,11 +516,40 @@ func (api *BlockChainAPI) GetBlockByNumber(ctx context.Context, number rpc.Block
 func (api *BlockChainAPI) GetBlockByHash(ctx context.Context, hash common.Hash, fullTx bool) (map[string]interface{}, error) {
 	block, err := api.b.BlockByHash(ctx, hash)
 	if block != nil {
+		cacheIfFinalized(ctx, api.b, block.NumberU64())
 		return RPCMarshalBlock(block, true, fullTx, api.b.ChainConfig()), nil
 	}
 	return nil, err
 }
 
+// cacheIfFinalized marks the response of the call as immutable if the block with
+// the given number is finalized, allowing it to be served from the RPC response
+// cache.
+func cacheIfFinalized(ctx context.Context, b Backend, number uint64) {
+	finalized, err := b.HeaderByNumber(ctx, rpc.FinalizedBlockNumber)
+	if err != nil || finalized == nil {
+		return
+	}
+	if number <= finalized.Number.Uint64() {
+		rpc.CacheResponse(ctx)
+	}
+}
+
+// cacheIfFinalizedAt marks the response of the call as immutable if the given
+// block is finalized. The block tags are never cached, as they move along with
+// the chain.
+func cacheIfFinalizedAt(ctx context.Context, b Backend, blockNrOrHash rpc.BlockNumberOrHash) {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		if header, _ := b.HeaderByHash(ctx, hash); header != nil {
+			cacheIfFinalized(ctx, b, header.Number.Uint64())
+		}
+		return
+	}
+	if number, ok := blockNrOrHash.Number(); ok && number >= 0 {
+		cacheIfFinalized(ctx, b, uint64(number))
+	}
+}
+
 // GetUncleByBlockNumberAndIndex returns the uncle block for the given block hash and index.
 func (api *BlockChainAPI) GetUncleByBlockNumberAndIndex(ctx context.Context, blockNr rpc.BlockNumber, index hexutil.Uint) (map[string]interface{}, error) {
 	block, err := api.b.BlockByNumber(ctx, blockNr)
@@ -614,7 +646,7 @@ func (api *BlockChainAPI) GetBlockReceipts(ctx context.Context, blockNrOrHash rp
 
 	result := make([]map[string]interface{}, len(receipts))
 	for i, receipt := range receipts {
-		result[i] = marshalReceipt(receipt, block.Hash(), block.NumberU64(), signer, txs[i], i)
+		result[i] = MarshalReceipt(receipt, block.Hash(), block.NumberU64(), signer, txs[i], i)
 	}
 
 	return result, nil
@@ -766,6 +798,9 @@ func (api *BlockChainAPI) Call(ctx context.Context, args TransactionArgs, blockN
 	if errors.Is(result.Err, vm.ErrExecutionReverted) {
 		return nil, newRevertError(result.Revert())
 	}
+	if result.Err == nil {
+		cacheIfFinalizedAt(ctx, api.b, *blockNrOrHash)
+	}
 	return result.Return(), result.Err
 }
 
@@ -903,6 +938,9 @@ func RPCMarshalHeader(head *types.Header) map[string]interface{} {
 	if head.RequestsHash != nil {
 		result["requestsHash"] = head.RequestsHash
 	}
+	if head.BlockAccessListHash != nil {
+		result["blockAccessListHash"] = head.BlockAccessListHash
+	}
 	return result
 }
 
@@ -919,7 +957,7 @@ func RPCMarshalBlock(block *types.Block, inclTx bool, fullTx bool, config *param
 		}
 		if fullTx {
 			formatTx = func(idx int, tx *types.Transaction) interface{} {
-				return newRPCTransactionFromBlockIndex(block, uint64(idx), config)
+				return NewRPCTransactionFromBlockIndex(block, uint64(idx), config)
 			}
 		}
 		txs := block.Transactions()
@@ -1087,8 +1125,8 @@ func NewRPCPendingTransaction(tx *types.Transaction, current *types.Header, conf
 	return newRPCTransaction(tx, common.Hash{}, blockNumber, blockTime, 0, baseFee, config)
 }
 
-// newRPCTransactionFromBlockIndex returns a transaction that will serialize to the RPC representation.
-func newRPCTransactionFromBlockIndex(b *types.Block, index uint64, config *params.ChainConfig) *RPCTransaction {
+// NewRPCTransactionFromBlockIndex returns a transaction that will serialize to the RPC representation.
+func NewRPCTransactionFromBlockIndex(b *types.Block, index uint64, config *params.ChainConfig) *RPCTransaction {
 	txs := b.Transactions()
 	if index >= uint64(len(txs)) {
 		return nil
@@ -1281,7 +1319,7 @@ func (api *TransactionAPI) GetBlockTransactionCountByHash(ctx context.Context, b
 func (api *TransactionAPI) GetTransactionByBlockNumberAndIndex(ctx context.Context, blockNr rpc.BlockNumber, index hexutil.Uint) (*RPCTransaction, error) {
 	block, err := api.b.BlockByNumber(ctx, blockNr)
 	if block != nil {
-		return newRPCTransactionFromBlockIndex(block, uint64(index), api.b.ChainConfig()), nil
+		return NewRPCTransactionFromBlockIndex(block, uint64(index), api.b.ChainConfig()), nil
 	}
 	return nil, err
 }
@@ -1290,7 +1328,7 @@ func (api *TransactionAPI) GetTransactionByBlockNumberAndIndex(ctx context.Conte
 func (api *TransactionAPI) GetTransactionByBlockHashAndIndex(ctx context.Context, blockHash common.Hash, index hexutil.Uint) (*RPCTransaction, error) {
 	block, err := api.b.BlockByHash(ctx, blockHash)
 	if block != nil {
-		return newRPCTransactionFromBlockIndex(block, uint64(index), api.b.ChainConfig()), nil
+		return NewRPCTransactionFromBlockIndex(block, uint64(index), api.b.ChainConfig()), nil
 	}
 	return nil, err
 }
@@ -1391,11 +1429,12 @@ func (api *TransactionAPI) GetTransactionReceipt(ctx context.Context, hash commo
 
 	// Derive the sender.
 	signer := types.MakeSigner(api.b.ChainConfig(), header.Number, header.Time)
-	return marshalReceipt(receipt, blockHash, blockNumber, signer, tx, int(index)), nil
+	cacheIfFinalized(ctx, api.b, blockNumber)
+	return MarshalReceipt(receipt, blockHash, blockNumber, signer, tx, int(index)), nil
 }
 
-// marshalReceipt marshals a transaction receipt into a JSON object.
-func marshalReceipt(receipt *types.Receipt, blockHash common.Hash, blockNumber uint64, signer types.Signer, tx *types.Transaction, txIndex int) map[string]interface{} {
+// MarshalReceipt marshals a transaction receipt into a JSON object.
+func MarshalReceipt(receipt *types.Receipt, blockHash common.Hash, blockNumber uint64, signer types.Signer, tx *types.Transaction, txIndex int) map[string]interface{} {
 	from, _ := types.Sender(signer, tx)
 
 	fields := map[string]interface{}{
@@ -1451,6 +1490,12 @@ func (api *TransactionAPI) sign(addr common.Address, tx *types.Transaction) (*ty
 
 // SubmitTransaction is a helper function that submits tx to txPool and logs a message.
 func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (common.Hash, error) {
+	return submitTransaction(ctx, b, tx, nil)
+}
+
+// submitTransaction submits tx to txPool, along with the conditions it must
+// satisfy to be included if any, and logs a message.
+func submitTransaction(ctx context.Context, b Backend, tx *types.Transaction, cond *types.TransactionConditional) (common.Hash, error) {
 	// If the transaction fee cap is already specified, ensure the
 	// fee of the given transaction is _reasonable_.
 	if err := checkTxFee(tx.GasPrice(), tx.Gas(), b.RPCTxFeeCap()); err != nil {
@@ -1460,7 +1505,11 @@ func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (c
 		// Ensure only eip155 signed transactions are submitted if EIP155Required is set.
 		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
 	}
-	if err := b.SendTx(ctx, tx); err != nil {
+	if cond != nil {
+		if err := b.SendConditionalTx(ctx, tx, cond); err != nil {
+			return common.Hash{}, err
+		}
+	} else if err := b.SendTx(ctx, tx); err != nil {
 		return common.Hash{}, err
 	}
 	// Print a log with full tx details for manual investigations and interventions
@@ -1544,6 +1593,21 @@ func (api *TransactionAPI) SendRawTransaction(ctx context.Context, input hexutil
 	return SubmitTransaction(ctx, api.b, tx)
 }
 
+// SendRawTransactionConditional will add the signed transaction to the transaction
+// pool, to be included only while the given conditions on the known accounts'
+// storage, the block number and the timestamp hold. Conditional transactions are
+// not propagated to the network.
+func (api *TransactionAPI) SendRawTransactionConditional(ctx context.Context, input hexutil.Bytes, cond types.TransactionConditional) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	if err := cond.Validate(); err != nil {
+		return common.Hash{}, &invalidParamsError{message: err.Error()}
+	}
+	return submitTransaction(ctx, api.b, tx, &cond)
+}
+
 // Sign calculates an ECDSA signature for:
 // keccak256("\x19Ethereum Signed Message:\n" + len(message) + message).
 //
@@ -246,6 +246,10 @@ func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header,
 	if precompiles != nil {
 		evm.SetPrecompiles(precompiles)
 	}
+	amsterdam := sim.chainConfig.IsAmsterdam(header.Number, header.Time)
+	if amsterdam {
+		sim.state.EnableBlockAccessList()
+	}
 	if sim.chainConfig.IsPrague(header.Number, header.Time) || sim.chainConfig.IsVerkle(header.Number, header.Time) {
 		core.ProcessParentBlockHash(header.ParentHash, evm)
 	}
@@ -305,6 +309,9 @@ func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header,
 	if sim.chainConfig.IsCancun(header.Number, header.Time) {
 		header.BlobGasUsed = &blobGasUsed
 	}
+	if amsterdam {
+		sim.state.SetAccessListIndex(len(block.Calls) + 1)
+	}
 	var requests [][]byte
 	// Process EIP-7685 requests
 	if sim.chainConfig.IsPrague(header.Number, header.Time) {
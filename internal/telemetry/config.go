@@ -0,0 +1,35 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package telemetry
+
+// Config contains the configuration for the distributed tracing span export.
+type Config struct {
+	Enabled     bool    `toml:",omitempty"`
+	Endpoint    string  `toml:",omitempty"` // OTLP/HTTP collector endpoint, empty to disable
+	File        string  `toml:",omitempty"` // File to write spans into as JSON, empty to disable
+	SampleRatio float64 `toml:",omitempty"` // Fraction of root spans to sample
+	ServiceName string  `toml:",omitempty"` // Service name reported to the collector
+}
+
+// DefaultConfig is the default config for tracing used in go-ethereum.
+var DefaultConfig = Config{
+	Enabled:     false,
+	Endpoint:    "http://localhost:4318",
+	File:        "",
+	SampleRatio: 1.0,
+	ServiceName: "geth",
+}
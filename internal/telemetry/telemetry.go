@@ -0,0 +1,135 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package telemetry implements OpenTelemetry span collection and export.
+//
+// Instrumented packages obtain their tracers through Tracer. Until Setup installs
+// an exporting provider, all spans created by them are no-ops, so the overhead
+// of the instrumentation on a node running without tracing is negligible.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// shutdownTimeout is the maximum time allowed for flushing the buffered spans
+// to the exporters when the provider is stopped.
+const shutdownTimeout = 5 * time.Second
+
+// Tracer returns a named tracer from the globally installed provider.
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// EndSpan records the error on the span, if any, and ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Provider is a span collection and export pipeline installed as the global
+// tracer provider. It satisfies node.Lifecycle so that buffered spans can be
+// flushed when the node shuts down.
+type Provider struct {
+	provider *sdktrace.TracerProvider
+	file     *os.File // Output file of the file exporter, nil if disabled
+}
+
+// Setup creates the exporters requested by the config and installs a provider
+// feeding them as the global tracer provider.
+func Setup(cfg Config, version string) (*Provider, error) {
+	if cfg.Endpoint == "" && cfg.File == "" {
+		return nil, errors.New("no span exporter configured")
+	}
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return nil, fmt.Errorf("invalid sample ratio %v, want [0, 1]", cfg.SampleRatio)
+	}
+	res := resource.NewSchemaless(
+		attribute.String("service.name", cfg.ServiceName),
+		attribute.String("service.version", version),
+	)
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	}
+	p := new(Provider)
+	if cfg.Endpoint != "" {
+		exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(cfg.Endpoint))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP exporter: %v", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+		log.Info("Enabling span export to OTLP collector", "endpoint", cfg.Endpoint)
+	}
+	if cfg.File != "" {
+		file, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open span file: %v", err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to create file exporter: %v", err)
+		}
+		p.file = file
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+		log.Info("Enabling span export to file", "path", cfg.File)
+	}
+	p.provider = sdktrace.NewTracerProvider(opts...)
+
+	otel.SetTracerProvider(p.provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return p, nil
+}
+
+// Start implements node.Lifecycle, starting nothing as the exporters are already
+// running after Setup.
+func (p *Provider) Start() error {
+	return nil
+}
+
+// Stop implements node.Lifecycle, flushing all buffered spans and terminating
+// the exporters.
+func (p *Provider) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	err := p.provider.Shutdown(ctx)
+	if p.file != nil {
+		if cerr := p.file.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Tests that spans are written into the configured file, nested and annotated
+// with their errors.
+func TestFileExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spans.json")
+
+	cfg := DefaultConfig
+	cfg.Endpoint = ""
+	cfg.File = path
+
+	provider, err := Setup(cfg, "test")
+	if err != nil {
+		t.Fatalf("failed to set up tracing: %v", err)
+	}
+	tracer := Tracer("telemetry-test")
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, child := tracer.Start(ctx, "child")
+	EndSpan(child, errors.New("child failure"))
+	EndSpan(parent, nil)
+
+	if err := provider.Stop(); err != nil {
+		t.Fatalf("failed to stop tracing: %v", err)
+	}
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read spans: %v", err)
+	}
+	for _, want := range []string{`"Name":"parent"`, `"Name":"child"`, "child failure", `"service.name"`} {
+		if !strings.Contains(string(blob), want) {
+			t.Errorf("exported spans missing %s", want)
+		}
+	}
+}
+
+// Tests that invalid configurations are rejected.
+func TestSetupValidation(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.Endpoint = ""
+	if _, err := Setup(cfg, "test"); err == nil {
+		t.Errorf("setup without exporters succeeded")
+	}
+	cfg = DefaultConfig
+	cfg.SampleRatio = 1.5
+	if _, err := Setup(cfg, "test"); err == nil {
+		t.Errorf("setup with invalid sample ratio succeeded")
+	}
+}
@@ -158,6 +158,10 @@ web3._extend({
 			name: 'stopWS',
 			call: 'admin_stopWS'
 		}),
+		new web3._extend.Method({
+			name: 'reloadRPCPolicy',
+			call: 'admin_reloadRPCPolicy'
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
@@ -270,3 +270,38 @@ func TestPayloadId(t *testing.T) {
 		ids[id] = i
 	}
 }
+
+func TestBuildPayloadConditional(t *testing.T) {
+	var (
+		timestamp = uint64(time.Now().Unix())
+		future    = timestamp + 1
+	)
+	for i, tt := range []struct {
+		cond *types.TransactionConditional
+		txs  int
+	}{
+		{&types.TransactionConditional{KnownAccounts: types.KnownAccounts{testUserAddress: {StorageRoot: &types.EmptyRootHash}}}, 1},
+		{&types.TransactionConditional{KnownAccounts: types.KnownAccounts{testUserAddress: {StorageSlots: map[common.Hash]common.Hash{{}: {}}}}}, 1},
+		{&types.TransactionConditional{TimestampMin: &timestamp}, 1},
+		{&types.TransactionConditional{BlockNumberMin: big.NewInt(2)}, 0},
+		{&types.TransactionConditional{TimestampMin: &future}, 0},
+	} {
+		b := newTestWorkerBackend(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+		if err := b.txPool.AddConditional(pendingTxs[0], tt.cond); err != nil {
+			t.Fatalf("test %d: failed to add conditional transaction: %v", i, err)
+		}
+		b.txPool.Sync()
+
+		w := New(b, testConfig, ethash.NewFaker())
+		payload, err := w.buildPayload(&BuildPayloadArgs{
+			Parent:    b.chain.CurrentBlock().Hash(),
+			Timestamp: timestamp,
+		}, false)
+		if err != nil {
+			t.Fatalf("test %d: failed to build payload: %v", i, err)
+		}
+		if have := len(payload.ResolveFull().ExecutionPayload.Transactions); have != tt.txs {
+			t.Errorf("test %d: transaction count mismatch: have %d, want %d", i, have, tt.txs)
+		}
+	}
+}
@@ -118,6 +118,11 @@ func (miner *Miner) generateWork(params *generateParams, witness bool) *newPaylo
 		allLogs = append(allLogs, r.Logs...)
 	}
 
+	// Attribute the post-transaction modifications to their own index in the
+	// block access list if Amsterdam is enabled.
+	if miner.chainConfig.IsAmsterdam(work.header.Number, work.header.Time) {
+		work.state.SetAccessListIndex(len(work.txs) + 1)
+	}
 	// Collect consensus-layer requests if Prague is enabled.
 	var requests [][]byte
 	if miner.chainConfig.IsPrague(work.header.Number, work.header.Time) {
@@ -251,6 +256,10 @@ func (miner *Miner) makeEnv(parent *types.Header, header *types.Header, coinbase
 		}
 		state.StartPrefetcher("miner", bundle)
 	}
+	// Record the block access list if Amsterdam is enabled.
+	if miner.chainConfig.IsAmsterdam(header.Number, header.Time) {
+		state.EnableBlockAccessList()
+	}
 	// Note the passed coinbase may be different with header.Coinbase.
 	return &environment{
 		signer:   types.MakeSigner(miner.chainConfig, header.Number, header.Time),
@@ -289,6 +298,15 @@ func (miner *Miner) commitBlobTransaction(env *environment, tx *types.Transactio
 	if env.blobs+len(sc.Blobs) > maxBlobs {
 		return errors.New("max data blobs reached")
 	}
+	// The sidecar format must match the fork of the block being built, otherwise
+	// the blobs bundle handed to the consensus client would be unusable.
+	wantVersion := types.BlobSidecarVersion0
+	if miner.chainConfig.IsOsaka(env.header.Number, env.header.Time) {
+		wantVersion = types.BlobSidecarVersion1
+	}
+	if sc.Version != wantVersion {
+		return fmt.Errorf("unexpected blob sidecar version: have %d, want %d", sc.Version, wantVersion)
+	}
 	receipt, err := miner.applyTransaction(env, tx)
 	if err != nil {
 		return err
@@ -401,6 +419,14 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 			txs.Pop()
 			continue
 		}
+		// Ensure the conditions the transaction was submitted with still hold
+		if ltx.Conditional != nil {
+			if err := miner.checkConditional(env, ltx.Conditional); err != nil {
+				log.Trace("Ignoring transaction with failed conditional", "hash", ltx.Hash, "err", err)
+				txs.Pop()
+				continue
+			}
+		}
 		// Start executing the transaction
 		env.state.SetTxContext(tx.Hash(), env.tcount)
 
@@ -425,6 +451,23 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 	return nil
 }
 
+// checkConditional checks whether the conditions of a transaction hold for the
+// block being built, on top of the transactions already included in it.
+func (miner *Miner) checkConditional(env *environment, cond *types.TransactionConditional) error {
+	if err := cond.CheckBlock(env.header.Number, env.header.Time); err != nil {
+		return err
+	}
+	// Storage roots are only updated when hashing the state, so do that if any
+	// of the known accounts is pinned to one.
+	for _, account := range cond.KnownAccounts {
+		if account.StorageRoot != nil {
+			env.state.IntermediateRoot(miner.chainConfig.IsEIP158(env.header.Number))
+			break
+		}
+	}
+	return txpool.ValidateConditional(cond, env.state)
+}
+
 // fillTransactions retrieves the pending transactions from the txpool and fills them
 // into the given sealing block. The transaction selection and ordering strategy can
 // be customized with the plugin in the future.
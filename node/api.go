@@ -181,6 +181,9 @@ func (api *adminAPI) StartHTTP(host *string, port *int, cors *string, apis *stri
 		rpcEndpointConfig: rpcEndpointConfig{
 			batchItemLimit:         api.node.config.BatchRequestLimit,
 			batchResponseSizeLimit: api.node.config.BatchResponseMaxSize,
+			rateLimit:              api.node.config.rateLimitConfig(),
+			responseCache:          api.node.responseCache,
+			accessPolicy:           api.node.rpcPolicy.HTTP,
 		},
 	}
 	if cors != nil {
@@ -259,6 +262,9 @@ func (api *adminAPI) StartWS(host *string, port *int, allowedOrigins *string, ap
 		rpcEndpointConfig: rpcEndpointConfig{
 			batchItemLimit:         api.node.config.BatchRequestLimit,
 			batchResponseSizeLimit: api.node.config.BatchResponseMaxSize,
+			rateLimit:              api.node.config.rateLimitConfig(),
+			responseCache:          api.node.responseCache,
+			accessPolicy:           api.node.rpcPolicy.WS,
 		},
 	}
 	if apis != nil {
@@ -297,6 +303,15 @@ func (api *adminAPI) StopWS() (bool, error) {
 	return true, nil
 }
 
+// ReloadRPCPolicy reads the RPC policy file again and applies the method access
+// policies to the running HTTP, WebSocket and authenticated endpoints.
+func (api *adminAPI) ReloadRPCPolicy() (bool, error) {
+	if err := api.node.reloadRPCPolicy(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // Peers retrieves all the information we know about each individual peer at the
 // protocol granularity.
 func (api *adminAPI) Peers() ([]*p2p.PeerInfo, error) {
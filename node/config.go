@@ -19,6 +19,7 @@ package node
 import (
 	"crypto/ecdsa"
 	"fmt"
+	"maps"
 	"net"
 	"os"
 	"path/filepath"
@@ -137,6 +138,9 @@ type Config struct {
 	// HTTPPathPrefix specifies a path prefix on which http-rpc is to be served.
 	HTTPPathPrefix string `toml:",omitempty"`
 
+	// HTTPH2C enables HTTP/2 without TLS (h2c) on the HTTP RPC interface.
+	HTTPH2C bool `toml:",omitempty"`
+
 	// AuthAddr is the listening address on which authenticated APIs are provided.
 	AuthAddr string `toml:",omitempty"`
 
@@ -147,6 +151,9 @@ type Config struct {
 	// for the authenticated api. This is by default {'localhost'}.
 	AuthVirtualHosts []string `toml:",omitempty"`
 
+	// AuthH2C enables HTTP/2 without TLS (h2c) on the authenticated endpoint.
+	AuthH2C bool `toml:",omitempty"`
+
 	// WSHost is the host interface on which to start the websocket RPC server. If
 	// this field is empty, no websocket API endpoint will be started.
 	WSHost string
@@ -204,6 +211,30 @@ type Config struct {
 	// BatchResponseMaxSize is the maximum number of bytes returned from a batched rpc call.
 	BatchResponseMaxSize int `toml:",omitempty"`
 
+	// RPCRateLimit is the number of request cost units granted per second to
+	// each client of the HTTP, WebSocket and authenticated endpoints, zero means
+	// unlimited. The clients of the authenticated endpoint are identified by the
+	// "id" claim of their tokens if present.
+	RPCRateLimit float64 `toml:",omitempty"`
+
+	// RPCRateBurst is the maximum number of request cost units a client can
+	// accumulate, zero means the rate. It's raised to the highest method cost.
+	RPCRateBurst int `toml:",omitempty"`
+
+	// RPCMethodCosts overrides the request costs of the methods charged by the
+	// rate limiter, on top of DefaultRPCMethodCosts.
+	RPCMethodCosts map[string]int `toml:",omitempty"`
+
+	// RPCResponseCache is the size of the cache in megabytes for the immutable
+	// responses served on the HTTP, WebSocket and authenticated endpoints, e.g.
+	// the finalized blocks. Zero disables the cache.
+	RPCResponseCache int `toml:",omitempty"`
+
+	// RPCPolicyFile is the path to the JSON file of the method access policies
+	// applied to the HTTP, WebSocket and authenticated endpoints. It's reloaded
+	// by admin_reloadRPCPolicy.
+	RPCPolicyFile string `toml:",omitempty"`
+
 	// JWTSecret is the path to the hex-encoded jwt secret.
 	JWTSecret string `toml:",omitempty"`
 
@@ -478,3 +509,15 @@ func (c *Config) GetKeyStoreDir() (string, bool, error) {
 
 	return keydir, isEphemeral, nil
 }
+
+// rateLimitConfig returns the rate limit applied to the clients of the HTTP,
+// WebSocket and authenticated endpoints.
+func (c *Config) rateLimitConfig() rpc.RateLimitConfig {
+	costs := maps.Clone(DefaultRPCMethodCosts)
+	maps.Copy(costs, c.RPCMethodCosts)
+	return rpc.RateLimitConfig{
+		Rate:        c.RPCRateLimit,
+		Burst:       c.RPCRateBurst,
+		MethodCosts: costs,
+	}
+}
@@ -52,6 +52,27 @@ var (
 	DefaultAuthModules = []string{"eth", "engine"}
 )
 
+// DefaultRPCMethodCosts are the request costs charged by the rate limiter for
+// the methods which are more expensive to serve than plain reads. The engine API
+// is free, the consensus client must never be throttled.
+var DefaultRPCMethodCosts = map[string]int{
+	"engine_*":                 0,
+	"debug_*":                  10,
+	"debug_traceBlock":         100,
+	"debug_traceBlockByHash":   100,
+	"debug_traceBlockByNumber": 100,
+	"debug_traceTransaction":   50,
+	"debug_traceCall":          50,
+	"trace_*":                  50,
+	"ots_*":                    10,
+	"eth_getLogs":              20,
+	"eth_getProof":             10,
+	"eth_call":                 5,
+	"eth_estimateGas":          5,
+	"eth_createAccessList":     10,
+	"eth_simulateV1":           20,
+}
+
 // DefaultConfig contains reasonable default settings.
 var DefaultConfig = Config{
 	DataDir:              DefaultDataDir(),
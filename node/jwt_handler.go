@@ -21,11 +21,19 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/golang-jwt/jwt/v4"
 )
 
 const jwtExpiryTimeout = 60 * time.Second
 
+// jwtClaims are the claims accepted in the tokens. The optional "id" claim
+// identifies the client for rate limiting.
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	ClientID string `json:"id,omitempty"`
+}
+
 type jwtHandler struct {
 	keyFunc func(token *jwt.Token) (interface{}, error)
 	next    http.Handler
@@ -45,7 +53,7 @@ func newJWTHandler(secret []byte, next http.Handler) http.Handler {
 func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
 	var (
 		strToken string
-		claims   jwt.RegisteredClaims
+		claims   jwtClaims
 	)
 	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
 		strToken = strings.TrimPrefix(auth, "Bearer ")
@@ -75,6 +83,9 @@ func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
 	case time.Until(claims.IssuedAt.Time) > jwtExpiryTimeout:
 		http.Error(out, "future token", http.StatusUnauthorized)
 	default:
+		if claims.ClientID != "" {
+			r = r.WithContext(rpc.ContextWithClientID(r.Context(), claims.ClientID))
+		}
 		handler.next.ServeHTTP(out, r)
 	}
 }
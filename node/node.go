@@ -65,8 +65,13 @@ type Node struct {
 	wsAuth        *httpServer //
 	ipc           *ipcServer  // Stores information about the ipc http server
 	inprocHandler *rpc.Server // In-process RPC request handler to process the API requests
+	rpcPolicy     *rpcPolicy  // Method access policies of the HTTP, WS and authenticated endpoints
 
 	databases map[*closeTrackingDB]struct{} // All open databases
+
+	responseCache *rpc.ResponseCache // Cache of the immutable RPC responses, nil if disabled
+
+	authHandlers map[string]http.Handler // Handlers served on the authenticated HTTP endpoint
 }
 
 const (
@@ -108,11 +113,16 @@ func New(conf *Config) (*Node, error) {
 	node := &Node{
 		config:        conf,
 		inprocHandler: server,
+		rpcPolicy:     new(rpcPolicy),
 		eventmux:      new(event.TypeMux),
 		log:           conf.Logger,
 		stop:          make(chan struct{}),
 		server:        &p2p.Server{Config: conf.P2P},
 		databases:     make(map[*closeTrackingDB]struct{}),
+		authHandlers:  make(map[string]http.Handler),
+	}
+	if conf.RPCResponseCache > 0 {
+		node.responseCache = rpc.NewResponseCache(uint64(conf.RPCResponseCache) * 1024 * 1024)
 	}
 
 	// Register built-in APIs.
@@ -359,6 +369,21 @@ func ObtainJWTSecret(fileName string) ([]byte, error) {
 	return jwtSecret, nil
 }
 
+// ReadJWTSecret loads the hex encoded JWT secret from the given file. Unlike
+// ObtainJWTSecret, it fails if the file is missing instead of generating a new
+// secret, as needed by clients authenticating to an existing node.
+func ReadJWTSecret(fileName string) ([]byte, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(jwtSecret) != 32 {
+		return nil, fmt.Errorf("invalid JWT secret in %s, length %d", fileName, len(jwtSecret))
+	}
+	return jwtSecret, nil
+}
+
 // obtainJWTSecret loads the jwt-secret, either from the provided config,
 // or from the default location. If neither of those are present, it generates
 // a new secret and stores to the default location.
@@ -385,6 +410,12 @@ func (n *Node) startRPC() error {
 			return err
 		}
 	}
+	policy, err := loadRPCPolicy(n.config.RPCPolicyFile)
+	if err != nil {
+		return err
+	}
+	n.rpcPolicy = policy
+
 	var (
 		servers           []*httpServer
 		openAPIs, allAPIs = n.getAPIs()
@@ -393,6 +424,8 @@ func (n *Node) startRPC() error {
 	rpcConfig := rpcEndpointConfig{
 		batchItemLimit:         n.config.BatchRequestLimit,
 		batchResponseSizeLimit: n.config.BatchResponseMaxSize,
+		rateLimit:              n.config.rateLimitConfig(),
+		responseCache:          n.responseCache,
 	}
 
 	initHttp := func(server *httpServer, port int) error {
@@ -404,7 +437,8 @@ func (n *Node) startRPC() error {
 			Vhosts:             n.config.HTTPVirtualHosts,
 			Modules:            n.config.HTTPModules,
 			prefix:             n.config.HTTPPathPrefix,
-			rpcEndpointConfig:  rpcConfig,
+			h2c:                n.config.HTTPH2C,
+			rpcEndpointConfig:  rpcConfig.withAccessPolicy(policy.HTTP),
 		}); err != nil {
 			return err
 		}
@@ -421,7 +455,7 @@ func (n *Node) startRPC() error {
 			Modules:           n.config.WSModules,
 			Origins:           n.config.WSOrigins,
 			prefix:            n.config.WSPathPrefix,
-			rpcEndpointConfig: rpcConfig,
+			rpcEndpointConfig: rpcConfig.withAccessPolicy(policy.WS),
 		}); err != nil {
 			return err
 		}
@@ -440,17 +474,25 @@ func (n *Node) startRPC() error {
 			batchItemLimit:         engineAPIBatchItemLimit,
 			batchResponseSizeLimit: engineAPIBatchResponseSizeLimit,
 			httpBodyLimit:          engineAPIBodyLimit,
+			accessPolicy:           policy.AuthRPC,
+			rateLimit:              n.config.rateLimitConfig(),
+			responseCache:          n.responseCache,
 		}
 		err := server.enableRPC(allAPIs, httpConfig{
 			CorsAllowedOrigins: DefaultAuthCors,
 			Vhosts:             n.config.AuthVirtualHosts,
 			Modules:            DefaultAuthModules,
 			prefix:             DefaultAuthPrefix,
+			h2c:                n.config.AuthH2C,
 			rpcEndpointConfig:  sharedConfig,
 		})
 		if err != nil {
 			return err
 		}
+		// Mount the authenticated handlers behind the same JWT check.
+		for path, handler := range n.authHandlers {
+			server.mux.Handle(path, NewHTTPHandlerStack(handler, DefaultAuthCors, n.config.AuthVirtualHosts, secret))
+		}
 		servers = append(servers, server)
 
 		// Enable auth via WS
@@ -503,6 +545,35 @@ func (n *Node) startRPC() error {
 	return nil
 }
 
+// ResponseCache returns the cache of the immutable RPC responses, or nil if it's
+// disabled. The services must reset it if the cached responses are invalidated.
+func (n *Node) ResponseCache() *rpc.ResponseCache {
+	return n.responseCache
+}
+
+// reloadRPCPolicy reads the policy file again, and applies the policies to the
+// running HTTP, WS and authenticated endpoints.
+func (n *Node) reloadRPCPolicy() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	policy, err := loadRPCPolicy(n.config.RPCPolicyFile)
+	if err != nil {
+		return err
+	}
+	n.rpcPolicy = policy
+
+	// The WebSocket handlers might be served by the HTTP servers if the ports
+	// are shared, so both handlers are updated on every server.
+	for _, server := range []*httpServer{n.http, n.ws} {
+		server.setAccessPolicy(policy.HTTP, policy.WS)
+	}
+	for _, server := range []*httpServer{n.httpAuth, n.wsAuth} {
+		server.setAccessPolicy(policy.AuthRPC, policy.AuthRPC)
+	}
+	return nil
+}
+
 func (n *Node) wsServerForPort(port int, authenticated bool) *httpServer {
 	httpServer, wsServer := n.http, n.ws
 	if authenticated {
@@ -606,6 +677,34 @@ func (n *Node) RegisterHandler(name, path string, handler http.Handler) {
 	n.http.handlerNames[path] = name
 }
 
+// RegisterAuthenticatedHandler mounts a handler on the given path on the
+// authenticated HTTP endpoint. Requests to the handler must carry a valid JWT
+// token, like the authenticated RPC APIs. The handler is only served if the
+// authenticated endpoint is enabled.
+func (n *Node) RegisterAuthenticatedHandler(name, path string, handler http.Handler) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.state != initializingState {
+		panic("can't register HTTP handler on running/stopped node")
+	}
+
+	n.authHandlers[path] = handler
+	n.httpAuth.handlerNames[path] = name
+}
+
+// AdmitAuthenticated checks a method call served by an authenticated handler
+// against the access policy and the rate limit of the authenticated RPC
+// endpoint. Handlers serving the RPC methods over another transport use it to
+// apply the same restrictions as the JSON-RPC endpoint.
+func (n *Node) AdmitAuthenticated(r *http.Request, method string) error {
+	handler, _ := n.httpAuth.httpHandler.Load().(*rpcHandler)
+	if handler == nil {
+		return ErrNodeStopped
+	}
+	return handler.server.Admit(r, method)
+}
+
 // Attach creates an RPC client attached to an in-process API handler.
 func (n *Node) Attach() *rpc.Client {
 	return rpc.DialInProc(n.inprocHandler)
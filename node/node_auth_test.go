@@ -19,7 +19,9 @@ package node
 import (
 	"context"
 	crand "crypto/rand"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -197,6 +199,192 @@ func TestAuthEndpoints(t *testing.T) {
 	}
 }
 
+// Tests that handlers registered for the authenticated endpoint are only served
+// there, and only to requests with a valid token.
+func TestAuthHandler(t *testing.T) {
+	var secret [32]byte
+	if _, err := crand.Read(secret[:]); err != nil {
+		t.Fatalf("failed to create jwt secret: %v", err)
+	}
+	jwtPath := filepath.Join(t.TempDir(), "jwt_secret")
+	if err := os.WriteFile(jwtPath, []byte(hexutil.Encode(secret[:])), 0600); err != nil {
+		t.Fatalf("failed to prepare jwt secret file: %v", err)
+	}
+	node, err := New(&Config{HTTPHost: "127.0.0.1", AuthAddr: "127.0.0.1", JWTSecret: jwtPath})
+	if err != nil {
+		t.Fatalf("could not create a new node: %v", err)
+	}
+	node.RegisterAPIs([]rpc.API{{Namespace: "engine", Service: helloRPC("hello engine"), Authenticated: true}})
+	node.RegisterAuthenticatedHandler("test", "/test", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("success"))
+	}))
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start test node: %v", err)
+	}
+	defer node.Close()
+
+	get := func(url string, auth rpc.HTTPAuth) (int, string) {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			t.Fatalf("could not create request: %v", err)
+		}
+		if auth != nil {
+			if err := auth(req.Header); err != nil {
+				t.Fatalf("could not authenticate request: %v", err)
+			}
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+	if status, body := get(node.HTTPAuthEndpoint()+"/test", NewJWTAuth(secret)); status != http.StatusOK || body != "success" {
+		t.Fatalf("authenticated request failed: status %d, body %q", status, body)
+	}
+	if status, _ := get(node.HTTPAuthEndpoint()+"/test", nil); status != http.StatusUnauthorized {
+		t.Fatalf("unauthenticated request not rejected: status %d", status)
+	}
+	if status, _ := get(node.HTTPEndpoint()+"/test", nil); status != http.StatusNotFound {
+		t.Fatalf("handler served on the public endpoint: status %d", status)
+	}
+}
+
+// Tests that authenticated handlers admit the calls by the access policy of the
+// authenticated endpoint, following the reloads of the policy.
+func TestAuthHandlerPolicy(t *testing.T) {
+	var secret [32]byte
+	if _, err := crand.Read(secret[:]); err != nil {
+		t.Fatalf("failed to create jwt secret: %v", err)
+	}
+	dir := t.TempDir()
+	jwtPath := filepath.Join(dir, "jwt_secret")
+	if err := os.WriteFile(jwtPath, []byte(hexutil.Encode(secret[:])), 0600); err != nil {
+		t.Fatalf("failed to prepare jwt secret file: %v", err)
+	}
+	policyFile := filepath.Join(dir, "policy.json")
+	writePolicy := func(policy string) {
+		if err := os.WriteFile(policyFile, []byte(policy), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writePolicy(`{"authrpc": {"clients": {"bob": {"deny": ["engine_helloWorld"]}}}}`)
+
+	node, err := New(&Config{AuthAddr: "127.0.0.1", JWTSecret: jwtPath, RPCPolicyFile: policyFile})
+	if err != nil {
+		t.Fatalf("could not create a new node: %v", err)
+	}
+	node.RegisterAPIs([]rpc.API{{Namespace: "engine", Service: helloRPC("hello engine"), Authenticated: true}})
+	node.RegisterAuthenticatedHandler("test", "/test", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := node.AdmitAuthenticated(r, "engine_helloWorld"); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		w.Write([]byte("success"))
+	}))
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start test node: %v", err)
+	}
+	defer node.Close()
+
+	get := func(id string) int {
+		req, err := http.NewRequest(http.MethodGet, node.HTTPAuthEndpoint()+"/test", nil)
+		if err != nil {
+			t.Fatalf("could not create request: %v", err)
+		}
+		if err := idAuth(secret, id)(req.Header); err != nil {
+			t.Fatalf("could not authenticate request: %v", err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if status := get("alice"); status != http.StatusOK {
+		t.Fatalf("allowed client rejected: status %d", status)
+	}
+	if status := get("bob"); status != http.StatusForbidden {
+		t.Fatalf("denied client not rejected: status %d", status)
+	}
+	// Reload the policy without the restriction
+	writePolicy(`{}`)
+	if err := node.reloadRPCPolicy(); err != nil {
+		t.Fatalf("failed to reload policy: %v", err)
+	}
+	if status := get("bob"); status != http.StatusOK {
+		t.Fatalf("client rejected after reload: status %d", status)
+	}
+}
+
+// Tests that the clients of the authenticated endpoint are rate limited by the
+// identifier in their tokens, and that the engine API is exempt.
+func TestAuthRateLimit(t *testing.T) {
+	var secret [32]byte
+	if _, err := crand.Read(secret[:]); err != nil {
+		t.Fatalf("failed to create jwt secret: %v", err)
+	}
+	jwtPath := filepath.Join(t.TempDir(), "jwt_secret")
+	if err := os.WriteFile(jwtPath, []byte(hexutil.Encode(secret[:])), 0600); err != nil {
+		t.Fatalf("failed to prepare jwt secret file: %v", err)
+	}
+	node, err := New(&Config{
+		AuthAddr:       "127.0.0.1",
+		JWTSecret:      jwtPath,
+		RPCRateLimit:   0.001,
+		RPCMethodCosts: map[string]int{"eth_helloWorld": 200},
+	})
+	if err != nil {
+		t.Fatalf("could not create a new node: %v", err)
+	}
+	node.RegisterAPIs([]rpc.API{
+		{Namespace: "engine", Service: helloRPC("hello engine"), Authenticated: true},
+		{Namespace: "eth", Service: helloRPC("hello eth"), Authenticated: true},
+	})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start test node: %v", err)
+	}
+	defer node.Close()
+
+	for _, id := range []string{"alice", "bob"} {
+		client, err := rpc.DialOptions(context.Background(), node.HTTPAuthEndpoint(), rpc.WithHTTPAuth(idAuth(secret, id)))
+		if err != nil {
+			t.Fatalf("failed to dial rpc endpoint: %v", err)
+		}
+		var res string
+		if err := client.Call(&res, "eth_helloWorld"); err != nil {
+			t.Fatalf("client %s: first call failed: %v", id, err)
+		}
+		var rpcErr rpc.Error
+		if err := client.Call(&res, "eth_helloWorld"); !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != -32005 {
+			t.Fatalf("client %s: expected rate limit error, got %v", id, err)
+		}
+		if err := client.Call(&res, "engine_helloWorld"); err != nil {
+			t.Fatalf("client %s: engine call failed: %v", id, err)
+		}
+		client.Close()
+	}
+}
+
+// idAuth creates tokens carrying the given client identifier.
+func idAuth(secret [32]byte, id string) rpc.HTTPAuth {
+	return func(header http.Header) error {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"iat": &jwt.NumericDate{Time: time.Now()},
+			"id":  id,
+		})
+		s, err := token.SignedString(secret[:])
+		if err != nil {
+			return fmt.Errorf("failed to create JWT token: %w", err)
+		}
+		header.Set("Authorization", "Bearer "+s)
+		return nil
+	}
+}
+
 func noneAuth(secret [32]byte) rpc.HTTPAuth {
 	return func(header http.Header) error {
 		token := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{
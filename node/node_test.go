@@ -22,6 +22,8 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
 	"slices"
 	"strings"
@@ -569,6 +571,63 @@ func (test rpcPrefixTest) check(t *testing.T, node *Node) {
 	}
 }
 
+// Tests that the method access policy is applied to the HTTP endpoint, and can be
+// replaced by reloading the policy file.
+func TestNodeRPCPolicy(t *testing.T) {
+	policyFile := filepath.Join(t.TempDir(), "policy.json")
+	writePolicy := func(policy string) {
+		if err := os.WriteFile(policyFile, []byte(policy), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writePolicy(`{"http": {"default": {"deny": ["test_greet"]}}}`)
+
+	conf := &Config{
+		HTTPHost:      "127.0.0.1",
+		HTTPModules:   []string{"test"},
+		HTTPTimeouts:  rpc.DefaultHTTPTimeouts,
+		RPCPolicyFile: policyFile,
+	}
+	node, err := New(conf)
+	if err != nil {
+		t.Fatalf("could not create a new node: %v", err)
+	}
+	node.RegisterAPIs(apis())
+	if err := node.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+	defer node.Close()
+
+	client, err := rpc.Dial(node.HTTPEndpoint())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	var res string
+	err = client.Call(&res, "test_greet")
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != -32006 {
+		t.Fatalf("expected access denied error, got %v", err)
+	}
+	// Reload the policy without the restriction
+	writePolicy(`{"http": {"default": {"allow": ["test_*"]}}}`)
+	if err := node.reloadRPCPolicy(); err != nil {
+		t.Fatalf("failed to reload policy: %v", err)
+	}
+	if err := client.Call(&res, "test_greet"); err != nil {
+		t.Fatalf("call failed after reload: %v", err)
+	}
+	// Invalid policies are rejected, leaving the current ones in place
+	writePolicy(`{"http": {"default": {"allow": ["test*"]}}}`)
+	if err := node.reloadRPCPolicy(); err == nil {
+		t.Fatal("invalid policy is accepted")
+	}
+	if err := client.Call(&res, "test_greet"); err != nil {
+		t.Fatalf("call failed after invalid reload: %v", err)
+	}
+}
+
 func createNode(t *testing.T, httpPort, wsPort int) *Node {
 	conf := &Config{
 		HTTPHost:     "127.0.0.1",
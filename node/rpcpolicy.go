@@ -0,0 +1,75 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// rpcPolicy contains the method access policies of the RPC endpoints, loaded
+// from the policy file. An endpoint without a policy is unrestricted.
+//
+// An example of the policy file:
+//
+//	{
+//	  "http": {
+//	    "default": {"allow": ["eth_*", "debug_traceTransaction"]}
+//	  },
+//	  "authrpc": {
+//	    "default": {"allow": ["engine_*", "eth_*"]},
+//	    "clients": {
+//	      "partner": {"allow": ["eth_*", "debug_*"], "deny": ["debug_setHead"]}
+//	    }
+//	  }
+//	}
+type rpcPolicy struct {
+	HTTP    *rpc.AccessPolicy `json:"http,omitempty"`
+	WS      *rpc.AccessPolicy `json:"ws,omitempty"`
+	AuthRPC *rpc.AccessPolicy `json:"authrpc,omitempty"` // both HTTP and WebSocket
+}
+
+// loadRPCPolicy reads and validates the policy file. An empty path results in
+// the unrestricted policy.
+func loadRPCPolicy(path string) (*rpcPolicy, error) {
+	policy := new(rpcPolicy)
+	if path == "" {
+		return policy, nil
+	}
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read RPC policy file: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(blob))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(policy); err != nil {
+		return nil, fmt.Errorf("invalid RPC policy file: %w", err)
+	}
+	for name, endpoint := range map[string]*rpc.AccessPolicy{"http": policy.HTTP, "ws": policy.WS, "authrpc": policy.AuthRPC} {
+		if endpoint == nil {
+			continue
+		}
+		if err := endpoint.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid RPC policy for %s: %w", name, err)
+		}
+	}
+	return policy, nil
+}
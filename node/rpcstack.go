@@ -34,6 +34,8 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/rs/cors"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 )
 
 // httpConfig is the JSON-RPC/HTTP configuration.
@@ -42,6 +44,7 @@ type httpConfig struct {
 	CorsAllowedOrigins []string
 	Vhosts             []string
 	prefix             string // path prefix on which to mount http handler
+	h2c                bool   // whether HTTP/2 is also served without TLS
 	rpcEndpointConfig
 }
 
@@ -58,6 +61,15 @@ type rpcEndpointConfig struct {
 	batchItemLimit         int
 	batchResponseSizeLimit int
 	httpBodyLimit          int
+	rateLimit              rpc.RateLimitConfig
+	accessPolicy           *rpc.AccessPolicy  // optional method access policy
+	responseCache          *rpc.ResponseCache // optional cache of the immutable responses
+}
+
+// withAccessPolicy returns a copy of the config with the given access policy.
+func (c rpcEndpointConfig) withAccessPolicy(policy *rpc.AccessPolicy) rpcEndpointConfig {
+	c.accessPolicy = policy
+	return c
 }
 
 type rpcHandler struct {
@@ -138,14 +150,24 @@ func (h *httpServer) start() error {
 		return nil // already running or not configured
 	}
 
-	// Initialize the server.
+	// Initialize the server. If enabled, HTTP/2 is served without TLS (h2c)
+	// as well, allowing the clients to multiplex the requests and event streams
+	// over a single connection.
 	h.server = &http.Server{Handler: h}
+	var h2s *http2.Server
+	if h.httpConfig.h2c {
+		h2s = &http2.Server{}
+		h.server.Handler = h2c.NewHandler(h, h2s)
+	}
 	if h.timeouts != (rpc.HTTPTimeouts{}) {
 		CheckTimeouts(&h.timeouts)
 		h.server.ReadTimeout = h.timeouts.ReadTimeout
 		h.server.ReadHeaderTimeout = h.timeouts.ReadHeaderTimeout
 		h.server.WriteTimeout = h.timeouts.WriteTimeout
 		h.server.IdleTimeout = h.timeouts.IdleTimeout
+		if h2s != nil {
+			h2s.IdleTimeout = h.timeouts.IdleTimeout
+		}
 	}
 
 	// Start the server.
@@ -309,6 +331,9 @@ func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 	if config.httpBodyLimit > 0 {
 		srv.SetHTTPBodyLimit(config.httpBodyLimit)
 	}
+	srv.SetRateLimit(config.rateLimit)
+	srv.SetAccessPolicy(config.accessPolicy)
+	srv.SetResponseCache(config.responseCache)
 	if err := RegisterApis(apis, config.Modules, srv); err != nil {
 		return err
 	}
@@ -344,6 +369,9 @@ func (h *httpServer) enableWS(apis []rpc.API, config wsConfig) error {
 	if config.httpBodyLimit > 0 {
 		srv.SetHTTPBodyLimit(config.httpBodyLimit)
 	}
+	srv.SetRateLimit(config.rateLimit)
+	srv.SetAccessPolicy(config.accessPolicy)
+	srv.SetResponseCache(config.responseCache)
 	if err := RegisterApis(apis, config.Modules, srv); err != nil {
 		return err
 	}
@@ -377,6 +405,22 @@ func (h *httpServer) disableWS() bool {
 	return ws != nil
 }
 
+// setAccessPolicy replaces the access policies of the running HTTP and WebSocket
+// handlers.
+func (h *httpServer) setAccessPolicy(rpcPolicy, wsPolicy *rpc.AccessPolicy) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if handler := h.httpHandler.Load().(*rpcHandler); handler != nil {
+		handler.server.SetAccessPolicy(rpcPolicy)
+		h.httpConfig.accessPolicy = rpcPolicy
+	}
+	if handler := h.wsHandler.Load().(*rpcHandler); handler != nil {
+		handler.server.SetAccessPolicy(wsPolicy)
+		h.wsConfig.accessPolicy = wsPolicy
+	}
+}
+
 // rpcAllowed returns true when JSON-RPC over HTTP is enabled.
 func (h *httpServer) rpcAllowed() bool {
 	return h.httpHandler.Load().(*rpcHandler) != nil
@@ -561,6 +605,11 @@ func (w *gzipResponseWriter) Flush() {
 	}
 }
 
+// Unwrap returns the underlying response writer, for http.ResponseController.
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.resp
+}
+
 func (w *gzipResponseWriter) close() {
 	if w.gz == nil {
 		return
@@ -18,8 +18,11 @@ package node
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -34,6 +37,7 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2"
 )
 
 const testMethod = "rpc_modules"
@@ -235,6 +239,49 @@ func Test_checkPath(t *testing.T) {
 	}
 }
 
+// TestHTTP2Cleartext makes sure the server accepts HTTP/2 requests without TLS
+// only if enabled.
+func TestHTTP2Cleartext(t *testing.T) {
+	client := &http.Client{
+		Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, addr)
+			},
+		},
+	}
+	body := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"%s","params":[]}`, testMethod)
+
+	// HTTP/2 without TLS is rejected by default.
+	srv := createAndStartServer(t, &httpConfig{}, false, &wsConfig{}, nil)
+	if resp, err := client.Post("http://"+srv.listenAddr(), "application/json", strings.NewReader(body)); err == nil {
+		resp.Body.Close()
+		t.Fatalf("HTTP/2 request accepted without h2c enabled")
+	}
+	srv.stop()
+
+	srv = createAndStartServer(t, &httpConfig{h2c: true}, false, &wsConfig{}, nil)
+	defer srv.stop()
+
+	resp, err := client.Post("http://"+srv.listenAddr(), "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("HTTP/2 request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.ProtoMajor != 2 {
+		t.Fatalf("wrong protocol %s", resp.Proto)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
+	}
+	blob, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(blob), `"result"`) {
+		t.Fatalf("unexpected response %s", blob)
+	}
+}
+
 func createAndStartServer(t *testing.T, conf *httpConfig, ws bool, wsConf *wsConfig, timeouts *rpc.HTTPTimeouts) *httpServer {
 	t.Helper()
 
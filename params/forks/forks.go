@@ -40,6 +40,7 @@ const (
 	Cancun
 	Prague
 	Osaka
+	Amsterdam
 )
 
 // String implements fmt.Stringer.
@@ -72,4 +73,5 @@ var forkToString = map[Fork]string{
 	Cancun:           "Cancun",
 	Prague:           "Prague",
 	Osaka:            "Osaka",
+	Amsterdam:        "Amsterdam",
 }
@@ -0,0 +1,102 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AccessRule is a set of method patterns permitted and prohibited for a client.
+// A pattern is either a full method name like "debug_traceTransaction", all the
+// methods of a namespace like "debug_*", or all the methods "*".
+//
+// A method is permitted if it matches any of the allowed patterns, or if there
+// is no allowed pattern at all, and none of the denied patterns.
+type AccessRule struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// AccessPolicy restricts the methods available to the clients of a server, on
+// top of the exposed namespaces. The clients are identified by the "id" claim
+// of their JWT tokens, the ones without a dedicated rule are subject to the
+// default rule.
+type AccessPolicy struct {
+	Default AccessRule            `json:"default"`
+	Clients map[string]AccessRule `json:"clients,omitempty"`
+}
+
+// Validate checks whether all the patterns in the policy are well-formed.
+func (p *AccessPolicy) Validate() error {
+	check := func(rule AccessRule) error {
+		for _, pattern := range append(append([]string{}, rule.Allow...), rule.Deny...) {
+			if err := validatePattern(pattern); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := check(p.Default); err != nil {
+		return fmt.Errorf("default rule: %v", err)
+	}
+	for id, rule := range p.Clients {
+		if err := check(rule); err != nil {
+			return fmt.Errorf("client %q: %v", id, err)
+		}
+	}
+	return nil
+}
+
+// Allowed returns whether the given client is permitted to call the method.
+func (p *AccessPolicy) Allowed(clientID string, method string) bool {
+	rule, ok := p.Clients[clientID]
+	if !ok {
+		rule = p.Default
+	}
+	if len(rule.Allow) > 0 && !matchPatterns(rule.Allow, method) {
+		return false
+	}
+	return !matchPatterns(rule.Deny, method)
+}
+
+// validatePattern checks whether the given method pattern is well-formed.
+func validatePattern(pattern string) error {
+	if pattern == "*" {
+		return nil
+	}
+	namespace, name, ok := strings.Cut(pattern, serviceMethodSeparator)
+	if !ok || namespace == "" || name == "" {
+		return fmt.Errorf("invalid method pattern %q", pattern)
+	}
+	if strings.Contains(namespace, "*") || (name != "*" && strings.Contains(name, "*")) {
+		return fmt.Errorf("invalid wildcard in method pattern %q", pattern)
+	}
+	return nil
+}
+
+// matchPatterns returns whether the method matches any of the given patterns.
+func matchPatterns(patterns []string, method string) bool {
+	namespace, _, _ := strings.Cut(method, serviceMethodSeparator)
+	for _, pattern := range patterns {
+		switch pattern {
+		case "*", method, namespace + serviceMethodSeparator + "*":
+			return true
+		}
+	}
+	return false
+}
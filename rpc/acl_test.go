@@ -0,0 +1,130 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAccessPolicyAllowed(t *testing.T) {
+	policy := &AccessPolicy{
+		Default: AccessRule{Allow: []string{"eth_*", "debug_traceTransaction"}},
+		Clients: map[string]AccessRule{
+			"internal": {Deny: []string{"debug_setHead"}},
+		},
+	}
+	if err := policy.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		client, method string
+		allowed        bool
+	}{
+		{"", "eth_call", true},
+		{"", "debug_traceTransaction", true},
+		{"", "debug_traceCall", false},
+		{"", "admin_peers", false},
+		{"partner", "debug_setHead", false},
+		{"internal", "debug_traceCall", true},
+		{"internal", "admin_peers", true},
+		{"internal", "debug_setHead", false},
+	}
+	for _, test := range tests {
+		if have := policy.Allowed(test.client, test.method); have != test.allowed {
+			t.Errorf("client %q method %s: allowed %v, want %v", test.client, test.method, have, test.allowed)
+		}
+	}
+}
+
+func TestAccessPolicyValidate(t *testing.T) {
+	for _, pattern := range []string{"", "eth", "eth_", "_call", "eth*", "*_call", "eth_c*"} {
+		policy := &AccessPolicy{Default: AccessRule{Deny: []string{pattern}}}
+		if err := policy.Validate(); err == nil {
+			t.Errorf("invalid pattern %q is accepted", pattern)
+		}
+	}
+	for _, pattern := range []string{"*", "eth_*", "eth_call"} {
+		policy := &AccessPolicy{Clients: map[string]AccessRule{"id": {Allow: []string{pattern}}}}
+		if err := policy.Validate(); err != nil {
+			t.Errorf("valid pattern %q is rejected: %v", pattern, err)
+		}
+	}
+}
+
+func TestServerAccessPolicy(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+	server.SetAccessPolicy(&AccessPolicy{
+		Default: AccessRule{Deny: []string{"test_echo", "nftest_subscribe"}},
+		Clients: map[string]AccessRule{
+			"trusted": {Allow: []string{"*"}},
+		},
+	})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := r.Header.Get("X-Client-Id"); id != "" {
+			r = r.WithContext(ContextWithClientID(r.Context(), id))
+		}
+		server.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	dial := func(id string) *Client {
+		client, err := DialOptions(context.Background(), ts.URL, WithHeader("X-Client-Id", id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(client.Close)
+		return client
+	}
+	var (
+		anonymous = dial("")
+		trusted   = dial("trusted")
+		res       echoResult
+	)
+	err := anonymous.Call(&res, "test_echo", "x", 1)
+	if !IsAccessDenied(err) {
+		t.Fatalf("expected access denied error, got %v", err)
+	}
+	if err := anonymous.Call(nil, "test_noArgsRets"); err != nil {
+		t.Fatalf("allowed call failed: %v", err)
+	}
+	if err := trusted.Call(&res, "test_echo", "x", 1); err != nil {
+		t.Fatalf("trusted call failed: %v", err)
+	}
+	// Lifting the policy takes effect immediately.
+	server.SetAccessPolicy(nil)
+	if err := anonymous.Call(&res, "test_echo", "x", 1); err != nil {
+		t.Fatalf("call failed without policy: %v", err)
+	}
+}
+
+func TestServerAccessPolicySubscription(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+	server.SetAccessPolicy(&AccessPolicy{Default: AccessRule{Deny: []string{"nftest_subscribe"}}})
+
+	client := DialInProc(server)
+	defer client.Close()
+
+	_, err := client.Subscribe(context.Background(), "nftest", make(chan int), "someSubscription", 1, 1)
+	if !IsAccessDenied(err) {
+		t.Fatalf("expected access denied error, got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+)
+
+// ResponseCache is a size-bounded cache of the responses of the method calls.
+// Only the responses explicitly marked as immutable by the methods are stored,
+// see CacheResponse. The cache can be shared by multiple servers.
+type ResponseCache struct {
+	maxSize uint64
+
+	lock    sync.RWMutex
+	entries *lru.SizeConstrainedCache[string, []byte]
+}
+
+// NewResponseCache creates a response cache with the given capacity in bytes.
+func NewResponseCache(maxSize uint64) *ResponseCache {
+	return &ResponseCache{
+		maxSize: maxSize,
+		entries: lru.NewSizeConstrainedCache[string, []byte](maxSize),
+	}
+}
+
+// Reset drops all the cached responses. It must be called when the responses
+// marked as immutable are invalidated, e.g. when the chain is rewound.
+func (c *ResponseCache) Reset() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries = lru.NewSizeConstrainedCache[string, []byte](c.maxSize)
+}
+
+// serve answers the method call from the cache if the response is available,
+// otherwise it invokes the method and stores the response if the method marks
+// it as immutable.
+func (c *ResponseCache) serve(ctx context.Context, msg *jsonrpcMessage, run func(context.Context) *jsonrpcMessage) *jsonrpcMessage {
+	key := responseCacheKey(msg)
+
+	c.lock.RLock()
+	entries := c.entries
+	c.lock.RUnlock()
+
+	if result, ok := entries.Get(key); ok {
+		responseCacheHitMeter.Mark(1)
+		return &jsonrpcMessage{Version: vsn, ID: msg.ID, Result: result}
+	}
+	responseCacheMissMeter.Mark(1)
+
+	mark := new(atomic.Bool)
+	answer := run(context.WithValue(ctx, cacheMarkContextKey{}, mark))
+	if answer.Error != nil || !mark.Load() {
+		return answer
+	}
+
+	// Skip the oversized responses, preventing a single entry from flushing
+	// the entire cache.
+	if uint64(len(answer.Result)) > c.maxSize/16 {
+		return answer
+	}
+	// Drop the response if the cache was reset during the call, as it might
+	// have been invalidated.
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if c.entries == entries {
+		c.entries.Add(key, answer.Result)
+	}
+	return answer
+}
+
+// responseCacheKey returns the cache key of the method call, consisting of the
+// method name and the compacted parameters.
+func responseCacheKey(msg *jsonrpcMessage) string {
+	var buf bytes.Buffer
+	buf.WriteString(msg.Method)
+	buf.WriteByte(0)
+	if err := json.Compact(&buf, msg.Params); err != nil {
+		buf.Write(msg.Params)
+	}
+	return buf.String()
+}
+
+type cacheMarkContextKey struct{}
+
+// CacheResponse marks the response of the method call being processed as
+// immutable, allowing the server to answer the identical calls from its
+// response cache afterwards. It's a no-op if the response cache is disabled.
+func CacheResponse(ctx context.Context) {
+	if mark, ok := ctx.Value(cacheMarkContextKey{}).(*atomic.Bool); ok && mark != nil {
+		mark.Store(true)
+	}
+}
+
+// WithoutResponseCache returns a copy of the context in which CacheResponse has
+// no effect. Methods invoking cacheable methods internally must use it, as the
+// mark would otherwise apply to their own responses.
+func WithoutResponseCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheMarkContextKey{}, (*atomic.Bool)(nil))
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+type cacheTestService struct {
+	calls atomic.Int64
+}
+
+// Value returns a different value on every call, marking the response as
+// immutable if requested.
+func (s *cacheTestService) Value(ctx context.Context, immutable bool) int64 {
+	if immutable {
+		CacheResponse(ctx)
+	}
+	return s.calls.Add(1)
+}
+
+// Wrapped returns the value of an internal call to an immutable method, without
+// being immutable itself.
+func (s *cacheTestService) Wrapped(ctx context.Context) int64 {
+	return s.Value(WithoutResponseCache(ctx), true)
+}
+
+// Large returns a response exceeding the cacheable size.
+func (s *cacheTestService) Large(ctx context.Context) string {
+	CacheResponse(ctx)
+	s.calls.Add(1)
+	return strings.Repeat("x", 1024)
+}
+
+// Fail returns an error even if marked as immutable.
+func (s *cacheTestService) Fail(ctx context.Context) error {
+	CacheResponse(ctx)
+	s.calls.Add(1)
+	return testError{}
+}
+
+func TestResponseCache(t *testing.T) {
+	var (
+		service = new(cacheTestService)
+		server  = newTestServer()
+		cache   = NewResponseCache(1024)
+	)
+	defer server.Stop()
+	if err := server.RegisterName("cache", service); err != nil {
+		t.Fatal(err)
+	}
+	server.SetResponseCache(cache)
+
+	client := DialInProc(server)
+	defer client.Close()
+
+	call := func(method string, args ...interface{}) int64 {
+		t.Helper()
+		var res int64
+		if err := client.Call(&res, method, args...); err != nil {
+			t.Fatalf("call %s failed: %v", method, err)
+		}
+		return res
+	}
+	// Mutable responses are not cached
+	if a, b := call("cache_value", false), call("cache_value", false); a == b {
+		t.Fatalf("mutable response is cached")
+	}
+	// Immutable responses are served from the cache
+	first := call("cache_value", true)
+	if have := call("cache_value", true); have != first {
+		t.Fatalf("immutable response is not cached, have %d, want %d", have, first)
+	}
+	// Internal calls don't mark the response of the caller
+	if a, b := call("cache_wrapped"), call("cache_wrapped"); a == b {
+		t.Fatalf("wrapping response is cached")
+	}
+	// Failures and oversized responses are not cached
+	for i := 0; i < 2; i++ {
+		client.Call(nil, "cache_fail")
+		client.Call(nil, "cache_large")
+	}
+	if calls := service.calls.Load(); calls != 9 {
+		t.Fatalf("unexpected number of calls %d, want 9", calls)
+	}
+	// The responses are discarded by resetting the cache
+	cache.Reset()
+	if have := call("cache_value", true); have == first {
+		t.Fatal("response is served after reset")
+	}
+}
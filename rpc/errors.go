@@ -16,7 +16,10 @@
 
 package rpc
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // HTTPError is returned by client operations when the HTTP status code of the
 // response is not a 2xx status.
@@ -55,12 +58,16 @@ var (
 	_ Error = new(invalidMessageError)
 	_ Error = new(invalidParamsError)
 	_ Error = new(internalServerError)
+	_ Error = new(rateLimitError)
+	_ Error = new(accessDeniedError)
 )
 
 const (
 	errcodeDefault          = -32000
 	errcodeTimeout          = -32002
 	errcodeResponseTooLarge = -32003
+	errcodeLimitExceeded    = -32005
+	errcodeAccessDenied     = -32006
 	errcodePanic            = -32603
 	errcodeMarshalError     = -32603
 
@@ -71,8 +78,26 @@ const (
 	errMsgTimeout          = "request timed out"
 	errMsgResponseTooLarge = "response too large"
 	errMsgBatchTooLarge    = "batch too large"
+	errMsgLimitExceeded    = "rate limit exceeded"
 )
 
+// IsAccessDenied reports whether err is an RPC error rejecting the call by the
+// access policy of the server.
+func IsAccessDenied(err error) bool {
+	return hasErrorCode(err, errcodeAccessDenied)
+}
+
+// IsLimitExceeded reports whether err is an RPC error rejecting the call by the
+// rate limit of the server.
+func IsLimitExceeded(err error) bool {
+	return hasErrorCode(err, errcodeLimitExceeded)
+}
+
+func hasErrorCode(err error, code int) bool {
+	var rpcErr Error
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == code
+}
+
 type methodNotFoundError struct{ method string }
 
 func (e *methodNotFoundError) ErrorCode() int { return -32601 }
@@ -109,6 +134,14 @@ func (e notificationsUnsupportedError) Is(other error) bool {
 	return false
 }
 
+type accessDeniedError struct{ method string }
+
+func (e *accessDeniedError) ErrorCode() int { return errcodeAccessDenied }
+
+func (e *accessDeniedError) Error() string {
+	return fmt.Sprintf("the method %s is not allowed", e.method)
+}
+
 type subscriptionNotFoundError struct{ namespace, subscription string }
 
 func (e *subscriptionNotFoundError) ErrorCode() int { return -32601 }
@@ -154,3 +187,9 @@ type internalServerError struct {
 func (e *internalServerError) ErrorCode() int { return e.code }
 
 func (e *internalServerError) Error() string { return e.message }
+
+type rateLimitError struct{}
+
+func (e *rateLimitError) ErrorCode() int { return errcodeLimitExceeded }
+
+func (e *rateLimitError) Error() string { return errMsgLimitExceeded }
@@ -0,0 +1,220 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	eventStreamContentType = "text/event-stream"
+
+	// eventStreamPingInterval is the interval of the comment lines sent to keep
+	// idle event streams alive through the proxies.
+	eventStreamPingInterval = 30 * time.Second
+)
+
+var errEventStreamRequest = errors.New("event streams only support a single subscription request")
+
+// acceptsEventStream returns whether the client of the request asks for the
+// response as a stream of server-sent events.
+func acceptsEventStream(r *http.Request) bool {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		if mt, _, err := mime.ParseMediaType(accept); err == nil && mt == eventStreamContentType {
+			return true
+		}
+	}
+	return false
+}
+
+// serveEventStream serves a subscription request over HTTP, streaming the
+// response and the notifications of the subscription as server-sent events
+// until the client disconnects.
+func (s *Server) serveEventStream(w http.ResponseWriter, r *http.Request, info PeerInfo) {
+	// The content length is checked by validateRequest, bodies of unknown
+	// length are rejected once they exceed the limit.
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, int64(s.httpBodyLimit)))
+	if err != nil {
+		code := http.StatusBadRequest
+		if _, ok := err.(*http.MaxBytesError); ok {
+			code = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, err.Error(), code)
+		return
+	}
+	msgs, batch := parseMessage(body)
+	if batch || len(msgs) != 1 || msgs[0] == nil || !msgs[0].isCall() || !msgs[0].isSubscribe() {
+		http.Error(w, errEventStreamRequest.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("content-type", eventStreamContentType)
+	w.Header().Set("cache-control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	codec := newEventStreamCodec(w, msgs[0], info)
+	go func() {
+		select {
+		case <-r.Context().Done():
+			codec.close()
+		case <-codec.closed():
+		}
+	}()
+	s.ServeCodec(codec, 0)
+}
+
+// eventStreamCodec is the codec of the server-sent event streams. It yields the
+// subscription request once, and writes all the outgoing messages as events
+// until the stream is closed.
+type eventStreamCodec struct {
+	req  *jsonrpcMessage
+	info PeerInfo
+
+	mu      sync.Mutex // protects the response writer and the closing
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	read    bool
+	done    bool
+	closeCh chan interface{}
+}
+
+func newEventStreamCodec(w http.ResponseWriter, req *jsonrpcMessage, info PeerInfo) *eventStreamCodec {
+	c := &eventStreamCodec{
+		req:     req,
+		info:    info,
+		w:       w,
+		rc:      http.NewResponseController(w),
+		closeCh: make(chan interface{}),
+	}
+	go c.pingLoop()
+	return c
+}
+
+func (c *eventStreamCodec) peerInfo() PeerInfo {
+	return c.info
+}
+
+func (c *eventStreamCodec) remoteAddr() string {
+	return c.info.RemoteAddr
+}
+
+// readBatch returns the subscription request on the first call, and blocks
+// until the stream is closed afterwards.
+func (c *eventStreamCodec) readBatch() ([]*jsonrpcMessage, bool, error) {
+	c.mu.Lock()
+	read := c.read
+	c.read = true
+	c.mu.Unlock()
+
+	if !read {
+		return []*jsonrpcMessage{c.req}, false, nil
+	}
+	<-c.closeCh
+	return nil, false, io.EOF
+}
+
+// writeJSON writes the message as an event. The stream is closed if the
+// subscription request fails.
+func (c *eventStreamCodec) writeJSON(ctx context.Context, v interface{}, isError bool) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.done {
+		return io.ErrClosedPipe
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultWriteTimeout)
+	}
+	if err := c.write(deadline, "data: ", string(data), "\n\n"); err != nil {
+		c.closeLocked()
+		return err
+	}
+	if msg, ok := v.(*jsonrpcMessage); ok && msg.Error != nil {
+		c.closeLocked()
+	}
+	return nil
+}
+
+// write writes the given chunks to the stream and flushes it. The caller must
+// hold c.mu.
+//
+// The write deadline is only applied if supported by the response writer, and
+// lifted after the write, as the stream is idle most of the time and the HTTP
+// server's write timeout would abort it otherwise.
+func (c *eventStreamCodec) write(deadline time.Time, chunks ...string) error {
+	c.rc.SetWriteDeadline(deadline)
+	defer c.rc.SetWriteDeadline(time.Time{})
+
+	for _, chunk := range chunks {
+		if _, err := io.WriteString(c.w, chunk); err != nil {
+			return err
+		}
+	}
+	return c.rc.Flush()
+}
+
+// pingLoop sends a comment line periodically until the stream is closed.
+func (c *eventStreamCodec) pingLoop() {
+	ticker := time.NewTicker(eventStreamPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.mu.Lock()
+			if !c.done {
+				if err := c.write(time.Now().Add(wsPingWriteTimeout), ":\n\n"); err != nil {
+					c.closeLocked()
+				}
+			}
+			c.mu.Unlock()
+		case <-c.closeCh:
+			return
+		}
+	}
+}
+
+func (c *eventStreamCodec) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closeLocked()
+}
+
+// closeLocked marks the stream closed, no more writes are performed after it
+// returns. The caller must hold c.mu.
+func (c *eventStreamCodec) closeLocked() {
+	if !c.done {
+		c.done = true
+		close(c.closeCh)
+	}
+}
+
+func (c *eventStreamCodec) closed() <-chan interface{} {
+	return c.closeCh
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func eventStreamRequest(t *testing.T, url, body string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("content-type", contentType)
+	req.Header.Set("accept", eventStreamContentType)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+// readEvent reads the data of the next event from the stream, skipping the
+// comment lines.
+func readEvent(t *testing.T, r *bufio.Reader) *jsonrpcMessage {
+	t.Helper()
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read event: %v", err)
+		}
+		data, ok := strings.CutPrefix(line, "data: ")
+		if !ok {
+			continue
+		}
+		var msg jsonrpcMessage
+		if err := json.Unmarshal([]byte(data), &msg); err != nil {
+			t.Fatalf("invalid event %q: %v", data, err)
+		}
+		return &msg
+	}
+}
+
+func TestEventStreamSubscription(t *testing.T) {
+	service := &notificationTestService{unsubscribed: make(chan string, 1)}
+	server := NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("nftest", service); err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	resp := eventStreamRequest(t, ts.URL, `{"jsonrpc":"2.0","id":1,"method":"nftest_subscribe","params":["someSubscription",3,10]}`)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("content-type"); ct != eventStreamContentType {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+	reader := bufio.NewReader(resp.Body)
+
+	var subid string
+	if msg := readEvent(t, reader); !msg.isResponse() || msg.Error != nil {
+		t.Fatalf("unexpected subscription response %+v", msg)
+	} else if err := json.Unmarshal(msg.Result, &subid); err != nil {
+		t.Fatalf("invalid subscription id: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		msg := readEvent(t, reader)
+		if msg.Method != "nftest"+notificationMethodSuffix {
+			t.Fatalf("unexpected notification method %q", msg.Method)
+		}
+		var result struct {
+			Subscription string `json:"subscription"`
+			Result       int    `json:"result"`
+		}
+		if err := json.Unmarshal(msg.Params, &result); err != nil {
+			t.Fatalf("invalid notification: %v", err)
+		}
+		if result.Subscription != subid || result.Result != 10+i {
+			t.Fatalf("unexpected notification %+v", result)
+		}
+	}
+	// Disconnecting the client ends the subscription.
+	resp.Body.Close()
+	select {
+	case id := <-service.unsubscribed:
+		if id != subid {
+			t.Fatalf("wrong subscription unsubscribed, have %s, want %s", id, subid)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription is not ended after disconnection")
+	}
+}
+
+func TestEventStreamInvalidRequest(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	for _, body := range []string{
+		`{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["x",1]}`,
+		`[{"jsonrpc":"2.0","id":1,"method":"nftest_subscribe","params":["someSubscription",1,1]}]`,
+		`{"jsonrpc":"2.0","method":"nftest_subscribe","params":["someSubscription",1,1]}`,
+	} {
+		resp := eventStreamRequest(t, ts.URL, body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("request %s: unexpected status %d", body, resp.StatusCode)
+		}
+	}
+	// Failed subscriptions end the stream after the error response.
+	resp := eventStreamRequest(t, ts.URL, `{"jsonrpc":"2.0","id":1,"method":"nftest_subscribe","params":["missing"]}`)
+	defer resp.Body.Close()
+	reader := bufio.NewReader(resp.Body)
+	if msg := readEvent(t, reader); msg.Error == nil {
+		t.Fatalf("expected error response, got %+v", msg)
+	}
+	done := make(chan struct{})
+	go func() {
+		io.Copy(io.Discard, reader)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream is not closed")
+	}
+}
+
+// Tests that subscription requests exceeding the body limit are rejected, also
+// if the content length is unknown.
+func TestEventStreamBodyLimit(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+	server.SetHTTPBodyLimit(100)
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"nftest_subscribe","params":["someSubscription",1,1],"padding":"` + strings.Repeat("x", 100) + `"}`
+	for _, reader := range []io.Reader{
+		strings.NewReader(body),
+		io.MultiReader(strings.NewReader(body)), // unknown content length
+	} {
+		req, err := http.NewRequest(http.MethodPost, ts.URL, reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("content-type", contentType)
+		req.Header.Set("accept", eventStreamContentType)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusRequestEntityTooLarge {
+			t.Errorf("content length %d: unexpected status %d", req.ContentLength, resp.StatusCode)
+		}
+	}
+}
@@ -28,9 +28,15 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/internal/telemetry"
 	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// tracer creates the spans of the served method calls.
+var tracer = telemetry.Tracer("github.com/ethereum/go-ethereum/rpc")
+
 // handler handles JSON-RPC messages. There is one handler per connection. Note that
 // handler is not safe for concurrent use. Message handling never blocks indefinitely
 // because RPCs are processed on background goroutines launched by handler.
@@ -65,6 +71,8 @@ type handler struct {
 	allowSubscribe       bool
 	batchRequestLimit    int
 	batchResponseMaxSize int
+	rateLimiter          *rateLimiter   // admission control of the calls, nil if disabled
+	responseCache        *ResponseCache // cache of the immutable responses, nil if disabled
 
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
@@ -203,6 +211,10 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 			callBuffer = &batchCallBuffer{calls: calls, resp: make([]*jsonrpcMessage, 0, len(calls))}
 		)
 
+		var span trace.Span
+		cp.ctx, span = tracer.Start(cp.ctx, "rpc.batch", trace.WithAttributes(attribute.Int("rpc.batch.size", len(calls))))
+		defer span.End()
+
 		cp.ctx, cancel = context.WithCancel(cp.ctx)
 		defer cancel()
 
@@ -501,10 +513,14 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 	if msg.isUnsubscribe() {
 		callb = h.unsubscribeCb
 	} else {
-		callb = h.reg.callback(msg.Method)
+		var err error
+		callb, err = h.reg.callback(PeerInfoFromContext(cp.ctx).ClientID, msg.Method)
+		if err != nil {
+			return msg.errorResponse(err)
+		}
 	}
-	if callb == nil {
-		return msg.errorResponse(&methodNotFoundError{method: msg.Method})
+	if err := h.admit(cp.ctx, msg.Method); err != nil {
+		return msg.errorResponse(err)
 	}
 
 	args, err := parsePositionalArguments(msg.Params, callb.argTypes)
@@ -512,7 +528,14 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 		return msg.errorResponse(&invalidParamsError{err.Error()})
 	}
 	start := time.Now()
-	answer := h.runMethod(cp.ctx, msg, callb, args)
+	var answer *jsonrpcMessage
+	if h.responseCache != nil && callb != h.unsubscribeCb {
+		answer = h.responseCache.serve(cp.ctx, msg, func(ctx context.Context) *jsonrpcMessage {
+			return h.runMethod(ctx, msg, callb, args)
+		})
+	} else {
+		answer = h.runMethod(cp.ctx, msg, callb, args)
+	}
 
 	// Collect the statistics for RPC calls if metrics is enabled.
 	// We only care about pure rpc call. Filter out subscription.
@@ -530,6 +553,14 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 	return answer
 }
 
+// admit applies the rate limit on the method call, if enabled.
+func (h *handler) admit(ctx context.Context, method string) error {
+	if h.rateLimiter == nil {
+		return nil
+	}
+	return h.rateLimiter.admit(ctx, method)
+}
+
 // handleSubscribe processes *_subscribe method calls.
 func (h *handler) handleSubscribe(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
 	if !h.allowSubscribe {
@@ -542,9 +573,12 @@ func (h *handler) handleSubscribe(cp *callProc, msg *jsonrpcMessage) *jsonrpcMes
 		return msg.errorResponse(&invalidParamsError{err.Error()})
 	}
 	namespace := msg.namespace()
-	callb := h.reg.subscription(namespace, name)
-	if callb == nil {
-		return msg.errorResponse(&subscriptionNotFoundError{namespace, name})
+	callb, err := h.reg.subscription(PeerInfoFromContext(cp.ctx).ClientID, namespace, name)
+	if err != nil {
+		return msg.errorResponse(err)
+	}
+	if err := h.admit(cp.ctx, msg.Method); err != nil {
+		return msg.errorResponse(err)
 	}
 
 	// Parse subscription name arg too, but remove it before calling the callback.
@@ -565,10 +599,19 @@ func (h *handler) handleSubscribe(cp *callProc, msg *jsonrpcMessage) *jsonrpcMes
 
 // runMethod runs the Go callback for an RPC method.
 func (h *handler) runMethod(ctx context.Context, msg *jsonrpcMessage, callb *callback, args []reflect.Value) *jsonrpcMessage {
+	ctx, span := tracer.Start(ctx, msg.Method, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(
+		attribute.String("rpc.system", "jsonrpc"),
+		attribute.String("rpc.method", msg.Method),
+		attribute.String("rpc.jsonrpc.request_id", string(msg.ID)),
+	))
 	result, err := callb.call(ctx, msg.Method, args)
 	if err != nil {
-		return msg.errorResponse(err)
+		resp := msg.errorResponse(err)
+		span.SetAttributes(attribute.Int("rpc.jsonrpc.error_code", resp.Error.Code))
+		telemetry.EndSpan(span, err)
+		return resp
 	}
+	span.End()
 	return msg.response(result)
 }
 
@@ -32,6 +32,12 @@ var (
 	serveTimeHistName = "rpc/duration"
 
 	rpcServingTimer = metrics.NewRegisteredTimer("rpc/duration/all", nil)
+
+	rateLimitCostMeter = metrics.NewRegisteredMeter("rpc/ratelimit/cost", nil)
+	rateLimitedMeter   = metrics.NewRegisteredMeter("rpc/ratelimit/rejected", nil)
+
+	responseCacheHitMeter  = metrics.NewRegisteredMeter("rpc/cache/hit", nil)
+	responseCacheMissMeter = metrics.NewRegisteredMeter("rpc/cache/miss", nil)
 )
 
 // updateServeTimeHistogram tracks the serving time of a remote RPC call.
@@ -48,3 +54,9 @@ func updateServeTimeHistogram(method string, success bool, elapsed time.Duration
 	}
 	metrics.GetOrRegisterHistogramLazy(h, nil, sampler).Update(elapsed.Nanoseconds())
 }
+
+// updateRateLimitedCounter tracks the number of calls rejected by the rate
+// limiter for the given method.
+func updateRateLimitedCounter(method string) {
+	metrics.GetOrRegisterCounter(fmt.Sprintf("rpc/ratelimit/rejected/%s", method), nil).Inc(1)
+}
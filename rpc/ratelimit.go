@@ -0,0 +1,165 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimiterCleanupInterval is the interval for discarding the limiters of the
+// idle clients.
+const rateLimiterCleanupInterval = time.Minute
+
+// RateLimitConfig specifies the admission control applied to the clients of a
+// server. Every method call, including the ones in batches, is charged with the
+// cost of the method, and rejected if the client has not accumulated enough
+// budget. The clients are identified by the "id" claim of their JWT tokens if
+// available, or by their IP addresses otherwise.
+type RateLimitConfig struct {
+	// Rate is the number of cost units granted per second to each client.
+	// Zero means no limit.
+	Rate float64
+
+	// Burst is the maximum number of cost units a client can accumulate. If
+	// zero, it defaults to the rate. It's raised to the highest method cost, as
+	// the methods costing more could never be admitted otherwise.
+	Burst int
+
+	// MethodCosts is the cost of the methods, indexed by the method name. The
+	// "namespace_*" entry applies to all the methods of a namespace without an
+	// explicit cost. The methods not listed cost one unit.
+	MethodCosts map[string]int
+}
+
+// rateLimiter is a token bucket based admission control, tracking the budget
+// of each client separately.
+type rateLimiter struct {
+	rate  rate.Limit
+	burst int
+	costs map[string]int
+
+	lock    sync.Mutex
+	clients map[string]*rate.Limiter
+	cleaned time.Time
+}
+
+// newRateLimiter creates a rate limiter with the given configuration.
+func newRateLimiter(config RateLimitConfig) *rateLimiter {
+	burst := config.Burst
+	if burst == 0 {
+		burst = int(math.Ceil(config.Rate))
+	}
+	for _, cost := range config.MethodCosts {
+		burst = max(burst, cost)
+	}
+	costs := make(map[string]int, len(config.MethodCosts))
+	for method, cost := range config.MethodCosts {
+		costs[method] = cost
+	}
+	return &rateLimiter{
+		rate:    rate.Limit(config.Rate),
+		burst:   burst,
+		costs:   costs,
+		clients: make(map[string]*rate.Limiter),
+		cleaned: time.Now(),
+	}
+}
+
+// cost returns the cost of the given method.
+func (l *rateLimiter) cost(method string) int {
+	if cost, ok := l.costs[method]; ok {
+		return cost
+	}
+	if namespace, _, ok := strings.Cut(method, serviceMethodSeparator); ok {
+		if cost, ok := l.costs[namespace+serviceMethodSeparator+"*"]; ok {
+			return cost
+		}
+	}
+	return 1
+}
+
+// admit charges the cost of the given method to the client making the call,
+// returning an error if the client has run out of budget.
+func (l *rateLimiter) admit(ctx context.Context, method string) error {
+	cost := l.cost(method)
+	if cost <= 0 {
+		return nil
+	}
+	var (
+		key = rateLimitKey(PeerInfoFromContext(ctx))
+		now = time.Now()
+	)
+	l.lock.Lock()
+	if now.Sub(l.cleaned) > rateLimiterCleanupInterval {
+		for id, limiter := range l.clients {
+			if limiter.TokensAt(now) >= float64(l.burst) {
+				delete(l.clients, id)
+			}
+		}
+		l.cleaned = now
+	}
+	limiter := l.clients[key]
+	if limiter == nil {
+		limiter = rate.NewLimiter(l.rate, l.burst)
+		l.clients[key] = limiter
+	}
+	allowed := limiter.AllowN(now, cost)
+	l.lock.Unlock()
+
+	if !allowed {
+		rateLimitedMeter.Mark(1)
+		updateRateLimitedCounter(method)
+		return &rateLimitError{}
+	}
+	rateLimitCostMeter.Mark(int64(cost))
+	return nil
+}
+
+// rateLimitKey returns the identifier of the client for rate limiting.
+func rateLimitKey(info PeerInfo) string {
+	if info.ClientID != "" {
+		return "id:" + info.ClientID
+	}
+	host, _, err := net.SplitHostPort(info.RemoteAddr)
+	if err != nil {
+		return info.RemoteAddr
+	}
+	return host
+}
+
+type clientIDContextKey struct{}
+
+// ContextWithClientID returns a new context carrying the identifier of the
+// client, which is reported in PeerInfo and used for rate limiting. It's meant
+// to be used by the HTTP middlewares which authenticate the clients.
+func ContextWithClientID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, clientIDContextKey{}, id)
+}
+
+// clientIDFromContext returns the identifier of the client carried by the
+// context, or empty if not available.
+func clientIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(clientIDContextKey{}).(string)
+	return id
+}
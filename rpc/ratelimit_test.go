@@ -0,0 +1,167 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newRateLimitedServer(t *testing.T, config RateLimitConfig) *Server {
+	server := newTestServer()
+	server.SetRateLimit(config)
+	t.Cleanup(server.Stop)
+	return server
+}
+
+func checkRateLimited(t *testing.T, err error) {
+	t.Helper()
+	if !IsLimitExceeded(err) {
+		t.Fatalf("expected rate limit error, got %v", err)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	server := newRateLimitedServer(t, RateLimitConfig{
+		Rate:        0.001,
+		Burst:       10,
+		MethodCosts: map[string]int{"test_*": 2, "test_repeat": 4, "test_null": 0},
+	})
+	client := DialInProc(server)
+	defer client.Close()
+
+	var res string
+	for i := 0; i < 2; i++ {
+		if err := client.Call(&res, "test_repeat", "x", 1); err != nil {
+			t.Fatalf("call %d failed: %v", i, err)
+		}
+	}
+	// The remaining budget covers a single call with the namespace cost.
+	if err := client.Call(nil, "test_noArgsRets"); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	checkRateLimited(t, client.Call(nil, "test_noArgsRets"))
+
+	// Free methods and unknown methods are not charged.
+	if err := client.Call(nil, "test_null"); err != nil {
+		t.Fatalf("free call failed: %v", err)
+	}
+	err := client.Call(nil, "test_unknown")
+	var rpcErr Error
+	if !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != -32601 {
+		t.Fatalf("expected method not found, got %v", err)
+	}
+}
+
+// Tests that the burst is raised to the highest method cost, so that the most
+// expensive methods remain callable.
+func TestRateLimitBurst(t *testing.T) {
+	server := newRateLimitedServer(t, RateLimitConfig{
+		Rate:        0.001,
+		Burst:       1,
+		MethodCosts: map[string]int{"test_repeat": 4},
+	})
+	client := DialInProc(server)
+	defer client.Close()
+
+	var res string
+	if err := client.Call(&res, "test_repeat", "x", 1); err != nil {
+		t.Fatalf("expensive call failed: %v", err)
+	}
+	checkRateLimited(t, client.Call(nil, "test_noArgsRets"))
+}
+
+func TestRateLimitBatch(t *testing.T) {
+	server := newRateLimitedServer(t, RateLimitConfig{Rate: 0.001, Burst: 3})
+	client := DialInProc(server)
+	defer client.Close()
+
+	batch := make([]BatchElem, 4)
+	for i := range batch {
+		batch[i] = BatchElem{Method: "test_null", Result: new(any)}
+	}
+	if err := client.BatchCall(batch); err != nil {
+		t.Fatalf("batch failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if batch[i].Error != nil {
+			t.Fatalf("batch item %d failed: %v", i, batch[i].Error)
+		}
+	}
+	checkRateLimited(t, batch[3].Error)
+}
+
+func TestRateLimitClientID(t *testing.T) {
+	server := newRateLimitedServer(t, RateLimitConfig{Rate: 0.001, Burst: 1})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := r.Header.Get("X-Client-Id"); id != "" {
+			r = r.WithContext(ContextWithClientID(r.Context(), id))
+		}
+		server.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	for _, id := range []string{"alice", "bob"} {
+		client, err := DialOptions(context.Background(), ts.URL, WithHeader("X-Client-Id", id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var info PeerInfo
+		if err := client.Call(&info, "test_peerInfo"); err != nil {
+			t.Fatalf("client %s: call failed: %v", id, err)
+		}
+		if info.ClientID != id {
+			t.Fatalf("client %s: wrong client id %q", id, info.ClientID)
+		}
+		checkRateLimited(t, client.Call(&info, "test_peerInfo"))
+		client.Close()
+	}
+}
+
+// Tests that calls admitted outside of the JSON-RPC protocol are subject to the
+// access policy and share the rate limit of the client with its JSON-RPC calls.
+func TestServerAdmit(t *testing.T) {
+	server := newRateLimitedServer(t, RateLimitConfig{Rate: 0.001, Burst: 2})
+	server.SetAccessPolicy(&AccessPolicy{
+		Default: AccessRule{Deny: []string{"test_echo"}},
+		Clients: map[string]AccessRule{
+			"trusted": {Allow: []string{"*"}},
+		},
+	})
+	request := func(id string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		return r.WithContext(ContextWithClientID(r.Context(), id))
+	}
+	if err := server.Admit(request("anonymous"), "test_echo"); !IsAccessDenied(err) {
+		t.Fatalf("expected access denied error, got %v", err)
+	}
+	if err := server.Admit(request("trusted"), "test_echo"); err != nil {
+		t.Fatalf("trusted call not admitted: %v", err)
+	}
+	if err := server.Admit(request("trusted"), "test_echo"); err != nil {
+		t.Fatalf("second trusted call not admitted: %v", err)
+	}
+	checkRateLimited(t, server.Admit(request("trusted"), "test_echo"))
+
+	// Other clients are limited separately.
+	if err := server.Admit(request("anonymous"), "test_noArgsRets"); err != nil {
+		t.Fatalf("call of other client not admitted: %v", err)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"io"
 	"net"
+	"net/http"
 	"sync"
 	"sync/atomic"
 
@@ -54,6 +55,8 @@ type Server struct {
 	batchItemLimit     int
 	batchResponseLimit int
 	httpBodyLimit      int
+	rateLimiter        *rateLimiter
+	responseCache      *ResponseCache
 }
 
 // NewServer creates a new server instance with no registered handlers.
@@ -89,6 +92,55 @@ func (s *Server) SetHTTPBodyLimit(limit int) {
 	s.httpBodyLimit = limit
 }
 
+// SetRateLimit enables the admission control of the method calls, which is
+// applied to each client separately. See RateLimitConfig for more details.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetRateLimit(config RateLimitConfig) {
+	if config.Rate <= 0 {
+		s.rateLimiter = nil
+		return
+	}
+	s.rateLimiter = newRateLimiter(config)
+}
+
+// SetResponseCache enables answering the method calls from the given cache, if
+// the responses are marked as immutable by the methods. A nil cache disables
+// the caching.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetResponseCache(cache *ResponseCache) {
+	s.responseCache = cache
+}
+
+// SetAccessPolicy restricts the methods available to the clients. The policy
+// only narrows down the registered services, a nil policy lifts all the
+// restrictions.
+//
+// Unlike the other settings, the policy can be replaced while the server is
+// processing requests, it applies to the calls made afterwards.
+func (s *Server) SetAccessPolicy(policy *AccessPolicy) {
+	s.services.setPolicy(policy)
+}
+
+// Admit checks a call of the given method, which is served over HTTP outside
+// of the JSON-RPC protocol, against the access policy and the rate limit of
+// the server. The caller is identified like a JSON-RPC client making the call
+// in the same request. A nil error means the call may proceed.
+func (s *Server) Admit(r *http.Request, method string) error {
+	clientID := clientIDFromContext(r.Context())
+	if err := s.services.allowed(clientID, method); err != nil {
+		return err
+	}
+	if s.rateLimiter == nil {
+		return nil
+	}
+	info := PeerInfo{Transport: "http", RemoteAddr: r.RemoteAddr, ClientID: clientID}
+	return s.rateLimiter.admit(context.WithValue(r.Context(), peerInfoContextKey{}, info), method)
+}
+
 // RegisterName creates a service for the given receiver type under the given name. When no
 // methods on the given receiver match the criteria to be either an RPC method or a
 // subscription an error is returned. Otherwise a new service is created and added to the
@@ -114,6 +166,8 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 		idgen:              s.idgen,
 		batchItemLimit:     s.batchItemLimit,
 		batchResponseLimit: s.batchResponseLimit,
+		rateLimiter:        s.rateLimiter,
+		responseCache:      s.responseCache,
 	}
 	c := initClient(codec, &s.services, cfg)
 	<-codec.closed()
@@ -149,6 +203,8 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 
 	h := newHandler(ctx, codec, s.idgen, &s.services, s.batchItemLimit, s.batchResponseLimit)
 	h.allowSubscribe = false
+	h.rateLimiter = s.rateLimiter
+	h.responseCache = s.responseCache
 	defer h.close(io.EOF, nil)
 
 	reqs, batch, err := codec.readBatch()
@@ -226,6 +282,10 @@ type PeerInfo struct {
 	// Address of client. This will usually contain the IP address and port.
 	RemoteAddr string
 
+	// Identifier of the client, i.e. the "id" claim of the JWT token for the
+	// authenticated connections. Empty if not available.
+	ClientID string
+
 	// Additional information for HTTP and WebSocket connections.
 	HTTP struct {
 		// Protocol version, i.e. "HTTP/1.1". This is not set for WebSocket.
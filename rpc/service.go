@@ -38,6 +38,7 @@ var (
 type serviceRegistry struct {
 	mu       sync.Mutex
 	services map[string]service
+	policy   *AccessPolicy // restricts the callbacks available to the clients, nil if unrestricted
 }
 
 // service represents a registered object.
@@ -91,22 +92,58 @@ func (r *serviceRegistry) registerName(name string, rcvr interface{}) error {
 	return nil
 }
 
-// callback returns the callback corresponding to the given RPC method name.
-func (r *serviceRegistry) callback(method string) *callback {
+// setPolicy replaces the access policy of the registry.
+func (r *serviceRegistry) setPolicy(policy *AccessPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policy = policy
+}
+
+// allowed returns an error if the given client is not allowed to call the
+// method by the access policy.
+func (r *serviceRegistry) allowed(clientID, method string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.policy != nil && !r.policy.Allowed(clientID, method) {
+		return &accessDeniedError{method: method}
+	}
+	return nil
+}
+
+// callback returns the callback corresponding to the given RPC method name, or
+// an error if it doesn't exist or the given client is not allowed to call it.
+func (r *serviceRegistry) callback(clientID, method string) (*callback, error) {
 	before, after, found := strings.Cut(method, serviceMethodSeparator)
 	if !found {
-		return nil
+		return nil, &methodNotFoundError{method: method}
 	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	return r.services[before].callbacks[after]
+	cb := r.services[before].callbacks[after]
+	if cb == nil {
+		return nil, &methodNotFoundError{method: method}
+	}
+	if r.policy != nil && !r.policy.Allowed(clientID, method) {
+		return nil, &accessDeniedError{method: method}
+	}
+	return cb, nil
 }
 
-// subscription returns a subscription callback in the given service.
-func (r *serviceRegistry) subscription(service, name string) *callback {
+// subscription returns a subscription callback in the given service, or an
+// error if it doesn't exist or the given client is not allowed to subscribe
+// in the service.
+func (r *serviceRegistry) subscription(clientID, service, name string) (*callback, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	return r.services[service].subscriptions[name]
+	cb := r.services[service].subscriptions[name]
+	if cb == nil {
+		return nil, &subscriptionNotFoundError{service, name}
+	}
+	method := service + subscribeMethodSuffix
+	if r.policy != nil && !r.policy.Allowed(clientID, method) {
+		return nil, &accessDeniedError{method: method}
+	}
+	return cb, nil
 }
 
 // suitableCallbacks iterates over the methods of the given type. It determines if a method
@@ -243,6 +243,19 @@ func (db *Database) Reference(root common.Hash, parent common.Hash) error {
 	return nil
 }
 
+// SetFlushHook registers a callback which is invoked with the hash of every
+// trie node before it's persisted into disk, or removes it if nil is given.
+//
+// It's only supported by hash-based database and will return an error for others.
+func (db *Database) SetFlushHook(hook func(hash common.Hash)) error {
+	hdb, ok := db.backend.(*hashdb.Database)
+	if !ok {
+		return errors.New("not supported")
+	}
+	hdb.SetFlushHook(hook)
+	return nil
+}
+
 // Dereference removes an existing reference from a root node. It's only
 // supported by hash-based database and will return an error for others.
 func (db *Database) Dereference(root common.Hash) error {
@@ -277,6 +290,28 @@ func (db *Database) Recoverable(root common.Hash) (bool, error) {
 	return pdb.Recoverable(root), nil
 }
 
+// HistoricReader constructs a reader for accessing the requested historic state.
+// It's only supported by path-based database with state history indexing enabled
+// and will return an error for others.
+func (db *Database) HistoricReader(root common.Hash) (*pathdb.HistoricalStateReader, error) {
+	pdb, ok := db.backend.(*pathdb.Database)
+	if !ok {
+		return nil, errors.New("not supported")
+	}
+	return pdb.HistoricReader(root)
+}
+
+// HistoricNodeReader returns a node reader of the requested historic state by
+// rolling the state histories back in memory. It's only supported by path-based
+// database with the rollback enabled and will return an error for others.
+func (db *Database) HistoricNodeReader(root common.Hash) (database.NodeReader, error) {
+	pdb, ok := db.backend.(*pathdb.Database)
+	if !ok {
+		return nil, errors.New("not supported")
+	}
+	return pdb.HistoricNodeReader(root)
+}
+
 // Disable deactivates the database and invalidates all available state layers
 // as stale to prevent access to the persistent state, which is in the syncing
 // stage.
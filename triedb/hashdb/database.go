@@ -95,6 +95,8 @@ type Database struct {
 	dirtiesSize  common.StorageSize // Storage size of the dirty node cache (exc. metadata)
 	childrenSize common.StorageSize // Storage size of the external children tracking
 
+	onFlush func(hash common.Hash) // Callback invoked before a node is written into disk, used by online pruning
+
 	lock sync.RWMutex
 }
 
@@ -342,6 +344,9 @@ func (db *Database) Cap(limit common.StorageSize) error {
 	for size > limit && oldest != (common.Hash{}) {
 		// Fetch the oldest referenced node and push into the batch
 		node := db.dirties[oldest]
+		if db.onFlush != nil {
+			db.onFlush(oldest)
+		}
 		rawdb.WriteLegacyTrieNode(batch, oldest, node.node)
 
 		// If we exceeded the ideal batch size, commit and reset
@@ -394,6 +399,16 @@ func (db *Database) Cap(limit common.StorageSize) error {
 	return nil
 }
 
+// SetFlushHook registers a callback which is invoked with the hash of every
+// trie node before it's persisted into disk, or removes it if nil is given.
+// The callback is invoked with the database lock held.
+func (db *Database) SetFlushHook(hook func(hash common.Hash)) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.onFlush = hook
+}
+
 // Commit iterates over all the children of a particular node, writes them out
 // to disk, forcefully tearing down all references in both directions. As a side
 // effect, all pre-images accumulated up to this point are also written.
@@ -465,6 +480,9 @@ func (db *Database) commit(hash common.Hash, batch ethdb.Batch, uncacher *cleane
 		return err
 	}
 	// If we've reached an optimal batch size, commit and start over
+	if db.onFlush != nil {
+		db.onFlush(hash)
+	}
 	rawdb.WriteLegacyTrieNode(batch, hash, node.node)
 	if batch.ValueSize() >= ethdb.IdealBatchSize {
 		if err := batch.Write(); err != nil {
@@ -110,10 +110,12 @@ type layer interface {
 
 // Config contains the settings for database.
 type Config struct {
-	StateHistory    uint64 // Number of recent blocks to maintain state history for
-	CleanCacheSize  int    // Maximum memory allowance (in bytes) for caching clean nodes
-	WriteBufferSize int    // Maximum memory allowance (in bytes) for write buffer
-	ReadOnly        bool   // Flag whether the database is opened in read only mode.
+	StateHistory        uint64 // Number of recent blocks to maintain state history for
+	EnableStateIndexing bool   // Whether to index the state histories for serving historical states
+	RollbackDepth       uint64 // Number of states below the persistent one allowed to be rolled back in memory
+	CleanCacheSize      int    // Maximum memory allowance (in bytes) for caching clean nodes
+	WriteBufferSize     int    // Maximum memory allowance (in bytes) for write buffer
+	ReadOnly            bool   // Flag whether the database is opened in read only mode.
 }
 
 // sanitize checks the provided user configurations and changes anything that's
@@ -136,6 +138,12 @@ func (c *Config) fields() []interface{} {
 	list = append(list, "cache", common.StorageSize(c.CleanCacheSize))
 	list = append(list, "buffer", common.StorageSize(c.WriteBufferSize))
 	list = append(list, "history", c.StateHistory)
+	if c.EnableStateIndexing {
+		list = append(list, "indexing", true)
+	}
+	if c.RollbackDepth != 0 {
+		list = append(list, "rollback", c.RollbackDepth)
+	}
 	return list
 }
 
@@ -193,11 +201,13 @@ type Database struct {
 	isVerkle bool       // Flag if database is used for verkle tree
 	hasher   nodeHasher // Trie node hasher
 
-	config  *Config                      // Configuration for database
-	diskdb  ethdb.Database               // Persistent storage for matured trie nodes
-	tree    *layerTree                   // The group for all known layers
-	freezer ethdb.ResettableAncientStore // Freezer for storing trie histories, nil possible in tests
-	lock    sync.RWMutex                 // Lock to prevent mutations from happening at the same time
+	config   *Config                      // Configuration for database
+	diskdb   ethdb.Database               // Persistent storage for matured trie nodes
+	tree     *layerTree                   // The group for all known layers
+	freezer  ethdb.ResettableAncientStore // Freezer for storing trie histories, nil possible in tests
+	indexer  *historyIndexer              // Indexer of state histories, nil if indexing is disabled
+	rollback *rollback                    // In-memory rollback of historic states, nil if disabled
+	lock     sync.RWMutex                 // Lock to prevent mutations from happening at the same time
 }
 
 // New attempts to load an already existing layer from a persistent key-value
@@ -234,6 +244,10 @@ func New(diskdb ethdb.Database, config *Config, isVerkle bool) *Database {
 	if err := db.repairHistory(); err != nil {
 		log.Crit("Failed to repair state history", "err", err)
 	}
+	db.setHistoryIndexer()
+	if config.RollbackDepth != 0 && db.freezer != nil && !db.isVerkle {
+		db.rollback = newRollback(db, config.RollbackDepth)
+	}
 	// Disable database in case node is still in the initial state sync stage.
 	if rawdb.ReadSnapSyncStatusFlag(diskdb) == rawdb.StateSyncRunning && !db.readOnly {
 		if err := db.Disable(); err != nil {
@@ -281,12 +295,18 @@ func (db *Database) repairHistory() error {
 			if err != nil {
 				log.Crit("Failed to reset state histories", "err", err)
 			}
+			if err := rawdb.DeleteStateHistoryIndex(db.diskdb); err != nil {
+				log.Crit("Failed to delete state history index", "err", err)
+			}
 			log.Info("Truncated extraneous state history")
 		}
 		return nil
 	}
 	// Truncate the extra state histories above in freezer in case it's not
 	// aligned with the disk layer. It might happen after a unclean shutdown.
+	if err := unindexHistory(db.diskdb, db.freezer, id); err != nil {
+		log.Crit("Failed to unindex extra state histories", "err", err)
+	}
 	pruned, err := truncateFromHead(db.diskdb, db.freezer, id)
 	if err != nil {
 		log.Crit("Failed to truncate extra state histories", "err", err)
@@ -297,6 +317,25 @@ func (db *Database) repairHistory() error {
 	return nil
 }
 
+// setHistoryIndexer starts indexing the state histories in the background if
+// it's enabled, otherwise the leftover index is removed as it can't be kept
+// up to date.
+func (db *Database) setHistoryIndexer() {
+	if db.freezer == nil || db.readOnly {
+		return
+	}
+	if !db.config.EnableStateIndexing {
+		if rawdb.ReadStateHistoryIndexHead(db.diskdb) != nil {
+			if err := rawdb.DeleteStateHistoryIndex(db.diskdb); err != nil {
+				log.Crit("Failed to delete state history index", "err", err)
+			}
+			log.Info("Deleted state history index")
+		}
+		return
+	}
+	db.indexer = newHistoryIndexer(db.diskdb, db.freezer)
+}
+
 // Update adds a new layer into the tree, if that can be linked to an existing
 // old parent. It is disallowed to insert a disk layer (the origin of all). Apart
 // from that this function will flatten the extra diff layers at bottom into disk
@@ -399,9 +438,17 @@ func (db *Database) Enable(root common.Hash) error {
 	// mappings can be huge and might take a while to clear
 	// them, just leave them in disk and wait for overwriting.
 	if db.freezer != nil {
+		if db.indexer != nil {
+			db.indexer.close()
+			db.indexer = nil
+		}
 		if err := db.freezer.Reset(); err != nil {
 			return err
 		}
+		if err := rawdb.DeleteStateHistoryIndex(db.diskdb); err != nil {
+			return err
+		}
+		db.setHistoryIndexer()
 	}
 	// Re-construct a new disk layer backed by persistent state
 	// with **empty clean cache and node buffer**.
@@ -454,6 +501,11 @@ func (db *Database) Recover(root common.Hash) error {
 		db.tree.reset(dl)
 	}
 	rawdb.DeleteTrieJournal(db.diskdb)
+	if db.indexer != nil {
+		if err := db.indexer.shorten(dl.stateID()); err != nil {
+			return err
+		}
+	}
 	_, err := truncateFromHead(db.diskdb, db.freezer, dl.stateID())
 	if err != nil {
 		return err
@@ -508,6 +560,10 @@ func (db *Database) Close() error {
 	// Release the memory held by clean cache.
 	db.tree.bottom().resetCache()
 
+	// Terminate the state history indexing.
+	if db.indexer != nil {
+		db.indexer.close()
+	}
 	// Close the attached state history freezer.
 	if db.freezer == nil {
 		return nil
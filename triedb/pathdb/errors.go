@@ -39,4 +39,8 @@ var (
 	// errStateUnrecoverable is returned if state is required to be reverted to
 	// a destination without associated state history available.
 	errStateUnrecoverable = errors.New("state is unrecoverable")
+
+	// errStateHistoryNotIndexed is returned if an historical state is requested
+	// while the associated state histories are not yet indexed.
+	errStateHistoryNotIndexed = errors.New("state history is not fully indexed")
 )
@@ -0,0 +1,276 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// The state history index maps each account and storage slot to the ordered
+// list of state history ids in which it was modified. Since the history object
+// with id n records the value before the state transition n, the value of an
+// entry at state s can be found in the first history object after s that
+// modified it, or in the persistent state if there's none.
+//
+// The list of each entry is split into blocks of at most indexBlockEntries ids,
+// each encoded as a sequence of uvarint deltas. All blocks except the last one
+// are sealed and stored under the last id they contain, which allows looking up
+// the block covering a given id with a single database seek. The last block is
+// still open for appending and is stored under math.MaxUint64.
+//
+//   key(entry, 4096)   -> [1, 5, 10, ..., 4096]
+//   key(entry, 8200)   -> [4100, ..., 8200]
+//   key(entry, max)    -> [8210, 8300]
+
+// indexBlockEntries is the maximum number of state ids stored in a single block.
+const indexBlockEntries = 4096
+
+// openBlockID is the identifier of the last index block, which is not yet sealed.
+const openBlockID = math.MaxUint64
+
+// errIndexCorrupted is returned if an index block cannot be decoded.
+var errIndexCorrupted = errors.New("state history index is corrupted")
+
+// stateIdent represents the identifier of a state element, which can be either
+// an account or a storage slot.
+type stateIdent struct {
+	account     bool
+	addressHash common.Hash
+	storageHash common.Hash // the hash of the raw storage slot key
+}
+
+// newAccountIdent constructs a state identifier for an account.
+func newAccountIdent(addressHash common.Hash) stateIdent {
+	return stateIdent{
+		account:     true,
+		addressHash: addressHash,
+	}
+}
+
+// newStorageIdent constructs a state identifier for a storage slot.
+func newStorageIdent(addressHash common.Hash, storageHash common.Hash) stateIdent {
+	return stateIdent{
+		addressHash: addressHash,
+		storageHash: storageHash,
+	}
+}
+
+// String returns the string format state identifier.
+func (ident stateIdent) String() string {
+	if ident.account {
+		return ident.addressHash.Hex()
+	}
+	return ident.addressHash.Hex() + ident.storageHash.Hex()
+}
+
+// readBlock retrieves the index block of the state identified by its last id.
+func (ident stateIdent) readBlock(db ethdb.KeyValueReader, last uint64) []byte {
+	if ident.account {
+		return rawdb.ReadAccountHistoryIndexBlock(db, ident.addressHash, last)
+	}
+	return rawdb.ReadStorageHistoryIndexBlock(db, ident.addressHash, ident.storageHash, last)
+}
+
+// writeBlock stores the index block of the state under its last id.
+func (ident stateIdent) writeBlock(db ethdb.KeyValueWriter, last uint64, ids []uint64) {
+	blob := encodeIndexBlock(ids)
+	if ident.account {
+		rawdb.WriteAccountHistoryIndexBlock(db, ident.addressHash, last, blob)
+	} else {
+		rawdb.WriteStorageHistoryIndexBlock(db, ident.addressHash, ident.storageHash, last, blob)
+	}
+}
+
+// deleteBlock removes the index block of the state identified by its last id.
+func (ident stateIdent) deleteBlock(db ethdb.KeyValueWriter, last uint64) {
+	if ident.account {
+		rawdb.DeleteAccountHistoryIndexBlock(db, ident.addressHash, last)
+	} else {
+		rawdb.DeleteStorageHistoryIndexBlock(db, ident.addressHash, ident.storageHash, last)
+	}
+}
+
+// iterator returns an iterator over the index blocks of the state, starting
+// from the first block whose last id is not less than the given one.
+func (ident stateIdent) iterator(db ethdb.Iteratee, start uint64) ethdb.Iterator {
+	if ident.account {
+		return rawdb.IterateAccountHistoryIndex(db, ident.addressHash, start)
+	}
+	return rawdb.IterateStorageHistoryIndex(db, ident.addressHash, ident.storageHash, start)
+}
+
+// encodeIndexBlock packs the sorted list of state ids into byte stream.
+func encodeIndexBlock(ids []uint64) []byte {
+	var (
+		prev uint64
+		buf  = make([]byte, 0, len(ids)*binary.MaxVarintLen32)
+	)
+	for _, id := range ids {
+		buf = binary.AppendUvarint(buf, id-prev)
+		prev = id
+	}
+	return buf
+}
+
+// decodeIndexBlock unpacks the list of state ids from the byte stream.
+func decodeIndexBlock(blob []byte) ([]uint64, error) {
+	var (
+		prev uint64
+		ids  []uint64
+	)
+	for len(blob) > 0 {
+		delta, n := binary.Uvarint(blob)
+		if n <= 0 || (len(ids) > 0 && delta == 0) {
+			return nil, errIndexCorrupted
+		}
+		prev += delta
+		ids = append(ids, prev)
+		blob = blob[n:]
+	}
+	return ids, nil
+}
+
+// searchIndexBlock returns the first state id in the encoded index block which
+// is greater than the specified one, along with the flag whether it's found.
+func searchIndexBlock(blob []byte, id uint64) (uint64, bool, error) {
+	var prev uint64
+	for len(blob) > 0 {
+		delta, n := binary.Uvarint(blob)
+		if n <= 0 {
+			return 0, false, errIndexCorrupted
+		}
+		prev += delta
+		if prev > id {
+			return prev, true, nil
+		}
+		blob = blob[n:]
+	}
+	return 0, false, nil
+}
+
+// blockID extracts the last id of an index block from its database key.
+func blockID(key []byte) uint64 {
+	return binary.BigEndian.Uint64(key[len(key)-8:])
+}
+
+// readIndexGreaterThan returns the first state id in the index of the given
+// state which is greater than the specified id, or math.MaxUint64 if there's
+// no such id.
+func readIndexGreaterThan(db ethdb.Iteratee, ident stateIdent, id uint64) (uint64, error) {
+	it := ident.iterator(db, id+1)
+	defer it.Release()
+
+	if !it.Next() {
+		return math.MaxUint64, it.Error()
+	}
+	next, found, err := searchIndexBlock(it.Value(), id)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", err, ident)
+	}
+	if !found {
+		// Sealed blocks must contain their last id, only the open one
+		// is allowed to be exhausted.
+		if blockID(it.Key()) != openBlockID {
+			return 0, fmt.Errorf("%w: %v", errIndexCorrupted, ident)
+		}
+		return math.MaxUint64, nil
+	}
+	return next, nil
+}
+
+// appendIndex appends the sorted list of state ids to the index of the given
+// state, sealing blocks when they get full. The ids must be greater than all
+// the ones already indexed.
+func appendIndex(db ethdb.KeyValueReader, batch ethdb.KeyValueWriter, ident stateIdent, ids []uint64) error {
+	open, err := decodeIndexBlock(ident.readBlock(db, openBlockID))
+	if err != nil {
+		return fmt.Errorf("%w: %v", err, ident)
+	}
+	for _, id := range ids {
+		if len(open) > 0 && id <= open[len(open)-1] {
+			return fmt.Errorf("state history index out of order, ident: %v, last: %d, new: %d", ident, open[len(open)-1], id)
+		}
+		open = append(open, id)
+		if len(open) == indexBlockEntries {
+			ident.writeBlock(batch, id, open)
+			open = open[:0]
+		}
+	}
+	if len(open) == 0 {
+		ident.deleteBlock(batch, openBlockID)
+	} else {
+		ident.writeBlock(batch, openBlockID, open)
+	}
+	return nil
+}
+
+// truncateIndex removes all the state ids greater than the specified one from
+// the index of the given state.
+func truncateIndex(db ethdb.KeyValueStore, batch ethdb.KeyValueWriter, ident stateIdent, nhead uint64) error {
+	// Truncate the open block first, short circuit if some ids are left in it
+	// as all the sealed blocks contain smaller ids.
+	if blob := ident.readBlock(db, openBlockID); len(blob) > 0 {
+		ids, err := decodeIndexBlock(blob)
+		if err != nil {
+			return fmt.Errorf("%w: %v", err, ident)
+		}
+		n := sort.Search(len(ids), func(i int) bool { return ids[i] > nhead })
+		if n == len(ids) {
+			return nil
+		}
+		if n > 0 {
+			ident.writeBlock(batch, openBlockID, ids[:n])
+			return nil
+		}
+		ident.deleteBlock(batch, openBlockID)
+	}
+	// Drop the sealed blocks containing ids above the target. The first of them
+	// may still hold some ids which are kept by reopening the block.
+	it := ident.iterator(db, nhead+1)
+	defer it.Release()
+
+	first := true
+	for it.Next() {
+		last := blockID(it.Key())
+		if last == openBlockID {
+			continue
+		}
+		ident.deleteBlock(batch, last)
+		if !first {
+			continue
+		}
+		first = false
+
+		ids, err := decodeIndexBlock(it.Value())
+		if err != nil {
+			return fmt.Errorf("%w: %v", err, ident)
+		}
+		n := sort.Search(len(ids), func(i int) bool { return ids[i] > nhead })
+		if n > 0 {
+			ident.writeBlock(batch, openBlockID, ids[:n])
+		}
+	}
+	return it.Error()
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/internal/testrand"
+)
+
+func TestIndexBlockEncoding(t *testing.T) {
+	var (
+		ids  []uint64
+		last uint64
+	)
+	for i := 0; i < indexBlockEntries; i++ {
+		last += uint64(rand.Intn(1000) + 1)
+		ids = append(ids, last)
+	}
+	dec, err := decodeIndexBlock(encodeIndexBlock(ids))
+	if err != nil {
+		t.Fatalf("Failed to decode index block: %v", err)
+	}
+	if !reflect.DeepEqual(dec, ids) {
+		t.Fatal("Index block is mismatched")
+	}
+	if _, err := decodeIndexBlock([]byte{0x01, 0x00}); err == nil {
+		t.Fatal("Duplicated id is not rejected")
+	}
+}
+
+func TestHistoryIndex(t *testing.T) {
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		ident = newStorageIdent(testrand.Hash(), testrand.Hash())
+		ids   []uint64
+	)
+	// Append the ids in a few batches, spanning multiple index blocks
+	for n := 0; n < 5; n++ {
+		var batchIDs []uint64
+		for i := 0; i < 2000; i++ {
+			id := uint64(len(ids)+len(batchIDs))*3 + 1
+			batchIDs = append(batchIDs, id)
+		}
+		batch := db.NewBatch()
+		if err := appendIndex(db, batch, ident, batchIDs); err != nil {
+			t.Fatalf("Failed to append index: %v", err)
+		}
+		if err := batch.Write(); err != nil {
+			t.Fatalf("Failed to write batch: %v", err)
+		}
+		ids = append(ids, batchIDs...)
+	}
+	check := func(ids []uint64) {
+		t.Helper()
+		for i := uint64(0); i < ids[len(ids)-1]+10; i++ {
+			want := uint64(math.MaxUint64)
+			if pos := sort.Search(len(ids), func(n int) bool { return ids[n] > i }); pos < len(ids) {
+				want = ids[pos]
+			}
+			got, err := readIndexGreaterThan(db, ident, i)
+			if err != nil {
+				t.Fatalf("Failed to read index: %v", err)
+			}
+			if got != want {
+				t.Fatalf("Unexpected index, id: %d, want: %d, got: %d", i, want, got)
+			}
+		}
+	}
+	check(ids)
+
+	// Truncate the index into the open block, a sealed block and across blocks
+	for _, n := range []int{9000, 8192, 6000, 100} {
+		batch := db.NewBatch()
+		if err := truncateIndex(db, batch, ident, ids[n-1]); err != nil {
+			t.Fatalf("Failed to truncate index: %v", err)
+		}
+		if err := batch.Write(); err != nil {
+			t.Fatalf("Failed to write batch: %v", err)
+		}
+		ids = ids[:n]
+		check(ids)
+	}
+	// Append again after the truncation
+	batch := db.NewBatch()
+	if err := appendIndex(db, batch, ident, []uint64{1000, 1001}); err != nil {
+		t.Fatalf("Failed to append index: %v", err)
+	}
+	if err := batch.Write(); err != nil {
+		t.Fatalf("Failed to write batch: %v", err)
+	}
+	check(append(ids, 1000, 1001))
+}
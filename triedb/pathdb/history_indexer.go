@@ -0,0 +1,246 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// historyIndexBatch is the maximum number of state histories indexed in a
+// single database batch.
+const historyIndexBatch = 256
+
+// historyIndexer is responsible for building the state history index in the
+// background. The histories are indexed in ascending order and the progress
+// is persisted atomically along with the index, so that the indexing can be
+// resumed after a restart.
+type historyIndexer struct {
+	disk    ethdb.KeyValueStore
+	freezer ethdb.AncientReader
+	lock    sync.Mutex // Lock to prevent concurrent index mutations
+	trigger chan struct{}
+	closed  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// newHistoryIndexer constructs the history indexer and starts indexing the
+// histories which are not yet indexed.
+func newHistoryIndexer(disk ethdb.KeyValueStore, freezer ethdb.AncientReader) *historyIndexer {
+	indexer := &historyIndexer{
+		disk:    disk,
+		freezer: freezer,
+		trigger: make(chan struct{}, 1),
+		closed:  make(chan struct{}),
+	}
+	indexer.wg.Add(1)
+	go indexer.loop()
+	indexer.extend()
+	return indexer
+}
+
+// extend notifies the indexer that new state histories are available.
+func (i *historyIndexer) extend() {
+	select {
+	case i.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// shorten removes the state histories above the given id from the index. It
+// must be called before the histories are truncated from the freezer.
+func (i *historyIndexer) shorten(nhead uint64) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	return unindexHistory(i.disk, i.freezer, nhead)
+}
+
+// close terminates the background indexing and waits for it to exit.
+func (i *historyIndexer) close() {
+	select {
+	case <-i.closed:
+		return
+	default:
+		close(i.closed)
+	}
+	i.wg.Wait()
+}
+
+// loop runs the indexing whenever new state histories are available.
+func (i *historyIndexer) loop() {
+	defer i.wg.Done()
+
+	for {
+		select {
+		case <-i.trigger:
+			var (
+				start  = time.Now()
+				logged = time.Now()
+			)
+			for {
+				indexed, done, err := i.next()
+				if err != nil {
+					log.Error("Failed to index state history", "err", err)
+					break
+				}
+				if done {
+					if time.Since(start) > 8*time.Second {
+						log.Info("Indexed state history", "head", indexed, "elapsed", common.PrettyDuration(time.Since(start)))
+					}
+					break
+				}
+				if time.Since(logged) > 8*time.Second {
+					log.Info("Indexing state history", "indexed", indexed, "elapsed", common.PrettyDuration(time.Since(start)))
+					logged = time.Now()
+				}
+				select {
+				case <-i.closed:
+					return
+				default:
+				}
+			}
+		case <-i.closed:
+			return
+		}
+	}
+}
+
+// next indexes the next batch of state histories, returning the id of the last
+// indexed history and the flag whether all the histories have been indexed.
+func (i *historyIndexer) next() (uint64, bool, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	head, err := i.freezer.Ancients()
+	if err != nil {
+		return 0, false, err
+	}
+	tail, err := i.freezer.Tail()
+	if err != nil {
+		return 0, false, err
+	}
+	// Resume from the last indexed history, skipping the ones already pruned.
+	begin := tail
+	if indexed := rawdb.ReadStateHistoryIndexHead(i.disk); indexed != nil && *indexed > begin {
+		begin = *indexed
+	}
+	if begin >= head {
+		return begin, true, nil
+	}
+	last := min(head, begin+historyIndexBatch)
+
+	var (
+		start  = time.Now()
+		buff   = crypto.NewKeccakState()
+		idents = make(map[stateIdent][]uint64)
+	)
+	for id := begin + 1; id <= last; id++ {
+		h, err := readHistory(i.freezer, id)
+		if err != nil {
+			return 0, false, err
+		}
+		h.forEachIdent(buff, func(ident stateIdent) {
+			idents[ident] = append(idents[ident], id)
+		})
+	}
+	batch := i.disk.NewBatch()
+	for ident, ids := range idents {
+		if err := appendIndex(i.disk, batch, ident, ids); err != nil {
+			return 0, false, err
+		}
+	}
+	rawdb.WriteStateHistoryIndexHead(batch, last)
+	if err := batch.Write(); err != nil {
+		return 0, false, err
+	}
+	historyIndexTimeMeter.UpdateSince(start)
+	log.Debug("Indexed state history", "from", begin+1, "to", last, "states", len(idents), "elapsed", common.PrettyDuration(time.Since(start)))
+	return last, last == head, nil
+}
+
+// forEachIdent invokes the callback with the identifier of every state element
+// modified in the history.
+func (h *history) forEachIdent(buff crypto.KeccakState, fn func(stateIdent)) {
+	for _, addr := range h.accountList {
+		addrHash := crypto.HashData(buff, addr.Bytes())
+		fn(newAccountIdent(addrHash))
+
+		for _, slot := range h.storageList[addr] {
+			// The slots are keyed by hash in the legacy history format
+			// and by raw key since v1.
+			slotHash := slot
+			if h.meta.version != stateHistoryV0 {
+				slotHash = crypto.HashData(buff, slot.Bytes())
+			}
+			fn(newStorageIdent(addrHash, slotHash))
+		}
+	}
+}
+
+// unindexHistory removes the state histories above the given id from the index.
+// The histories to unindex must still be present in the freezer, otherwise the
+// entire index is dropped and rebuilt from scratch.
+func unindexHistory(disk ethdb.KeyValueStore, freezer ethdb.AncientReader, nhead uint64) error {
+	indexed := rawdb.ReadStateHistoryIndexHead(disk)
+	if indexed == nil || *indexed <= nhead {
+		return nil
+	}
+	head, err := freezer.Ancients()
+	if err != nil {
+		return err
+	}
+	tail, err := freezer.Tail()
+	if err != nil {
+		return err
+	}
+	if *indexed > head || nhead < tail {
+		log.Warn("Dropping state history index", "indexed", *indexed, "target", nhead, "tail", tail, "head", head)
+		return rawdb.DeleteStateHistoryIndex(disk)
+	}
+	var (
+		buff   = crypto.NewKeccakState()
+		idents = make(map[stateIdent]struct{})
+	)
+	for id := nhead + 1; id <= *indexed; id++ {
+		h, err := readHistory(freezer, id)
+		if err != nil {
+			return err
+		}
+		h.forEachIdent(buff, func(ident stateIdent) {
+			idents[ident] = struct{}{}
+		})
+	}
+	batch := disk.NewBatch()
+	for ident := range idents {
+		if err := truncateIndex(disk, batch, ident, nhead); err != nil {
+			return err
+		}
+	}
+	rawdb.WriteStateHistoryIndexHead(batch, nhead)
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	log.Debug("Unindexed state history", "from", nhead+1, "to", *indexed, "states", len(idents))
+	return nil
+}
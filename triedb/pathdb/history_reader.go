@@ -0,0 +1,330 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb/database"
+)
+
+// maxUnindexedHistories is the maximum number of state histories allowed to
+// be unindexed for serving historical states. The unindexed histories have to
+// be scanned one by one, it's only meant to bridge the indexing latency.
+const maxUnindexedHistories = 2 * historyIndexBatch
+
+// readHistoryMeta reads the meta object of the specified state history.
+func readHistoryMeta(freezer ethdb.AncientReader, id uint64) (*meta, error) {
+	blob := rawdb.ReadStateHistoryMeta(freezer, id)
+	if len(blob) == 0 {
+		return nil, fmt.Errorf("state history not found %d", id)
+	}
+	var m meta
+	if err := m.decode(blob); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// findHistoryAccount looks up the index of the specified account in the state
+// history, returning nil if the account is not included.
+func findHistoryAccount(freezer ethdb.AncientReader, address common.Address, id uint64) (*accountIndex, error) {
+	indexes := rawdb.ReadStateAccountIndex(freezer, id)
+	if len(indexes)%accountIndexSize != 0 {
+		return nil, fmt.Errorf("invalid account index, len: %d", len(indexes))
+	}
+	n := len(indexes) / accountIndexSize
+	pos := sort.Search(n, func(i int) bool {
+		return bytes.Compare(indexes[i*accountIndexSize:i*accountIndexSize+common.AddressLength], address.Bytes()) >= 0
+	})
+	if pos == n || !bytes.Equal(indexes[pos*accountIndexSize:pos*accountIndexSize+common.AddressLength], address.Bytes()) {
+		return nil, nil
+	}
+	var index accountIndex
+	index.decode(indexes[pos*accountIndexSize : (pos+1)*accountIndexSize])
+	return &index, nil
+}
+
+// readHistoryAccount retrieves the original value of the specified account
+// recorded in the state history, along with the flag whether it's found.
+func readHistoryAccount(freezer ethdb.AncientReader, address common.Address, id uint64) ([]byte, bool, error) {
+	if _, err := readHistoryMeta(freezer, id); err != nil {
+		return nil, false, err
+	}
+	index, err := findHistoryAccount(freezer, address, id)
+	if err != nil || index == nil {
+		return nil, false, err
+	}
+	data := rawdb.ReadStateAccountHistory(freezer, id)
+	last := uint64(index.offset) + uint64(index.length)
+	if uint64(len(data)) < last {
+		return nil, false, errors.New("account data buffer is corrupted")
+	}
+	return data[index.offset:last], true, nil
+}
+
+// readHistoryStorage retrieves the original value of the specified storage slot
+// recorded in the state history, along with the flag whether it's found.
+func readHistoryStorage(freezer ethdb.AncientReader, address common.Address, key common.Hash, keyHash common.Hash, id uint64) ([]byte, bool, error) {
+	m, err := readHistoryMeta(freezer, id)
+	if err != nil {
+		return nil, false, err
+	}
+	index, err := findHistoryAccount(freezer, address, id)
+	if err != nil || index == nil || index.storageSlots == 0 {
+		return nil, false, err
+	}
+	// The slots are keyed by hash in the legacy history format and by raw
+	// key since v1.
+	slotID := key
+	if m.version == stateHistoryV0 {
+		slotID = keyHash
+	}
+	indexes := rawdb.ReadStateStorageIndex(freezer, id)
+	start, end := uint64(index.storageOffset)*slotIndexSize, uint64(index.storageOffset+index.storageSlots)*slotIndexSize
+	if uint64(len(indexes)) < end {
+		return nil, false, errors.New("storage index buffer is corrupted")
+	}
+	indexes = indexes[start:end]
+
+	n := int(index.storageSlots)
+	pos := sort.Search(n, func(i int) bool {
+		return bytes.Compare(indexes[i*slotIndexSize:i*slotIndexSize+common.HashLength], slotID.Bytes()) >= 0
+	})
+	if pos == n || !bytes.Equal(indexes[pos*slotIndexSize:pos*slotIndexSize+common.HashLength], slotID.Bytes()) {
+		return nil, false, nil
+	}
+	var slot slotIndex
+	slot.decode(indexes[pos*slotIndexSize : (pos+1)*slotIndexSize])
+
+	data := rawdb.ReadStateStorageHistory(freezer, id)
+	last := uint64(slot.offset) + uint64(slot.length)
+	if uint64(len(data)) < last {
+		return nil, false, errors.New("storage data buffer is corrupted")
+	}
+	return data[slot.offset:last], true, nil
+}
+
+// layerNodeDatabase is a node database exposing the trie nodes of a specific
+// layer, used to read the persistent state or the state being rolled back.
+// It's only supported in merkle.
+type layerNodeDatabase struct {
+	layer layer
+}
+
+// NodeReader implements database.NodeDatabase, returning the node reader of
+// the layer.
+func (db *layerNodeDatabase) NodeReader(root common.Hash) (database.NodeReader, error) {
+	if root != db.layer.rootHash() {
+		return nil, fmt.Errorf("state %#x is not available", root)
+	}
+	return &reader{layer: db.layer}, nil
+}
+
+// trieAccount retrieves the account with the given address hash from the
+// account trie.
+func (db *layerNodeDatabase) trieAccount(addrHash common.Hash) (*types.StateAccount, error) {
+	tr, err := trie.New(trie.TrieID(db.layer.rootHash()), db)
+	if err != nil {
+		return nil, err
+	}
+	blob, err := tr.Get(addrHash.Bytes())
+	if err != nil || len(blob) == 0 {
+		return nil, err
+	}
+	account := new(types.StateAccount)
+	if err := rlp.DecodeBytes(blob, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// account retrieves the account with the given address hash from the account
+// trie, in the slim format used by state histories.
+func (db *layerNodeDatabase) account(addrHash common.Hash) ([]byte, error) {
+	account, err := db.trieAccount(addrHash)
+	if err != nil || account == nil {
+		return nil, err
+	}
+	return types.SlimAccountRLP(*account), nil
+}
+
+// storage retrieves the RLP-encoded storage slot with the given hash from the
+// storage trie of the specified account.
+func (db *layerNodeDatabase) storage(addrHash common.Hash, keyHash common.Hash) ([]byte, error) {
+	account, err := db.trieAccount(addrHash)
+	if err != nil || account == nil || account.Root == types.EmptyRootHash {
+		return nil, err
+	}
+	tr, err := trie.New(trie.StorageTrieID(db.layer.rootHash(), addrHash, account.Root), db)
+	if err != nil {
+		return nil, err
+	}
+	return tr.Get(keyHash.Bytes())
+}
+
+// HistoricalStateReader is a reader for an historical state which is no longer
+// available in the layer tree. The value of a state element is resolved from
+// the first state history modifying it after the target state, located via
+// the state history index, or from the persistent state if it has not been
+// modified since.
+type HistoricalStateReader struct {
+	db *Database
+	id uint64 // The id of the target state
+}
+
+// HistoricReader constructs a reader for accessing the historical state with
+// the given root. The state must be older than or equal to the persistent one
+// and the associated state histories must be indexed.
+func (db *Database) HistoricReader(root common.Hash) (*HistoricalStateReader, error) {
+	if !db.config.EnableStateIndexing || db.freezer == nil {
+		return nil, errors.New("state history indexing is disabled")
+	}
+	if db.isVerkle {
+		return nil, errors.New("historical state is not supported in verkle")
+	}
+	id := rawdb.ReadStateID(db.diskdb, root)
+	if id == nil {
+		return nil, fmt.Errorf("state %#x is not available", root)
+	}
+	dl := db.tree.bottom()
+	if *id > dl.stateID() {
+		return nil, fmt.Errorf("state %#x is not persisted yet", root)
+	}
+	// Ensure the state is canonical, the roots of reorged-out states might
+	// still be tracked by the id mappings.
+	if *id == dl.stateID() {
+		if dl.rootHash() != root {
+			return nil, fmt.Errorf("state %#x is not canonical", root)
+		}
+	} else {
+		m, err := readHistoryMeta(db.freezer, *id+1)
+		if err != nil {
+			return nil, err
+		}
+		if m.parent != root {
+			return nil, fmt.Errorf("state %#x is not canonical", root)
+		}
+	}
+	// Ensure the state histories are indexed sufficiently
+	indexed := *id
+	if head := rawdb.ReadStateHistoryIndexHead(db.diskdb); head != nil && *head > indexed {
+		indexed = *head
+	}
+	if dl.stateID()-indexed > maxUnindexedHistories {
+		return nil, fmt.Errorf("%w, indexed: %d, head: %d", errStateHistoryNotIndexed, indexed, dl.stateID())
+	}
+	return &HistoricalStateReader{db: db, id: *id}, nil
+}
+
+// read resolves the value of the state element at the target state, either
+// from the state histories or from the persistent state.
+func (r *HistoricalStateReader) read(ident stateIdent, history func(id uint64) ([]byte, bool, error), persistent func(db *layerNodeDatabase) ([]byte, error)) ([]byte, error) {
+	for {
+		dl := r.db.tree.bottom()
+		head := dl.stateID()
+		if r.id > head {
+			return nil, fmt.Errorf("state %d is not available, persistent state: %d", r.id, head)
+		}
+		// Look up the first indexed state history modifying the element
+		// after the target state.
+		var indexed uint64
+		if h := rawdb.ReadStateHistoryIndexHead(r.db.diskdb); h != nil {
+			indexed = *h
+		}
+		next, err := readIndexGreaterThan(r.db.diskdb, ident, r.id)
+		if err != nil {
+			return nil, err
+		}
+		if next <= head {
+			blob, found, err := history(next)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				return nil, fmt.Errorf("%w: %v is missing in history %d", errIndexCorrupted, ident, next)
+			}
+			return blob, nil
+		}
+		// Scan the state histories which are not yet indexed.
+		for id := max(r.id, indexed) + 1; id <= head; id++ {
+			blob, found, err := history(id)
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				return blob, nil
+			}
+		}
+		// The element is not modified since the target state, resolve it
+		// from the persistent state. Retry if the disk layer is replaced
+		// in the meantime.
+		blob, err := persistent(&layerNodeDatabase{layer: dl})
+		if err != nil && dl.isStale() {
+			continue
+		}
+		return blob, err
+	}
+}
+
+// Account returns the account with the given address at the target state in
+// the slim format, or nil if the account is not existent.
+func (r *HistoricalStateReader) Account(address common.Address) (*types.SlimAccount, error) {
+	defer func(start time.Time) { historicalAccountReadTimer.UpdateSince(start) }(time.Now())
+
+	addrHash := crypto.Keccak256Hash(address.Bytes())
+	blob, err := r.read(newAccountIdent(addrHash), func(id uint64) ([]byte, bool, error) {
+		return readHistoryAccount(r.db.freezer, address, id)
+	}, func(db *layerNodeDatabase) ([]byte, error) {
+		return db.account(addrHash)
+	})
+	if err != nil || len(blob) == 0 {
+		return nil, err
+	}
+	account := new(types.SlimAccount)
+	if err := rlp.DecodeBytes(blob, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// Storage returns the RLP-encoded value of the storage slot with the given raw
+// key at the target state, or nil if the slot is not existent.
+func (r *HistoricalStateReader) Storage(address common.Address, key common.Hash) ([]byte, error) {
+	defer func(start time.Time) { historicalStorageReadTimer.UpdateSince(start) }(time.Now())
+
+	var (
+		addrHash = crypto.Keccak256Hash(address.Bytes())
+		keyHash  = crypto.Keccak256Hash(key.Bytes())
+	)
+	return r.read(newStorageIdent(addrHash, keyHash), func(id uint64) ([]byte, bool, error) {
+		return readHistoryStorage(r.db.freezer, address, key, keyHash, id)
+	}, func(db *layerNodeDatabase) ([]byte, error) {
+		return db.storage(addrHash, keyHash)
+	})
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func waitIndexing(db *Database) {
+	for {
+		head := rawdb.ReadStateHistoryIndexHead(db.diskdb)
+		if head != nil && *head == db.tree.bottom().stateID() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func (t *tester) verifyHistoricalState(root common.Hash) error {
+	reader, err := t.db.HistoricReader(root)
+	if err != nil {
+		return err
+	}
+	// Check all the accounts ever existed, including the ones not existent
+	// at the target state.
+	for addrHash, preimage := range t.preimages {
+		if len(preimage) != common.AddressLength {
+			continue
+		}
+		account, err := reader.Account(common.BytesToAddress(preimage))
+		if err != nil {
+			return err
+		}
+		var blob []byte
+		if account != nil {
+			blob, _ = rlp.EncodeToBytes(account)
+		}
+		if want := t.snapAccounts[root][addrHash]; !bytes.Equal(blob, want) {
+			return fmt.Errorf("account %x is mismatched, want %x, got %x", addrHash, want, blob)
+		}
+	}
+	for addrHash, slots := range t.snapStorages[root] {
+		address := t.accountPreimage(addrHash)
+		for hash, want := range slots {
+			blob, err := reader.Storage(address, t.hashPreimage(hash))
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(blob, want) {
+				return fmt.Errorf("slot %x %x is mismatched, want %x, got %x", addrHash, hash, want, blob)
+			}
+		}
+	}
+	return nil
+}
+
+func TestHistoricalStateReader(t *testing.T) {
+	// Redefine the diff layer depth allowance for faster testing.
+	maxDiffLayers = 4
+	defer func() {
+		maxDiffLayers = 128
+	}()
+
+	tester := newTester(t, 0, false, 16, true)
+	defer tester.release()
+
+	waitIndexing(tester.db)
+	bottom := tester.bottomIndex()
+	for i := 0; i <= bottom; i++ {
+		if err := tester.verifyHistoricalState(tester.roots[i]); err != nil {
+			t.Fatalf("Failed to verify state %d: %v", i, err)
+		}
+	}
+	// States above the disk layer are not served
+	if _, err := tester.db.HistoricReader(tester.lastHash()); err == nil {
+		t.Fatal("Historical reader is created for in-memory state")
+	}
+	// Drop a part of the index, the unindexed histories are scanned
+	tester.db.indexer.close()
+	if err := unindexHistory(tester.db.diskdb, tester.db.freezer, uint64(bottom/2)); err != nil {
+		t.Fatalf("Failed to unindex state histories: %v", err)
+	}
+	for i := 0; i <= bottom; i++ {
+		if err := tester.verifyHistoricalState(tester.roots[i]); err != nil {
+			t.Fatalf("Failed to verify state %d with partial index: %v", i, err)
+		}
+	}
+}
+
+func TestHistoricalStateReaderRollback(t *testing.T) {
+	// Redefine the diff layer depth allowance for faster testing.
+	maxDiffLayers = 4
+	defer func() {
+		maxDiffLayers = 128
+	}()
+
+	tester := newTester(t, 0, false, 16, true)
+	defer tester.release()
+
+	waitIndexing(tester.db)
+	target := tester.bottomIndex() / 2
+	if err := tester.db.Recover(tester.roots[target]); err != nil {
+		t.Fatalf("Failed to revert db: %v", err)
+	}
+	if head := rawdb.ReadStateHistoryIndexHead(tester.db.diskdb); head == nil || *head != uint64(target+1) {
+		t.Fatalf("Unexpected index head, want %d, got %v", target+1, head)
+	}
+	for i := 0; i <= target; i++ {
+		if err := tester.verifyHistoricalState(tester.roots[i]); err != nil {
+			t.Fatalf("Failed to verify state %d: %v", i, err)
+		}
+	}
+	// The reverted states are not served anymore
+	if _, err := tester.db.HistoricReader(tester.roots[target+1]); err == nil {
+		t.Fatal("Historical reader is created for reverted state")
+	}
+}
+
+func TestHistoricalStateReaderDisabled(t *testing.T) {
+	tester := newTester(t, 0, false, 12, false)
+	defer tester.release()
+
+	if _, err := tester.db.HistoricReader(types.EmptyRootHash); err == nil {
+		t.Fatal("Historical reader is created with indexing disabled")
+	}
+}
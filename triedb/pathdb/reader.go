@@ -17,6 +17,7 @@
 package pathdb
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -148,3 +149,18 @@ func (db *Database) StateReader(root common.Hash) (database.StateReader, error)
 	}
 	return &reader{layer: layer}, nil
 }
+
+// HistoricNodeReader returns a node reader of the historic state with the given
+// root, which is older than the persistent state and no longer available in the
+// layer tree. The state is rolled back in memory by applying the state histories
+// in reverse, as long as it's within the configured rollback depth.
+func (db *Database) HistoricNodeReader(root common.Hash) (database.NodeReader, error) {
+	if db.rollback == nil {
+		return nil, errors.New("state rollback is disabled")
+	}
+	layer, err := db.rollback.layer(root)
+	if err != nil {
+		return nil, err
+	}
+	return &reader{layer: layer}, nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// rollback maintains a chain of in-memory layers below the persistent state,
+// each of which reverts the state transition recorded in a state history. It
+// allows accessing the trie nodes of the historic states which are no longer
+// available in the layer tree, without touching the persistent state.
+//
+// The layers are stacked in the reverse order compared with the layer tree,
+// the newest one is linked to the disk layer and the older ones are linked on
+// top. As the trie nodes are recorded in full in each layer, the chain can be
+// relinked onto the new disk layer by inserting the layers reverting the newly
+// persisted transitions in between.
+type rollback struct {
+	db     *Database
+	depth  uint64                     // Maximum number of states to roll back
+	base   *diskLayer                 // Disk layer which the chain is linked to
+	layers []*diffLayer               // Rolled back layers, from the newest to the oldest
+	roots  map[common.Hash]*diffLayer // Rolled back layers, indexed by state root
+	lock   sync.Mutex
+}
+
+// newRollback constructs the rollback with the given depth.
+func newRollback(db *Database, depth uint64) *rollback {
+	return &rollback{
+		db:    db,
+		depth: depth,
+		roots: make(map[common.Hash]*diffLayer),
+	}
+}
+
+// reset discards all the rolled back layers and links the chain to the given
+// disk layer.
+func (r *rollback) reset(base *diskLayer) {
+	r.base = base
+	r.layers = nil
+	r.roots = make(map[common.Hash]*diffLayer)
+}
+
+// revert applies the given state history in reverse upon the given layer,
+// constructing the layer of the parent state.
+func (r *rollback) revert(parent layer, h *history) (*diffLayer, error) {
+	if h.meta.root != parent.rootHash() {
+		return nil, errUnexpectedHistory
+	}
+	nodes, err := apply(&layerNodeDatabase{layer: parent}, h.meta.parent, h.meta.root, h.meta.version != stateHistoryV0, h.accounts, h.storages)
+	if err != nil {
+		return nil, err
+	}
+	accounts, storages := h.stateSet()
+	return &diffLayer{
+		root:   h.meta.parent,
+		id:     parent.stateID() - 1,
+		block:  h.meta.block - 1,
+		nodes:  newNodeSet(nodes),
+		states: NewStateSetWithOrigin(accounts, storages, nil, nil, false),
+		parent: parent,
+	}, nil
+}
+
+// rebase links the chain onto the given disk layer, reverting the transitions
+// persisted since the last time.
+func (r *rollback) rebase(base *diskLayer) error {
+	if r.base == base {
+		return nil
+	}
+	// Discard the rolled back layers if the persistent state is reverted or
+	// too far ahead, relinking is not possible or not worthwhile.
+	if len(r.layers) == 0 || base.stateID() < r.base.stateID() || base.stateID()-r.base.stateID() > r.depth {
+		r.reset(base)
+		return nil
+	}
+	var (
+		prev   = r.base
+		layers []*diffLayer
+		parent layer = base
+	)
+	for parent.stateID() > prev.stateID() {
+		h, err := readHistory(r.db.freezer, parent.stateID())
+		if err != nil {
+			return err
+		}
+		dl, err := r.revert(parent, h)
+		if err != nil {
+			return err
+		}
+		layers = append(layers, dl)
+		parent = dl
+	}
+	// Discard the rolled back layers if they are not derived from the state
+	// persisted last time, e.g. the persistent state is reorged.
+	if parent.rootHash() != prev.rootHash() {
+		r.reset(base)
+		return nil
+	}
+	bottom := r.layers[0]
+	bottom.lock.Lock()
+	bottom.parent = parent
+	bottom.lock.Unlock()
+
+	for i := len(layers) - 1; i >= 0; i-- {
+		r.roots[layers[i].root] = layers[i]
+	}
+	r.layers = append(layers, r.layers...)
+	r.base = base
+	return nil
+}
+
+// layer returns the rolled back layer of the state with the given root.
+func (r *rollback) layer(root common.Hash) (layer, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	id := rawdb.ReadStateID(r.db.diskdb, root)
+	if id == nil {
+		return nil, fmt.Errorf("state %#x is not available", root)
+	}
+	base := r.db.tree.bottom()
+	if *id >= base.stateID() {
+		return nil, fmt.Errorf("state %#x is not persisted yet", root)
+	}
+	if base.stateID()-*id > r.depth {
+		return nil, fmt.Errorf("state %#x is too old, id: %d, persistent: %d, depth: %d", root, *id, base.stateID(), r.depth)
+	}
+	if err := r.rebase(base); err != nil {
+		return nil, err
+	}
+	// Drop the layers beyond the depth
+	var n int
+	for n < len(r.layers) && base.stateID()-r.layers[n].id <= r.depth {
+		n++
+	}
+	for _, dl := range r.layers[n:] {
+		if r.roots[dl.root] == dl {
+			delete(r.roots, dl.root)
+		}
+	}
+	r.layers = r.layers[:n]
+
+	// Roll back the state further if it's not deep enough
+	var parent layer = r.base
+	if len(r.layers) > 0 {
+		parent = r.layers[len(r.layers)-1]
+	}
+	for parent.stateID() > *id {
+		h, err := readHistory(r.db.freezer, parent.stateID())
+		if err != nil {
+			return nil, err
+		}
+		dl, err := r.revert(parent, h)
+		if err != nil {
+			return nil, err
+		}
+		r.layers = append(r.layers, dl)
+		r.roots[dl.root] = dl
+		parent = dl
+
+		log.Debug("Rolled back state", "id", dl.id, "root", dl.root)
+	}
+	dl, ok := r.roots[root]
+	if !ok {
+		return nil, errors.New("state is not canonical")
+	}
+	return dl, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/triedb/database"
+)
+
+// historicNodeDatabase is a node database serving the rolled back states.
+type historicNodeDatabase struct {
+	db *Database
+}
+
+func (db *historicNodeDatabase) NodeReader(root common.Hash) (database.NodeReader, error) {
+	return db.db.HistoricNodeReader(root)
+}
+
+func TestHistoricNodeReader(t *testing.T) {
+	// Redefine the diff layer depth allowance for faster testing.
+	maxDiffLayers = 4
+	defer func() {
+		maxDiffLayers = 128
+	}()
+
+	tester := newTester(t, 0, false, 32, false)
+	defer tester.release()
+
+	depth := uint64(16)
+	tester.db.rollback = newRollback(tester.db, depth)
+
+	verify := func() {
+		t.Helper()
+
+		bottom := tester.bottomIndex()
+		for i := bottom - 1; i >= 0; i-- {
+			if uint64(bottom-i) > depth {
+				if _, err := tester.db.HistoricNodeReader(tester.roots[i]); err == nil {
+					t.Fatalf("State %d beyond the depth is accessible", i)
+				}
+				continue
+			}
+			if err := tester.verifyStateWith(&historicNodeDatabase{tester.db}, tester.roots[i]); err != nil {
+				t.Fatalf("Failed to verify state %d: %v", i, err)
+			}
+		}
+		if uint64(len(tester.db.rollback.layers)) != depth {
+			t.Fatalf("Unexpected number of rolled back layers, have %d, want %d", len(tester.db.rollback.layers), depth)
+		}
+	}
+	verify()
+
+	// Advance the persistent state, the rolled back layers should be relinked
+	// onto the new disk layer.
+	var (
+		oldest = tester.db.rollback.layers[len(tester.db.rollback.layers)-1]
+		kept   = tester.db.rollback.layers[len(tester.db.rollback.layers)-4-1]
+	)
+	for i := 0; i < 4; i++ {
+		parent := tester.lastHash()
+		root, nodes, states := tester.generate(parent, true)
+		if err := tester.db.Update(root, parent, uint64(len(tester.roots)), nodes, states); err != nil {
+			t.Fatalf("Failed to update state changes: %v", err)
+		}
+		tester.roots = append(tester.roots, root)
+	}
+	if err := tester.verifyStateWith(&historicNodeDatabase{tester.db}, tester.roots[tester.bottomIndex()-1]); err != nil {
+		t.Fatalf("Failed to verify state: %v", err)
+	}
+	if tester.db.rollback.roots[kept.root] != kept {
+		t.Fatal("Rolled back layers are discarded")
+	}
+	if tester.db.rollback.roots[oldest.root] != nil {
+		t.Fatal("Rolled back layers beyond the depth are retained")
+	}
+	verify()
+}